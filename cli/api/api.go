@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "api",
+		Short: "Make raw calls to the Blimp manager API",
+		Long: "Make raw calls to the Blimp manager API.\n\n" +
+			"This is intended for debugging issues with the cluster manager. " +
+			"The API isn't stable, and may change between releases.",
+	}
+	cobraCmd.AddCommand(newCallCommand(), newListCommand())
+	return cobraCmd
+}
+
+func newCallCommand() *cobra.Command {
+	var data string
+	cobraCmd := &cobra.Command{
+		Use:   "call METHOD",
+		Short: "Call a manager API method, and print the response as JSON",
+		Long: "Call a manager API method, and print the response as JSON.\n\n" +
+			"The request is passed as JSON with --data. If the request has a token field " +
+			"and it isn't set, it's filled in with your credentials. Responses from " +
+			"streaming methods are printed as they arrive until the stream ends.",
+		Example: `  blimp api call GetStatus
+  blimp api call GetServiceHistory --data '{"service": "web"}'`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			if err := call(os.Stdout, auth.AuthToken, args[0], data); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().StringVarP(&data, "data", "d", "{}",
+		"The request as a JSON object. Field names can be in either snake_case or camelCase.")
+	return cobraCmd
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the manager API methods",
+		Run: func(_ *cobra.Command, _ []string) {
+			for _, method := range listMethods() {
+				fmt.Println(method)
+			}
+		},
+	}
+}
+
+var managerClientType = reflect.TypeOf((*cluster.ManagerClient)(nil)).Elem()
+
+func listMethods() []string {
+	var methods []string
+	for i := 0; i < managerClientType.NumMethod(); i++ {
+		methods = append(methods, managerClientType.Method(i).Name)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// call invokes the method on the manager through the generated client, so
+// that the request and response are converted with the same types as the
+// rest of the CLI.
+func call(out io.Writer, authToken, methodName, data string) error {
+	method := reflect.ValueOf(manager.C.ManagerClient).MethodByName(methodName)
+	if !method.IsValid() {
+		return errors.NewFriendlyError("Unknown method %q. The available methods are:\n%s",
+			methodName, strings.Join(listMethods(), "\n"))
+	}
+
+	// Methods that stream requests from the client only take a context and
+	// call options.
+	methodType := method.Type()
+	if methodType.NumIn() != 3 {
+		return errors.NewFriendlyError("%s streams requests from the client, which isn't supported.", methodName)
+	}
+
+	req := reflect.New(methodType.In(1).Elem())
+	if err := jsonpb.UnmarshalString(data, req.Interface().(proto.Message)); err != nil {
+		return errors.NewFriendlyError("Failed to parse --data as a %s: %s", req.Elem().Type().Name(), err)
+	}
+
+	if token := req.Elem().FieldByName("Token"); token.IsValid() && token.Kind() == reflect.String &&
+		token.String() == "" {
+		token.SetString(authToken)
+	}
+
+	results := method.Call([]reflect.Value{reflect.ValueOf(context.Background()), req})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return errors.WithContext(fmt.Sprintf("call %s", methodName), err)
+	}
+
+	marshaler := jsonpb.Marshaler{Indent: "  ", OrigName: true}
+	printResp := func(resp interface{}) error {
+		str, err := marshaler.MarshalToString(resp.(proto.Message))
+		if err != nil {
+			return errors.WithContext("marshal response", err)
+		}
+		fmt.Fprintln(out, str)
+		return nil
+	}
+
+	// Methods that stream responses return a client with a Recv method.
+	recv := results[0].MethodByName("Recv")
+	if !recv.IsValid() {
+		return printResp(results[0].Interface())
+	}
+
+	for {
+		recvResults := recv.Call(nil)
+		if err, _ := recvResults[1].Interface().(error); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.WithContext("receive response", err)
+		}
+
+		if err := printResp(recvResults[0].Interface()); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package attach
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kelda/blimp/cli/audit"
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+func New() *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach SERVICE",
+		Short: "Attach to the input and output of a running service",
+		Long: "Attach to the input and output of a running service.\n\n" +
+			"The service must set `stdin_open: true` in the Compose file so that its " +
+			"process keeps stdin open, such as for a debugger or a Rails console. If the " +
+			"service also sets `tty: true`, the terminal is attached as a TTY.\n\n" +
+			"The input is sent to the service's main process, so Ctrl-C stops the " +
+			"process if the service has a TTY.",
+		ValidArgsFunction: util.CompleteServices,
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Fprintln(os.Stderr, "Exactly one service is required")
+				errors.Exit(1)
+			}
+
+			if err := run(args[0]); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+}
+
+func run(svc string) error {
+	auth, err := authstore.New()
+	if err != nil {
+		return errors.WithContext("parse auth config", err)
+	}
+
+	if auth.AuthToken == "" {
+		fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+		return nil
+	}
+
+	// Make sure the pod is actually booted.
+	err = manager.CheckServiceRunning(svc, auth.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	kubeClient, restConfig, err := auth.KubeClient()
+	if err != nil {
+		return errors.WithContext("get kube client", err)
+	}
+
+	container, err := getAttachableContainer(kubeClient, auth.KubeNamespace, svc)
+	if err != nil {
+		return err
+	}
+
+	// Put the terminal into raw mode to prevent it echoing characters twice.
+	tty := container.TTY && terminal.IsTerminal(0)
+	if tty {
+		oldState, err := terminal.MakeRaw(0)
+		if err != nil {
+			return errors.WithContext("set terminal mode", err)
+		}
+
+		defer func() {
+			_ = terminal.Restore(0, oldState)
+		}()
+	}
+
+	attachOpts := core.PodAttachOptions{
+		Container: container.Name,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    !tty,
+		TTY:       tty,
+	}
+	recorder := audit.StartRecording(auth.AuthToken, svc, []string{"attach"})
+	defer recorder.Close()
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:  recorder.Stdin(os.Stdin),
+		Stdout: recorder.Stdout(os.Stdout),
+		Tty:    tty,
+	}
+	if !tty {
+		streamOpts.Stderr = recorder.Stdout(os.Stderr)
+	}
+
+	attach, err := newAttacher(kubeClient, restConfig, auth.KubeNamespace, svc, attachOpts)
+	if err != nil {
+		return errors.WithContext("setup attach", err)
+	}
+
+	err = attach.Stream(streamOpts)
+	if err != nil {
+		return errors.WithContext("stream", err)
+	}
+	return nil
+}
+
+// getAttachableContainer returns the service's main container. It fails if
+// the container doesn't keep stdin open, since there would be nothing to
+// attach to.
+func getAttachableContainer(kubeClient kubernetes.Interface, namespace, svc string) (core.Container, error) {
+	pod, err := kubeClient.CoreV1().Pods(namespace).Get(names.PodName(svc), metav1.GetOptions{})
+	if err != nil {
+		return core.Container{}, errors.WithContext("get pod", err)
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name != names.LogCaptureContainer && container.Stdin {
+			return container, nil
+		}
+	}
+
+	return core.Container{}, errors.NewFriendlyError("%s doesn't keep stdin open, so there's nothing to attach to.\n"+
+		"Set `stdin_open: true` for the service in the Compose file, and run `blimp up` again. "+
+		"To run a command in the service instead, use `blimp exec %s` or `blimp ssh %s`.", svc, svc, svc)
+}
+
+func newAttacher(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	namespace, svc string, attachOpts core.PodAttachOptions) (remotecommand.Executor, error) {
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		SubResource("attach").
+		Name(names.PodName(svc)).
+		Namespace(namespace).
+		VersionedParams(&attachOpts, scheme.ParameterCodec)
+	return remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+}
@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// maxReplayIdle is the longest pause between events during a replay. Longer
+// pauses, such as when the user stepped away, are shortened to this.
+const maxReplayIdle = 2 * time.Second
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Audit activity in your shared sandbox",
+	}
+	cobraCmd.AddCommand(newSessionsCommand())
+	return cobraCmd
+}
+
+func newSessionsCommand() *cobra.Command {
+	var speed float64
+	cobraCmd := &cobra.Command{
+		Use:   "sessions [SESSION_ID]",
+		Short: "List and replay recorded ssh and exec sessions",
+		Long: "List and replay recorded ssh and exec sessions.\n\n" +
+			"Sessions are only recorded if recording was enabled when sharing the sandbox. " +
+			"If SESSION_ID is specified, the session is replayed in the terminal. " +
+			"Otherwise, all recorded sessions are listed.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) > 1 {
+				fmt.Fprintln(os.Stderr, "At most one session can be specified")
+				errors.Exit(1)
+			}
+
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			if len(args) == 0 {
+				err = listSessions(auth.AuthToken)
+			} else {
+				err = replaySession(auth.AuthToken, args[0], speed)
+			}
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().Float64VarP(&speed, "speed", "", 1,
+		"The speed to replay the session at. For example, 2 replays the session twice as fast")
+	return cobraCmd
+}
+
+func listSessions(authToken string) error {
+	resp, err := manager.C.ListSessions(context.Background(), &cluster.ListSessionsRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return errors.WithContext("list sessions", err)
+	}
+
+	if len(resp.Sessions) == 0 {
+		fmt.Println("No sessions have been recorded.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "ID\tUSER\tSERVICE\tCOMMAND\tSTARTED\tDURATION")
+	for _, session := range resp.Sessions {
+		started := time.Unix(session.StartTime, 0).Format(time.Stamp)
+		duration := time.Duration(session.Duration * float64(time.Second)).Round(time.Second)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", session.Id, session.User, session.Service,
+			strings.Join(session.Command, " "), started, duration)
+	}
+	return nil
+}
+
+func replaySession(authToken, id string, speed float64) error {
+	if speed <= 0 {
+		return errors.NewFriendlyError("--speed must be greater than zero")
+	}
+
+	resp, err := manager.C.GetSessionRecording(context.Background(), &cluster.GetSessionRecordingRequest{
+		Token: authToken,
+		Id:    id,
+	})
+	if err != nil {
+		return errors.WithContext("get session recording", err)
+	}
+
+	session := resp.GetSession()
+	fmt.Printf("Replaying session by %s in %s (%s)\n\n",
+		session.GetUser(), session.GetService(), strings.Join(session.GetCommand(), " "))
+
+	var lastEvent float64
+	for _, event := range resp.Events {
+		// The output already contains the echoed input, so we only need to
+		// replay the output.
+		if event.Type != cluster.SessionEvent_OUTPUT {
+			continue
+		}
+
+		delay := time.Duration((event.Time - lastEvent) / speed * float64(time.Second))
+		if delay > maxReplayIdle {
+			delay = maxReplayIdle
+		}
+		time.Sleep(delay)
+		lastEvent = event.Time
+
+		if _, err := os.Stdout.Write(event.Data); err != nil {
+			return errors.WithContext("write output", err)
+		}
+	}
+
+	fmt.Println("\n\nReplay finished.")
+	return nil
+}
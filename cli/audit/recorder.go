@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+const (
+	// uploadInterval is how often buffered events are uploaded to the manager.
+	uploadInterval = 250 * time.Millisecond
+
+	// maxBufferedEvents is the number of events that can be waiting to be
+	// uploaded. If the upload falls behind, events are dropped rather than
+	// slowing down the session.
+	maxBufferedEvents = 1024
+)
+
+// Recorder uploads the input and output of an interactive session to the
+// manager so that the sandbox owner can audit it. Recording is best effort,
+// and never interferes with the session itself. A nil Recorder is valid, and
+// doesn't record anything.
+type Recorder struct {
+	start  time.Time
+	stream cluster.Manager_RecordSessionClient
+	cancel context.CancelFunc
+
+	events    chan *cluster.SessionEvent
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// StartRecording starts recording a session if the sandbox owner enabled
+// recording. It returns nil if the session won't be recorded.
+func StartRecording(token, svc string, command []string) *Recorder {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := manager.C.RecordSession(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Failed to start session recording")
+		cancel()
+		return nil
+	}
+
+	header := &cluster.SessionInfo{
+		Token:     token,
+		Service:   svc,
+		Command:   command,
+		StartTime: time.Now().Unix(),
+	}
+	if width, height, err := terminal.GetSize(0); err == nil {
+		header.Width = int32(width)
+		header.Height = int32(height)
+	}
+
+	if err := stream.Send(&cluster.RecordSessionRequest{Header: header}); err != nil {
+		log.WithError(err).Debug("Failed to send session recording header")
+		cancel()
+		return nil
+	}
+
+	r := &Recorder{
+		start:  time.Now(),
+		stream: stream,
+		cancel: cancel,
+		events: make(chan *cluster.SessionEvent, maxBufferedEvents),
+		done:   make(chan struct{}),
+	}
+	go r.upload()
+	return r
+}
+
+// Stdin returns a reader that records the data read from the given reader.
+func (r *Recorder) Stdin(in io.Reader) io.Reader {
+	if r == nil {
+		return in
+	}
+	return recordingReader{in, r}
+}
+
+// Stdout returns a writer that records the data written to the given writer.
+// It should also be used for stderr, since terminals don't distinguish
+// between the two.
+func (r *Recorder) Stdout(out io.Writer) io.Writer {
+	if r == nil {
+		return out
+	}
+	return recordingWriter{out, r}
+}
+
+// Close uploads any buffered events, and finishes the recording.
+func (r *Recorder) Close() {
+	if r == nil {
+		return
+	}
+
+	r.closeOnce.Do(func() {
+		close(r.events)
+		select {
+		case <-r.done:
+		case <-time.After(5 * time.Second):
+			log.Debug("Timed out waiting for session recording to upload")
+		}
+		r.cancel()
+	})
+}
+
+func (r *Recorder) record(eventType cluster.SessionEvent_Type, data []byte) {
+	event := &cluster.SessionEvent{
+		Time: time.Since(r.start).Seconds(),
+		Type: eventType,
+		// Copy the data since the caller may reuse the buffer.
+		Data: append([]byte(nil), data...),
+	}
+
+	select {
+	case r.events <- event:
+	default:
+		log.Debug("Dropped session recording event")
+	}
+}
+
+func (r *Recorder) upload() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(uploadInterval)
+	defer ticker.Stop()
+
+	var pending []*cluster.SessionEvent
+	failed := false
+	flush := func() {
+		if failed || len(pending) == 0 {
+			pending = nil
+			return
+		}
+
+		// If the manager closed the stream, recording isn't enabled for the
+		// sandbox, so we just discard the rest of the events.
+		if err := r.stream.Send(&cluster.RecordSessionRequest{Events: pending}); err != nil {
+			log.WithError(err).Debug("Stopping session recording")
+			failed = true
+		}
+		pending = nil
+	}
+
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				flush()
+				if !failed {
+					if _, err := r.stream.CloseAndRecv(); err != nil {
+						log.WithError(err).Debug("Failed to finish session recording")
+					}
+				}
+				return
+			}
+			pending = append(pending, event)
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+type recordingReader struct {
+	io.Reader
+	recorder *Recorder
+}
+
+func (r recordingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.recorder.record(cluster.SessionEvent_INPUT, p[:n])
+	}
+	return n, err
+}
+
+type recordingWriter struct {
+	io.Writer
+	recorder *Recorder
+}
+
+func (w recordingWriter) Write(p []byte) (int, error) {
+	w.recorder.record(cluster.SessionEvent_OUTPUT, p)
+	return w.Writer.Write(p)
+}
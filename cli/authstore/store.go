@@ -3,11 +3,13 @@ package authstore
 import (
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
+	"github.com/kelda/blimp/pkg/auth"
 	"github.com/kelda/blimp/pkg/cfgdir"
 	"github.com/kelda/blimp/pkg/errors"
 )
@@ -15,6 +17,10 @@ import (
 type Store struct {
 	AuthToken string
 
+	// RefreshToken is used to get a new AuthToken once it expires. It's
+	// empty if the user logged in before refresh tokens were supported.
+	RefreshToken string
+
 	KubeToken     string
 	KubeHost      string
 	KubeCACrt     string
@@ -34,6 +40,42 @@ func (store Store) KubeClient() (kubernetes.Interface, *rest.Config, error) {
 	return kubeClient, restConfig, err
 }
 
+// RefreshWindow is how long before the auth token expires that
+// RefreshAuthToken replaces it. It's longer than tunnel.RotationInterval so
+// that open tunnels receive the new token before the old one expires.
+const RefreshWindow = 10 * time.Minute
+
+// RefreshAuthToken replaces the auth token with a new one if it expires
+// within RefreshWindow, and saves it so that other commands use it as well.
+func (store *Store) RefreshAuthToken() error {
+	expiry, err := auth.IDTokenExpiry(store.AuthToken)
+	if err != nil {
+		return errors.WithContext("get token expiry", err)
+	}
+
+	if time.Until(expiry) > RefreshWindow {
+		return nil
+	}
+
+	if store.RefreshToken == "" {
+		return errors.NewFriendlyError("Your Blimp session expires at %s, and can't be renewed "+
+			"automatically. Please log in again with `blimp login`.", expiry.Format(time.Kitchen))
+	}
+
+	token, refreshToken, err := auth.RefreshIDToken(store.RefreshToken)
+	if err != nil {
+		return errors.NewFriendlyError("Failed to renew your Blimp session, which expires at %s. "+
+			"Please log in again with `blimp login`.\n\nThe full error was: %s",
+			expiry.Format(time.Kitchen), err)
+	}
+
+	store.AuthToken = token
+	if refreshToken != "" {
+		store.RefreshToken = refreshToken
+	}
+	return store.Save()
+}
+
 func (store Store) Save() error {
 	configPath := getStorePath()
 	configBytes, err := yaml.Marshal(store)
@@ -0,0 +1,26 @@
+// Package buildlog stores the full output of image builds, so that the
+// progress shown by `blimp up` can be condensed, and the full output can be
+// read afterwards with `blimp logs --build`.
+package buildlog
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/kelda/blimp/pkg/cfgdir"
+)
+
+// Path returns the path to the log of the most recent build of the service.
+func Path(svc string) string {
+	return cfgdir.Expand(filepath.Join("build-logs", svc+".log"))
+}
+
+// Create creates the log for a new build of the service, replacing the log of
+// the previous build.
+func Create(svc string) (*os.File, error) {
+	path := Path(svc)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
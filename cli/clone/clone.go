@@ -0,0 +1,134 @@
+package clone
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	var source string
+	var skipVolumes bool
+	cobraCmd := &cobra.Command{
+		Use:   "clone --from OWNER/SANDBOX",
+		Short: "Replace your sandbox with a copy of a teammate's shared sandbox",
+		Long: "Replace your sandbox with a copy of a sandbox that was shared with you.\n\n" +
+			"The clone runs the exact images that the shared sandbox is running, and its " +
+			"volumes are restored from snapshots of the shared sandbox's volumes. This makes " +
+			"it possible to reproduce a teammate's environment without their working tree.\n\n" +
+			"Your sandbox's current containers and volumes are replaced. Files aren't synced " +
+			"into the clone, so run `blimp up` afterwards to go back to running your own code.",
+		Example: `  blimp clone --from alice/feature-x`,
+		Args:    cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			if source == "" {
+				fmt.Fprintln(os.Stderr, "The sandbox to clone is required, such as `blimp clone --from alice/feature-x`.")
+				errors.Exit(1)
+			}
+
+			if parts := strings.Split(source, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				errors.HandleFatalError(errors.NewFriendlyError(
+					"Invalid --from %q. It should be in the form OWNER/SANDBOX, such as alice/feature-x.", source))
+			}
+
+			if util.UpRunning() {
+				fmt.Printf("It looks like `blimp up` is still running. You should stop it before running `blimp clone`.\n" +
+					"Are you sure you want to continue, even though things might break? (y/N) ")
+				var response string
+				num, err := fmt.Scanln(&response)
+				if err != nil || num != 1 ||
+					(strings.ToLower(response) != "y" && strings.ToLower(response) != "yes") {
+					fmt.Printf("Aborting.\n")
+					errors.Exit(1)
+				}
+			}
+
+			if err := run(auth, source, skipVolumes); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().StringVarP(&source, "from", "", "",
+		"The shared sandbox to clone, in the form OWNER/SANDBOX")
+	cobraCmd.Flags().BoolVarP(&skipVolumes, "no-volumes", "", false,
+		"Start the clone with empty volumes, rather than restoring the shared sandbox's volumes")
+	return cobraCmd
+}
+
+func run(auth authstore.Store, source string, skipVolumes bool) error {
+	pp := util.NewProgressPrinter(os.Stdout, fmt.Sprintf("Cloning %s", source))
+	go pp.Run()
+
+	resp, err := manager.C.CloneSandbox(context.Background(), &cluster.CloneSandboxRequest{
+		Token:       auth.AuthToken,
+		Source:      source,
+		SkipVolumes: skipVolumes,
+	})
+	pp.Stop()
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return errors.NewFriendlyError("The Blimp cluster doesn't support cloning sandboxes.")
+		}
+		return errors.WithContext("clone sandbox", err)
+	}
+
+	// Save the Kubernetes API credentials for use by other Blimp commands,
+	// the same as `blimp up`.
+	kubeCreds := resp.GetKubeCredentials()
+	auth.KubeToken = kubeCreds.GetToken()
+	auth.KubeHost = kubeCreds.GetHost()
+	auth.KubeCACrt = kubeCreds.GetCaCrt()
+	auth.KubeNamespace = kubeCreds.GetNamespace()
+	if err := auth.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Cloned %s into your sandbox.\n\n", source)
+	printImages(resp.GetImages())
+	if volumes := resp.GetRestoredVolumes(); len(volumes) != 0 {
+		fmt.Printf("\nRestored volumes: %s\n", strings.Join(volumes, ", "))
+	}
+	fmt.Println("\nUse `blimp ps` and `blimp logs` to inspect the clone. " +
+		"Run `blimp up` to replace it with your own code.")
+	return nil
+}
+
+func printImages(images map[string]string) {
+	var services []string
+	for svc := range images {
+		services = append(services, svc)
+	}
+	sort.Strings(services)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "SERVICE\tIMAGE")
+	for _, svc := range services {
+		fmt.Fprintf(w, "%s\t%s\n", svc, images[svc])
+	}
+}
@@ -0,0 +1,38 @@
+package completion
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func New() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh",
+		Short: "Output shell completion code",
+		Long: "Output shell completion code for bash or zsh.\n\n" +
+			"Service names, and the service groups defined in blimp.yaml, are completed " +
+			"for commands that accept services.\n\n" +
+			"To load completions in the current bash session, run `source <(blimp completion bash)`.",
+		Args:      cobra.ExactValidArgs(1),
+		ValidArgs: []string{"bash", "zsh"},
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			switch args[0] {
+			case "bash":
+				err = cmd.Root().GenBashCompletion(os.Stdout)
+			case "zsh":
+				err = cmd.Root().GenZshCompletion(os.Stdout)
+			default:
+				fmt.Fprintf(os.Stderr, "Unsupported shell %q\n", args[0])
+				errors.Exit(1)
+			}
+			if err != nil {
+				errors.HandleFatalError(errors.WithContext("generate completion", err))
+			}
+		},
+	}
+}
@@ -29,7 +29,7 @@ To copy TO a container:
 		Run: func(_ *cobra.Command, args []string) {
 			if len(args) != 2 {
 				fmt.Fprintf(os.Stderr, "Dest path and src path need to be defined")
-				os.Exit(1)
+				errors.Exit(1)
 			}
 
 			if err := run(args[0], args[1]); err != nil {
@@ -0,0 +1,176 @@
+package curl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// curlImage is the image used to make requests from within the sandbox.
+const curlImage = "curlimages/curl:7.72.0"
+
+// requestTimeout is the maximum amount of time that the request may take,
+// including the time to boot the helper pod.
+const requestTimeout = 2 * time.Minute
+
+type curl struct {
+	auth    authstore.Store
+	method  string
+	headers []string
+	data    string
+}
+
+func New() *cobra.Command {
+	cmd := curl{}
+	cobraCmd := &cobra.Command{
+		Use:   "curl SERVICE[:PORT][/PATH]",
+		Short: "Make an HTTP request from within the sandbox",
+		Long: "Make an HTTP request from within the sandbox, and print the response's " +
+			"status, headers, and body.\n\n" +
+			"The request is made from a helper container in the sandbox, so services are " +
+			"addressed by their service names, exactly as other services see them. " +
+			"For example, `blimp curl web:3000/healthz`.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Fprintln(os.Stderr, "Exactly one URL is required")
+				errors.Exit(1)
+			}
+
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			cmd.auth = auth
+			exitCode, err := cmd.run(args[0])
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+			errors.Exit(exitCode)
+		},
+	}
+	cobraCmd.Flags().StringVarP(&cmd.method, "request", "X", "",
+		"The HTTP method to use. Defaults to GET, or POST if --data is set")
+	cobraCmd.Flags().StringArrayVarP(&cmd.headers, "header", "H", nil,
+		"A header to include in the request, such as 'Content-Type: application/json'")
+	cobraCmd.Flags().StringVarP(&cmd.data, "data", "d", "",
+		"The body to send with the request")
+	return cobraCmd
+}
+
+// run makes the request from a helper pod, and returns the exit code of
+// curl.
+func (cmd curl) run(url string) (int, error) {
+	kubeClient, _, err := cmd.auth.KubeClient()
+	if err != nil {
+		return 0, errors.WithContext("connect to cluster", err)
+	}
+
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+
+	curlCmd := []string{"curl", "--silent", "--show-error", "--include",
+		"--max-time", fmt.Sprintf("%d", int(requestTimeout.Seconds()))}
+	if cmd.method != "" {
+		curlCmd = append(curlCmd, "--request", cmd.method)
+	}
+	for _, header := range cmd.headers {
+		curlCmd = append(curlCmd, "--header", header)
+	}
+	if cmd.data != "" {
+		curlCmd = append(curlCmd, "--data", cmd.data)
+	}
+	curlCmd = append(curlCmd, url)
+
+	pods := kubeClient.CoreV1().Pods(cmd.auth.KubeNamespace)
+	pod, err := pods.Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "blimp-curl-",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:    "curl",
+					Image:   curlImage,
+					Command: curlCmd,
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	})
+	if err != nil {
+		return 0, errors.WithContext("create helper pod", err)
+	}
+	defer func() {
+		err := pods.Delete(pod.Name, &metav1.DeleteOptions{})
+		if err != nil {
+			log.WithError(err).WithField("pod", pod.Name).Warn("Failed to delete helper pod")
+		}
+	}()
+
+	pp := util.NewProgressPrinter(os.Stderr, "Sending request from sandbox")
+	go pp.Run()
+	exitCode, err := waitForExit(kubeClient, cmd.auth.KubeNamespace, pod.Name)
+	pp.Stop()
+	if err != nil {
+		return 0, err
+	}
+
+	logs, err := pods.GetLogs(pod.Name, &corev1.PodLogOptions{}).Stream()
+	if err != nil {
+		return 0, errors.WithContext("get response", err)
+	}
+	defer logs.Close()
+
+	if _, err := io.Copy(os.Stdout, logs); err != nil {
+		return 0, errors.WithContext("print response", err)
+	}
+	return exitCode, nil
+}
+
+// waitForExit waits for the curl container to exit, and returns its exit
+// code.
+func waitForExit(kubeClient kubernetes.Interface, namespace, podName string) (exitCode int, err error) {
+	err = wait.PollImmediate(500*time.Millisecond, requestTimeout, func() (bool, error) {
+		pod, err := kubeClient.CoreV1().Pods(namespace).Get(podName, metav1.GetOptions{})
+		if err != nil {
+			return false, errors.WithContext("get helper pod", err)
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			if terminated := status.State.Terminated; terminated != nil {
+				exitCode = int(terminated.ExitCode)
+				return true, nil
+			}
+
+			if waiting := status.State.Waiting; waiting != nil && waiting.Reason == "ErrImagePull" {
+				return false, errors.New("failed to pull %s: %s", curlImage, waiting.Message)
+			}
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return 0, errors.NewFriendlyError("Timed out waiting for the request to complete.")
+	}
+	return exitCode, err
+}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/kelda/blimp/cli/authstore"
 	"github.com/kelda/blimp/cli/manager"
@@ -17,12 +20,16 @@ import (
 )
 
 func New() *cobra.Command {
-	return &cobra.Command{
+	var dryRun bool
+	cobraCmd := &cobra.Command{
 		Use:   "down",
 		Short: "Delete your cloud sandbox",
 		Long: `Delete your cloud sandbox.
 
-All containers and volumes are removed.`,
+All containers and volumes are removed.
+
+Use --dry-run to list the resources that would be deleted, and the volumes
+that would be kept, without deleting anything.`,
 		Run: func(_ *cobra.Command, args []string) {
 			auth, err := authstore.New()
 			if err != nil {
@@ -32,7 +39,14 @@ All containers and volumes are removed.`,
 			// TODO: Prompt to login again if token is expired.
 			if auth.AuthToken == "" {
 				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
-				os.Exit(1)
+				errors.Exit(1)
+			}
+
+			if dryRun {
+				if err := printResources(auth.AuthToken); err != nil {
+					errors.HandleFatalError(err)
+				}
+				return
 			}
 
 			if util.UpRunning() {
@@ -43,7 +57,7 @@ All containers and volumes are removed.`,
 				if err != nil || num != 1 ||
 					(strings.ToLower(response) != "y" && strings.ToLower(response) != "yes") {
 					fmt.Printf("Aborting.\n")
-					os.Exit(1)
+					errors.Exit(1)
 				}
 			}
 
@@ -52,6 +66,9 @@ All containers and volumes are removed.`,
 			}
 		},
 	}
+	cobraCmd.Flags().BoolVarP(&dryRun, "dry-run", "", false,
+		"Print what would be deleted without deleting anything")
+	return cobraCmd
 }
 
 func Run(authToken string) error {
@@ -88,3 +105,81 @@ func Run(authToken string) error {
 		}
 	}
 }
+
+// printResources prints the resources that would be deleted by `blimp down`,
+// and the resources that would be kept.
+func printResources(authToken string) error {
+	resp, err := manager.C.ListSandboxResources(context.Background(), &cluster.ListSandboxResourcesRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return errors.NewFriendlyError("The cluster doesn't support `blimp down --dry-run`. " +
+				"Ask your administrator to upgrade the Blimp manager.")
+		}
+		return errors.WithContext("list sandbox resources", err)
+	}
+
+	if err := errors.Unmarshal(nil, resp.Error); err != nil {
+		return err
+	}
+
+	var deleted, retained []*cluster.SandboxResource
+	for _, resource := range resp.Resources {
+		if resource.Retained {
+			retained = append(retained, resource)
+		} else {
+			deleted = append(deleted, resource)
+		}
+	}
+
+	if len(deleted) == 0 {
+		fmt.Println("Nothing would be deleted.")
+	} else {
+		fmt.Println("The following resources would be deleted:")
+		printResourceTable(deleted)
+	}
+
+	if len(retained) != 0 {
+		fmt.Println("\nThe following resources would be kept for the next `blimp up`:")
+		printResourceTable(retained)
+	}
+
+	fmt.Println("\nRun without --dry-run to delete the sandbox.")
+	return nil
+}
+
+func printResourceTable(resources []*cluster.SandboxResource) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME\tSERVICE\tSIZE")
+	for _, resource := range resources {
+		svc := resource.Service
+		if svc == "" {
+			svc = "-"
+		}
+
+		size := "-"
+		if resource.SizeBytes != 0 {
+			size = util.FormatBytes(resource.SizeBytes)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", getTypeString(resource.Type), resource.Name, svc, size)
+	}
+	w.Flush()
+}
+
+func getTypeString(resourceType cluster.SandboxResource_Type) string {
+	switch resourceType {
+	case cluster.SandboxResource_POD:
+		return "Pod"
+	case cluster.SandboxResource_SERVICE:
+		return "Service"
+	case cluster.SandboxResource_VOLUME:
+		return "Volume"
+	case cluster.SandboxResource_EXPOSE_URL:
+		return "Public URL"
+	case cluster.SandboxResource_SECRET:
+		return "Secret"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,112 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	composeTypes "github.com/kelda/compose-go/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "env",
+		Short: "Manage the environment variables of your sandbox",
+	}
+	cobraCmd.AddCommand(newExportCommand())
+	cobraCmd.AddCommand(newSetCommand())
+	return cobraCmd
+}
+
+func newExportCommand() *cobra.Command {
+	var composePaths []string
+	var projectDir string
+	cobraCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print the addresses of the sandbox services as a dotenv file",
+		Long: "Print the addresses of the sandbox services as a dotenv file.\n\n" +
+			"For each service with published ports, SERVICE_HOST and SERVICE_PORT are set to " +
+			"the address that `blimp up` forwards the service to, and SERVICE_PORT_<PORT> is " +
+			"set for each of the service's ports. This way, tests running on your machine " +
+			"can connect to the sandbox by loading the file, such as with " +
+			"`blimp env export > sandbox.env`. The file can be passed to the services in " +
+			"the sandbox with `blimp up --inject-env sandbox.env`.",
+		Run: func(_ *cobra.Command, args []string) {
+			composePath, overridePaths, err := util.GetComposePaths(projectDir, composePaths)
+			if err != nil {
+				if os.IsNotExist(err) {
+					log.Fatal("Docker Compose file not found.\n" +
+						"Blimp must be run from the same directory as docker-compose.yml.")
+				}
+				log.WithError(err).Fatal("Failed to get absolute path to Compose file")
+			}
+
+			if projectDir == "" {
+				projectDir = filepath.Dir(composePath)
+			}
+
+			cfg, err := dockercompose.LoadProject(projectDir, composePath, overridePaths, nil)
+			if err != nil {
+				errors.HandleFatalError(errors.WithContext("load compose file", err))
+			}
+
+			for _, line := range formatDotenv(getConnectionEnv(cfg)) {
+				fmt.Println(line)
+			}
+		},
+	}
+	cobraCmd.Flags().StringSliceVarP(&composePaths, "file", "f", nil,
+		"Specify an alternate compose file\nDefaults to docker-compose.yml and docker-compose.yaml")
+	cobraCmd.Flags().StringVarP(&projectDir, "project-dir", "", "",
+		"Specify an alternate working directory\nDefaults to the directory of the first compose file")
+	return cobraCmd
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// getConnectionEnv returns the environment variables for connecting to the
+// services' published ports from the user's machine.
+func getConnectionEnv(cfg composeTypes.Config) map[string]string {
+	env := map[string]string{}
+	for _, svc := range cfg.Services {
+		prefix := nonIdentifierChars.ReplaceAllString(strings.ToUpper(svc.Name), "_")
+		for _, mapping := range svc.Ports {
+			if mapping.Protocol != "tcp" {
+				continue
+			}
+
+			host := mapping.HostIP
+			if host == "" || host == "0.0.0.0" {
+				host = "127.0.0.1"
+			}
+
+			// The first published port is the service's default address.
+			if _, ok := env[prefix+"_HOST"]; !ok {
+				env[prefix+"_HOST"] = host
+				env[prefix+"_PORT"] = fmt.Sprint(mapping.Published)
+			}
+			env[fmt.Sprintf("%s_PORT_%d", prefix, mapping.Target)] = fmt.Sprint(mapping.Published)
+		}
+	}
+	return env
+}
+
+// formatDotenv formats the environment variables as the lines of a dotenv
+// file, sorted by key.
+func formatDotenv(env map[string]string) []string {
+	var lines []string
+	for key, val := range env {
+		lines = append(lines, fmt.Sprintf("%s=%s", key, val))
+	}
+	sort.Strings(lines)
+	return lines
+}
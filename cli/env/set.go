@@ -0,0 +1,141 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// DefaultReloadSignal is the signal sent by `blimp env set --hot` if
+// --signal isn't set. Most servers that reload their config do it on SIGHUP.
+const DefaultReloadSignal = "SIGHUP"
+
+// reloadSignals are the signals that --signal accepts. Signals that
+// typically stop the process aren't allowed, since the point of a hot reload
+// is to keep the service running.
+var reloadSignals = []string{"SIGHUP", "SIGUSR1", "SIGUSR2", "SIGWINCH"}
+
+func newSetCommand() *cobra.Command {
+	var hot, untilDown bool
+	var signal string
+	cobraCmd := &cobra.Command{
+		Use:   "set SERVICE KEY=VALUE...",
+		Short: "Set temporary environment variables in a service",
+		Long: "Set temporary environment variables in a service. They're merged with the " +
+			"service's other temporary environment variables, and last until the next " +
+			"`blimp up` or `blimp down`, the same as `blimp restart -e`.\n\n" +
+			"By default, the service is restarted so that the variables take effect. With --hot, " +
+			"the service keeps running. Instead, the dotenv file at " + names.HotEnvFile + " is " +
+			"updated, and --signal is sent to the service's main process. This only works for " +
+			"applications that reload their config from the file when signaled, since the " +
+			"environment of a running process can't be changed.",
+		Example: `  blimp env set web LOG_LEVEL=debug --until-down
+  blimp env set web FEATURE_FLAGS=new-ui --hot --signal SIGUSR1 --until-down`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: util.CompleteServices,
+		Run: func(_ *cobra.Command, args []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			env, err := util.ParseEnv(args[1:])
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if !untilDown {
+				errors.HandleFatalError(errors.NewFriendlyError(
+					"Temporary environment variables last until the next `blimp up` or `blimp down`.\n" +
+						"Please pass --until-down to confirm, or add them to your Compose file instead."))
+			}
+
+			var reloadSignal string
+			if hot {
+				reloadSignal, err = parseSignal(signal)
+				if err != nil {
+					errors.HandleFatalError(err)
+				}
+			}
+
+			if err := runSet(auth.AuthToken, args[0], env, hot, reloadSignal); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&hot, "hot", "", false,
+		"Update "+names.HotEnvFile+" and signal the service to reload it, rather than restarting the service")
+	cobraCmd.Flags().StringVarP(&signal, "signal", "", DefaultReloadSignal,
+		fmt.Sprintf("The signal to send with --hot. One of %s.", strings.Join(reloadSignals, ", ")))
+	cobraCmd.Flags().BoolVarP(&untilDown, "until-down", "", false,
+		"Keep the environment variables until the next 'blimp up' or 'blimp down'")
+	return cobraCmd
+}
+
+func runSet(authToken, service string, env map[string]string, hot bool, reloadSignal string) error {
+	_, err := manager.C.SetServiceEnv(context.Background(), &cluster.SetServiceEnvRequest{
+		Token:        authToken,
+		Service:      service,
+		Env:          env,
+		Hot:          hot,
+		ReloadSignal: reloadSignal,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return errors.NewFriendlyError("The Blimp cluster doesn't support setting environment variables. "+
+				"Use `blimp restart %s -e KEY=VALUE --until-down` instead.", service)
+		}
+		return errors.WithContext("set service env", err)
+	}
+
+	var keys []string
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if hot {
+		fmt.Printf("Set %s in %s, and sent %s to reload it.\n", strings.Join(keys, ", "), service, reloadSignal)
+	} else {
+		fmt.Printf("Restarted %s with %s until the next `blimp up` or `blimp down`.\n",
+			service, strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+// parseSignal normalizes the --signal flag, which may omit the SIG prefix,
+// such as HUP.
+func parseSignal(signal string) (string, error) {
+	normalized := strings.ToUpper(signal)
+	if !strings.HasPrefix(normalized, "SIG") {
+		normalized = "SIG" + normalized
+	}
+
+	for _, allowed := range reloadSignals {
+		if normalized == allowed {
+			return normalized, nil
+		}
+	}
+	return "", errors.NewFriendlyError("Unsupported --signal %q. It must be one of %s.",
+		signal, strings.Join(reloadSignals, ", "))
+}
@@ -0,0 +1,142 @@
+package exec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// outputJSON is the only supported value for --output. Since flag parsing is
+// disabled for exec, --output has to be parsed by hand.
+const outputJSON = "json"
+
+// parseOutputFlag strips a leading `--output FORMAT`, `--output=FORMAT`, or
+// `-o FORMAT` from the arguments.
+func parseOutputFlag(args []string) (output string, rest []string, err error) {
+	if len(args) == 0 {
+		return "", args, nil
+	}
+
+	switch {
+	case args[0] == "--output" || args[0] == "-o":
+		if len(args) < 2 {
+			return "", nil, errors.NewFriendlyError("%s requires a format", args[0])
+		}
+		output, rest = args[1], args[2:]
+	case strings.HasPrefix(args[0], "--output="):
+		output, rest = strings.TrimPrefix(args[0], "--output="), args[1:]
+	default:
+		return "", args, nil
+	}
+
+	if output != outputJSON {
+		return "", nil, errors.NewFriendlyError("Unsupported output format %q. "+
+			"The only supported format is %q.", output, outputJSON)
+	}
+	return output, rest, nil
+}
+
+// capturedResult is the JSON document describing the result of running a
+// command with `--output json`.
+type capturedResult struct {
+	Service  string   `json:"service"`
+	Command  []string `json:"command"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr"`
+	ExitCode int      `json:"exit_code"`
+
+	// Duration is how long the command took to run, in seconds.
+	Duration float64 `json:"duration"`
+
+	// Error is set if the command couldn't be run, as opposed to the command
+	// exiting with a non-zero exit code.
+	Error string `json:"error,omitempty"`
+}
+
+// runCaptured runs the command in each service, and prints the results as
+// JSON once all the commands complete. If a single service is given, a single
+// object is printed. Otherwise, a list of objects is printed.
+func runCaptured(services []string, all bool, cmd []string) error {
+	auth, err := authstore.New()
+	if err != nil {
+		return errors.WithContext("parse auth config", err)
+	}
+
+	if auth.AuthToken == "" {
+		fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+		return nil
+	}
+
+	if all {
+		services, err = getRunningServices(auth.AuthToken)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, svc := range services {
+			if err := manager.CheckServiceRunning(svc, auth.AuthToken); err != nil {
+				return errors.WithContext(fmt.Sprintf("check %s", svc), err)
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	results := make([]capturedResult, len(services))
+	for i, svc := range services {
+		wg.Add(1)
+		go func(i int, svc string) {
+			defer wg.Done()
+			results[i] = runCapturedInService(auth, svc, cmd)
+		}(i, svc)
+	}
+	wg.Wait()
+
+	var toPrint interface{} = results
+	if !all && len(results) == 1 {
+		toPrint = results[0]
+	}
+
+	out, err := json.MarshalIndent(toPrint, "", "  ")
+	if err != nil {
+		return errors.WithContext("marshal results", err)
+	}
+	fmt.Println(string(out))
+
+	var exitCode int
+	for _, result := range results {
+		if result.ExitCode > exitCode {
+			exitCode = result.ExitCode
+		}
+	}
+	if exitCode != 0 {
+		errors.Exit(exitCode)
+	}
+	return nil
+}
+
+func runCapturedInService(auth authstore.Store, svc string, cmd []string) capturedResult {
+	var stdout, stderr bytes.Buffer
+	start := time.Now()
+	result := runInService(auth, svc, cmd, &stdout, &stderr)
+
+	captured := capturedResult{
+		Service:  svc,
+		Command:  cmd,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+		ExitCode: result.exitCode,
+		Duration: time.Since(start).Seconds(),
+	}
+	if result.err != nil {
+		captured.Error = errors.GetPrintableMessage(result.err)
+	}
+	return captured
+}
@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
 
+	"github.com/kelda/blimp/cli/audit"
 	"github.com/kelda/blimp/cli/authstore"
 	"github.com/kelda/blimp/cli/manager"
 	"github.com/kelda/blimp/pkg/errors"
@@ -20,7 +21,19 @@ func New() *cobra.Command {
 	usageMsg := "exec [-h | --help ] SERVICE CMD [ARGS...]"
 	helpMsg := "Run a command in a service." +
 		"\n\n" +
-		"Usage: blimp " + usageMsg + "\n"
+		"Usage: blimp " + usageMsg + "\n" +
+		"       blimp exec --all CMD [ARGS...]\n" +
+		"       blimp exec --services SERVICE[,SERVICE...] CMD [ARGS...]\n" +
+		"       blimp exec --output json [--services SERVICE[,SERVICE...] | SERVICE] CMD [ARGS...]\n" +
+		"\n" +
+		"When multiple services are specified with --services, or --all is used, the\n" +
+		"command is run concurrently in each service. The output of each command is\n" +
+		"prefixed with the name of its service, and blimp exits with a non-zero status\n" +
+		"if the command failed in any service. A `--` after --services or --all is\n" +
+		"ignored, so that commands starting with a dash can be run.\n" +
+		"\n" +
+		"With --output json, the command's stdout, stderr, exit code, and duration\n" +
+		"are captured and printed as a single JSON document once it completes.\n"
 
 	execCmd := cobra.Command{
 		Use:   usageMsg,
@@ -30,14 +43,57 @@ func New() *cobra.Command {
 		DisableFlagParsing:    true,
 		DisableFlagsInUseLine: true,
 		Run: func(_ *cobra.Command, args []string) {
-			if args[0] == "--help" || args[0] == "-h" {
+			if len(args) > 0 && (args[0] == "--help" || args[0] == "-h") {
 				fmt.Fprintf(os.Stdout, helpMsg)
-				os.Exit(1)
+				errors.Exit(1)
+			}
+
+			output, args, err := parseOutputFlag(args)
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			services, all, args, parallel, err := parseServicesFlag(args)
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if output == outputJSON {
+				if !parallel && len(args) >= 1 {
+					services, args = args[:1], args[1:]
+				}
+				if (len(services) == 0 && !all) || len(args) == 0 {
+					fmt.Fprintf(os.Stderr, "Service and command need to be defined\n")
+					errors.Exit(1)
+				}
+
+				if err := runCaptured(services, all, args); err != nil {
+					errors.HandleFatalError(err)
+				}
+				return
+			}
+
+			if parallel {
+				if len(args) == 0 {
+					fmt.Fprintf(os.Stderr, "A command needs to be defined\n")
+					errors.Exit(1)
+				}
+
+				// A single service doesn't need any of the parallel machinery,
+				// so give it an interactive session like a normal exec.
+				if !all && len(services) == 1 {
+					args = append([]string{services[0]}, args...)
+				} else {
+					if err := runParallel(services, all, args); err != nil {
+						errors.HandleFatalError(err)
+					}
+					return
+				}
 			}
 
 			if len(args) < 2 {
 				fmt.Fprintf(os.Stderr, "Service and command need to be defined\n")
-				os.Exit(1)
+				errors.Exit(1)
 			}
 
 			if err := run(args[0], args[1], args[2:]); err != nil {
@@ -66,11 +122,6 @@ func run(svc, cmd string, cmdArguments []string) error {
 		return err
 	}
 
-	kubeClient, restConfig, err := auth.KubeClient()
-	if err != nil {
-		return errors.WithContext("get kube client", err)
-	}
-
 	// Put the terminal into raw mode to prevent it echoing characters twice.
 	tty := terminal.IsTerminal(0)
 	if tty {
@@ -91,20 +142,17 @@ func run(svc, cmd string, cmdArguments []string) error {
 		Stderr:  true,
 		TTY:     tty,
 	}
+	recorder := audit.StartRecording(auth.AuthToken, svc, execOpts.Command)
+	defer recorder.Close()
+
 	streamOpts := remotecommand.StreamOptions{
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
+		Stdin:  recorder.Stdin(os.Stdin),
+		Stdout: recorder.Stdout(os.Stdout),
+		Stderr: recorder.Stdout(os.Stderr),
 		Tty:    tty,
 	}
 
-	req := kubeClient.CoreV1().RESTClient().Post().
-		Resource("pods").
-		SubResource("exec").
-		Name(names.PodName(svc)).
-		Namespace(auth.KubeNamespace).
-		VersionedParams(&execOpts, scheme.ParameterCodec)
-	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	exec, err := newExecutor(auth, svc, execOpts)
 	if err != nil {
 		return errors.WithContext("setup remote shell", err)
 	}
@@ -115,3 +163,18 @@ func run(svc, cmd string, cmdArguments []string) error {
 	}
 	return nil
 }
+
+func newExecutor(auth authstore.Store, svc string, execOpts core.PodExecOptions) (remotecommand.Executor, error) {
+	kubeClient, restConfig, err := auth.KubeClient()
+	if err != nil {
+		return nil, errors.WithContext("get kube client", err)
+	}
+
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		SubResource("exec").
+		Name(names.PodName(svc)).
+		Namespace(auth.KubeNamespace).
+		VersionedParams(&execOpts, scheme.ParameterCodec)
+	return remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+}
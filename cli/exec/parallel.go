@@ -0,0 +1,249 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// parseServicesFlag strips a leading `--all`, `--services SERVICES`, or
+// `--services=SERVICES` from the arguments, where SERVICES is a
+// comma-separated list of services to run the command in. A `--` after the
+// flag is stripped as well, so that the command can start with a dash. `ok`
+// is false if neither flag was given, in which case the first argument is the
+// service.
+func parseServicesFlag(args []string) (services []string, all bool, rest []string, ok bool, err error) {
+	if len(args) == 0 {
+		return nil, false, args, false, nil
+	}
+
+	var list string
+	switch {
+	case args[0] == "--all":
+		all, rest = true, args[1:]
+	case args[0] == "--services":
+		if len(args) < 2 {
+			return nil, false, nil, false, errors.NewFriendlyError("--services requires a list of services")
+		}
+		list, rest = args[1], args[2:]
+	case strings.HasPrefix(args[0], "--services="):
+		list, rest = strings.TrimPrefix(args[0], "--services="), args[1:]
+	default:
+		return nil, false, args, false, nil
+	}
+
+	if !all {
+		for _, svc := range strings.Split(list, ",") {
+			if svc = strings.TrimSpace(svc); svc != "" {
+				services = append(services, svc)
+			}
+		}
+
+		if len(services) == 0 {
+			return nil, false, nil, false, errors.NewFriendlyError("--services requires a list of services")
+		}
+	}
+
+	if len(rest) != 0 && rest[0] == "--" {
+		rest = rest[1:]
+	}
+	return services, all, rest, true, nil
+}
+
+type execResult struct {
+	service  string
+	exitCode int
+	err      error
+}
+
+func runParallel(services []string, all bool, cmd []string) error {
+	auth, err := authstore.New()
+	if err != nil {
+		return errors.WithContext("parse auth config", err)
+	}
+
+	if auth.AuthToken == "" {
+		fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+		return nil
+	}
+
+	if all {
+		services, err = getRunningServices(auth.AuthToken)
+		if err != nil {
+			return err
+		}
+
+		if len(services) == 0 {
+			return errors.NewFriendlyError(
+				"No services are running. You can check their status with `blimp ps`.")
+		}
+	} else {
+		for _, svc := range services {
+			if err := manager.CheckServiceRunning(svc, auth.AuthToken); err != nil {
+				return errors.WithContext(fmt.Sprintf("check %s", svc), err)
+			}
+		}
+	}
+
+	// Serialize writes to the terminal so that lines from different services
+	// don't get interleaved mid-line.
+	var outputLock sync.Mutex
+	var wg sync.WaitGroup
+	results := make([]execResult, len(services))
+	for i, svc := range services {
+		wg.Add(1)
+		go func(i int, svc string) {
+			defer wg.Done()
+
+			stdout := newPrefixWriter(os.Stdout, svc, &outputLock)
+			stderr := newPrefixWriter(os.Stderr, svc, &outputLock)
+			results[i] = runInService(auth, svc, cmd, stdout, stderr)
+			stdout.Flush()
+			stderr.Flush()
+		}(i, svc)
+	}
+	wg.Wait()
+
+	exitCode := printSummary(results)
+	if exitCode != 0 {
+		errors.Exit(exitCode)
+	}
+	return nil
+}
+
+func runInService(auth authstore.Store, svc string, cmd []string, stdout, stderr io.Writer) execResult {
+	execOpts := core.PodExecOptions{
+		Command: cmd,
+		Stdout:  true,
+		Stderr:  true,
+	}
+	exec, err := newExecutor(auth, svc, execOpts)
+	if err != nil {
+		return execResult{service: svc, exitCode: 1,
+			err: errors.WithContext("setup remote command", err)}
+	}
+
+	err = exec.Stream(remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if err != nil {
+		if exitErr, ok := err.(utilexec.ExitError); ok && exitErr.Exited() {
+			return execResult{service: svc, exitCode: exitErr.ExitStatus()}
+		}
+		return execResult{service: svc, exitCode: 1, err: err}
+	}
+	return execResult{service: svc}
+}
+
+// printSummary prints the result of the command in each service, and returns
+// the exit code that blimp should exit with. If the command failed in any
+// service, the highest exit code is returned.
+func printSummary(results []execResult) int {
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].service < results[j].service
+	})
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "SERVICE\tEXIT CODE\tERROR")
+
+	var exitCode int
+	for _, result := range results {
+		var errMsg string
+		if result.err != nil {
+			errMsg = errors.GetPrintableMessage(result.err)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\n", result.service, result.exitCode, errMsg)
+
+		if result.exitCode > exitCode {
+			exitCode = result.exitCode
+		}
+	}
+	return exitCode
+}
+
+func getRunningServices(authToken string) ([]string, error) {
+	statusResp, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return nil, errors.WithContext("get status", err)
+	}
+
+	status := statusResp.GetStatus()
+	if status.GetPhase() != cluster.SandboxStatus_RUNNING {
+		return nil, errors.NewFriendlyError(
+			"Your sandbox is not booted. Please run `blimp up` first.")
+	}
+
+	var services []string
+	for name, svcStatus := range status.GetServices() {
+		phase := svcStatus.GetPhase()
+		if phase == cluster.ServicePhase_RUNNING || phase == cluster.ServicePhase_UNHEALTHY {
+			services = append(services, name)
+		}
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+// prefixWriter prefixes each line written to it with the name of a service.
+// Partial lines are buffered until they're completed, or until Flush is
+// called.
+type prefixWriter struct {
+	out    io.Writer
+	prefix string
+	lock   *sync.Mutex
+	buf    []byte
+}
+
+func newPrefixWriter(out io.Writer, svc string, lock *sync.Mutex) *prefixWriter {
+	return &prefixWriter{out: out, prefix: svc + " › ", lock: lock}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.writeLine(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes any buffered partial line.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) == 0 {
+		return
+	}
+
+	w.writeLine(append(w.buf, '\n'))
+	w.buf = nil
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+}
@@ -0,0 +1,90 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServicesFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expServices []string
+		expAll      bool
+		expRest     []string
+		expOK       bool
+		expErr      bool
+	}{
+		{
+			name:    "Single service",
+			args:    []string{"web", "git", "log", "--", "file"},
+			expRest: []string{"web", "git", "log", "--", "file"},
+		},
+		{
+			name:    "All",
+			args:    []string{"--all", "uptime"},
+			expAll:  true,
+			expRest: []string{"uptime"},
+			expOK:   true,
+		},
+		{
+			name:        "Services",
+			args:        []string{"--services", "web,worker", "git", "log", "--", "file"},
+			expServices: []string{"web", "worker"},
+			expRest:     []string{"git", "log", "--", "file"},
+			expOK:       true,
+		},
+		{
+			name:        "Services with equals",
+			args:        []string{"--services=web, worker", "uptime"},
+			expServices: []string{"web", "worker"},
+			expRest:     []string{"uptime"},
+			expOK:       true,
+		},
+		{
+			name:        "Separator after the flag",
+			args:        []string{"--services", "web", "--", "-c", "echo"},
+			expServices: []string{"web"},
+			expRest:     []string{"-c", "echo"},
+			expOK:       true,
+		},
+		{
+			name:    "Separator only stripped once",
+			args:    []string{"--all", "--", "--", "echo"},
+			expAll:  true,
+			expRest: []string{"--", "echo"},
+			expOK:   true,
+		},
+		{
+			name:   "Missing services",
+			args:   []string{"--services"},
+			expErr: true,
+		},
+		{
+			name:   "Empty services",
+			args:   []string{"--services=,", "uptime"},
+			expErr: true,
+		},
+		{
+			name: "No arguments",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			services, all, rest, ok, err := parseServicesFlag(test.args)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expServices, services)
+			assert.Equal(t, test.expAll, all)
+			assert.Equal(t, test.expRest, rest)
+			assert.Equal(t, test.expOK, ok)
+		})
+	}
+}
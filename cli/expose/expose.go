@@ -0,0 +1,124 @@
+package expose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "expose",
+		Short: "Inspect the public URLs of services",
+	}
+	cobraCmd.AddCommand(newLogsCommand())
+	return cobraCmd
+}
+
+func newLogsCommand() *cobra.Command {
+	var follow bool
+	var since time.Duration
+	cobraCmd := &cobra.Command{
+		Use:   "logs SERVICE",
+		Short: "Print the requests made to a service's public URL",
+		Long: "Print the requests made to a service's public URL.\n\n" +
+			"Requests are logged by the proxy in front of the service, so requests that " +
+			"never reached the service, such as ones made while it was booting, are included. " +
+			"This is useful for checking whether a webhook provider actually called your URL.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Fprintln(os.Stderr, "Exactly one service is required")
+				errors.Exit(1)
+			}
+
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			if err := printLogs(auth.AuthToken, args[0], since, follow); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&follow, "follow", "f", false,
+		"Keep printing new requests as they're made.")
+	cobraCmd.Flags().DurationVarP(&since, "since", "", 0,
+		"Only print requests newer than a relative duration like 5s, 2m, or 3h.")
+	return cobraCmd
+}
+
+func printLogs(authToken, svc string, since time.Duration, follow bool) error {
+	req := &cluster.WatchExposeLogsRequest{
+		Token:   authToken,
+		Service: svc,
+		Follow:  follow,
+	}
+	if since != 0 {
+		req.Since = time.Now().Add(-since).Unix()
+	}
+
+	stream, err := manager.C.WatchExposeLogs(context.Background(), req)
+	if err != nil {
+		return errors.WithContext("watch request logs", err)
+	}
+
+	// Flush after each batch so that requests show up immediately when
+	// following.
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	for {
+		msg, err := stream.Recv()
+		switch {
+		case err == io.EOF:
+			return nil
+		case status.Code(err) == codes.Unimplemented:
+			return errors.NewFriendlyError(
+				"The Blimp cluster doesn't support request logs for public URLs.")
+		case err != nil:
+			return errors.WithContext("read request logs", err)
+		}
+
+		if err := errors.Unmarshal(nil, msg.Error); err != nil {
+			return err
+		}
+
+		for _, entry := range msg.Requests {
+			fmt.Fprintln(w, formatRequest(entry))
+		}
+		w.Flush()
+	}
+}
+
+func formatRequest(req *cluster.HTTPRequestLog) string {
+	respStatus := "-"
+	if req.Status != 0 {
+		respStatus = fmt.Sprintf("%d", req.Status)
+	}
+
+	return fmt.Sprintf("%s\t%s\t%s%s\t%s\t%s\t%s",
+		time.Unix(0, req.Timestamp).Format(time.RFC3339),
+		req.Method,
+		req.Host,
+		req.Path,
+		respStatus,
+		time.Duration(req.LatencyMs)*time.Millisecond,
+		req.ClientIp)
+}
@@ -0,0 +1,312 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/buger/goterm"
+	composeTypes "github.com/kelda/compose-go/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/ps"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+const (
+	FormatDot     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+// The status classes that nodes are colored by.
+const (
+	statusRunning = "running"
+	statusPending = "pending"
+	statusFailed  = "failed"
+)
+
+// defaultNetwork is the network that services are connected to if they don't
+// specify any networks. It's left out of the graph since it would connect
+// every service.
+const defaultNetwork = "default"
+
+func New() *cobra.Command {
+	var composePaths []string
+	var projectDir string
+	var format string
+	cobraCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Print a graph of the dependencies between services",
+		Long: "Print a graph of the dependencies between the services in the Docker Compose file.\n\n" +
+			"The graph includes depends_on, links, and the networks that services share. " +
+			"If your sandbox is running, each service is annotated with its status.\n\n" +
+			"The graph is printed in Graphviz's DOT format by default, which can be rendered " +
+			"with `blimp graph | dot -Tsvg > graph.svg`. Mermaid is also supported for " +
+			"embedding graphs in Markdown.",
+		Run: func(_ *cobra.Command, args []string) {
+			if format != FormatDot && format != FormatMermaid {
+				fmt.Fprintf(os.Stderr, "Unknown format %q. It must be either %q or %q.\n",
+					format, FormatDot, FormatMermaid)
+				errors.Exit(1)
+			}
+
+			composePath, overridePaths, err := util.GetComposePaths(projectDir, composePaths)
+			if err != nil {
+				if os.IsNotExist(err) {
+					log.Fatal("Docker Compose file not found.\n" +
+						"Blimp must be run from the same directory as docker-compose.yml.")
+				}
+				log.WithError(err).Fatal("Failed to get absolute path to Compose file")
+			}
+
+			if projectDir == "" {
+				projectDir = filepath.Dir(composePath)
+			}
+
+			cfg, err := dockercompose.LoadProject(projectDir, composePath, overridePaths, nil)
+			if err != nil {
+				errors.HandleFatalError(errors.WithContext("load compose file", err))
+			}
+
+			g := newGraph(cfg, getStatuses())
+			if format == FormatMermaid {
+				g.writeMermaid(os.Stdout)
+			} else {
+				g.writeDot(os.Stdout)
+			}
+		},
+	}
+	cobraCmd.Flags().StringVarP(&format, "format", "", FormatDot,
+		fmt.Sprintf("The format of the graph. Either %q or %q.", FormatDot, FormatMermaid))
+	cobraCmd.Flags().StringSliceVarP(&composePaths, "file", "f", nil,
+		"Specify an alternate compose file\nDefaults to docker-compose.yml and docker-compose.yaml")
+	cobraCmd.Flags().StringVarP(&projectDir, "project-dir", "", "",
+		"Specify an alternate working directory\nDefaults to the directory of the first compose file")
+	return cobraCmd
+}
+
+// getStatuses returns the status of each service in the sandbox. The graph
+// can be printed without a sandbox, so it returns nil if the user isn't
+// logged in, or the sandbox isn't running.
+func getStatuses() map[string]*cluster.ServiceStatus {
+	auth, err := authstore.New()
+	if err != nil || auth.AuthToken == "" {
+		return nil
+	}
+
+	statusResp, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{
+		Token: auth.AuthToken,
+	})
+	if err != nil {
+		log.WithError(err).Debug("Failed to get sandbox status")
+		return nil
+	}
+
+	if statusResp.GetStatus().GetPhase() != cluster.SandboxStatus_RUNNING {
+		return nil
+	}
+	return statusResp.GetStatus().GetServices()
+}
+
+type graph struct {
+	services []service
+	edges    []edge
+
+	// The networks other than the default network, and the services
+	// connected to them.
+	networks map[string][]string
+}
+
+type service struct {
+	name string
+
+	// The service's status, if the sandbox is running.
+	status      string
+	statusClass string
+}
+
+type edge struct {
+	from, to string
+	isLink   bool
+}
+
+func newGraph(cfg composeTypes.Config, statuses map[string]*cluster.ServiceStatus) graph {
+	g := graph{networks: map[string][]string{}}
+	for _, svc := range cfg.Services {
+		node := service{name: svc.Name}
+		if svcStatus, ok := statuses[svc.Name]; ok {
+			node.status, node.statusClass = getStatus(svcStatus)
+		}
+		g.services = append(g.services, node)
+
+		for dep := range svc.DependsOn {
+			g.edges = append(g.edges, edge{from: svc.Name, to: dep})
+		}
+
+		// Links also imply a dependency, so they're only drawn if they aren't
+		// already covered by depends_on.
+		for _, link := range svc.Links {
+			target := strings.SplitN(link, ":", 2)[0]
+			if _, ok := svc.DependsOn[target]; !ok {
+				g.edges = append(g.edges, edge{from: svc.Name, to: target, isLink: true})
+			}
+		}
+
+		for network := range svc.Networks {
+			if network != defaultNetwork {
+				g.networks[network] = append(g.networks[network], svc.Name)
+			}
+		}
+	}
+
+	sort.Slice(g.services, func(i, j int) bool {
+		return g.services[i].name < g.services[j].name
+	})
+	sort.Slice(g.edges, func(i, j int) bool {
+		if g.edges[i].from != g.edges[j].from {
+			return g.edges[i].from < g.edges[j].from
+		}
+		return g.edges[i].to < g.edges[j].to
+	})
+	for _, services := range g.networks {
+		sort.Strings(services)
+	}
+	return g
+}
+
+// getStatus returns a short description of the service's status, and the
+// class used to color it.
+func getStatus(svcStatus *cluster.ServiceStatus) (string, string) {
+	// Only use the first sentence of the phase description, and skip the
+	// details in svcStatus.Msg, so that the nodes stay small.
+	msg, color, _ := ps.GetStatusString(&cluster.ServiceStatus{Phase: svcStatus.GetPhase()})
+	msg = strings.SplitN(msg, ". ", 2)[0]
+
+	switch color {
+	case goterm.GREEN:
+		return msg, statusRunning
+	case goterm.RED:
+		return msg, statusFailed
+	default:
+		return msg, statusPending
+	}
+}
+
+func (g graph) sortedNetworks() []string {
+	var networks []string
+	for network := range g.networks {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+var dotColors = map[string]string{
+	statusRunning: "palegreen",
+	statusPending: "lightyellow",
+	statusFailed:  "lightpink",
+}
+
+func (g graph) writeDot(out io.Writer) {
+	fmt.Fprintln(out, "digraph services {")
+	fmt.Fprintln(out, "  rankdir=LR;")
+	fmt.Fprintln(out, `  node [shape=box, style="rounded,filled", fillcolor=white];`)
+
+	for _, svc := range g.services {
+		if svc.status == "" {
+			fmt.Fprintf(out, "  %q;\n", svc.name)
+			continue
+		}
+		fmt.Fprintf(out, "  %q [label=%q, fillcolor=%s];\n",
+			svc.name, svc.name+"\n"+svc.status, dotColors[svc.statusClass])
+	}
+
+	for _, e := range g.edges {
+		if e.isLink {
+			fmt.Fprintf(out, "  %q -> %q [style=dashed, label=\"link\"];\n", e.from, e.to)
+		} else {
+			fmt.Fprintf(out, "  %q -> %q;\n", e.from, e.to)
+		}
+	}
+
+	for _, network := range g.sortedNetworks() {
+		node := "network:" + network
+		fmt.Fprintf(out, "  %q [label=%q, shape=ellipse, style=dashed];\n", node, network)
+		for _, svc := range g.networks[network] {
+			fmt.Fprintf(out, "  %q -> %q [dir=none, style=dotted];\n", svc, node)
+		}
+	}
+	fmt.Fprintln(out, "}")
+}
+
+var mermaidStyles = map[string]string{
+	statusRunning: "fill:#cfc,stroke:#393",
+	statusPending: "fill:#ffc,stroke:#cc3",
+	statusFailed:  "fill:#fcc,stroke:#c33",
+}
+
+func (g graph) writeMermaid(out io.Writer) {
+	fmt.Fprintln(out, "graph LR")
+
+	// Mermaid IDs can't contain all the characters that are allowed in
+	// service names, so the nodes are referenced by index.
+	ids := map[string]string{}
+	classes := map[string][]string{}
+	for i, svc := range g.services {
+		id := fmt.Sprintf("svc%d", i)
+		ids[svc.name] = id
+
+		label := svc.name
+		if svc.status != "" {
+			label += "<br/>" + svc.status
+			classes[svc.statusClass] = append(classes[svc.statusClass], id)
+		}
+		fmt.Fprintf(out, "  %s[%q]\n", id, label)
+	}
+
+	// Services that are referenced but not defined are still drawn, so that
+	// typos in the Compose file are visible.
+	nodeID := func(name string) string {
+		if id, ok := ids[name]; ok {
+			return id
+		}
+		id := fmt.Sprintf("svc%d", len(ids))
+		ids[name] = id
+		fmt.Fprintf(out, "  %s[%q]\n", id, name)
+		return id
+	}
+
+	for _, e := range g.edges {
+		if e.isLink {
+			fmt.Fprintf(out, "  %s -.->|link| %s\n", nodeID(e.from), nodeID(e.to))
+		} else {
+			fmt.Fprintf(out, "  %s --> %s\n", nodeID(e.from), nodeID(e.to))
+		}
+	}
+
+	for i, network := range g.sortedNetworks() {
+		id := fmt.Sprintf("net%d", i)
+		fmt.Fprintf(out, "  %s((%q))\n", id, network)
+		for _, svc := range g.networks[network] {
+			fmt.Fprintf(out, "  %s -.- %s\n", nodeID(svc), id)
+		}
+	}
+
+	for _, class := range []string{statusRunning, statusPending, statusFailed} {
+		if len(classes[class]) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "  classDef %s %s\n", class, mermaidStyles[class])
+		fmt.Fprintf(out, "  class %s %s\n", strings.Join(classes[class], ","), class)
+	}
+}
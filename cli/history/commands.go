@@ -0,0 +1,347 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/cfgdir"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/hash"
+)
+
+// commandsFile is where the commands run by the user are recorded, so that
+// they can be listed with `blimp history commands`, and rerun with `blimp replay`.
+var commandsFile = cfgdir.Expand("command-history.json")
+
+// commandsLockFile is locked while the command history is updated, so that
+// concurrent blimp processes don't overwrite each other's entries.
+var commandsLockFile = cfgdir.Expand("command-history.lock")
+
+// maxCommands is the number of commands that are kept in the history. Older
+// commands are dropped.
+const maxCommands = 1000
+
+// The results of recorded commands.
+const (
+	ResultRunning   = "running"
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+)
+
+// Command is a recorded invocation of the CLI.
+type Command struct {
+	ID        int           `json:"id"`
+	StartedAt time.Time     `json:"started_at"`
+	Args      []string      `json:"args"`
+	Dir       string        `json:"dir"`
+	Result    string        `json:"result"`
+	Duration  time.Duration `json:"duration"`
+
+	// ComposeHash is the hash of the resolved Compose config, for commands
+	// that deploy the Compose file. It's used to warn when a replayed command
+	// would run against a different config.
+	ComposeHash string `json:"compose_hash,omitempty"`
+
+	// PID is the process that ran the command. Commands that are still
+	// "running" after their process exited didn't record their result.
+	PID int `json:"pid"`
+
+	// Redacted is whether values were removed from Args because they might
+	// contain secrets. Redacted commands can't be replayed.
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// skipCommands are the commands that aren't recorded, either because they're
+// only used to inspect or replay the history, or because their arguments are
+// credentials.
+var skipCommands = map[string]struct{}{
+	"commands":                      {},
+	"completion":                    {},
+	"history":                       {},
+	"loginpw":                       {},
+	"replay":                        {},
+	cobra.ShellCompRequestCmd:       {},
+	cobra.ShellCompNoDescRequestCmd: {},
+}
+
+// secretFlags are the flags whose values might contain secrets, such as the
+// environment variables passed to `blimp restart -e`, or the Authorization
+// headers passed to `blimp curl -H`.
+var secretFlags = map[string]struct{}{
+	"data":     {},
+	"env":      {},
+	"header":   {},
+	"password": {},
+}
+
+// secretArgCommands are the commands whose positional arguments might
+// contain secrets, keyed by their command path.
+var secretArgCommands = map[string]struct{}{
+	"blimp env set": {},
+}
+
+// composeHashCommands are the commands whose Compose config is hashed when
+// they start. The hash is only recorded for commands that deploy the config
+// since loading it is too slow to do for every command.
+var composeHashCommands = map[string]struct{}{
+	"up": {},
+}
+
+// redactedValue replaces the values that are removed from recorded commands.
+const redactedValue = "<redacted>"
+
+// current is the command being run by this process, if it's recorded.
+var current *Command
+
+// Start records that the command started. Finish should be called once it
+// completes.
+func Start(cmd *cobra.Command) {
+	if _, ok := skipCommands[cmd.Name()]; ok {
+		return
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.WithError(err).Debug("Failed to get working directory for command history")
+	}
+
+	args, redacted := redactArgs(cmd, os.Args[1:])
+	current = &Command{
+		StartedAt:   time.Now(),
+		Args:        args,
+		Dir:         dir,
+		Result:      ResultRunning,
+		ComposeHash: getComposeHash(cmd),
+		PID:         os.Getpid(),
+		Redacted:    redacted,
+	}
+
+	updateCommands(func(commands []Command) []Command {
+		if len(commands) != 0 {
+			current.ID = commands[len(commands)-1].ID + 1
+		} else {
+			current.ID = 1
+		}
+		return append(commands, *current)
+	})
+}
+
+// Finish records the result of the command started by Start.
+func Finish(err error) {
+	if current == nil {
+		return
+	}
+
+	current.Duration = time.Since(current.StartedAt)
+	current.Result = ResultSucceeded
+	if err != nil {
+		current.Result = ResultFailed
+	}
+
+	updateCommands(func(commands []Command) []Command {
+		for i, cmd := range commands {
+			if cmd.ID == current.ID && cmd.PID == current.PID {
+				commands[i] = *current
+			}
+		}
+		return commands
+	})
+	current = nil
+}
+
+// redactArgs removes the values of secretFlags from the arguments, as well as
+// the positional arguments of secretArgCommands. It returns whether anything
+// was removed.
+func redactArgs(cmd *cobra.Command, args []string) ([]string, bool) {
+	_, redactPositional := secretArgCommands[cmd.CommandPath()]
+
+	var redacted []string
+	var changed, redactNext, flagsDone bool
+	for _, arg := range args {
+		switch {
+		case redactNext:
+			arg = redactedValue
+			redactNext = false
+			changed = true
+
+		case flagsDone || !strings.HasPrefix(arg, "-") || arg == "-":
+			if redactPositional {
+				if kv := strings.SplitN(arg, "=", 2); len(kv) == 2 {
+					arg = kv[0] + "=" + redactedValue
+					changed = true
+				}
+			}
+
+		case arg == "--":
+			flagsDone = true
+
+		case strings.HasPrefix(arg, "--"):
+			kv := strings.SplitN(strings.TrimPrefix(arg, "--"), "=", 2)
+			flag := cmd.Flags().Lookup(kv[0])
+			if flag == nil {
+				break
+			}
+
+			if _, ok := secretFlags[flag.Name]; ok {
+				if len(kv) == 2 {
+					arg = "--" + kv[0] + "=" + redactedValue
+					changed = true
+				} else {
+					redactNext = flag.NoOptDefVal == ""
+				}
+			}
+
+		default:
+			// Only the last flag in a group of shorthands, such as `-it`,
+			// can take a value.
+			shorthands := strings.TrimPrefix(arg, "-")
+			for i, shorthand := range shorthands {
+				flag := cmd.Flags().ShorthandLookup(string(shorthand))
+				if flag == nil || flag.NoOptDefVal != "" {
+					continue
+				}
+
+				if _, ok := secretFlags[flag.Name]; ok {
+					if i == len(shorthands)-1 {
+						redactNext = true
+					} else {
+						arg = "-" + shorthands[:i+1] + redactedValue
+						changed = true
+					}
+				}
+				break
+			}
+		}
+		redacted = append(redacted, arg)
+	}
+	return redacted, changed
+}
+
+// getComposeHash returns the hash of the Compose config for
+// composeHashCommands, or an empty string if the config can't be loaded.
+func getComposeHash(cmd *cobra.Command) string {
+	if _, ok := composeHashCommands[cmd.Name()]; !ok {
+		return ""
+	}
+
+	dirFlag := cmd.Flags().Lookup("project-dir")
+	if cmd.Flags().Lookup("file") == nil || dirFlag == nil {
+		return ""
+	}
+
+	composePaths, _ := cmd.Flags().GetStringSlice("file")
+	projectDir := dirFlag.Value.String()
+	composePath, overridePaths, err := util.GetComposePaths(projectDir, composePaths)
+	if err != nil {
+		return ""
+	}
+
+	if projectDir == "" {
+		projectDir = filepath.Dir(composePath)
+	}
+
+	cfg, err := dockercompose.LoadProject(projectDir, composePath, overridePaths, nil)
+	if err != nil {
+		return ""
+	}
+
+	cfgBytes, err := dockercompose.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	return hash.Bytes(cfgBytes)
+}
+
+func readCommands() []Command {
+	commandsBytes, err := ioutil.ReadFile(commandsFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithError(err).Debug("Failed to read command history")
+		}
+		return nil
+	}
+
+	var commands []Command
+	if err := json.Unmarshal(commandsBytes, &commands); err != nil {
+		log.WithError(err).Debug("Failed to parse command history")
+		return nil
+	}
+	return commands
+}
+
+// updateCommands applies `update` to the command history while holding the
+// history's lock, and saves the result.
+func updateCommands(update func([]Command) []Command) {
+	lockFile, err := os.OpenFile(commandsLockFile, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		log.WithError(err).Debug("Failed to open command history lock")
+		return
+	}
+	defer lockFile.Close()
+
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		log.WithError(err).Debug("Failed to lock command history")
+		return
+	}
+	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+
+	writeCommands(update(readCommands()))
+}
+
+func writeCommands(commands []Command) {
+	if len(commands) > maxCommands {
+		commands = commands[len(commands)-maxCommands:]
+	}
+
+	commandsBytes, err := json.Marshal(commands)
+	if err != nil {
+		log.WithError(err).Debug("Failed to marshal command history")
+		return
+	}
+
+	// The history is only readable by the user since commands such as
+	// `blimp exec` might still include sensitive arguments.
+	if err := ioutil.WriteFile(commandsFile, commandsBytes, 0600); err != nil {
+		log.WithError(err).Debug("Failed to write command history")
+	}
+}
+
+// getResultString returns the command's result. Commands that exited
+// without recording their result, such as because they were killed, are
+// shown as unknown.
+func getResultString(cmd Command) string {
+	if cmd.Result == ResultRunning && !util.ProcessAlive(cmd.PID) {
+		return "unknown"
+	}
+	return cmd.Result
+}
+
+func printCommands(commands []Command) {
+	if len(commands) == 0 {
+		fmt.Println("No commands have been recorded yet.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "ID\tTIME\tRESULT\tDURATION\tDIRECTORY\tCOMMAND")
+	for _, cmd := range commands {
+		duration := "-"
+		if cmd.Result != ResultRunning {
+			duration = cmd.Duration.Round(time.Second).String()
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\tblimp %s\n", cmd.ID, cmd.StartedAt.Format(time.Stamp),
+			getResultString(cmd), duration, cmd.Dir, strings.Join(cmd.Args, " "))
+	}
+}
@@ -0,0 +1,129 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func TestRedactArgs(t *testing.T) {
+	cmd := &cobra.Command{Use: "curl"}
+	cmd.Flags().StringArrayP("header", "H", nil, "")
+	cmd.Flags().StringArrayP("env", "e", nil, "")
+	cmd.Flags().StringP("output", "o", "", "")
+	cmd.Flags().BoolP("insecure", "k", false, "")
+	cmd.Flags().BoolP("verbose", "v", false, "")
+
+	tests := []struct {
+		name        string
+		args        []string
+		expArgs     []string
+		expRedacted bool
+	}{
+		{
+			name:    "No secrets",
+			args:    []string{"curl", "-o", "out.html", "-k", "web"},
+			expArgs: []string{"curl", "-o", "out.html", "-k", "web"},
+		},
+		{
+			name:        "Flag with equals",
+			args:        []string{"curl", "--header=Authorization: Bearer token", "web"},
+			expArgs:     []string{"curl", "--header=<redacted>", "web"},
+			expRedacted: true,
+		},
+		{
+			name:        "Flag with separate value",
+			args:        []string{"curl", "--header", "Authorization: Bearer token", "web"},
+			expArgs:     []string{"curl", "--header", "<redacted>", "web"},
+			expRedacted: true,
+		},
+		{
+			name:        "Shorthand with separate value",
+			args:        []string{"curl", "-H", "Authorization: Bearer token", "web"},
+			expArgs:     []string{"curl", "-H", "<redacted>", "web"},
+			expRedacted: true,
+		},
+		{
+			name:        "Shorthand group ending with secret flag",
+			args:        []string{"curl", "-kvH", "Authorization: Bearer token", "web"},
+			expArgs:     []string{"curl", "-kvH", "<redacted>", "web"},
+			expRedacted: true,
+		},
+		{
+			name:        "Shorthand group with attached value",
+			args:        []string{"curl", "-keSECRET=value", "web"},
+			expArgs:     []string{"curl", "-ke<redacted>", "web"},
+			expRedacted: true,
+		},
+		{
+			name:    "Shorthand group whose value isn't secret",
+			args:    []string{"curl", "-kvo", "out.html", "web"},
+			expArgs: []string{"curl", "-kvo", "out.html", "web"},
+		},
+		{
+			name:    "Arguments after --",
+			args:    []string{"curl", "--", "-H", "web"},
+			expArgs: []string{"curl", "--", "-H", "web"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			args, redacted := redactArgs(cmd, test.args)
+			assert.Equal(t, test.expArgs, args)
+			assert.Equal(t, test.expRedacted, redacted)
+		})
+	}
+}
+
+func TestFinish(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		expResult string
+	}{
+		{
+			name:      "Succeeded",
+			expResult: ResultSucceeded,
+		},
+		{
+			name:      "Failed",
+			err:       errors.New("exited with code 1"),
+			expResult: ResultFailed,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "command-history")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			commandsFile = filepath.Join(dir, "command-history.json")
+			commandsLockFile = filepath.Join(dir, "command-history.lock")
+
+			Start(&cobra.Command{Use: "ps"})
+			assert.Equal(t, ResultRunning, readCommands()[0].Result)
+
+			Finish(test.err)
+			commands := readCommands()
+			assert.Len(t, commands, 1)
+			assert.Equal(t, test.expResult, commands[0].Result)
+
+			// Later calls, such as from the exit handler after the command
+			// already finished, don't overwrite the result.
+			Finish(nil)
+			assert.Equal(t, test.expResult, readCommands()[0].Result)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "history SERVICE",
+		Short: "Print the health and restart history of a service",
+		Long: "Print the health and restart history of a service.\n\n" +
+			"The history covers the entire lifetime of the sandbox, so it can be used " +
+			"to investigate crashes that happened while you weren't watching.\n\n" +
+			"To print the blimp commands that you ran instead, use `blimp history commands`.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Fprintln(os.Stderr, "Exactly one service is required. "+
+					"To print the commands that you ran, use `blimp history commands`.")
+				errors.Exit(1)
+			}
+
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			if err := run(auth.AuthToken, args[0]); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.AddCommand(newCommandsCommand())
+	return cobraCmd
+}
+
+func newCommandsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "commands",
+		Short: "Print the blimp commands that you ran",
+		Long: "Print the blimp commands that you ran, along with their results.\n\n" +
+			"They can be rerun with `blimp replay ID`.",
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			printCommands(readCommands())
+		},
+	}
+}
+
+func run(authToken, svc string) error {
+	resp, err := manager.C.GetServiceHistory(context.Background(), &cluster.GetServiceHistoryRequest{
+		Token:   authToken,
+		Service: svc,
+	})
+	if err != nil {
+		return errors.WithContext("get service history", err)
+	}
+
+	if len(resp.Events) == 0 {
+		fmt.Printf("No events have been recorded for %s.\n", svc)
+		return nil
+	}
+
+	printHistory(resp.Events)
+	return nil
+}
+
+func printHistory(events []*cluster.ServiceEvent) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "TIME\tEVENT\tDETAILS")
+
+	for _, event := range events {
+		timestamp := time.Unix(event.Timestamp, 0).Format(time.Stamp)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", timestamp, getEventString(event.Type), event.Msg)
+	}
+}
+
+func getEventString(eventType cluster.ServiceEvent_Type) string {
+	switch eventType {
+	case cluster.ServiceEvent_STARTED:
+		return "Started"
+	case cluster.ServiceEvent_HEALTHY:
+		return "Became healthy"
+	case cluster.ServiceEvent_UNHEALTHY:
+		return "Became unhealthy"
+	case cluster.ServiceEvent_EXITED:
+		return "Exited"
+	case cluster.ServiceEvent_OOM_KILLED:
+		return "OOMKilled"
+	case cluster.ServiceEvent_RESTARTED:
+		return "Restarted"
+	case cluster.ServiceEvent_RESCHEDULED:
+		return "Moved to a new node"
+	default:
+		return "Unknown"
+	}
+}
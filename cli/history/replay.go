@@ -0,0 +1,104 @@
+package history
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/buger/goterm"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func NewReplayCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "replay ID",
+		Short: "Rerun a command from `blimp history commands`",
+		Long: "Rerun a command from `blimp history commands`, with the same arguments and in the same directory.\n\n" +
+			"A warning is printed if the Compose file has changed since the command was run.",
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				errors.HandleFatalError(errors.NewFriendlyError(
+					"Invalid command ID %q. Run `blimp history commands` to see the IDs.", args[0]))
+			}
+
+			code, err := replay(id)
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+			errors.Exit(code)
+		},
+	}
+}
+
+// replay reruns the command with the given ID, and returns its exit code.
+func replay(id int) (int, error) {
+	var cmd Command
+	var ok bool
+	for _, recorded := range readCommands() {
+		if recorded.ID == id {
+			cmd, ok = recorded, true
+		}
+	}
+	if !ok {
+		return 0, errors.NewFriendlyError("No command with ID %d. Run `blimp history commands` to see the IDs.", id)
+	}
+
+	if cmd.Redacted {
+		return 0, errors.NewFriendlyError("Command %d can't be replayed because it included "+
+			"values that might be secrets, which aren't recorded in the history.", id)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, errors.WithContext("get path to blimp", err)
+	}
+
+	fmt.Printf("Running `blimp %s` in %s\n", strings.Join(cmd.Args, " "), cmd.Dir)
+	if cmd.ComposeHash != "" {
+		// The replayed command records the hash of the config that it
+		// used, so the hashes are compared once it finishes.
+		defer warnIfComposeChanged(cmd)
+	}
+
+	replayCmd := exec.Command(executable, cmd.Args...)
+	replayCmd.Dir = cmd.Dir
+	replayCmd.Stdin = os.Stdin
+	replayCmd.Stdout = os.Stdout
+	replayCmd.Stderr = os.Stderr
+	if err := replayCmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return exitErr.ExitCode(), nil
+		}
+		return 0, errors.WithContext("run command", err)
+	}
+	return 0, nil
+}
+
+// warnIfComposeChanged warns if the Compose config used by the replay is
+// different from the config used by the original command, since that's the
+// most likely reason for the command to behave differently.
+func warnIfComposeChanged(original Command) {
+	commands := readCommands()
+	if len(commands) == 0 {
+		return
+	}
+
+	replayed := commands[len(commands)-1]
+	if replayed.ID <= original.ID || replayed.ComposeHash == "" {
+		log.Debug("Failed to find the replayed command in the history")
+		return
+	}
+
+	if replayed.ComposeHash != original.ComposeHash {
+		fmt.Fprintln(os.Stderr, goterm.Color(fmt.Sprintf(
+			"Warning: The Compose config has changed since command %d was run on %s.",
+			original.ID, original.StartedAt.Format("Jan 2 15:04")), goterm.YELLOW))
+	}
+}
@@ -0,0 +1,14 @@
+package image
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "image",
+		Short: "Manage the images used by services in your sandbox",
+	}
+	cobraCmd.AddCommand(newSaveCommand())
+	return cobraCmd
+}
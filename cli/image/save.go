@@ -0,0 +1,237 @@
+package image
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// refNameAnnotation is the OCI annotation for the name of an image in an
+// image layout.
+const refNameAnnotation = "org.opencontainers.image.ref.name"
+
+func newSaveCommand() *cobra.Command {
+	var output string
+	cobraCmd := &cobra.Command{
+		Use:   "save SERVICE",
+		Short: "Save the image that a service is running as an OCI archive",
+		Long: "Save the image that a service is running as an OCI archive.\n\n" +
+			"The archive contains the exact image that's running in the sandbox, " +
+			"so it can be scanned or archived by tools that support the OCI image layout.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Fprintln(os.Stderr, "Exactly one service is required")
+				errors.Exit(1)
+			}
+
+			if output == "" {
+				fmt.Fprintln(os.Stderr, "An output path is required")
+				errors.Exit(1)
+			}
+
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			if err := save(auth, args[0], output); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().StringVarP(&output, "output", "o", "",
+		"The path to write the archive to, such as web.oci.tar")
+	return cobraCmd
+}
+
+func save(auth authstore.Store, svc, output string) error {
+	ref, err := getRunningImage(auth, svc)
+	if err != nil {
+		return err
+	}
+
+	img, err := pullImage(ref, auth.AuthToken)
+	if err != nil {
+		return errors.WithContext("pull image", err)
+	}
+
+	// The layers are downloaded lazily when the image is written.
+	pp := util.NewProgressPrinter(os.Stdout, fmt.Sprintf("Pulling %s", ref))
+	go pp.Run()
+	err = writeArchive(img, ref, output)
+	pp.Stop()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved %s to %s\n", ref, output)
+	return nil
+}
+
+// writeArchive writes the image to path as a tar archive of an OCI image
+// layout.
+func writeArchive(img v1.Image, ref name.Reference, path string) error {
+	layoutDir, err := ioutil.TempDir("", "blimp-image-save")
+	if err != nil {
+		return errors.WithContext("create temp dir", err)
+	}
+	defer os.RemoveAll(layoutDir)
+
+	layoutPath, err := layout.Write(layoutDir, empty.Index)
+	if err != nil {
+		return errors.WithContext("create image layout", err)
+	}
+
+	err = layoutPath.AppendImage(img, layout.WithAnnotations(map[string]string{
+		refNameAnnotation: ref.String(),
+	}))
+	if err != nil {
+		return errors.WithContext("write image", err)
+	}
+
+	if err := writeTar(layoutDir, path); err != nil {
+		return errors.WithContext("write archive", err)
+	}
+	return nil
+}
+
+// getRunningImage returns a reference to the image that the service is
+// running. If the runtime reports the image's digest, the digest is used so
+// that the saved image is exactly what ran, even if the tag has since moved.
+func getRunningImage(auth authstore.Store, svc string) (name.Reference, error) {
+	kubeClient, _, err := auth.KubeClient()
+	if err != nil {
+		return nil, errors.WithContext("get kube client", err)
+	}
+
+	pod, err := kubeClient.CoreV1().Pods(auth.KubeNamespace).Get(names.PodName(svc), metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.WithContext("get pod", err)
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == names.LogCaptureContainer {
+			continue
+		}
+
+		imageID := strings.TrimPrefix(status.ImageID, "docker-pullable://")
+		if strings.Contains(imageID, "@") {
+			if ref, err := name.NewDigest(imageID); err == nil {
+				return ref, nil
+			}
+		}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name != names.LogCaptureContainer {
+			return name.ParseReference(container.Image)
+		}
+	}
+	return nil, errors.NewFriendlyError("%s isn't running any containers", svc)
+}
+
+// pullImage pulls the image using the local registry credentials. If the
+// registry rejects them, the image is assumed to be in the sandbox registry,
+// which authenticates with the Blimp token.
+func pullImage(ref name.Reference, authToken string) (v1.Image, error) {
+	img, err := remote.Image(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err == nil {
+		return img, nil
+	}
+
+	if !isUnauthorized(err) {
+		return nil, err
+	}
+	return remote.Image(ref, remote.WithAuth(&authn.Basic{Username: "ignored", Password: authToken}))
+}
+
+func isUnauthorized(err error) bool {
+	transportErr, ok := err.(*transport.Error)
+	if !ok {
+		return false
+	}
+
+	for _, diagnostic := range transportErr.Errors {
+		if diagnostic.Code == transport.UnauthorizedErrorCode {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTar writes the contents of dir to a tar archive at path.
+func writeTar(dir, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	err = filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil || relPath == "." {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		src, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
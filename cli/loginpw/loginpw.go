@@ -29,6 +29,9 @@ func New() *cobra.Command {
 			}
 
 			store.AuthToken = token
+			// Password logins don't get a refresh token, so make sure that
+			// the token isn't replaced with one for the previous user.
+			store.RefreshToken = ""
 			if err := store.Save(); err != nil {
 				log.WithError(err).Fatal("Failed to update local Kelda Blimp credentials")
 			}
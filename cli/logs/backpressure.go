@@ -0,0 +1,102 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/buger/goterm"
+)
+
+const (
+	// SlowOutputPause stops reading from the log streams while stdout is
+	// blocked, so that the logs are delayed but never lost.
+	SlowOutputPause = "pause"
+
+	// SlowOutputDrop keeps reading from the log streams while stdout is
+	// blocked, and drops the oldest unprinted lines once the buffer is full.
+	SlowOutputDrop = "drop"
+)
+
+// DefaultDropBufferSize is the number of unprinted lines that are buffered
+// with SlowOutputDrop before lines are dropped.
+const DefaultDropBufferSize = 10000
+
+// dropOldestLogs relays the lines from `in` to the returned channel. Lines
+// are read from `in` as quickly as they arrive, even if the returned channel
+// isn't read, and at most `size` unprinted lines are buffered. Once the buffer
+// is full, the oldest line is dropped, and a notice with the number of
+// dropped lines is printed to `notices` before the next line is relayed.
+//
+// Lines that end a stream or report an error are never dropped, since the
+// consumer depends on them to shut down.
+func dropOldestLogs(ctx context.Context, in <-chan rawLogLine, size int, notices io.Writer) <-chan rawLogLine {
+	out := make(chan rawLogLine)
+	go func() {
+		defer close(out)
+
+		// `buffer` is a ring of the unprinted lines, starting at `head`.
+		buffer := make([]rawLogLine, size)
+		var head, count, dropped int
+		for in != nil || count != 0 {
+			// Only try to relay a line if there's one buffered. Sends on a
+			// nil channel block forever, so the case is disabled otherwise.
+			var sendChan chan<- rawLogLine
+			var next rawLogLine
+			if count != 0 {
+				sendChan = out
+				next = buffer[head]
+			}
+
+			select {
+			case line, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+
+				if count == size {
+					if droppedLine := buffer[head]; droppedLine.readError == nil {
+						head = (head + 1) % size
+						count--
+						dropped++
+					} else {
+						// Never drop the end of a stream, so instead wait
+						// for room in the buffer.
+						select {
+						case out <- droppedLine:
+						case <-ctx.Done():
+							return
+						}
+						head = (head + 1) % size
+						count--
+					}
+				}
+				buffer[(head+count)%size] = line
+				count++
+			case sendChan <- next:
+				if dropped != 0 {
+					fmt.Fprintln(notices, goterm.Color(fmt.Sprintf(
+						"%d log lines were dropped because the output couldn't keep up.", dropped),
+						goterm.YELLOW))
+					dropped = 0
+				}
+				buffer[head] = rawLogLine{}
+				head = (head + 1) % size
+				count--
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// withSlowOutput applies the SlowOutput policy to `rawLogs`.
+func withSlowOutput(ctx context.Context, rawLogs <-chan rawLogLine, policy string) <-chan rawLogLine {
+	if policy != SlowOutputDrop {
+		return rawLogs
+	}
+	return dropOldestLogs(ctx, rawLogs, DefaultDropBufferSize, os.Stderr)
+}
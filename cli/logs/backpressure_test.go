@@ -0,0 +1,100 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropOldestLogs(t *testing.T) {
+	line := func(message string) rawLogLine {
+		return rawLogLine{fromContainer: "web", message: message}
+	}
+	eof := rawLogLine{fromContainer: "web", readError: io.EOF}
+
+	tests := []struct {
+		name      string
+		size      int
+		input     []rawLogLine
+		expOutput []rawLogLine
+		expNotice string
+	}{
+		{
+			name:      "Buffer isn't full",
+			size:      3,
+			input:     []rawLogLine{line("1"), line("2"), eof},
+			expOutput: []rawLogLine{line("1"), line("2"), eof},
+		},
+		{
+			name:      "Drops the oldest lines",
+			size:      2,
+			input:     []rawLogLine{line("1"), line("2"), line("3"), line("4"), line("5")},
+			expOutput: []rawLogLine{line("4"), line("5")},
+			expNotice: "3 log lines were dropped because the output couldn't keep up.",
+		},
+		{
+			name:      "Never drops the end of a stream",
+			size:      2,
+			input:     []rawLogLine{line("1"), eof, line("3"), line("4")},
+			expOutput: []rawLogLine{eof, line("3"), line("4")},
+			expNotice: "1 log lines were dropped because the output couldn't keep up.",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			in := make(chan rawLogLine, len(test.input))
+			for _, line := range test.input {
+				in <- line
+			}
+			close(in)
+
+			var notices bytes.Buffer
+			out := dropOldestLogs(context.Background(), in, test.size, &notices)
+
+			// Simulate a slow consumer by waiting for all the lines to be
+			// read before printing any of them.
+			for len(in) != 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			var output []rawLogLine
+			for line := range out {
+				output = append(output, line)
+			}
+			assert.Equal(t, test.expOutput, output)
+
+			if test.expNotice == "" {
+				assert.Empty(t, notices.String())
+			} else {
+				assert.Contains(t, notices.String(), test.expNotice)
+				assert.Equal(t, 1, bytes.Count(notices.Bytes(), []byte("\n")))
+			}
+		})
+	}
+}
+
+func TestDropOldestLogsCancelled(t *testing.T) {
+	in := make(chan rawLogLine)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := dropOldestLogs(ctx, in, 2, &bytes.Buffer{})
+
+	cancel()
+	select {
+	case _, ok := <-out:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("output wasn't closed after the context was cancelled")
+	}
+}
+
+func TestWithSlowOutput(t *testing.T) {
+	rawLogs := make(chan rawLogLine)
+	assert.Equal(t, (<-chan rawLogLine)(rawLogs), withSlowOutput(context.Background(), rawLogs, SlowOutputPause))
+	assert.Equal(t, (<-chan rawLogLine)(rawLogs), withSlowOutput(context.Background(), rawLogs, ""))
+}
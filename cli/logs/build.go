@@ -0,0 +1,39 @@
+package logs
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kelda/blimp/cli/buildlog"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// printBuildLogs prints the saved output of the most recent image build for
+// each service.
+func printBuildLogs(out io.Writer, services []string) error {
+	for i, svc := range services {
+		f, err := os.Open(buildlog.Path(svc))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return errors.NewFriendlyError("No build log for %s. "+
+					"Build logs are saved when `blimp up` builds the service's image.", svc)
+			}
+			return errors.WithContext("open build log", err)
+		}
+
+		if len(services) > 1 {
+			if i != 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "==> %s <==\n", svc)
+		}
+
+		_, err = io.Copy(out, f)
+		f.Close()
+		if err != nil {
+			return errors.WithContext("read build log", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// streamCapturedLogs returns a stream of the logs persisted by the log capture
+// sidecar for the given service. The logs are read by running `tail` in the
+// sidecar, so the stream can be followed just like a regular logs stream.
+func streamCapturedLogs(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	namespace, svc string, follow bool) (io.ReadCloser, error) {
+
+	tailCmd := []string{"tail", "-n", "+1"}
+	if follow {
+		tailCmd = append(tailCmd, "-F")
+	}
+	tailCmd = append(tailCmd, names.LogCapturePath)
+	return execInCaptureSidecar(kubeClient, restConfig, namespace, svc, tailCmd, nil)
+}
+
+// execInCaptureSidecar runs the command in the log capture sidecar of the
+// given service, and returns its stdout. Exit codes in `okExitCodes` aren't
+// treated as errors, such as grep's exit code when nothing matches.
+func execInCaptureSidecar(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	namespace, svc string, command []string, okExitCodes []int) (io.ReadCloser, error) {
+
+	execOpts := corev1.PodExecOptions{
+		Container: names.LogCaptureContainer,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	}
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		SubResource("exec").
+		Name(names.PodName(svc)).
+		Namespace(namespace).
+		VersionedParams(&execOpts, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return nil, errors.WithContext("setup remote command", err)
+	}
+
+	stdoutReader, stdoutWriter := io.Pipe()
+	go func() {
+		var stderr bytes.Buffer
+		err := exec.Stream(remotecommand.StreamOptions{
+			Stdout: stdoutWriter,
+			Stderr: &stderr,
+		})
+		if err != nil && !isOKExit(err, okExitCodes) {
+			stdoutWriter.CloseWithError(captureError(svc, err, stderr.String()))
+			return
+		}
+		stdoutWriter.Close()
+	}()
+	return stdoutReader, nil
+}
+
+func isOKExit(err error, okExitCodes []int) bool {
+	exitErr, ok := err.(utilexec.ExitError)
+	if !ok || !exitErr.Exited() {
+		return false
+	}
+
+	for _, code := range okExitCodes {
+		if exitErr.ExitStatus() == code {
+			return true
+		}
+	}
+	return false
+}
+
+func captureError(svc string, err error, stderr string) error {
+	if strings.Contains(err.Error(), "container not found") ||
+		strings.Contains(err.Error(), "not a valid container") {
+		return errors.NewFriendlyError(
+			"Full log history isn't enabled for %s.\n"+
+				"To enable it, add the label `%s: \"true\"` to the service, "+
+				"and restart it with `blimp up`.", svc, names.LogCaptureLabel)
+	}
+
+	if stderr != "" {
+		return errors.WithContext(fmt.Sprintf("read captured logs (%s)", strings.TrimSpace(stderr)), err)
+	}
+	return errors.WithContext("read captured logs", err)
+}
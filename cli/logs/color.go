@@ -0,0 +1,196 @@
+package logs
+
+import (
+	"hash/fnv"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/buger/goterm"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+const (
+	// ColorAuto prints colors if stdout is a terminal, and the NO_COLOR
+	// environment variable isn't set.
+	ColorAuto = "auto"
+
+	// ColorAlways prints colors even if stdout isn't a terminal, such as
+	// when piping to `less -R`.
+	ColorAlways = "always"
+
+	// ColorNever never prints colors.
+	ColorNever = "never"
+)
+
+// noColorCode is used in place of a color to print service names without
+// color.
+const noColorCode = -1
+
+// colorNames maps the color names used in blimp.yaml to terminal colors.
+var colorNames = map[string]int{
+	"black":   goterm.BLACK,
+	"blue":    goterm.BLUE,
+	"cyan":    goterm.CYAN,
+	"green":   goterm.GREEN,
+	"magenta": goterm.MAGENTA,
+	"red":     goterm.RED,
+	"white":   goterm.WHITE,
+	"yellow":  goterm.YELLOW,
+	"none":    noColorCode,
+}
+
+// colorList is the default palette that services are assigned colors from.
+var colorList = []int{
+	goterm.BLUE,
+	goterm.CYAN,
+	goterm.GREEN,
+	goterm.MAGENTA,
+	goterm.RED,
+	goterm.YELLOW,
+}
+
+func pickColor(container string, palette []int) int {
+	hash := fnv.New32()
+	hash.Write([]byte(container))
+	idx := hash.Sum32() % uint32(len(palette))
+	return palette[idx]
+}
+
+// colorizer colors the text printed by the logs commands.
+type colorizer struct {
+	// Whether colors are printed at all.
+	enabled bool
+
+	// The colors configured for specific services.
+	services map[string]int
+
+	// The colors that the other services are assigned from.
+	palette []int
+}
+
+// service returns the service's name in its color.
+func (c colorizer) service(name string) string {
+	if !c.enabled {
+		return name
+	}
+
+	color, ok := c.services[name]
+	if !ok {
+		color = pickColor(name, c.palette)
+	}
+	return c.color(name, color)
+}
+
+func (c colorizer) color(str string, color int) string {
+	if !c.enabled || color == noColorCode {
+		return str
+	}
+	return goterm.Color(str, color)
+}
+
+func (c colorizer) bold(str string) string {
+	if !c.enabled {
+		return str
+	}
+	return goterm.Bold(str)
+}
+
+// highlight makes the text stand out from the rest of the line, such as for
+// matches of --highlight.
+func (c colorizer) highlight(str string) string {
+	if !c.enabled {
+		return str
+	}
+	return goterm.Color(goterm.Background(str, goterm.YELLOW), goterm.BLACK)
+}
+
+// stderr colors messages that were logged to stderr. Each line is colored
+// separately so that the color isn't reset by the prefixes added to
+// multi-line records.
+func (c colorizer) stderr(str string) string {
+	if !c.enabled {
+		return str
+	}
+
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		lines[i] = goterm.Color(line, goterm.RED)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// useColor returns whether colors should be printed to `out` according to
+// the --color mode.
+func useColor(mode string, out *os.File) (bool, error) {
+	switch mode {
+	case ColorAlways:
+		return true, nil
+	case ColorNever:
+		return false, nil
+	case "", ColorAuto:
+		// See https://no-color.org.
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			return false, nil
+		}
+		return terminal.IsTerminal(int(out.Fd())), nil
+	default:
+		return false, errors.NewFriendlyError("Unknown --color value %q. It must be one of %q, %q, or %q.",
+			mode, ColorAuto, ColorAlways, ColorNever)
+	}
+}
+
+// getColors returns the colorizer for the command's --color mode, and the
+// colors configured in the project's blimp.yaml.
+func (cmd LogsCommand) getColors() (colorizer, error) {
+	enabled, err := useColor(cmd.Color, os.Stdout)
+	if err != nil {
+		return colorizer{}, err
+	}
+
+	colors := colorizer{
+		enabled:  enabled,
+		services: map[string]int{},
+		palette:  colorList,
+	}
+	for svc, cfg := range cmd.ServiceConfigs {
+		if cfg.Color == "" {
+			continue
+		}
+
+		color, err := parseColor(cfg.Color)
+		if err != nil {
+			return colorizer{}, errors.NewFriendlyError("Unknown log color %q for %s in %s. %s",
+				cfg.Color, svc, projectcfg.Filename, err)
+		}
+		colors.services[svc] = color
+	}
+
+	if len(cmd.Palette) != 0 {
+		colors.palette = nil
+		for _, name := range cmd.Palette {
+			color, err := parseColor(name)
+			if err != nil {
+				return colorizer{}, errors.NewFriendlyError("Unknown palette color %q. %s", name, err)
+			}
+			colors.palette = append(colors.palette, color)
+		}
+	}
+	return colors, nil
+}
+
+func parseColor(name string) (int, error) {
+	color, ok := colorNames[strings.ToLower(name)]
+	if !ok {
+		var names []string
+		for name := range colorNames {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return 0, errors.New("It must be one of %s.", strings.Join(names, ", "))
+	}
+	return color, nil
+}
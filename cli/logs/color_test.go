@@ -0,0 +1,226 @@
+package logs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/buger/goterm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kelda/blimp/cli/projectcfg"
+)
+
+func TestUseColor(t *testing.T) {
+	// A regular file is never a terminal.
+	out, err := ioutil.TempFile("", "blimp-logs-color")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	tests := []struct {
+		name       string
+		mode       string
+		noColorEnv bool
+		exp        bool
+		expErr     bool
+	}{
+		{
+			name: "Always",
+			mode: ColorAlways,
+			exp:  true,
+		},
+		{
+			name:       "Always ignores NO_COLOR",
+			mode:       ColorAlways,
+			noColorEnv: true,
+			exp:        true,
+		},
+		{
+			name: "Never",
+			mode: ColorNever,
+			exp:  false,
+		},
+		{
+			name: "Auto without a terminal",
+			mode: ColorAuto,
+			exp:  false,
+		},
+		{
+			name:       "Default with NO_COLOR",
+			mode:       "",
+			noColorEnv: true,
+			exp:        false,
+		},
+		{
+			name:   "Unknown mode",
+			mode:   "sometimes",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if test.noColorEnv {
+				os.Setenv("NO_COLOR", "")
+				defer os.Unsetenv("NO_COLOR")
+			}
+
+			enabled, err := useColor(test.mode, out)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.exp, enabled)
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		color    string
+		expColor int
+		expErr   bool
+	}{
+		{
+			name:     "Lowercase",
+			color:    "blue",
+			expColor: goterm.BLUE,
+		},
+		{
+			name:     "Mixed case",
+			color:    "Magenta",
+			expColor: goterm.MAGENTA,
+		},
+		{
+			name:     "None",
+			color:    "none",
+			expColor: noColorCode,
+		},
+		{
+			name:   "Unknown",
+			color:  "orange",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			color, err := parseColor(test.color)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expColor, color)
+		})
+	}
+}
+
+func TestGetColors(t *testing.T) {
+	tests := []struct {
+		name      string
+		cmd       LogsCommand
+		expColors colorizer
+		expErr    bool
+	}{
+		{
+			name: "Defaults",
+			cmd:  LogsCommand{Color: ColorNever},
+			expColors: colorizer{
+				services: map[string]int{},
+				palette:  colorList,
+			},
+		},
+		{
+			name: "Service colors",
+			cmd: LogsCommand{
+				Color: ColorAlways,
+				ServiceConfigs: map[string]projectcfg.ServiceLogsConfig{
+					"web":    {Color: "green"},
+					"worker": {Color: "none"},
+					"db":     {},
+				},
+			},
+			expColors: colorizer{
+				enabled: true,
+				services: map[string]int{
+					"web":    goterm.GREEN,
+					"worker": noColorCode,
+				},
+				palette: colorList,
+			},
+		},
+		{
+			name: "Palette",
+			cmd: LogsCommand{
+				Color:   ColorAlways,
+				Palette: []string{"blue", "Yellow"},
+			},
+			expColors: colorizer{
+				enabled:  true,
+				services: map[string]int{},
+				palette:  []int{goterm.BLUE, goterm.YELLOW},
+			},
+		},
+		{
+			name: "Unknown service color",
+			cmd: LogsCommand{
+				Color: ColorAlways,
+				ServiceConfigs: map[string]projectcfg.ServiceLogsConfig{
+					"web": {Color: "orange"},
+				},
+			},
+			expErr: true,
+		},
+		{
+			name: "Unknown palette color",
+			cmd: LogsCommand{
+				Color:   ColorAlways,
+				Palette: []string{"blue", "orange"},
+			},
+			expErr: true,
+		},
+		{
+			name:   "Unknown mode",
+			cmd:    LogsCommand{Color: "sometimes"},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			colors, err := test.cmd.getColors()
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expColors, colors)
+		})
+	}
+}
+
+func TestColorizer(t *testing.T) {
+	disabled := colorizer{services: map[string]int{"web": goterm.GREEN}, palette: colorList}
+	assert.Equal(t, "web", disabled.service("web"))
+	assert.Equal(t, "worker", disabled.service("worker"))
+	assert.Equal(t, "a", disabled.bold("a"))
+
+	enabled := colorizer{
+		enabled:  true,
+		services: map[string]int{"web": goterm.GREEN, "worker": noColorCode},
+		palette:  []int{goterm.CYAN},
+	}
+	assert.Equal(t, goterm.Color("web", goterm.GREEN), enabled.service("web"))
+	assert.Equal(t, "worker", enabled.service("worker"))
+	assert.Equal(t, goterm.Color("db", goterm.CYAN), enabled.service("db"))
+	assert.Equal(t, goterm.Bold("a"), enabled.bold("a"))
+}
@@ -0,0 +1,86 @@
+package logs
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// filterByContainer returns the services whose pods have a container with the
+// given name. Services without it are skipped with a warning, so that
+// --container can be used with --all even if only some of the services have
+// the container. It only fails if none of the services have it.
+func filterByContainer(kubeClient kubernetes.Interface, namespace string, services []string,
+	container string) ([]string, error) {
+
+	var matching []string
+	for _, svc := range services {
+		ok, err := hasContainer(kubeClient, namespace, svc, container)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			matching = append(matching, svc)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil, errors.NewFriendlyError("None of the services have a container named %q.", container)
+	}
+	return matching, nil
+}
+
+// hasContainer returns whether the service's pod has a container with the
+// given name. If it doesn't, a warning that lists the pod's containers is
+// printed, so that typos in --container are easy to spot.
+func hasContainer(kubeClient kubernetes.Interface, namespace, svc, container string) (bool, error) {
+	pod, err := kubeClient.CoreV1().Pods(namespace).Get(names.PodName(svc), metav1.GetOptions{})
+	if err != nil {
+		return false, errors.WithContext("get pod", err)
+	}
+
+	var containers []string
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return true, nil
+		}
+		containers = append(containers, c.Name)
+	}
+
+	log.Warnf("Skipping %s because it doesn't have a container named %q. Its containers are: %s.",
+		svc, container, strings.Join(containers, ", "))
+	return false, nil
+}
+
+// getCapturedContainer returns the name of the container whose logs are
+// persisted by the log capture sidecar. Pods with the sidecar have multiple
+// containers, so Kubernetes requires the container to be named when reading
+// their logs. It returns an empty string for pods without the sidecar, so
+// that Kubernetes picks the pod's only container.
+func getCapturedContainer(kubeClient kubernetes.Interface, namespace, svc string) (string, error) {
+	pod, err := kubeClient.CoreV1().Pods(namespace).Get(names.PodName(svc), metav1.GetOptions{})
+	if err != nil {
+		return "", errors.WithContext("get pod", err)
+	}
+
+	var hasCapture bool
+	var mainContainer string
+	for _, container := range pod.Spec.Containers {
+		if container.Name == names.LogCaptureContainer {
+			hasCapture = true
+		} else if mainContainer == "" {
+			mainContainer = container.Name
+		}
+	}
+
+	if !hasCapture {
+		return "", nil
+	}
+	return mainContainer, nil
+}
@@ -0,0 +1,74 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// deduper collapses consecutive identical log lines from the same stream
+// into a single "last message repeated N times" line, so that a service
+// spamming one message doesn't drown out the others. The state is kept per
+// stream, so lines from other services in between don't reset the count.
+//
+// The summary is printed once the stream logs a different line, or the logs
+// end.
+type deduper struct {
+	streams map[string]*dedupeState
+}
+
+type dedupeState struct {
+	// The most recently printed line.
+	line parsedLogLine
+
+	// The number of times that the line was repeated since it was printed,
+	// and when the most recent repeat was logged.
+	repeats        int
+	lastRepeatedAt time.Time
+}
+
+func newDeduper() *deduper {
+	return &deduper{streams: map[string]*dedupeState{}}
+}
+
+// add returns the lines that should be printed as a result of `line`.
+func (d *deduper) add(line parsedLogLine) []parsedLogLine {
+	state, ok := d.streams[line.stream()]
+	if ok && state.line.message == line.message {
+		state.repeats++
+		state.lastRepeatedAt = line.loggedAt
+		return nil
+	}
+
+	d.streams[line.stream()] = &dedupeState{line: line}
+	if ok && state.repeats != 0 {
+		return []parsedLogLine{state.summary(), line}
+	}
+	return []parsedLogLine{line}
+}
+
+// flush returns the summaries of the lines that are still being repeated, in
+// the order that they were last repeated.
+func (d *deduper) flush() (lines []parsedLogLine) {
+	for stream, state := range d.streams {
+		if state.repeats != 0 {
+			lines = append(lines, state.summary())
+		}
+		delete(d.streams, stream)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].loggedAt.Before(lines[j].loggedAt)
+	})
+	return lines
+}
+
+func (state dedupeState) summary() parsedLogLine {
+	summary := state.line
+	summary.loggedAt = state.lastRepeatedAt
+	if state.repeats == 1 {
+		summary.message = "last message repeated 1 time"
+	} else {
+		summary.message = fmt.Sprintf("last message repeated %d times", state.repeats)
+	}
+	return summary
+}
@@ -0,0 +1,119 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduper(t *testing.T) {
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	line := func(container, message string, offset int) parsedLogLine {
+		return parsedLogLine{
+			fromContainer: container,
+			message:       message,
+			loggedAt:      start.Add(time.Duration(offset) * time.Second),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		input  []parsedLogLine
+		expAdd []parsedLogLine
+		expEnd []parsedLogLine
+	}{
+		{
+			name: "No repeats",
+			input: []parsedLogLine{
+				line("web", "a", 0),
+				line("web", "b", 1),
+			},
+			expAdd: []parsedLogLine{
+				line("web", "a", 0),
+				line("web", "b", 1),
+			},
+		},
+		{
+			name: "Repeated once",
+			input: []parsedLogLine{
+				line("web", "a", 0),
+				line("web", "a", 1),
+				line("web", "b", 2),
+			},
+			expAdd: []parsedLogLine{
+				line("web", "a", 0),
+				line("web", "last message repeated 1 time", 1),
+				line("web", "b", 2),
+			},
+		},
+		{
+			name: "Repeated many times",
+			input: []parsedLogLine{
+				line("web", "a", 0),
+				line("web", "a", 1),
+				line("web", "a", 2),
+				line("web", "a", 3),
+				line("web", "b", 4),
+			},
+			expAdd: []parsedLogLine{
+				line("web", "a", 0),
+				line("web", "last message repeated 3 times", 3),
+				line("web", "b", 4),
+			},
+		},
+		{
+			name: "Other services don't reset the count",
+			input: []parsedLogLine{
+				line("web", "a", 0),
+				line("worker", "a", 1),
+				line("web", "a", 2),
+				line("worker", "b", 3),
+				line("web", "b", 4),
+			},
+			expAdd: []parsedLogLine{
+				line("web", "a", 0),
+				line("worker", "a", 1),
+				line("worker", "b", 3),
+				line("web", "last message repeated 1 time", 2),
+				line("web", "b", 4),
+			},
+		},
+		{
+			name: "Flush in the order the lines were last repeated",
+			input: []parsedLogLine{
+				line("web", "a", 0),
+				line("worker", "b", 1),
+				line("worker", "b", 2),
+				line("web", "a", 3),
+				line("db", "c", 4),
+			},
+			expAdd: []parsedLogLine{
+				line("web", "a", 0),
+				line("worker", "b", 1),
+				line("db", "c", 4),
+			},
+			expEnd: []parsedLogLine{
+				line("worker", "last message repeated 1 time", 2),
+				line("web", "last message repeated 1 time", 3),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			d := newDeduper()
+			var added []parsedLogLine
+			for _, l := range test.input {
+				added = append(added, d.add(l)...)
+			}
+			assert.Equal(t, test.expAdd, added)
+			assert.Equal(t, test.expEnd, d.flush())
+
+			// Flushing resets the state.
+			assert.Empty(t, d.flush())
+			assert.Equal(t, []parsedLogLine{line("web", "a", 5)}, d.add(line("web", "a", 5)))
+		})
+	}
+}
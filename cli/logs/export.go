@@ -0,0 +1,255 @@
+package logs
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// DefaultExportPath is where `blimp logs export` writes the archive if --out
+// isn't set.
+const DefaultExportPath = "blimp-logs.tar.gz"
+
+// exportManifestName is the name of the manifest within the archive.
+const exportManifestName = "manifest.json"
+
+// The sources that exported logs are read from.
+const (
+	exportSourceKubernetes = "kubernetes"
+	exportSourceLogCapture = "log-capture"
+)
+
+// exportManifest describes the contents of an exported archive. It's part of
+// the archive format, so fields shouldn't be renamed.
+type exportManifest struct {
+	ExportedAt time.Time                  `json:"exported_at"`
+	Services   map[string]exportedService `json:"services"`
+}
+
+type exportedService struct {
+	// File is the name of the service's logs within the archive.
+	File string `json:"file"`
+
+	// Source is where the logs were read from. Logs read from Kubernetes
+	// may be missing lines that were rotated away.
+	Source string `json:"source"`
+
+	Lines          int        `json:"lines"`
+	FirstTimestamp *time.Time `json:"first_timestamp,omitempty"`
+	LastTimestamp  *time.Time `json:"last_timestamp,omitempty"`
+}
+
+// ExportCommand writes the logs of services to a compressed archive.
+type ExportCommand struct {
+	Auth     authstore.Store
+	Services []string
+	Out      string
+}
+
+func NewExportCommand() *cobra.Command {
+	cmd := &ExportCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "export [SERVICE ...]",
+		Short: "Save the logs of services to an archive",
+		Long: "Save the current logs of services to a .tar.gz archive, such as to attach to a bug report.\n\n" +
+			"The archive contains a file for each service, and " + exportManifestName + ", which lists " +
+			"the time range of each service's logs. Services with the " + names.LogCaptureLabel +
+			" label have their full log history exported. If no services are provided, the logs for " +
+			"all the services in the sandbox are exported.",
+		Example: `  blimp logs export --out logs.tar.gz
+  blimp logs export web worker`,
+		Run: func(_ *cobra.Command, args []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse auth store")
+			}
+
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			projectCfg, err := projectcfg.Load(".")
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			cmd.Auth = auth
+			cmd.Services, err = manager.ResolveServices(auth.AuthToken, projectCfg.ExpandGroups(args))
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if len(cmd.Services) == 0 {
+				cmd.Services, err = getAllServices(auth.AuthToken, nil)
+				if err != nil {
+					errors.HandleFatalError(err)
+				}
+			}
+
+			if err := cmd.Run(); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&cmd.Out, "out", "o", DefaultExportPath,
+		"The path to write the archive to.")
+	return cobraCmd
+}
+
+func (cmd ExportCommand) Run() error {
+	kubeClient, restConfig, err := cmd.Auth.KubeClient()
+	if err != nil {
+		return errors.WithContext("connect to cluster", err)
+	}
+
+	f, err := os.Create(cmd.Out)
+	if err != nil {
+		return errors.WithContext("create archive", err)
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	manifest := exportManifest{
+		ExportedAt: time.Now(),
+		Services:   map[string]exportedService{},
+	}
+	for _, svc := range cmd.Services {
+		exported, err := cmd.exportService(kubeClient, restConfig, tw, svc)
+		if err != nil {
+			return errors.WithContext(fmt.Sprintf("export logs for %s", svc), err)
+		}
+		manifest.Services[svc] = exported
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.WithContext("marshal manifest", err)
+	}
+
+	if err := writeTarFile(tw, exportManifestName, int64(len(manifestJSON)), bytes.NewReader(manifestJSON)); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.WithContext("write archive", err)
+	}
+	if err := gzw.Close(); err != nil {
+		return errors.WithContext("write archive", err)
+	}
+
+	fmt.Printf("Exported the logs of %d services to %s.\n", len(cmd.Services), cmd.Out)
+	return nil
+}
+
+// exportService writes the service's logs to the archive. The logs are
+// buffered in a temporary file first, since tar headers must include the
+// file's size.
+func (cmd ExportCommand) exportService(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	tw *tar.Writer, svc string) (exportedService, error) {
+
+	exported := exportedService{
+		File:   svc + ".log",
+		Source: exportSourceKubernetes,
+	}
+
+	var logsStream io.ReadCloser
+	var err error
+	if hasLogCapture(kubeClient, cmd.Auth.KubeNamespace, svc) {
+		exported.Source = exportSourceLogCapture
+		logsStream, err = streamCapturedLogs(kubeClient, restConfig, cmd.Auth.KubeNamespace, svc, false)
+	} else {
+		logsStream, err = kubeClient.CoreV1().
+			Pods(cmd.Auth.KubeNamespace).
+			GetLogs(names.PodName(svc), &corev1.PodLogOptions{Timestamps: true}).
+			Stream()
+	}
+	if err != nil {
+		return exportedService{}, errors.WithContext("start logs stream", err)
+	}
+	defer logsStream.Close()
+
+	tmp, err := ioutil.TempFile("", "blimp-logs-export")
+	if err != nil {
+		return exportedService{}, errors.WithContext("create temp file", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	// Copy the logs line by line to track the time range that they cover.
+	reader := bufio.NewReader(logsStream)
+	var size int64
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			n, writeErr := tmp.WriteString(line)
+			if writeErr != nil {
+				return exportedService{}, errors.WithContext("write temp file", writeErr)
+			}
+			size += int64(n)
+			exported.Lines++
+
+			if _, timestamp, parseErr := parseLogLine(strings.TrimSuffix(line, "\n")); parseErr == nil {
+				if exported.FirstTimestamp == nil {
+					exported.FirstTimestamp = &timestamp
+				}
+				exported.LastTimestamp = &timestamp
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return exportedService{}, errors.WithContext("read logs", err)
+		}
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return exportedService{}, errors.WithContext("rewind temp file", err)
+	}
+
+	if err := writeTarFile(tw, exported.File, size, tmp); err != nil {
+		return exportedService{}, err
+	}
+	return exported, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, size int64, contents io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return errors.WithContext("write archive header", err)
+	}
+
+	if _, err := io.Copy(tw, contents); err != nil {
+		return errors.WithContext(fmt.Sprintf("write %s to archive", name), err)
+	}
+	return nil
+}
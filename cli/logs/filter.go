@@ -0,0 +1,94 @@
+package logs
+
+import (
+	"regexp"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// getGrepFilter returns a filter that only shows log lines matching `grep`,
+// and hides log lines matching `grepV`. It returns nil if neither is set.
+func getGrepFilter(grep, grepV string) (func(parsedLogLine) bool, error) {
+	if grep == "" && grepV == "" {
+		return nil, nil
+	}
+
+	compile := func(flag, pattern string) (*regexp.Regexp, error) {
+		if pattern == "" {
+			return nil, nil
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.NewFriendlyError("Invalid %s pattern %q: %s", flag, pattern, err)
+		}
+		return re, nil
+	}
+
+	include, err := compile("--grep", grep)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := compile("--grep-v", grepV)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(log parsedLogLine) bool {
+		if include != nil && !include.MatchString(log.message) {
+			return false
+		}
+		return exclude == nil || !exclude.MatchString(log.message)
+	}, nil
+}
+
+// combineFilters returns a filter that only passes log lines that pass all
+// of the given filters. Nil filters are ignored.
+func combineFilters(filters ...func(parsedLogLine) bool) func(parsedLogLine) bool {
+	var nonNil []func(parsedLogLine) bool
+	for _, filter := range filters {
+		if filter != nil {
+			nonNil = append(nonNil, filter)
+		}
+	}
+
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+
+	return func(log parsedLogLine) bool {
+		for _, filter := range nonNil {
+			if !filter(log) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// withHighlight wraps `format` to highlight the parts of each message that
+// match `pattern`. Unlike --grep, lines that don't match are still printed.
+func withHighlight(format func(parsedLogLine) string, pattern string,
+	colors colorizer) (func(parsedLogLine) string, error) {
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.NewFriendlyError("Invalid --highlight pattern %q: %s", pattern, err)
+	}
+
+	return func(log parsedLogLine) string {
+		log.message = re.ReplaceAllStringFunc(log.message, func(match string) string {
+			// Patterns such as `a*` also match the empty string between
+			// characters, which would otherwise add escape codes everywhere.
+			if match == "" {
+				return match
+			}
+			return colors.highlight(match)
+		})
+		return format(log)
+	}, nil
+}
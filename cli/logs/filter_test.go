@@ -0,0 +1,192 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/buger/goterm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGrepFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		grep      string
+		grepV     string
+		expNil    bool
+		expErr    bool
+		expPrints map[string]bool
+	}{
+		{
+			name:   "No patterns",
+			expNil: true,
+		},
+		{
+			name: "Grep",
+			grep: "GET|POST",
+			expPrints: map[string]bool{
+				"GET /index.html": true,
+				"POST /login":     true,
+				"Starting server": false,
+			},
+		},
+		{
+			name:  "GrepV",
+			grepV: "healthz",
+			expPrints: map[string]bool{
+				"GET /healthz":    false,
+				"GET /index.html": true,
+			},
+		},
+		{
+			name:  "Grep and GrepV",
+			grep:  "^GET",
+			grepV: "healthz",
+			expPrints: map[string]bool{
+				"GET /healthz":    false,
+				"GET /index.html": true,
+				"POST /login":     false,
+			},
+		},
+		{
+			name:   "Invalid grep pattern",
+			grep:   "(",
+			expErr: true,
+		},
+		{
+			name:   "Invalid grep-v pattern",
+			grepV:  "[",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := getGrepFilter(test.grep, test.grepV)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			if test.expNil {
+				assert.Nil(t, filter)
+				return
+			}
+
+			for message, expPrint := range test.expPrints {
+				assert.Equal(t, expPrint, filter(parsedLogLine{message: message}), message)
+			}
+		})
+	}
+}
+
+func TestCombineFilters(t *testing.T) {
+	fromWeb := func(log parsedLogLine) bool { return log.fromContainer == "web" }
+	notEmpty := func(log parsedLogLine) bool { return log.message != "" }
+
+	assert.Nil(t, combineFilters())
+	assert.Nil(t, combineFilters(nil, nil))
+
+	tests := []struct {
+		name     string
+		filters  []func(parsedLogLine) bool
+		log      parsedLogLine
+		expPrint bool
+	}{
+		{
+			name:     "Single filter",
+			filters:  []func(parsedLogLine) bool{nil, fromWeb},
+			log:      parsedLogLine{fromContainer: "web"},
+			expPrint: true,
+		},
+		{
+			name:     "All filters pass",
+			filters:  []func(parsedLogLine) bool{fromWeb, nil, notEmpty},
+			log:      parsedLogLine{fromContainer: "web", message: "GET /"},
+			expPrint: true,
+		},
+		{
+			name:     "One filter fails",
+			filters:  []func(parsedLogLine) bool{fromWeb, notEmpty},
+			log:      parsedLogLine{fromContainer: "worker", message: "GET /"},
+			expPrint: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expPrint, combineFilters(test.filters...)(test.log))
+		})
+	}
+}
+
+func TestWithHighlight(t *testing.T) {
+	format := func(log parsedLogLine) string { return log.message }
+	highlight := func(str string) string {
+		return goterm.Color(goterm.Background(str, goterm.YELLOW), goterm.BLACK)
+	}
+
+	tests := []struct {
+		name       string
+		pattern    string
+		colors     bool
+		message    string
+		expErr     bool
+		expMessage string
+	}{
+		{
+			name:       "Colors disabled",
+			pattern:    "error",
+			message:    "an error occurred",
+			expMessage: "an error occurred",
+		},
+		{
+			name:       "Single match",
+			pattern:    "error",
+			colors:     true,
+			message:    "an error occurred",
+			expMessage: "an " + highlight("error") + " occurred",
+		},
+		{
+			name:       "Multiple matches",
+			pattern:    "[0-9]+",
+			colors:     true,
+			message:    "GET /users/12 took 30ms",
+			expMessage: "GET /users/" + highlight("12") + " took " + highlight("30") + "ms",
+		},
+		{
+			name:       "No match",
+			pattern:    "error",
+			colors:     true,
+			message:    "Starting server",
+			expMessage: "Starting server",
+		},
+		{
+			name:       "Empty matches aren't highlighted",
+			pattern:    "x*",
+			colors:     true,
+			message:    "axb",
+			expMessage: "a" + highlight("x") + "b",
+		},
+		{
+			name:    "Invalid pattern",
+			pattern: "(",
+			expErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			highlighted, err := withHighlight(format, test.pattern, colorizer{enabled: test.colors})
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expMessage, highlighted(parsedLogLine{message: test.message}))
+		})
+	}
+}
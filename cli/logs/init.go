@@ -0,0 +1,57 @@
+package logs
+
+import (
+	"io"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// initLogsStream is the logs stream of one of a service's init containers.
+type initLogsStream struct {
+	initContainer string
+	io.ReadCloser
+}
+
+// startInitLogsStreams starts streaming the logs of the service's init
+// containers, such as the containers that seed volumes or wait for
+// dependencies. Init containers that haven't started yet are skipped, since
+// they don't have any logs.
+func (cmd LogsCommand) startInitLogsStreams(kubeClient kubernetes.Interface,
+	container string) ([]initLogsStream, error) {
+
+	pod, err := kubeClient.CoreV1().Pods(cmd.Auth.KubeNamespace).
+		Get(names.PodName(container), metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.WithContext("get pod", err)
+	}
+
+	var streams []initLogsStream
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.State.Running == nil && status.State.Terminated == nil {
+			continue
+		}
+
+		// Init containers are only restarted along with the rest of the
+		// pod, so there's no previous instance to read.
+		opts := cmd.Opts
+		opts.Timestamps = true
+		opts.Previous = false
+		opts.Container = status.Name
+		logsStream, err := kubeClient.CoreV1().
+			Pods(cmd.Auth.KubeNamespace).
+			GetLogs(pod.Name, &opts).
+			Stream()
+		if err != nil {
+			for _, stream := range streams {
+				stream.Close()
+			}
+			return nil, errors.WithContext("start init container logs stream", err)
+		}
+		streams = append(streams, initLogsStream{status.Name, logsStream})
+	}
+	return streams, nil
+}
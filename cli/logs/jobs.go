@@ -0,0 +1,51 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// jobStatusAttempts is how many times the status of a job is checked after
+// its logs end. The logs can end slightly before the manager notices that
+// the job exited.
+const jobStatusAttempts = 5
+
+// reportJobExit prints how the service exited if it's a job, so that it's
+// clear from the logs whether it succeeded. Other services are ignored, since
+// their logs only end if they crash or are stopped.
+func reportJobExit(out io.Writer, authToken, svc string) {
+	for i := 0; i < jobStatusAttempts; i++ {
+		if i != 0 {
+			time.Sleep(time.Second)
+		}
+
+		statusResp, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{
+			Token: authToken,
+		})
+		if err != nil {
+			log.WithError(err).WithField("service", svc).Debug("Failed to get job status")
+			return
+		}
+
+		svcStatus := statusResp.GetStatus().GetServices()[svc]
+		if !svcStatus.GetIsJob() {
+			return
+		}
+
+		switch svcStatus.GetPhase() {
+		case cluster.ServicePhase_COMPLETED:
+			fmt.Fprintf(out, "%s completed successfully\n", svc)
+			return
+		case cluster.ServicePhase_EXITED:
+			fmt.Fprintf(out, "%s failed with exit code %d\n", svc, svcStatus.GetExitCode())
+			return
+		}
+	}
+}
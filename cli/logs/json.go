@@ -0,0 +1,151 @@
+package logs
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// jsonLogRecord is the format of each log line when logs are printed as JSON.
+// The pod metadata lets log pipelines correlate log lines with events in the
+// cluster, such as container restarts.
+type jsonLogRecord struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Service      string    `json:"service"`
+	Message      string    `json:"message"`
+	Pod          string    `json:"pod,omitempty"`
+	Namespace    string    `json:"namespace,omitempty"`
+	Node         string    `json:"node,omitempty"`
+	RestartCount int32     `json:"restart_count"`
+
+	// InitContainer is set if the line was logged by one of the service's
+	// init containers.
+	InitContainer string `json:"init_container,omitempty"`
+
+	// Stream is the stream that the line was logged to, if it's known.
+	Stream string `json:"stream,omitempty"`
+}
+
+// podMetadata is the subset of a service's pod that's included in JSON
+// records.
+type podMetadata struct {
+	pod          string
+	namespace    string
+	node         string
+	restartCount int32
+}
+
+// metadataRefreshInterval is how long pod metadata is cached. It's refreshed
+// periodically so that restart counts stay accurate when following logs.
+const metadataRefreshInterval = 10 * time.Second
+
+// podMetadataCache caches the pod metadata for each service so that the pod
+// doesn't have to be fetched for every log line.
+type podMetadataCache struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+
+	cache map[string]cachedPodMetadata
+	sync.Mutex
+}
+
+type cachedPodMetadata struct {
+	podMetadata
+	fetchedAt time.Time
+}
+
+func newPodMetadataCache(kubeClient kubernetes.Interface, namespace string) *podMetadataCache {
+	return &podMetadataCache{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		cache:      map[string]cachedPodMetadata{},
+	}
+}
+
+// get returns the metadata for the given service's pod. If the pod can't be
+// fetched, the most recently fetched metadata is returned.
+func (c *podMetadataCache) get(svc string) podMetadata {
+	c.Lock()
+	defer c.Unlock()
+
+	cached, ok := c.cache[svc]
+	if ok && time.Since(cached.fetchedAt) < metadataRefreshInterval {
+		return cached.podMetadata
+	}
+
+	metadata, err := getPodMetadata(c.kubeClient, c.namespace, svc)
+	if err != nil {
+		log.WithError(err).WithField("service", svc).Debug("Failed to get pod metadata")
+
+		// Don't retry until the next refresh interval so that we don't
+		// hammer the API server.
+		cached.fetchedAt = time.Now()
+		c.cache[svc] = cached
+		return cached.podMetadata
+	}
+
+	cached = cachedPodMetadata{
+		podMetadata: metadata,
+		fetchedAt:   time.Now(),
+	}
+	c.cache[svc] = cached
+	return cached.podMetadata
+}
+
+// getPodMetadata fetches the metadata of the service's pod.
+func getPodMetadata(kubeClient kubernetes.Interface, namespace, svc string) (podMetadata, error) {
+	pod, err := kubeClient.CoreV1().Pods(namespace).Get(names.PodName(svc), metav1.GetOptions{})
+	if err != nil {
+		return podMetadata{}, err
+	}
+
+	return podMetadata{
+		pod:          pod.Name,
+		namespace:    pod.Namespace,
+		node:         pod.Spec.NodeName,
+		restartCount: getRestartCount(pod),
+	}, nil
+}
+
+// getRestartCount returns the restart count of the service's container,
+// ignoring any sidecars.
+func getRestartCount(pod *corev1.Pod) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != names.LogCaptureContainer {
+			return status.RestartCount
+		}
+	}
+	return 0
+}
+
+// formatJSON returns a function that formats log lines as JSON records
+// enriched with the metadata of the service's pod.
+func formatJSON(metadata *podMetadataCache) func(parsedLogLine) string {
+	return func(line parsedLogLine) string {
+		pod := metadata.get(line.fromContainer)
+		record, err := json.Marshal(jsonLogRecord{
+			Timestamp:    line.loggedAt,
+			Service:      line.fromContainer,
+			Message:      line.message,
+			Pod:          pod.pod,
+			Namespace:    pod.namespace,
+			Node:         pod.node,
+			RestartCount: pod.restartCount,
+
+			InitContainer: line.initContainer,
+			Stream:        line.outputStream,
+		})
+		if err != nil {
+			log.WithError(err).Warn("Failed to marshal log record")
+			return line.message
+		}
+		return string(record)
+	}
+}
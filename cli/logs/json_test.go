@@ -0,0 +1,140 @@
+package logs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kelda/blimp/pkg/names"
+)
+
+func TestFormatJSON(t *testing.T) {
+	loggedAt := time.Date(2020, 6, 1, 12, 0, 0, 123000000, time.UTC)
+
+	// Seed the cache so that the pods aren't fetched.
+	metadata := newPodMetadataCache(nil, "namespace")
+	metadata.cache["web"] = cachedPodMetadata{
+		podMetadata: podMetadata{
+			pod:          "web",
+			namespace:    "namespace",
+			node:         "node-1",
+			restartCount: 2,
+		},
+		fetchedAt: time.Now(),
+	}
+	metadata.cache["worker"] = cachedPodMetadata{fetchedAt: time.Now()}
+
+	tests := []struct {
+		name      string
+		line      parsedLogLine
+		expRecord jsonLogRecord
+	}{
+		{
+			name: "Pod metadata",
+			line: parsedLogLine{
+				fromContainer: "web",
+				message:       "Starting server",
+				loggedAt:      loggedAt,
+				outputStream:  OutputStreamStdout,
+			},
+			expRecord: jsonLogRecord{
+				Timestamp:    loggedAt,
+				Service:      "web",
+				Message:      "Starting server",
+				Pod:          "web",
+				Namespace:    "namespace",
+				Node:         "node-1",
+				RestartCount: 2,
+				Stream:       OutputStreamStdout,
+			},
+		},
+		{
+			name: "Init container",
+			line: parsedLogLine{
+				fromContainer: "web",
+				initContainer: "wait-for-db",
+				message:       "Waiting for db",
+				loggedAt:      loggedAt,
+			},
+			expRecord: jsonLogRecord{
+				Timestamp:     loggedAt,
+				Service:       "web",
+				Message:       "Waiting for db",
+				Pod:           "web",
+				Namespace:     "namespace",
+				Node:          "node-1",
+				RestartCount:  2,
+				InitContainer: "wait-for-db",
+			},
+		},
+		{
+			name: "Missing pod metadata",
+			line: parsedLogLine{
+				fromContainer: "worker",
+				message:       "line one\nline two",
+				loggedAt:      loggedAt,
+			},
+			expRecord: jsonLogRecord{
+				Timestamp: loggedAt,
+				Service:   "worker",
+				Message:   "line one\nline two",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			formatted := formatJSON(metadata)(test.line)
+
+			var record jsonLogRecord
+			assert.NoError(t, json.Unmarshal([]byte(formatted), &record))
+			assert.True(t, test.expRecord.Timestamp.Equal(record.Timestamp),
+				"expected %s, got %s", test.expRecord.Timestamp, record.Timestamp)
+			record.Timestamp = test.expRecord.Timestamp
+			assert.Equal(t, test.expRecord, record)
+
+			// Each record must be printed on its own line.
+			assert.NotContains(t, formatted, "\n")
+		})
+	}
+}
+
+func TestGetRestartCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []corev1.ContainerStatus
+		exp      int32
+	}{
+		{
+			name: "No statuses",
+			exp:  0,
+		},
+		{
+			name: "Service container",
+			statuses: []corev1.ContainerStatus{
+				{Name: "web", RestartCount: 3},
+			},
+			exp: 3,
+		},
+		{
+			name: "Ignores the log capture sidecar",
+			statuses: []corev1.ContainerStatus{
+				{Name: names.LogCaptureContainer, RestartCount: 5},
+				{Name: "web", RestartCount: 1},
+			},
+			exp: 1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: test.statuses}}
+			assert.Equal(t, test.exp, getRestartCount(pod))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+)
+
+// logLevels are the recognized log levels, from least to most severe.
+var logLevels = []string{"trace", "debug", "info", "warn", "error", "fatal"}
+
+// levelAliases maps alternate spellings of levels to the names in logLevels.
+var levelAliases = map[string]string{
+	"warning":  "warn",
+	"err":      "error",
+	"critical": "fatal",
+	"panic":    "fatal",
+}
+
+// levelPattern matches the level of a log line. Levels are recognized when
+// they're capitalized, such as `ERROR`, or when they're the value of a level
+// field, such as `level=error` or `"level":"error"`. Lowercase words in the
+// message itself aren't treated as levels.
+var levelPattern = regexp.MustCompile(
+	`\b(TRACE|DEBUG|INFO|WARN|WARNING|ERR|ERROR|FATAL|CRITICAL|PANIC)\b|` +
+		`(?i:level"?\s*[=:]\s*"?(trace|debug|info|warn|warning|err|error|fatal|critical|panic)\b)`)
+
+// parseLevel returns the index of the level in logLevels, or false if it's
+// not a recognized level.
+func parseLevel(level string) (int, bool) {
+	level = strings.ToLower(level)
+	if alias, ok := levelAliases[level]; ok {
+		level = alias
+	}
+
+	for i, candidate := range logLevels {
+		if candidate == level {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// detectLevel returns the level of the log message, or false if the message
+// doesn't contain a recognizable level.
+func detectLevel(message string) (int, bool) {
+	match := levelPattern.FindStringSubmatch(message)
+	if match == nil {
+		return 0, false
+	}
+
+	level := match[1]
+	if level == "" {
+		level = match[2]
+	}
+	return parseLevel(level)
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"context"
 	"fmt"
-	"hash/fnv"
 	"io"
 	"os"
 	"os/signal"
@@ -19,9 +18,14 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/kelda/blimp/cli/authstore"
 	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/cli/util"
 	"github.com/kelda/blimp/pkg/errors"
 	"github.com/kelda/blimp/pkg/names"
 	"github.com/kelda/blimp/pkg/proto/cluster"
@@ -31,8 +35,208 @@ type LogsCommand struct {
 	Containers []string
 	Opts       corev1.PodLogOptions
 	Auth       authstore.Store
+
+	// History selects where logs are read from. See the History constants.
+	History string
+
+	// FollowNewServices streams the logs of services that start after the
+	// command started, in addition to Containers. It only has an effect when
+	// following logs.
+	FollowNewServices bool
+
+	// NoConnectionWatch disables the notifications when the connection to
+	// the sandbox recovers. It's set by commands that already watch the
+	// connection, such as `blimp up`.
+	NoConnectionWatch bool
+
+	// Output selects the format that logs are printed in. See the Output
+	// constants.
+	Output string
+
+	// Replay is the speed at which to replay historical logs, such as "2x".
+	// If it's empty, logs are printed as quickly as possible.
+	Replay string
+
+	// TimestampSource selects which timestamp logs are ordered by. See the
+	// TimestampSource constants.
+	TimestampSource string
+
+	// Timestamps prefixes each printed log line with the time it was logged.
+	Timestamps bool
+
+	// TimestampFormat is the format of the timestamps added by Timestamps.
+	// It's either a key in timestampFormats, or a Go time layout.
+	TimestampFormat string
+
+	// Timezone is the timezone that the timestamps added by Timestamps and
+	// Format are converted to. It's either TimezoneLocal, or an IANA timezone name. If
+	// it's empty, timestamps are printed as they were logged, which is UTC
+	// for the timestamps added by Kubernetes.
+	Timezone string
+
+	// Format is a text/template that each log line is formatted with, such
+	// as `{{.Service}} [{{.Time}}] {{.Message}}`. See templateLogLine for the
+	// available fields. If it's empty, lines are prefixed with the service
+	// name.
+	Format string
+
+	// Multiline groups continuation lines, such as stack traces, with the
+	// log record that they belong to.
+	Multiline bool
+
+	// MultilineRegex overrides the heuristics used to detect continuation
+	// lines. Lines that match it are continuations. Setting it enables
+	// Multiline.
+	MultilineRegex string
+
+	// MultilineStart matches the first line of each log record, so that
+	// lines that don't match it are continuations. It's either a regular
+	// expression, or MultilineStartTimestamp. Setting it enables Multiline.
+	MultilineStart string
+
+	// MaxSkew is how long a log line is held while waiting for earlier lines
+	// from other services. Larger values order logs more reliably, but delay
+	// them. If it's zero, DefaultMaxSkew is used.
+	MaxSkew time.Duration
+
+	// SlowOutput selects what happens when logs arrive faster than they can
+	// be printed, such as when piping to a pager. See the SlowOutput
+	// constants.
+	SlowOutput string
+
+	// Color controls when colors are printed. See the Color constants.
+	Color string
+
+	// Palette overrides the colors that services are assigned from, such as
+	// for color-blind users. Each element is a color name such as "blue".
+	Palette []string
+
+	// ParseJSON pretty-prints log messages that are JSON objects as
+	// key=value pairs.
+	ParseJSON bool
+
+	// StripAppTimestamps removes the timestamps that applications prepend to
+	// their log messages, so that logs aren't printed with two timestamps.
+	StripAppTimestamps bool
+
+	// Build prints the output of the most recent image build for each
+	// service, rather than the service's logs.
+	Build bool
+
+	// IncludeInit also prints the logs of the services' init containers,
+	// such as the containers that seed volumes and wait for dependencies.
+	// Services that are still booting are printed even though their main
+	// container hasn't started.
+	IncludeInit bool
+
+	// Restart selects the container instance to read the logs of, by the
+	// number of restarts before it. It's nil if the current instance should
+	// be read, or the instance selected by Opts.Previous.
+	Restart *int
+
+	// ListRestarts prints the services' container instances, rather than
+	// their logs.
+	ListRestarts bool
+
+	// ViaManager streams the logs through the Blimp manager rather than
+	// directly from Kubernetes, for networks that block the Kubernetes API.
+	ViaManager bool
+
+	// ServiceConfigs are the per-service defaults from the project's
+	// blimp.yaml, keyed by service name.
+	ServiceConfigs map[string]projectcfg.ServiceLogsConfig
+
+	// MinLevel hides log lines below the given level in all services. It
+	// takes precedence over the levels in ServiceConfigs.
+	MinLevel string
+
+	// OutputDir, if set, is a directory that each service's logs are
+	// written to, in addition to stdout.
+	OutputDir string
+
+	// OutputMaxSize is the size at which files in OutputDir are rotated.
+	OutputMaxSize int64
+
+	// NoStdout disables printing logs to stdout, so that they're only
+	// written to OutputDir.
+	NoStdout bool
+
+	// Grep is a regular expression that log messages must match to be
+	// printed.
+	Grep string
+
+	// GrepV is a regular expression that hides the log messages it matches.
+	GrepV string
+
+	// Dedupe collapses consecutive identical lines from the same service
+	// into a "last message repeated N times" line.
+	Dedupe bool
+
+	// Highlight is a regular expression whose matches are highlighted in
+	// the printed messages. Unlike Grep, lines that don't match are still
+	// printed.
+	Highlight string
+
+	// Stats prints the number of lines and bytes that each service logs per
+	// second, every StatsInterval, rather than printing the logs.
+	Stats         bool
+	StatsInterval time.Duration
+
+	// NoPager disables piping the logs into $PAGER when they're printed to
+	// a terminal without --follow.
+	NoPager bool
+
+	// StdoutOnly and StderrOnly only print the lines logged to the given
+	// stream. Lines whose stream isn't known are always printed.
+	StdoutOnly bool
+	StderrOnly bool
+
+	// Excludes are glob patterns, such as `worker-*`, for services whose logs
+	// shouldn't be printed. They also apply to services that start later
+	// with FollowNewServices.
+	Excludes []string
+
+	// UntilMatch is a regular expression that stops following the logs
+	// once a printed line matches it, such as to wait for a server to start.
+	// If UntilTimeout expires first, the command fails.
+	UntilMatch   string
+	UntilTimeout time.Duration
+
+	// VerbosePrefix adds the pod name, restart count, and node name of the
+	// container that logged each line to its prefix.
+	VerbosePrefix bool
+
+	// ResumeAfter is the Kubernetes timestamp of the last line that was
+	// printed from each stream before the logs were interrupted. Lines
+	// logged at or before it are skipped, so that resumed logs don't repeat
+	// lines. It's set by Resume.
+	ResumeAfter map[string]time.Time
 }
 
+const (
+	// HistoryRecent reads the logs retained by Kubernetes. Older logs may have
+	// been lost due to log rotation.
+	HistoryRecent = "recent"
+
+	// HistoryFull reads the logs persisted by the log capture sidecar.
+	HistoryFull = "full"
+)
+
+const (
+	// OutputText prints the raw log lines, prefixed by the service name
+	// when logs for multiple services are printed.
+	OutputText = "text"
+
+	// OutputJSON prints each log line as a JSON object that includes the
+	// metadata of the service's pod.
+	OutputJSON = "json"
+
+	// OutputNDJSON is an alias for OutputJSON. Each record is already
+	// printed on its own line, so the output can be piped into tools that
+	// expect newline delimited JSON.
+	OutputNDJSON = "ndjson"
+)
+
 type rawLogLine struct {
 	// Any error that occurred when trying to read logs.
 	// If this is non-nil, `message` and `receivedAt` aren't meaningful.
@@ -41,34 +245,173 @@ type rawLogLine struct {
 	// The container that generated the log.
 	fromContainer string
 
+	// The init container that generated the log, if it wasn't generated by
+	// the service's main container.
+	initContainer string
+
 	// The contents of the log line (including the timestamp added by Kubernetes).
 	message string
 
 	// The time that we read the log line.
 	receivedAt time.Time
+
+	// The stream that the line was logged to, if it's known without parsing
+	// the message. See splitOutputStream.
+	outputStream string
+
+	// The metadata of the pod that logged the line, if --verbose-prefix is
+	// set.
+	pod *podMetadata
 }
 
 type parsedLogLine struct {
 	// The Kelda container that generated the log.
 	fromContainer string
 
+	// The init container that generated the log, if any.
+	initContainer string
+
 	// The contents of the log line (without the timestamp added by Kubernetes).
 	message string
 
 	// The time that the log line was generated by the application according to
 	// the machine that the container is running on.
 	loggedAt time.Time
+
+	// The stream that the line was logged to, either OutputStreamStdout or
+	// OutputStreamStderr. It's empty if the stream isn't known.
+	outputStream string
+
+	// The metadata of the pod that logged the line, if --verbose-prefix is
+	// set. It's nil if the metadata couldn't be fetched.
+	pod *podMetadata
+}
+
+// stream identifies the log stream that the line was read from. Each
+// service's init containers are streamed separately from its main container.
+func (line rawLogLine) stream() string {
+	return streamName(line.fromContainer, line.initContainer)
+}
+
+func (line parsedLogLine) stream() string {
+	return streamName(line.fromContainer, line.initContainer)
+}
+
+func streamName(container, initContainer string) string {
+	if initContainer == "" {
+		return container
+	}
+	return fmt.Sprintf("%s (init: %s)", container, initContainer)
+}
+
+// logProcessor converts the raw log lines into the output.
+type logProcessor struct {
+	parse func(rawLogLine) parsedLogLine
+
+	// isContinuation returns whether a line continues the previous log
+	// record, such as a stack trace. If it's nil, each line is a separate
+	// record. See multilineGrouper.
+	isContinuation func(message, previous string) bool
+
+	// filter returns whether the log line should be printed. If it's nil,
+	// all lines are printed.
+	filter func(parsedLogLine) bool
+
+	format func(parsedLogLine) string
+
+	// out is where log lines are printed, such as stdout or a pager.
+	out io.Writer
+
+	// If noStdout is set, log lines aren't printed to stdout. This is
+	// useful when they're written to outputDir instead.
+	noStdout bool
+
+	// untilMatch, if set, stops printing logs once a printed line matches
+	// its pattern.
+	untilMatch *untilMatcher
+
+	// outputDir, if set, receives a copy of each log line that passes the
+	// filter.
+	outputDir *outputDir
+
+	// dedupe, if set, collapses consecutive identical lines.
+	dedupe *deduper
+
+	// stats, if set, counts the lines logged by each service. They're
+	// counted before the filter is applied.
+	stats *logStats
+
+	// resumeAfter skips the lines that were printed before the logs were
+	// interrupted. See LogsCommand.ResumeAfter.
+	resumeAfter map[string]time.Time
+}
+
+// shouldPrint returns whether the log line passes the filter.
+func (proc logProcessor) shouldPrint(log parsedLogLine) bool {
+	return proc.filter == nil || proc.filter(log)
+}
+
+// output prints the log line, and writes it to the output directory.
+func (proc logProcessor) output(log parsedLogLine) {
+	if proc.dedupe == nil {
+		proc.write(log)
+		return
+	}
+
+	for _, line := range proc.dedupe.add(log) {
+		proc.write(line)
+	}
+}
+
+// flush prints the lines held back by output, once the logs end.
+func (proc logProcessor) flush() {
+	if proc.dedupe == nil {
+		return
+	}
+
+	for _, line := range proc.dedupe.flush() {
+		proc.write(line)
+	}
+}
+
+func (proc logProcessor) write(log parsedLogLine) {
+	// Nothing is printed after the line that matched --until-match, even if
+	// later lines were already buffered when it matched.
+	if proc.untilMatch != nil && proc.untilMatch.matched {
+		return
+	}
+
+	if !proc.noStdout {
+		fmt.Fprintln(proc.out, proc.format(log))
+	}
+	if proc.outputDir != nil {
+		proc.outputDir.write(log)
+	}
+	if proc.untilMatch != nil {
+		proc.untilMatch.check(log)
+	}
 }
 
 func New() *cobra.Command {
 	cmd := &LogsCommand{}
+	var since time.Duration
+	var sinceTime string
+	var tail int64
+	var all bool
+	var restart int
+	var outputMaxSize string
 
 	cobraCmd := &cobra.Command{
-		Use:   "logs SERVICE ...",
+		Use:   "logs [SERVICE ...]",
 		Short: "Print the logs for the given services",
 		Long: "Print the logs for the given services.\n\n" +
-			"If multiple services are provided, the log output is interleaved.",
-		Run: func(_ *cobra.Command, args []string) {
+			"If multiple services are provided, the log output is interleaved. " +
+			"Service groups defined in " + projectcfg.Filename + " are expanded to their services, " +
+			"and globs such as 'web-*' are expanded to the matching services in the sandbox. " +
+			"If no services are provided, the logs for all the services in the sandbox are printed, " +
+			"except for the services excluded in " + projectcfg.Filename + ".",
+		ValidArgsFunction: util.CompleteServices,
+		Run: func(cobraCmd *cobra.Command, args []string) {
 			auth, err := authstore.New()
 			if err != nil {
 				log.WithError(err).Fatal("Failed to parse auth store")
@@ -79,13 +422,86 @@ func New() *cobra.Command {
 				return
 			}
 
+			if all && len(args) != 0 {
+				fmt.Fprintln(os.Stderr, "Services can't be specified with --all")
+				errors.Exit(1)
+			}
+
+			projectCfg, err := projectcfg.Load(".")
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			args, err = manager.ResolveServices(auth.AuthToken, projectCfg.ExpandGroups(args))
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if len(args) == 0 && cmd.Build {
+				fmt.Fprintln(os.Stderr, "At least one service is required with --build")
+				errors.Exit(1)
+			}
+
 			if len(args) == 0 {
-				fmt.Fprintf(os.Stderr, "At least one container is required")
-				os.Exit(1)
+				// Services that start later are also printed, since the user
+				// asked for all services rather than a fixed set.
+				cmd.FollowNewServices = true
+				args, err = getAllServices(auth.AuthToken, projectCfg.Logs.Services)
+				if err != nil {
+					errors.HandleFatalError(err)
+				}
+			}
+
+			args, err = manager.ExcludeServices(args, cmd.Excludes)
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+			if len(args) == 0 {
+				fmt.Fprintln(os.Stderr, "All the services were excluded by --exclude.")
+				errors.Exit(1)
 			}
 
 			cmd.Auth = auth
 			cmd.Containers = args
+			cmd.ServiceConfigs = projectCfg.Logs.Services
+			if len(cmd.Palette) == 0 {
+				cmd.Palette = projectCfg.Logs.Palette
+			}
+			if cmd.Format == "" {
+				cmd.Format = projectCfg.Logs.Format
+			}
+			if since != 0 && sinceTime != "" {
+				fmt.Fprintln(os.Stderr, "Only one of --since and --since-time can be specified")
+				errors.Exit(1)
+			}
+			if since != 0 {
+				sinceSeconds := int64(since.Seconds())
+				cmd.Opts.SinceSeconds = &sinceSeconds
+			}
+			if tail >= 0 {
+				cmd.Opts.TailLines = &tail
+			}
+			if sinceTime != "" {
+				parsed, err := time.Parse(time.RFC3339, sinceTime)
+				if err != nil {
+					errors.HandleFatalError(errors.NewFriendlyError(
+						"Failed to parse --since-time %q. It must be an RFC3339 timestamp, "+
+							"such as 2020-06-01T15:04:05Z.", sinceTime))
+				}
+				cmd.Opts.SinceTime = &metav1.Time{Time: parsed}
+			}
+			if cobraCmd.Flags().Changed("restart") {
+				cmd.Restart = &restart
+			}
+			if cmd.NoStdout && cmd.OutputDir == "" {
+				fmt.Fprintln(os.Stderr, "--no-stdout can only be used with --output-dir")
+				errors.Exit(1)
+			}
+			cmd.OutputMaxSize, err = util.ParseBytes(outputMaxSize)
+			if err != nil {
+				errors.HandleFatalError(errors.NewFriendlyError(
+					"Failed to parse --output-max-size %q. It must be a size such as 100MiB.", outputMaxSize))
+			}
 			if err := cmd.Run(); err != nil {
 				errors.HandleFatalError(err)
 			}
@@ -94,30 +510,366 @@ func New() *cobra.Command {
 
 	cobraCmd.Flags().BoolVarP(&cmd.Opts.Follow, "follow", "f", false,
 		"Specify if the logs should be streamed.")
+	cobraCmd.Flags().BoolVarP(&all, "all", "", false,
+		"Print the logs for all the services in the sandbox. This is the default if no services are provided. "+
+			"With --follow, services that start later are also printed.")
+	cobraCmd.Flags().StringArrayVarP(&cmd.Excludes, "exclude", "", nil,
+		"Don't print the logs of services matching the given name or glob, such as 'worker-*'. "+
+			"Can be repeated. Services that start later with --follow are excluded as well.")
 	cobraCmd.Flags().BoolVarP(&cmd.Opts.Previous, "previous", "p", false,
 		"If true, print the logs for the previous instance of the container if it crashed.")
+	cobraCmd.Flags().IntVarP(&restart, "restart", "", 0,
+		"Print the logs of the container that ran after the given number of restarts, where 0 is the first "+
+			"container. See --list-restarts. Restarts before the previous container require full log history.")
+	cobraCmd.Flags().BoolVarP(&cmd.ListRestarts, "list-restarts", "", false,
+		"List when the services' containers started and why they ended, rather than printing logs.")
+	cobraCmd.Flags().StringVarP(&cmd.History, "history", "", HistoryRecent,
+		fmt.Sprintf("Where to read logs from. %q reads the logs retained by the cluster, "+
+			"which may be truncated for chatty services. %q reads the complete logs "+
+			"for services that have the %s label set.",
+			HistoryRecent, HistoryFull, names.LogCaptureLabel))
+	cobraCmd.Flags().DurationVarP(&since, "since", "", 0,
+		"Only return logs newer than a relative duration like 5s, 2m, or 3h.")
+	cobraCmd.Flags().StringVarP(&sinceTime, "since-time", "", "",
+		"Only return logs after a specific date (RFC3339), such as 2020-06-01T15:04:05Z.")
+	cobraCmd.Flags().Int64VarP(&tail, "tail", "", -1,
+		"The number of lines from the end of each service's logs to show. "+
+			"Defaults to -1, which shows all lines.")
+	cobraCmd.Flags().StringVarP(&cmd.Replay, "replay", "", "",
+		"Replay the logs with the same timing as when they were originally logged, "+
+			"sped up by the given factor (e.g. 1x or 2x). Can't be used with --follow.")
+	cobraCmd.Flags().StringVarP(&cmd.Output, "output", "o", OutputText,
+		fmt.Sprintf("The format to print logs in. Either %q or %q (%q is an alias). "+
+			"JSON is printed as one object per line with the service, message, and timestamp, "+
+			"as well as the pod name, node, namespace, and restart count "+
+			"so that they can be correlated with cluster events.", OutputText, OutputJSON, OutputNDJSON))
+	cobraCmd.Flags().StringVarP(&cmd.TimestampSource, "timestamp-source", "", TimestampSourceCluster,
+		fmt.Sprintf("The timestamp used to order and display logs. %q uses the time the cluster received "+
+			"the log. %q uses the timestamp at the start of the log message, if the application adds one.",
+			TimestampSourceCluster, TimestampSourceApp))
+	cobraCmd.Flags().BoolVarP(&cmd.Timestamps, "timestamps", "", false,
+		"Prefix each log line with the time it was logged.")
+	cobraCmd.Flags().StringVarP(&cmd.TimestampFormat, "timestamp-format", "", DefaultTimestampFormat,
+		"The format of the timestamps added by --timestamps. Either 'rfc3339', 'time', "+
+			"or a Go time layout such as '15:04:05'.")
+	cobraCmd.Flags().StringVarP(&cmd.Format, "format", "", "",
+		"A Go template to format each log line with, such as '{{.Service}} [{{.Time}}] {{.Message}}'. "+
+			"The fields are Service, InitContainer, Stream, Time, Timestamp, Level, and Message. "+
+			"Time is formatted according to --timestamp-format and --tz.")
+	cobraCmd.Flags().StringVarP(&cmd.Timezone, "tz", "", "",
+		"The timezone to print the timestamps added by --timestamps and --format in. Either 'local', or a timezone name "+
+			"such as 'America/New_York'. Defaults to the timezone the logs were written in, which is usually UTC.")
+	cobraCmd.Flags().BoolVarP(&cmd.Multiline, "multiline", "", false,
+		"Group continuation lines, such as the lines of stack traces, with the log line they belong to, "+
+			"so that they aren't interleaved with the logs of other services.")
+	cobraCmd.Flags().StringVarP(&cmd.MultilineRegex, "multiline-regex", "", "",
+		"A regular expression that matches continuation lines, such as '^\\s'. "+
+			"Overrides the built-in detection of indented lines and stack traces. Implies --multiline.")
+	cobraCmd.Flags().StringVarP(&cmd.MultilineStart, "multiline-start", "", "",
+		fmt.Sprintf("A regular expression that matches the first line of each log record, such as '^\\['. "+
+			"Lines that don't match it are continuations. %q matches lines that start with a timestamp. "+
+			"Implies --multiline.",
+			MultilineStartTimestamp))
+	cobraCmd.Flags().DurationVarP(&cmd.MaxSkew, "max-skew", "", DefaultMaxSkew,
+		"How long to wait for delayed log lines from other services before printing a line. "+
+			"Larger values order the logs more reliably, but print them later.")
+	cobraCmd.Flags().StringVarP(&cmd.SlowOutput, "on-slow-output", "", SlowOutputPause,
+		fmt.Sprintf("What to do when logs arrive faster than they can be printed. Either %q, which stops reading "+
+			"logs until the output catches up, or %q, which keeps the %d most recent unprinted lines and "+
+			"drops the rest.", SlowOutputPause, SlowOutputDrop, DefaultDropBufferSize))
+	cobraCmd.Flags().StringVarP(&cmd.Color, "color", "", ColorAuto,
+		fmt.Sprintf("When to print colors. Either %q, %q, or %q. %q only prints colors to terminals, "+
+			"and respects the NO_COLOR environment variable.", ColorAuto, ColorAlways, ColorNever, ColorAuto))
+	cobraCmd.Flags().StringSliceVarP(&cmd.Palette, "palette", "", nil,
+		"The colors that services are assigned from, such as 'blue,yellow,white'. "+
+			"Defaults to the palette in blimp.yaml, or a palette of six colors.")
+	cobraCmd.Flags().BoolVarP(&cmd.ParseJSON, "parse-json", "", false,
+		"Pretty-print log messages that are JSON objects as key=value pairs, "+
+			"with the time, level, and message first.")
+	cobraCmd.Flags().BoolVarP(&cmd.StripAppTimestamps, "strip-app-timestamps", "", false,
+		"Remove the timestamps that applications add to the start of their log messages.")
+	cobraCmd.Flags().BoolVarP(&cmd.Build, "build", "", false,
+		"Print the full output of the most recent image build for the services, "+
+			"rather than the services' logs.")
+	cobraCmd.Flags().BoolVarP(&cmd.IncludeInit, "include-init", "", false,
+		"Also print the logs of the services' init containers, such as the containers that seed volumes "+
+			"and wait for dependencies. Services that are still booting are printed as well.")
+	cobraCmd.Flags().StringVarP(&cmd.Opts.Container, "container", "", "",
+		"Print the logs of the named container in each service's pod, such as a sidecar, "+
+			"rather than the service's main container")
+	cobraCmd.Flags().BoolVarP(&cmd.ViaManager, "via-manager", "", false,
+		"Stream the logs through the Blimp manager rather than directly from the cluster. "+
+			"Useful on networks that block the cluster's Kubernetes API.")
+	cobraCmd.Flags().StringVarP(&cmd.OutputDir, "output-dir", "", "",
+		"Also write each service's logs to DIR/SERVICE.log. Files are rotated once they reach --output-max-size.")
+	cobraCmd.Flags().StringVarP(&outputMaxSize, "output-max-size", "", util.FormatBytes(DefaultOutputMaxSize),
+		"The size at which files in --output-dir are rotated, such as 100MiB.")
+	cobraCmd.Flags().BoolVarP(&cmd.NoStdout, "no-stdout", "", false,
+		"Don't print logs to stdout. Only useful with --output-dir.")
+	cobraCmd.Flags().StringVarP(&cmd.Grep, "grep", "", "",
+		"Only print log lines whose message matches the given regular expression.")
+	cobraCmd.Flags().StringVarP(&cmd.GrepV, "grep-v", "", "",
+		"Hide log lines whose message matches the given regular expression.")
+	cobraCmd.Flags().BoolVarP(&cmd.Dedupe, "dedupe", "", false,
+		"Collapse consecutive identical lines from the same service into a 'last message repeated N times' line.")
+	cobraCmd.Flags().StringVarP(&cmd.Highlight, "highlight", "", "",
+		"Highlight the parts of log messages that match the given regular expression, while still "+
+			"printing all lines. Only has an effect when colors are printed.")
+	cobraCmd.Flags().BoolVarP(&cmd.Stats, "stats", "", false,
+		"Rather than printing the logs, periodically print how many lines and bytes each service "+
+			"logs per second. Useful for finding the service that's flooding the logs.")
+	cobraCmd.Flags().DurationVarP(&cmd.StatsInterval, "stats-interval", "", DefaultStatsInterval,
+		"How often --stats prints the log rates.")
+	cobraCmd.Flags().BoolVarP(&cmd.StdoutOnly, "stdout-only", "", false,
+		"Only print the lines that services logged to stdout. Kubernetes merges stdout and stderr, so "+
+			"the stream is only known with '--history full' or --via-manager. Other lines are always printed.")
+	cobraCmd.Flags().BoolVarP(&cmd.StderrOnly, "stderr-only", "", false,
+		"Only print the lines that services logged to stderr. Kubernetes merges stdout and stderr, so "+
+			"the stream is only known with '--history full' or --via-manager. Other lines are always printed.")
+	cobraCmd.Flags().StringVarP(&cmd.UntilMatch, "until-match", "", "",
+		"Exit successfully once a printed log line matches the given regular expression, such as "+
+			"'server started'. Requires --follow.")
+	cobraCmd.Flags().DurationVarP(&cmd.UntilTimeout, "until-timeout", "", 0,
+		"Fail if no line matches --until-match within the given duration. 0 waits forever.")
+	cobraCmd.Flags().BoolVarP(&cmd.VerbosePrefix, "verbose-prefix", "", false,
+		"Include the pod name, restart count, and node of the container that logged each line in its prefix, "+
+			"so that lines can be attributed to a container instance after crashes. The metadata is fetched "+
+			"when each logs stream starts.")
+	cobraCmd.Flags().BoolVarP(&cmd.NoPager, "no-pager", "", false,
+		"Print the logs directly to the terminal, rather than piping them into $PAGER. "+
+			"Logs are only paged when --follow isn't set.")
+	cobraCmd.Flags().StringVarP(&cmd.MinLevel, "min-level", "", "",
+		fmt.Sprintf("Hide log lines below the given level. One of %s. Lines without a "+
+			"recognizable level are always shown. Overrides the levels in %s.",
+			strings.Join(logLevels, ", "), projectcfg.Filename))
 
+	// `blimp logs search` is the same as `blimp search`, so that it can be
+	// found alongside the other ways of reading logs.
+	cobraCmd.AddCommand(NewSearchCommand())
+	cobraCmd.AddCommand(NewExportCommand())
 	return cobraCmd
 }
 
 func (cmd LogsCommand) Run() error {
-	kubeClient, _, err := cmd.Auth.KubeClient()
+	if cmd.Build {
+		if cmd.Opts.Follow {
+			return errors.NewFriendlyError("--build can't be used with --follow. " +
+				"Build logs are only available once the build completes.")
+		}
+		return printBuildLogs(os.Stdout, cmd.Containers)
+	}
+
+	if cmd.ListRestarts {
+		for _, container := range cmd.Containers {
+			instances, err := getContainerInstances(cmd.Auth.AuthToken, container)
+			if err != nil {
+				return err
+			}
+			printContainerInstances(os.Stdout, container, instances)
+		}
+		return nil
+	}
+
+	kubeClient, restConfig, err := cmd.Auth.KubeClient()
 	if err != nil {
 		return errors.WithContext("connect to cluster", err)
 	}
 
+	var restartFilter func(parsedLogLine) bool
+	if cmd.Restart != nil {
+		restartFilter, err = cmd.selectRestart(kubeClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch cmd.History {
+	case "", HistoryRecent, HistoryFull:
+	default:
+		return errors.NewFriendlyError("Unknown --history value %q. "+
+			"It must be either %q or %q.", cmd.History, HistoryRecent, HistoryFull)
+	}
+
+	switch cmd.Output {
+	case "", OutputText, OutputJSON:
+	case OutputNDJSON:
+		cmd.Output = OutputJSON
+	default:
+		return errors.NewFriendlyError("Unknown --output value %q. "+
+			"It must be either %q or %q.", cmd.Output, OutputText, OutputJSON)
+	}
+
+	switch cmd.SlowOutput {
+	case "", SlowOutputPause, SlowOutputDrop:
+	default:
+		return errors.NewFriendlyError("Unknown --on-slow-output value %q. "+
+			"It must be either %q or %q.", cmd.SlowOutput, SlowOutputPause, SlowOutputDrop)
+	}
+
+	switch cmd.TimestampSource {
+	case "", TimestampSourceCluster, TimestampSourceApp:
+	default:
+		return errors.NewFriendlyError("Unknown --timestamp-source value %q. "+
+			"It must be either %q or %q.", cmd.TimestampSource, TimestampSourceCluster, TimestampSourceApp)
+	}
+
+	if cmd.Timestamps && cmd.Output == OutputJSON {
+		return errors.NewFriendlyError("--timestamps can't be used with `--output %s`. "+
+			"JSON records already include the timestamp.", OutputJSON)
+	}
+
+	if cmd.ParseJSON && cmd.Output == OutputJSON {
+		return errors.NewFriendlyError("--parse-json can't be used with `--output %s`.", OutputJSON)
+	}
+
+	if cmd.Highlight != "" && cmd.Output == OutputJSON {
+		return errors.NewFriendlyError("--highlight can't be used with `--output %s`.", OutputJSON)
+	}
+
+	if cmd.UntilMatch != "" && !cmd.Opts.Follow {
+		return errors.NewFriendlyError("--until-match requires --follow.")
+	}
+
+	if cmd.UntilTimeout != 0 && cmd.UntilMatch == "" {
+		return errors.NewFriendlyError("--until-timeout can only be used with --until-match.")
+	}
+
+	if cmd.VerbosePrefix && (cmd.Output == OutputJSON || cmd.Format != "") {
+		return errors.NewFriendlyError("--verbose-prefix can't be used with --format or `--output %s`. "+
+			"JSON records already include the pod metadata.", OutputJSON)
+	}
+
+	if cmd.Format != "" && cmd.Output == OutputJSON {
+		return errors.NewFriendlyError("--format can't be used with `--output %s`.", OutputJSON)
+	}
+
+	if cmd.Format != "" && cmd.Timestamps {
+		return errors.NewFriendlyError("--timestamps can't be used with --format. " +
+			"Add {{.Time}} to the template instead.")
+	}
+
+	if cmd.Stats && (cmd.Replay != "" || cmd.Dedupe) {
+		return errors.NewFriendlyError("--stats can't be used with --replay or --dedupe.")
+	}
+
+	levelFilter, err := cmd.getLevelFilter()
+	if err != nil {
+		return err
+	}
+
+	grepFilter, err := getGrepFilter(cmd.Grep, cmd.GrepV)
+	if err != nil {
+		return err
+	}
+
+	outputStreamFilter, err := getOutputStreamFilter(cmd.StdoutOnly, cmd.StderrOnly)
+	if err != nil {
+		return err
+	}
+
+	// Lines are grouped after the app's timestamps are stripped, so there
+	// wouldn't be any timestamps left to detect.
+	if cmd.StripAppTimestamps && cmd.MultilineStart == MultilineStartTimestamp {
+		return errors.NewFriendlyError("--multiline-start=%s can't be used with --strip-app-timestamps.",
+			MultilineStartTimestamp)
+	}
+
+	continuationFilter, err := getContinuationFilter(cmd.Multiline, cmd.MultilineRegex, cmd.MultilineStart)
+	if err != nil {
+		return err
+	}
+
+	colors, err := cmd.getColors()
+	if err != nil {
+		return err
+	}
+
+	timezone, err := parseTimezone(cmd.Timezone)
+	if err != nil {
+		return err
+	}
+
+	var replaySpeed float64
+	if cmd.Replay != "" {
+		if cmd.Opts.Follow {
+			return errors.NewFriendlyError("--replay can't be used with --follow. " +
+				"Only logs that have already been written can be replayed.")
+		}
+
+		replaySpeed, err = parseReplaySpeed(cmd.Replay)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.History == HistoryFull && (cmd.Opts.SinceSeconds != nil || cmd.Opts.SinceTime != nil) {
+		return errors.NewFriendlyError("--since and --since-time can't be used with `--history %s`.", HistoryFull)
+	}
+
+	if cmd.History == HistoryFull && cmd.Opts.TailLines != nil {
+		return errors.NewFriendlyError("--tail can't be used with `--history %s`.", HistoryFull)
+	}
+
+	if cmd.History == HistoryFull && cmd.Opts.Previous {
+		return errors.NewFriendlyError("--previous can't be used with `--history %s`. "+
+			"The full history already includes the logs of previous containers.", HistoryFull)
+	}
+
+	if cmd.History == HistoryFull && cmd.IncludeInit {
+		return errors.NewFriendlyError("--include-init can't be used with `--history %s`. "+
+			"The logs of init containers aren't captured.", HistoryFull)
+	}
+
+	if cmd.ViaManager && cmd.History == HistoryFull {
+		return errors.NewFriendlyError("--via-manager can't be used with `--history %s`.", HistoryFull)
+	}
+
+	if cmd.ViaManager && cmd.IncludeInit {
+		return errors.NewFriendlyError("--via-manager can't be used with --include-init.")
+	}
+
+	if cmd.Opts.Container != "" && cmd.ViaManager {
+		return errors.NewFriendlyError("--container can't be used with --via-manager.")
+	}
+
+	if cmd.Opts.Container != "" && cmd.History == HistoryFull {
+		return errors.NewFriendlyError("--container can't be used with `--history %s`. "+
+			"Only the logs of the services' main containers are captured.", HistoryFull)
+	}
+
+	if cmd.Opts.Container != "" && cmd.IncludeInit {
+		return errors.NewFriendlyError("--container can't be used with --include-init.")
+	}
+
+	// New services are detected by watching the cluster directly, which is
+	// what --via-manager avoids. The manager only streams the services in
+	// the request.
+	if cmd.ViaManager {
+		cmd.FollowNewServices = false
+	}
+
+	started := map[string]bool{}
 	for _, container := range cmd.Containers {
 		// For logs to work, the container needs to have started, but it doesn't
-		// necessarily need to be running.
+		// necessarily need to be running. With --include-init, the init
+		// containers of services that are still booting are printed instead.
 		err = manager.CheckServiceStatus(container, cmd.Auth.AuthToken,
 			func(svcStatus *cluster.ServiceStatus) bool {
-				return svcStatus.GetHasStarted()
+				started[container] = svcStatus.GetHasStarted()
+				return started[container] || cmd.IncludeInit
 			})
 		if err != nil {
 			return err
 		}
 	}
 
+	if cmd.Opts.Container != "" {
+		cmd.Containers, err = filterByContainer(kubeClient, cmd.Auth.KubeNamespace, cmd.Containers, cmd.Opts.Container)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Exit gracefully when the user Ctrl-C's.
 	// The `printLogs` function will return when the context is cancelled,
 	// which allows functions defered in this method to run.
@@ -129,28 +881,92 @@ func (cmd LogsCommand) Run() error {
 		cancel()
 	}()
 
+	if cmd.UntilTimeout != 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, cmd.UntilTimeout)
+		defer cancelTimeout()
+	}
+
 	var wg sync.WaitGroup
 	combinedLogs := make(chan rawLogLine, len(cmd.Containers)*32)
+	var managerContainers []string
+
+	// The streams that are followed from the start, so that printLogs waits
+	// for them before printing the lines of other streams.
+	var streams []string
 	for _, container := range cmd.Containers {
-		// Enable timestamps so that `forwardLogs` can parse the logs.
-		cmd.Opts.Timestamps = true
-		logsReq := kubeClient.CoreV1().
-			Pods(cmd.Auth.KubeNamespace).
-			GetLogs(names.PodName(container), &cmd.Opts)
+		if cmd.IncludeInit {
+			initStreams, err := cmd.startInitLogsStreams(kubeClient, container)
+			if err != nil {
+				return err
+			}
+
+			for _, initStream := range initStreams {
+				defer initStream.Close()
+				streams = append(streams, streamName(container, initStream.initContainer))
+
+				wg.Add(1)
+				go func(container string, initStream initLogsStream) {
+					forwardLogs(combinedLogs, container, initStream.initContainer, initStream,
+						cmd.getStreamMetadata(kubeClient, container))
+					wg.Done()
+				}(container, initStream)
+			}
+		}
+
+		if !started[container] {
+			fmt.Fprintf(os.Stderr, "%s hasn't started yet, so only the logs of its init containers are printed.\n",
+				container)
+			continue
+		}
+
+		streams = append(streams, container)
+		if cmd.ViaManager {
+			managerContainers = append(managerContainers, container)
+			continue
+		}
 
-		logsStream, err := logsReq.Stream()
+		logsStream, err := cmd.startLogsStream(kubeClient, restConfig, container)
 		if err != nil {
-			return errors.WithContext("start logs stream", err)
+			return err
 		}
 		defer logsStream.Close()
 
+		metadata := cmd.getStreamMetadata(kubeClient, container)
 		wg.Add(1)
 		go func(container string) {
-			forwardLogs(combinedLogs, container, logsStream)
+			forwardLogs(combinedLogs, container, "", logsStream, metadata)
+			if cmd.Opts.Follow {
+				reportJobExit(os.Stderr, cmd.Auth.AuthToken, container)
+			}
 			wg.Done()
 		}(container)
 	}
 
+	if len(managerContainers) != 0 {
+		wg.Add(1)
+		go func() {
+			cmd.forwardManagerLogs(ctx, combinedLogs, managerContainers)
+			wg.Done()
+		}()
+	}
+
+	// Tell the user if services restarted while their machine was asleep,
+	// since their logs would otherwise be missing without explanation.
+	if cmd.Opts.Follow && !cmd.NoConnectionWatch {
+		go manager.WatchConnection(ctx, cmd.Auth.AuthToken, func(reconnect manager.Reconnect) {
+			fmt.Fprintln(os.Stderr, goterm.Color(reconnect.String(), goterm.YELLOW))
+		})
+	}
+
+	if cmd.FollowNewServices && cmd.Opts.Follow {
+		wg.Add(1)
+		go func() {
+			cmd.followNewServices(ctx, kubeClient, restConfig, combinedLogs, &wg)
+			wg.Done()
+		}()
+	}
+
 	// No more log messages will be written to the channel once all the
 	// `forwardLogs` threads finish.
 	go func() {
@@ -158,21 +974,270 @@ func (cmd LogsCommand) Run() error {
 		close(combinedLogs)
 	}()
 
-	noColor := len(cmd.Containers) == 1
-	return printLogs(ctx, combinedLogs, noColor)
+	noPrefix := len(cmd.Containers) == 1 && !cmd.FollowNewServices && !cmd.IncludeInit && !cmd.VerbosePrefix
+	proc := logProcessor{
+		parse:  withAppTimestamps(parseRawLog, cmd.TimestampSource, cmd.StripAppTimestamps),
+		filter: combineFilters(restartFilter, levelFilter, grepFilter, outputStreamFilter),
+		format: formatText(noPrefix, colors),
+		out:    os.Stdout,
+
+		isContinuation: continuationFilter,
+		resumeAfter:    cmd.ResumeAfter,
+	}
+	if cmd.Output == OutputJSON {
+		proc.format = formatJSON(newPodMetadataCache(kubeClient, cmd.Auth.KubeNamespace))
+	}
+	if cmd.Format != "" {
+		timestampFormat := cmd.TimestampFormat
+		if timestampFormat == "" {
+			timestampFormat = DefaultTimestampFormat
+		}
+
+		proc.format, err = formatTemplate(cmd.Format, timestampFormat, timezone)
+		if err != nil {
+			return err
+		}
+	}
+	if cmd.Highlight != "" {
+		proc.format, err = withHighlight(proc.format, cmd.Highlight, colors)
+		if err != nil {
+			return err
+		}
+	}
+	if cmd.ParseJSON {
+		proc.format = withParsedJSON(proc.format, colors)
+	}
+	if cmd.Timestamps {
+		timestampFormat := cmd.TimestampFormat
+		if timestampFormat == "" {
+			timestampFormat = DefaultTimestampFormat
+		}
+		proc.format = withTimestamps(proc.format, timestampFormat, timezone)
+	}
+	if cmd.OutputDir != "" {
+		maxSize := cmd.OutputMaxSize
+		if maxSize == 0 {
+			maxSize = DefaultOutputMaxSize
+		}
+
+		outputDir, err := newOutputDir(cmd.OutputDir, maxSize)
+		if err != nil {
+			return err
+		}
+		defer outputDir.Close()
+
+		proc.outputDir = outputDir
+		proc.noStdout = cmd.NoStdout
+	}
+	if cmd.Dedupe {
+		proc.dedupe = newDeduper()
+	}
+	if cmd.UntilMatch != "" {
+		proc.untilMatch, err = newUntilMatcher(cmd.UntilMatch, cancel)
+		if err != nil {
+			return err
+		}
+	}
+	if cmd.Stats {
+		interval := cmd.StatsInterval
+		if interval <= 0 {
+			interval = DefaultStatsInterval
+		}
+		proc.stats = newLogStats(os.Stdout, interval)
+		proc.noStdout = true
+	}
+	if cmd.MaxSkew == 0 {
+		cmd.MaxSkew = DefaultMaxSkew
+	}
+	if replaySpeed != 0 {
+		return replayLogs(ctx, combinedLogs, proc, replaySpeed)
+	}
+
+	if cmd.shouldPage() {
+		pager, err := startPager()
+		if err != nil {
+			return err
+		}
+
+		if pager != nil {
+			// Wait for the user to quit the pager before exiting.
+			defer pager.Close()
+			proc.out = pager
+
+			// Stop reading logs if the user quits the pager before all the
+			// logs were printed.
+			go func() {
+				<-pager.exited
+				cancel()
+			}()
+		}
+	}
+	if err := printLogs(ctx, withSlowOutput(ctx, combinedLogs, cmd.SlowOutput), proc, cmd.MaxSkew, streams); err != nil {
+		return err
+	}
+
+	// Scripts rely on the exit code to know whether the service logged the
+	// line, so any other reason for stopping is a failure.
+	if proc.untilMatch != nil && !proc.untilMatch.matched {
+		switch ctx.Err() {
+		case context.DeadlineExceeded:
+			return errors.NewFriendlyError("Timed out after %s waiting for a log line matching %q.",
+				cmd.UntilTimeout, cmd.UntilMatch)
+		case context.Canceled:
+			return errors.NewFriendlyError("Interrupted before a log line matched %q.", cmd.UntilMatch)
+		default:
+			return errors.NewFriendlyError("The logs ended before a log line matched %q.", cmd.UntilMatch)
+		}
+	}
+	return nil
+}
+
+// startLogsStream starts streaming the logs for the given service.
+func (cmd LogsCommand) startLogsStream(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	container string) (io.ReadCloser, error) {
+
+	if cmd.History == HistoryFull {
+		logsStream, err := streamCapturedLogs(kubeClient, restConfig,
+			cmd.Auth.KubeNamespace, container, cmd.Opts.Follow)
+		if err != nil {
+			return nil, errors.WithContext("start captured logs stream", err)
+		}
+		return logsStream, nil
+	}
+
+	// Enable timestamps so that `forwardLogs` can parse the logs.
+	opts := cmd.Opts
+	opts.Timestamps = true
+	if opts.Container == "" {
+		mainContainer, err := getCapturedContainer(kubeClient, cmd.Auth.KubeNamespace, container)
+		if err != nil {
+			return nil, err
+		}
+		opts.Container = mainContainer
+	}
+
+	logsStream, err := kubeClient.CoreV1().
+		Pods(cmd.Auth.KubeNamespace).
+		GetLogs(names.PodName(container), &opts).
+		Stream()
+	if err != nil {
+		return nil, errors.WithContext("start logs stream", err)
+	}
+	return logsStream, nil
+}
+
+// followNewServices forwards the logs of services that start after the
+// command started to `combinedLogs`. New services are detected with the
+// manager's status watch, which reports each service once its pod has
+// started. It returns once `ctx` is cancelled.
+func (cmd LogsCommand) followNewServices(ctx context.Context, kubeClient kubernetes.Interface,
+	restConfig *rest.Config, combinedLogs chan<- rawLogLine, wg *sync.WaitGroup) {
+
+	following := map[string]struct{}{}
+	for _, container := range cmd.Containers {
+		following[container] = struct{}{}
+	}
+
+	statusStream, err := manager.C.WatchStatus(ctx, &cluster.GetStatusRequest{
+		Token: cmd.Auth.AuthToken,
+	})
+	if err != nil {
+		log.WithError(err).Warn("Failed to watch for new services. " +
+			"Only the logs of services that have already started will be printed.")
+		return
+	}
+
+	var logsStreams []io.ReadCloser
+	defer func() {
+		for _, logsStream := range logsStreams {
+			logsStream.Close()
+		}
+	}()
+
+	for {
+		update, err := statusStream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.WithError(err).Warn("Stopped watching for new services")
+			}
+			return
+		}
+
+		var newServices []string
+		for name, svcStatus := range update.GetStatus().GetServices() {
+			if _, ok := following[name]; ok {
+				continue
+			}
+
+			if svcStatus.GetHasStarted() && !cmd.ServiceConfigs[name].Exclude && !manager.MatchesAny(name, cmd.Excludes) {
+				newServices = append(newServices, name)
+			}
+		}
+		sort.Strings(newServices)
+
+		for _, container := range newServices {
+			following[container] = struct{}{}
+			if cmd.Opts.Container != "" {
+				ok, err := hasContainer(kubeClient, cmd.Auth.KubeNamespace, container, cmd.Opts.Container)
+				if err != nil {
+					log.WithError(err).WithField("service", container).Warn("Failed to check containers")
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			logsStream, err := cmd.startLogsStream(kubeClient, restConfig, container)
+			if err != nil {
+				log.WithError(err).WithField("service", container).Warn("Failed to start logs stream")
+				continue
+			}
+			logsStreams = append(logsStreams, logsStream)
+
+			wg.Add(1)
+			metadata := cmd.getStreamMetadata(kubeClient, container)
+			go func(container string) {
+				forwardLogs(combinedLogs, container, "", logsStream, metadata)
+				reportJobExit(os.Stderr, cmd.Auth.AuthToken, container)
+				wg.Done()
+			}(container)
+		}
+	}
+}
+
+// getStreamMetadata returns the metadata of the service's pod if
+// --verbose-prefix is set. It's fetched once when a logs stream starts, so
+// that all the lines from the stream are attributed to the container
+// instance that was running when it started.
+func (cmd LogsCommand) getStreamMetadata(kubeClient kubernetes.Interface, container string) *podMetadata {
+	if !cmd.VerbosePrefix {
+		return nil
+	}
+
+	metadata, err := getPodMetadata(kubeClient, cmd.Auth.KubeNamespace, container)
+	if err != nil {
+		log.WithError(err).WithField("service", container).Warn("Failed to get pod metadata")
+		return nil
+	}
+	return &metadata
 }
 
 // forwardLogs forwards each log line from `logsReq` to the `combinedLogs`
-// channel.
-func forwardLogs(combinedLogs chan<- rawLogLine, container string, logsStream io.ReadCloser) {
+// channel. `initContainer` is set if the stream is for one of the service's
+// init containers. `pod` is attached to each line, and may be nil.
+func forwardLogs(combinedLogs chan<- rawLogLine, container, initContainer string, logsStream io.ReadCloser,
+	pod *podMetadata) {
+
 	reader := bufio.NewReader(logsStream)
 	for {
 		message, err := reader.ReadString('\n')
 		combinedLogs <- rawLogLine{
 			fromContainer: container,
+			initContainer: initContainer,
 			message:       strings.TrimSuffix(message, "\n"),
 			receivedAt:    time.Now(),
 			readError:     err,
+			pod:           pod,
 		}
 		if err == io.EOF {
 			// Signal to the parent that there will be no more logs for this
@@ -185,59 +1250,95 @@ func forwardLogs(combinedLogs chan<- rawLogLine, container string, logsStream io
 	}
 }
 
-// The logs within a window are guaranteed to be sorted.
-// Note that it's still possible for a delayed log to arrive in the next
-// window, in which case it will be printed out of order.
-const windowSize = 100 * time.Millisecond
-
-// printLogs reads logs from the `rawLogs` in `windowSize` intervals, and
-// prints the logs in each window in sorted order.
-func printLogs(ctx context.Context, rawLogs <-chan rawLogLine, noColor bool) error {
-	var window []rawLogLine
-	var flushTrigger <-chan time.Time
-
-	// flush prints the logs in the current window to the terminal.
-	flush := func() {
-		// Parse the logs in the windows to extract their timestamps.
-		var parsedLogs []parsedLogLine
-		for _, rawLog := range window {
-			message, timestamp, err := parseLogLine(rawLog.message)
-
-			// If we fail to parse the log's timestamp, revert to sorting based
-			// on its receival time.
-			if err != nil {
-				logrus.WithField("message", rawLog.message).
-					WithField("container", rawLog.fromContainer).
-					WithError(err).Warn("Failed to parse timestamp")
-				message = rawLog.message
-				timestamp = rawLog.receivedAt
-			}
+// streamInterruptedError is returned when reading a log stream fails after it
+// was opened, such as when the connection broke while the machine was asleep.
+type streamInterruptedError struct {
+	error
 
-			parsedLogs = append(parsedLogs, parsedLogLine{
-				fromContainer: rawLog.fromContainer,
-				message:       message,
-				loggedAt:      timestamp,
-			})
-		}
+	// The Kubernetes timestamp of the last line printed from each stream.
+	lastPrinted map[string]time.Time
+}
 
-		// Sort logs in the window.
-		byLogTime := func(i, j int) bool {
-			return parsedLogs[i].loggedAt.Before(parsedLogs[j].loggedAt)
+// IsStreamInterrupted returns whether `err` was caused by a log stream
+// breaking. See Resume for continuing the logs.
+func IsStreamInterrupted(err error) bool {
+	_, ok := errors.RootCause(err).(streamInterruptedError)
+	return ok
+}
+
+// Resume prepares the command to be run again after the logs were
+// interrupted by `err`, so that the logs continue from just after the last
+// line that was printed. It returns false, and leaves the command unchanged,
+// if `err` wasn't caused by a log stream breaking.
+func (cmd *LogsCommand) Resume(err error) bool {
+	interrupted, ok := errors.RootCause(err).(streamInterruptedError)
+	if !ok {
+		return false
+	}
+
+	// Kubernetes only filters logs to the second, and applies the same
+	// SinceTime to all streams, so start from the stream that's furthest
+	// behind, and rely on ResumeAfter to skip the lines that were already
+	// printed.
+	// The captured history is always read from the start, so it relies on
+	// ResumeAfter alone.
+	var since time.Time
+	for _, lastPrinted := range interrupted.lastPrinted {
+		if since.IsZero() || lastPrinted.Before(since) {
+			since = lastPrinted
 		}
-		sort.Slice(parsedLogs, byLogTime)
+	}
+	if !since.IsZero() && cmd.History != HistoryFull {
+		cmd.Opts.SinceTime = &metav1.Time{Time: since}
+	}
+	cmd.ResumeAfter = interrupted.lastPrinted
+	return true
+}
 
-		// Print the logs.
-		for _, log := range parsedLogs {
-			if noColor {
-				fmt.Fprintln(os.Stdout, log.message)
-			} else {
-				coloredContainer := goterm.Color(log.fromContainer, pickColor(log.fromContainer))
-				fmt.Fprintf(os.Stdout, "%s › %s\n", coloredContainer, log.message)
+// printLogs prints the logs from `rawLogs` in chronological order, as they
+// arrive. See logMerger for how the streams of different containers are
+// interleaved. `streams` are the streams that are being followed, so that
+// lines aren't printed before the streams that haven't logged anything yet.
+// Each log is parsed, filtered, and formatted by `proc` before being printed.
+func printLogs(ctx context.Context, rawLogs <-chan rawLogLine, proc logProcessor, maxSkew time.Duration,
+	streams []string) error {
+
+	// lastPrinted tracks the Kubernetes timestamp of the last line read from
+	// each stream, so that the logs can be resumed if they're interrupted.
+	lastPrinted := map[string]time.Time{}
+	for stream, timestamp := range proc.resumeAfter {
+		lastPrinted[stream] = timestamp
+	}
+
+	grouper := newMultilineGrouper(proc.isContinuation)
+	merger := newLogMerger(maxSkew)
+	for _, stream := range streams {
+		merger.add(stream)
+	}
+	push := func(records []bufferedLogLine) {
+		for _, record := range records {
+			merger.push(record.parsedLogLine, record.receivedAt)
+		}
+	}
+	output := func(lines []parsedLogLine) {
+		for _, log := range lines {
+			if proc.shouldPrint(log) {
+				proc.output(log)
 			}
 		}
+	}
+
+	// deadline fires when the oldest buffered line has to be printed, even
+	// if some containers haven't logged anything since.
+	deadline := time.NewTimer(0)
+	defer deadline.Stop()
 
-		// Clear the buffer now that we've printed its contents.
-		window = nil
+	// statsTicker is nil unless --stats is enabled, so it never fires.
+	var statsTicker <-chan time.Time
+	if proc.stats != nil {
+		ticker := time.NewTicker(proc.stats.interval)
+		defer ticker.Stop()
+		statsTicker = ticker.C
 	}
 
 	for {
@@ -245,32 +1346,189 @@ func printLogs(ctx context.Context, rawLogs <-chan rawLogLine, noColor bool) err
 		case logLine, ok := <-rawLogs:
 			if !ok {
 				// There won't be any more messages, so we can exit after
-				// flushing any unprinted logs.
-				flush()
+				// printing any buffered logs.
+				push(grouper.flush())
+				output(merger.flush())
+				proc.flush()
+				if proc.stats != nil {
+					proc.stats.print(time.Now())
+				}
 				return nil
 			}
 
-			// If it's an EOF error, still print the final contents of the buffer.
-			// We don't need any special handling for ending the stream because
-			// the log reader goroutine will just stop sending us messages.
 			if logLine.readError != nil && logLine.readError != io.EOF {
-				return errors.WithContext(fmt.Sprintf("read logs for %s", logLine.fromContainer), logLine.readError)
+				// Print the lines that were already read, so that the logs
+				// can be resumed from the last line that was read.
+				push(grouper.flush())
+				output(merger.flush())
+				proc.flush()
+				return errors.WithContext(fmt.Sprintf("read logs for %s", logLine.fromContainer),
+					streamInterruptedError{logLine.readError, lastPrinted})
+			}
+
+			// Skip the lines that were printed before the logs were
+			// resumed. The final line of a stream still has to be handled
+			// so that the stream is ended.
+			if _, clusterTime, err := parseLogLine(logLine.message); err == nil {
+				resumeAfter, ok := proc.resumeAfter[logLine.stream()]
+				switch {
+				case ok && !clusterTime.After(resumeAfter) && logLine.readError == nil:
+					continue
+				case ok && !clusterTime.After(resumeAfter):
+					logLine.message = ""
+				default:
+					lastPrinted[logLine.stream()] = clusterTime
+				}
 			}
 
-			// Wake up later to flush the buffered lines.
-			window = append(window, logLine)
-			if flushTrigger == nil {
-				flushTrigger = time.After(windowSize)
+			// The final read before EOF returns an empty line if the log
+			// ended with a newline.
+			if logLine.readError != io.EOF || logLine.message != "" {
+				parsed := proc.parse(logLine)
+				if proc.stats != nil {
+					proc.stats.add(parsed)
+				}
+				push(grouper.add(parsed, logLine.receivedAt))
 			}
-		case <-flushTrigger:
-			flush()
-			flushTrigger = nil
+			if logLine.readError == io.EOF {
+				push(grouper.end(logLine.stream()))
+				merger.end(logLine.stream())
+			}
+		case <-deadline.C:
+		case now := <-statsTicker:
+			proc.stats.print(now)
 		case <-ctx.Done():
+			// Print the lines that were already read, rather than dropping
+			// the lines that are still buffered for ordering.
+			push(grouper.flush())
+			output(merger.flush())
+			proc.flush()
 			return nil
 		}
+
+		now := time.Now()
+		push(grouper.expire(now))
+		output(merger.pop(now))
+
+		if !deadline.Stop() {
+			select {
+			case <-deadline.C:
+			default:
+			}
+		}
+		next, ok := merger.nextDeadline()
+		if groupDeadline, groupOK := grouper.nextDeadline(); groupOK && (!ok || groupDeadline.Before(next)) {
+			next, ok = groupDeadline, true
+		}
+		if ok {
+			deadline.Reset(time.Until(next))
+		}
 	}
 }
 
+// parseRawLog extracts the timestamp from the raw log line.
+func parseRawLog(rawLog rawLogLine) parsedLogLine {
+	message, timestamp, err := parseLogLine(rawLog.message)
+
+	// If we fail to parse the log's timestamp, revert to sorting based on its
+	// receival time.
+	if err != nil {
+		logrus.WithField("message", rawLog.message).
+			WithField("container", rawLog.fromContainer).
+			WithError(err).Warn("Failed to parse timestamp")
+		message = rawLog.message
+		timestamp = rawLog.receivedAt
+	}
+
+	outputStream := rawLog.outputStream
+	if outputStream == "" {
+		outputStream, message = splitOutputStream(message)
+	}
+
+	return parsedLogLine{
+		fromContainer: rawLog.fromContainer,
+		initContainer: rawLog.initContainer,
+		message:       message,
+		loggedAt:      timestamp,
+		outputStream:  outputStream,
+		pod:           rawLog.pod,
+	}
+}
+
+// formatText returns a function that formats log lines as plain text. Unless
+// `noPrefix` is set, each line is prefixed with the name of the service that
+// generated it, colored by `colors`.
+func formatText(noPrefix bool, colors colorizer) func(parsedLogLine) string {
+	return func(log parsedLogLine) string {
+		message := log.message
+		if log.outputStream == OutputStreamStderr {
+			message = colors.stderr(message)
+		}
+
+		if noPrefix {
+			return message
+		}
+		prefix := colors.service(log.fromContainer)
+		if log.initContainer != "" {
+			prefix += fmt.Sprintf(" (init: %s)", log.initContainer)
+		}
+		if log.pod != nil {
+			prefix += fmt.Sprintf(" [%s restart=%d node=%s]", log.pod.pod, log.pod.restartCount, log.pod.node)
+		}
+		return prefixLines(prefix+" › ", message)
+	}
+}
+
+// getLevelFilter returns a filter that hides log lines below the minimum
+// level configured for their service, or nil if no levels are configured.
+func (cmd LogsCommand) getLevelFilter() (func(parsedLogLine) bool, error) {
+	// Services that are followed after they start aren't in Containers, so
+	// the levels for all the configured services are parsed as well.
+	services := append([]string{}, cmd.Containers...)
+	if cmd.FollowNewServices {
+		for svc := range cmd.ServiceConfigs {
+			services = append(services, svc)
+		}
+	}
+
+	minLevels := map[string]int{}
+	for _, svc := range services {
+		level := cmd.ServiceConfigs[svc].MinLevel
+		if cmd.MinLevel != "" {
+			level = cmd.MinLevel
+		}
+
+		if level == "" {
+			continue
+		}
+
+		levelIdx, ok := parseLevel(level)
+		if !ok {
+			return nil, errors.NewFriendlyError("Unknown log level %q for %s. It must be one of %s.",
+				level, svc, strings.Join(logLevels, ", "))
+		}
+		minLevels[svc] = levelIdx
+	}
+
+	if len(minLevels) == 0 {
+		return nil, nil
+	}
+
+	defaultMinLevel, hasDefault := parseLevel(cmd.MinLevel)
+	return func(log parsedLogLine) bool {
+		minLevel, ok := minLevels[log.fromContainer]
+		if !ok {
+			if !hasDefault {
+				return true
+			}
+			minLevel = defaultMinLevel
+		}
+
+		level, ok := detectLevel(log.message)
+		return !ok || level >= minLevel
+	}, nil
+}
+
 func parseLogLine(rawMessage string) (string, time.Time, error) {
 	logParts := strings.SplitN(rawMessage, " ", 2)
 	if len(logParts) != 2 {
@@ -292,19 +1550,3 @@ func parseLogLine(rawMessage string) (string, time.Time, error) {
 	message := logParts[1]
 	return message, timestamp, nil
 }
-
-var colorList = []int{
-	goterm.BLUE,
-	goterm.CYAN,
-	goterm.GREEN,
-	goterm.MAGENTA,
-	goterm.RED,
-	goterm.YELLOW,
-}
-
-func pickColor(container string) int {
-	hash := fnv.New32()
-	hash.Write([]byte(container))
-	idx := hash.Sum32() % uint32(len(colorList))
-	return colorList[idx]
-}
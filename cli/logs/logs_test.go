@@ -0,0 +1,222 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func TestParseLogLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawMessage string
+		expMessage string
+		expTime    time.Time
+		expErr     bool
+	}{
+		{
+			name:       "RFC3339Nano",
+			rawMessage: "2020-06-01T15:04:05.123456789Z Starting server",
+			expMessage: "Starting server",
+			expTime:    time.Date(2020, 6, 1, 15, 4, 5, 123456789, time.UTC),
+		},
+		{
+			name:       "RFC3339",
+			rawMessage: "2020-06-01T15:04:05Z Starting server",
+			expMessage: "Starting server",
+			expTime:    time.Date(2020, 6, 1, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:       "Empty message",
+			rawMessage: "2020-06-01T15:04:05Z ",
+			expMessage: "",
+			expTime:    time.Date(2020, 6, 1, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:       "No timestamp",
+			rawMessage: "Starting server",
+			expErr:     true,
+		},
+		{
+			name:       "Missing message",
+			rawMessage: "2020-06-01T15:04:05Z",
+			expErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			message, timestamp, err := parseLogLine(test.rawMessage)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expMessage, message)
+			assert.True(t, test.expTime.Equal(timestamp), "expected %s, got %s", test.expTime, timestamp)
+		})
+	}
+}
+
+func TestParseRawLog(t *testing.T) {
+	loggedAt := time.Date(2020, 6, 1, 15, 4, 5, 0, time.UTC)
+	receivedAt := loggedAt.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		raw  rawLogLine
+		exp  parsedLogLine
+	}{
+		{
+			name: "Kubernetes format",
+			raw: rawLogLine{
+				fromContainer: "web",
+				message:       "2020-06-01T15:04:05Z Starting server",
+				receivedAt:    receivedAt,
+			},
+			exp: parsedLogLine{
+				fromContainer: "web",
+				message:       "Starting server",
+				loggedAt:      loggedAt,
+			},
+		},
+		{
+			name: "CRI format",
+			raw: rawLogLine{
+				fromContainer: "web",
+				initContainer: "migrate",
+				message:       "2020-06-01T15:04:05Z stderr F Migration failed",
+				receivedAt:    receivedAt,
+			},
+			exp: parsedLogLine{
+				fromContainer: "web",
+				initContainer: "migrate",
+				message:       "Migration failed",
+				loggedAt:      loggedAt,
+				outputStream:  OutputStreamStderr,
+			},
+		},
+		{
+			name: "Known output stream",
+			raw: rawLogLine{
+				fromContainer: "web",
+				message:       "2020-06-01T15:04:05Z stderr F Starting server",
+				receivedAt:    receivedAt,
+				outputStream:  OutputStreamStdout,
+			},
+			exp: parsedLogLine{
+				fromContainer: "web",
+				message:       "stderr F Starting server",
+				loggedAt:      loggedAt,
+				outputStream:  OutputStreamStdout,
+			},
+		},
+		{
+			name: "Unparseable timestamp",
+			raw: rawLogLine{
+				fromContainer: "web",
+				message:       "Starting server",
+				receivedAt:    receivedAt,
+			},
+			exp: parsedLogLine{
+				fromContainer: "web",
+				message:       "Starting server",
+				loggedAt:      receivedAt,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.exp, parseRawLog(test.raw))
+		})
+	}
+}
+
+func TestResumeInterruptedLogs(t *testing.T) {
+	run := func(cmd *LogsCommand, lines []rawLogLine) (string, error) {
+		var out bytes.Buffer
+		proc := logProcessor{
+			parse:       parseRawLog,
+			format:      func(line parsedLogLine) string { return line.message },
+			out:         &out,
+			resumeAfter: cmd.ResumeAfter,
+		}
+
+		rawLogs := make(chan rawLogLine, len(lines))
+		for _, line := range lines {
+			rawLogs <- line
+		}
+		close(rawLogs)
+
+		err := printLogs(context.Background(), rawLogs, proc, time.Second, []string{"web"})
+		return out.String(), err
+	}
+
+	cmd := LogsCommand{}
+	out, err := run(&cmd, []rawLogLine{
+		{fromContainer: "web", message: "2020-06-01T15:04:05.1Z first"},
+		{fromContainer: "web", message: "2020-06-01T15:04:05.2Z second"},
+		{fromContainer: "web", readError: errors.New("connection reset")},
+	})
+	assert.Equal(t, "first\nsecond\n", out)
+	assert.True(t, cmd.Resume(err))
+
+	lastPrinted := time.Date(2020, 6, 1, 15, 4, 5, 200000000, time.UTC)
+	assert.True(t, lastPrinted.Equal(cmd.Opts.SinceTime.Time))
+	assert.True(t, lastPrinted.Equal(cmd.ResumeAfter["web"]))
+
+	// Kubernetes filters logs by the second, so the resumed stream includes
+	// lines that were already printed.
+	out, err = run(&cmd, []rawLogLine{
+		{fromContainer: "web", message: "2020-06-01T15:04:05.1Z first"},
+		{fromContainer: "web", message: "2020-06-01T15:04:05.2Z second"},
+		{fromContainer: "web", message: "2020-06-01T15:04:05.3Z third"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "third\n", out)
+
+	assert.False(t, cmd.Resume(errors.New("other error")))
+
+	// The captured history is read from the start, so --since isn't set.
+	cmd = LogsCommand{History: HistoryFull}
+	_, err = run(&cmd, []rawLogLine{
+		{fromContainer: "web", message: "2020-06-01T15:04:05.1Z first"},
+		{fromContainer: "web", readError: errors.New("connection reset")},
+	})
+	assert.True(t, cmd.Resume(err))
+	assert.Nil(t, cmd.Opts.SinceTime)
+	assert.Contains(t, cmd.ResumeAfter, "web")
+}
+
+func TestPrintLogsCancelled(t *testing.T) {
+	var out bytes.Buffer
+	proc := logProcessor{
+		parse:  parseRawLog,
+		format: func(line parsedLogLine) string { return line.message },
+		out:    &out,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rawLogs := make(chan rawLogLine)
+	go func() {
+		// The worker hasn't logged anything, so the merger holds back the
+		// web service's lines until the logs are cancelled.
+		rawLogs <- rawLogLine{fromContainer: "web", message: "2020-06-01T15:04:05.1Z first",
+			receivedAt: time.Now()}
+		rawLogs <- rawLogLine{fromContainer: "web", message: "2020-06-01T15:04:05.2Z second",
+			receivedAt: time.Now()}
+		cancel()
+	}()
+
+	err := printLogs(ctx, rawLogs, proc, time.Hour, []string{"web", "worker"})
+	assert.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", out.String())
+}
@@ -0,0 +1,99 @@
+package logs
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// forwardManagerLogs streams the logs of `containers` through the manager,
+// rather than directly from Kubernetes, and forwards them to `combinedLogs`
+// in the same format as forwardLogs. All the services are streamed over a
+// single connection.
+func (cmd LogsCommand) forwardManagerLogs(ctx context.Context, combinedLogs chan<- rawLogLine, containers []string) {
+	// sendError reports a fatal error to the consumer of `combinedLogs`. It's
+	// attributed to the first service since the stream is shared.
+	sendError := func(err error) {
+		combinedLogs <- rawLogLine{
+			fromContainer: containers[0],
+			receivedAt:    time.Now(),
+			readError:     err,
+		}
+	}
+
+	req := &cluster.StreamLogsRequest{
+		Token:    cmd.Auth.AuthToken,
+		Services: containers,
+		Follow:   cmd.Opts.Follow,
+		Previous: cmd.Opts.Previous,
+	}
+	if cmd.Opts.SinceSeconds != nil {
+		req.SinceSeconds = *cmd.Opts.SinceSeconds
+	}
+	if cmd.Opts.SinceTime != nil {
+		req.SinceSeconds = int64(time.Since(cmd.Opts.SinceTime.Time).Seconds())
+	}
+	if cmd.Opts.TailLines != nil {
+		req.TailLines = *cmd.Opts.TailLines
+	}
+
+	stream, err := manager.C.StreamLogs(ctx, req)
+	if err != nil {
+		sendError(errors.WithContext("start logs stream", err))
+		return
+	}
+
+	ended := map[string]bool{}
+	for {
+		msg, err := stream.Recv()
+		switch {
+		case err == io.EOF:
+			// Services that the manager didn't explicitly end won't log
+			// anything else either.
+			for _, container := range containers {
+				if !ended[container] {
+					combinedLogs <- rawLogLine{fromContainer: container, receivedAt: time.Now(), readError: io.EOF}
+				}
+			}
+			return
+		case status.Code(err) == codes.Unimplemented:
+			sendError(errors.NewFriendlyError(
+				"The Blimp cluster doesn't support streaming logs through the manager. " +
+					"Run the command again without --via-manager."))
+			return
+		case status.Code(err) == codes.Canceled:
+			return
+		case err != nil:
+			sendError(err)
+			return
+		}
+
+		if err := errors.Unmarshal(nil, msg.Error); err != nil {
+			sendError(err)
+			return
+		}
+
+		for _, line := range msg.Lines {
+			// Format the line the same way as the Kubernetes API, so that it's
+			// parsed by parseRawLog like any other line.
+			combinedLogs <- rawLogLine{
+				fromContainer: line.Service,
+				message:       time.Unix(0, line.Timestamp).UTC().Format(time.RFC3339Nano) + " " + line.Message,
+				receivedAt:    time.Now(),
+				outputStream:  line.Stream,
+			}
+		}
+
+		for _, container := range msg.EndedServices {
+			ended[container] = true
+			combinedLogs <- rawLogLine{fromContainer: container, receivedAt: time.Now(), readError: io.EOF}
+		}
+	}
+}
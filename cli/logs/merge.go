@@ -0,0 +1,190 @@
+package logs
+
+import (
+	"container/heap"
+	"time"
+)
+
+// DefaultMaxSkew is the default for how long a log line is held while
+// waiting for earlier lines from other services.
+const DefaultMaxSkew = 200 * time.Millisecond
+
+// logMerger merges the log streams of multiple containers in chronological
+// order. Each container's lines are assumed to already be in order, so the
+// merger only has to pick which container to print from next.
+//
+// A line is printed once every container that's still streaming has a line
+// buffered, since no earlier lines can arrive after that. Streams are
+// registered with add when they start, so that streams that haven't logged
+// anything yet are waited for as well. Quiet containers
+// would otherwise delay the logs indefinitely, so lines are also printed once
+// they've been buffered for maxSkew. Lines from a container that are delayed
+// by more than maxSkew may still be printed out of order.
+type logMerger struct {
+	maxSkew time.Duration
+
+	queues map[string]*containerQueue
+
+	// The queues that have buffered lines, ordered by the time that their
+	// first line was logged.
+	ready mergeHeap
+}
+
+type containerQueue struct {
+	stream string
+	lines  []bufferedLogLine
+	ended  bool
+
+	// The queue's index in the heap, or -1 if it's not in the heap.
+	index int
+}
+
+type bufferedLogLine struct {
+	parsedLogLine
+	receivedAt time.Time
+}
+
+func newLogMerger(maxSkew time.Duration) *logMerger {
+	return &logMerger{
+		maxSkew: maxSkew,
+		queues:  map[string]*containerQueue{},
+	}
+}
+
+func (m *logMerger) queue(container string) *containerQueue {
+	q, ok := m.queues[container]
+	if !ok {
+		q = &containerQueue{stream: container, index: -1}
+		m.queues[container] = q
+	}
+	return q
+}
+
+// add registers a stream that's being followed, so that lines from other
+// streams wait for it even if it hasn't logged anything yet.
+func (m *logMerger) add(stream string) {
+	m.queue(stream)
+}
+
+// push buffers a log line.
+func (m *logMerger) push(line parsedLogLine, receivedAt time.Time) {
+	q := m.queue(line.stream())
+	q.lines = append(q.lines, bufferedLogLine{line, receivedAt})
+	if q.index == -1 {
+		heap.Push(&m.ready, q)
+	}
+}
+
+// end marks that the stream won't log any more lines, so the merger doesn't
+// wait for it. The stream is removed once its buffered lines are printed.
+func (m *logMerger) end(stream string) {
+	q, ok := m.queues[stream]
+	if !ok {
+		return
+	}
+
+	q.ended = true
+	if len(q.lines) == 0 {
+		delete(m.queues, stream)
+	}
+}
+
+// pop returns the lines that can be printed as of `now`, in order.
+func (m *logMerger) pop(now time.Time) (lines []parsedLogLine) {
+	for len(m.ready) != 0 {
+		deadline, _ := m.nextDeadline()
+		if !m.allStreamsBuffered() && now.Before(deadline) {
+			break
+		}
+		lines = append(lines, m.popHead())
+	}
+	return lines
+}
+
+// flush returns all the buffered lines, in order.
+func (m *logMerger) flush() (lines []parsedLogLine) {
+	for len(m.ready) != 0 {
+		lines = append(lines, m.popHead())
+	}
+	return lines
+}
+
+// nextDeadline returns when the oldest buffered line should be printed, even
+// if some containers don't have any lines buffered.
+func (m *logMerger) nextDeadline() (time.Time, bool) {
+	if len(m.ready) == 0 {
+		return time.Time{}, false
+	}
+	return m.oldestReceivedAt().Add(m.maxSkew), true
+}
+
+func (m *logMerger) popHead() parsedLogLine {
+	q := m.ready[0]
+	line := q.lines[0]
+	q.lines = q.lines[1:]
+	if len(q.lines) == 0 {
+		heap.Pop(&m.ready)
+		if q.ended {
+			delete(m.queues, q.stream)
+		}
+	} else {
+		heap.Fix(&m.ready, 0)
+	}
+	return line.parsedLogLine
+}
+
+// allStreamsBuffered returns whether every container that's still streaming
+// has a line buffered.
+func (m *logMerger) allStreamsBuffered() bool {
+	for _, q := range m.queues {
+		if !q.ended && len(q.lines) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// oldestReceivedAt returns when the line that's been buffered the longest
+// was received. Each queue's lines are received in order, so it's the first
+// line of one of the queues.
+func (m *logMerger) oldestReceivedAt() time.Time {
+	oldest := m.ready[0].lines[0].receivedAt
+	for _, q := range m.ready[1:] {
+		if receivedAt := q.lines[0].receivedAt; receivedAt.Before(oldest) {
+			oldest = receivedAt
+		}
+	}
+	return oldest
+}
+
+// mergeHeap implements heap.Interface for the queues with buffered lines.
+type mergeHeap []*containerQueue
+
+func (h mergeHeap) Len() int {
+	return len(h)
+}
+
+func (h mergeHeap) Less(i, j int) bool {
+	return h[i].lines[0].loggedAt.Before(h[j].lines[0].loggedAt)
+}
+
+func (h mergeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *mergeHeap) Push(x interface{}) {
+	q := x.(*containerQueue)
+	q.index = len(*h)
+	*h = append(*h, q)
+}
+
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	q := old[len(old)-1]
+	old[len(old)-1] = nil
+	q.index = -1
+	*h = old[:len(old)-1]
+	return q
+}
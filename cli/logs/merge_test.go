@@ -0,0 +1,93 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogMerger(t *testing.T) {
+	start := time.Date(2020, 6, 1, 15, 4, 5, 0, time.UTC)
+	maxSkew := 200 * time.Millisecond
+	line := func(container, message string, loggedAt time.Duration) parsedLogLine {
+		return parsedLogLine{fromContainer: container, message: message, loggedAt: start.Add(loggedAt)}
+	}
+	messages := func(lines []parsedLogLine) (msgs []string) {
+		for _, line := range lines {
+			msgs = append(msgs, line.message)
+		}
+		return msgs
+	}
+
+	t.Run("Waits for registered streams that haven't logged", func(t *testing.T) {
+		m := newLogMerger(maxSkew)
+		m.add("web")
+		m.add("api")
+		m.push(line("web", "web 1", 1), start)
+		assert.Empty(t, m.pop(start))
+
+		// web's line still waits, since api might log another line before
+		// it.
+		m.push(line("api", "api 0", 0), start)
+		assert.Equal(t, []string{"api 0"}, messages(m.pop(start)))
+	})
+
+	t.Run("Prints after the max skew", func(t *testing.T) {
+		m := newLogMerger(maxSkew)
+		m.add("web")
+		m.add("api")
+		m.push(line("web", "web 1", 1), start)
+
+		deadline, ok := m.nextDeadline()
+		assert.True(t, ok)
+		assert.Equal(t, start.Add(maxSkew), deadline)
+		assert.Empty(t, m.pop(deadline.Add(-time.Nanosecond)))
+		assert.Equal(t, []string{"web 1"}, messages(m.pop(deadline)))
+
+		_, ok = m.nextDeadline()
+		assert.False(t, ok)
+	})
+
+	t.Run("Ended streams aren't waited for", func(t *testing.T) {
+		m := newLogMerger(maxSkew)
+		m.add("web")
+		m.add("api")
+		m.add("worker")
+		m.push(line("web", "web 1", 1), start)
+		m.push(line("api", "api 2", 2), start)
+		m.end("worker")
+		assert.NotContains(t, m.queues, "worker")
+		assert.Equal(t, []string{"web 1"}, messages(m.pop(start)))
+
+		m.end("web")
+		assert.Equal(t, []string{"api 2"}, messages(m.pop(start)))
+	})
+
+	t.Run("Removes ended streams once they're printed", func(t *testing.T) {
+		m := newLogMerger(maxSkew)
+		m.add("web")
+		m.add("api")
+		m.push(line("web", "web 1", 1), start)
+		m.end("web")
+		assert.Contains(t, m.queues, "web")
+
+		// Once web's last line is printed, api's lines don't need to wait
+		// for it.
+		m.push(line("api", "api 2", 2), start)
+		assert.Equal(t, []string{"web 1", "api 2"}, messages(m.pop(start)))
+		assert.NotContains(t, m.queues, "web")
+
+		m.end("api")
+		assert.Empty(t, m.queues)
+	})
+
+	t.Run("Flushes in order", func(t *testing.T) {
+		m := newLogMerger(maxSkew)
+		m.push(line("web", "web 1", 1), start)
+		m.push(line("web", "web 3", 3), start)
+		m.push(line("api", "api 2", 2), start)
+		m.push(line("api", "api 4", 4), start)
+		assert.Equal(t, []string{"web 1", "api 2", "web 3", "api 4"}, messages(m.flush()))
+	})
+}
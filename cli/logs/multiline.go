@@ -0,0 +1,207 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// multilineTimeout is how long a log record is held waiting for more
+// continuation lines, starting from when its first line arrived. Stack traces
+// are usually written all at once, so the lines arrive together.
+const multilineTimeout = 100 * time.Millisecond
+
+// maxRecordLines and maxRecordBytes limit the size of a grouped log record,
+// so that a service that only logs indented lines doesn't build up an
+// unbounded record. Continuation lines past the limit start a new record.
+const (
+	maxRecordLines = 1000
+	maxRecordBytes = 64 * 1024
+)
+
+// continuationPatterns match lines that continue the previous log record,
+// such as the frames of Java and Python stack traces.
+var continuationPatterns = []*regexp.Regexp{
+	// Indented lines, such as `\tat com.example.Main(Main.java:10)` or
+	// `  File "main.py", line 10, in <module>`.
+	regexp.MustCompile(`^\s+\S`),
+
+	// The causes of Java exceptions.
+	regexp.MustCompile(`^Caused by: `),
+}
+
+// exceptionPattern matches the last line of Python tracebacks, such as
+// `ValueError: invalid literal`. Since they look like regular log lines,
+// they're only treated as continuations if they follow a stack frame.
+var exceptionPattern = regexp.MustCompile(`^[\w.]+(Error|Exception)(: |$)`)
+
+// stackFramePattern matches indented lines, such as stack frames.
+var stackFramePattern = continuationPatterns[0]
+
+// isContinuation returns whether the message continues the previous log
+// record according to the built-in heuristics. `previous` is the previous
+// line from the same container.
+func isContinuation(message, previous string) bool {
+	for _, pattern := range continuationPatterns {
+		if pattern.MatchString(message) {
+			return true
+		}
+	}
+	return exceptionPattern.MatchString(message) && stackFramePattern.MatchString(previous)
+}
+
+// MultilineStartTimestamp is the --multiline-start value for logs whose
+// records each start with a timestamp, so that any line without one is a
+// continuation.
+const MultilineStartTimestamp = "timestamp"
+
+// getContinuationFilter returns the function used to detect continuation
+// lines. If `pattern` is set, it overrides the built-in heuristics. If
+// `startPattern` is set, lines that don't match it are continuations
+// instead. Setting either pattern enables multi-line records, since they'd
+// have no effect otherwise. It returns nil if multi-line records are
+// disabled.
+func getContinuationFilter(enabled bool, pattern, startPattern string) (func(message, previous string) bool, error) {
+	if !enabled && pattern == "" && startPattern == "" {
+		return nil, nil
+	}
+
+	if pattern != "" && startPattern != "" {
+		return nil, errors.NewFriendlyError("--multiline-regex and --multiline-start can't be used together.")
+	}
+
+	if startPattern != "" {
+		start := appTimestampPattern
+		if startPattern != MultilineStartTimestamp {
+			var err error
+			start, err = regexp.Compile(startPattern)
+			if err != nil {
+				return nil, errors.NewFriendlyError("Failed to parse --multiline-start %q: %s", startPattern, err)
+			}
+		}
+		return func(message, _ string) bool {
+			return !start.MatchString(message)
+		}, nil
+	}
+
+	if pattern == "" {
+		return isContinuation, nil
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.NewFriendlyError("Failed to parse --multiline-regex %q: %s", pattern, err)
+	}
+	return func(message, _ string) bool {
+		return regex.MatchString(message)
+	}, nil
+}
+
+// multilineGrouper groups continuation lines with the log record that they
+// belong to, so that stack traces aren't interleaved with the logs of other
+// services. The continuation lines are joined to the record's message with
+// newlines, and the record keeps the timestamp of its first line.
+type multilineGrouper struct {
+	// isContinuation returns whether a line continues the previous record.
+	// If it's nil, lines aren't grouped.
+	isContinuation func(message, previous string) bool
+
+	// The most recent record of each container, which might still receive
+	// continuation lines.
+	open map[string]*openRecord
+}
+
+type openRecord struct {
+	bufferedLogLine
+	lastLine string
+	lines    int
+}
+
+func newMultilineGrouper(isContinuation func(string, string) bool) *multilineGrouper {
+	return &multilineGrouper{
+		isContinuation: isContinuation,
+		open:           map[string]*openRecord{},
+	}
+}
+
+// add adds a line, and returns the records that are complete as a result.
+func (g *multilineGrouper) add(line parsedLogLine, receivedAt time.Time) []bufferedLogLine {
+	if g.isContinuation == nil {
+		return []bufferedLogLine{{line, receivedAt}}
+	}
+
+	record, ok := g.open[line.stream()]
+	if ok && g.isContinuation(line.message, record.lastLine) && !record.full(line.message) {
+		record.message += "\n" + line.message
+		record.lastLine = line.message
+		record.lines++
+		return nil
+	}
+
+	g.open[line.stream()] = &openRecord{
+		bufferedLogLine: bufferedLogLine{line, receivedAt},
+		lastLine:        line.message,
+		lines:           1,
+	}
+	if !ok {
+		return nil
+	}
+	return []bufferedLogLine{record.bufferedLogLine}
+}
+
+// end returns the open record of a stream whose logs have ended.
+func (g *multilineGrouper) end(stream string) []bufferedLogLine {
+	record, ok := g.open[stream]
+	if !ok {
+		return nil
+	}
+
+	delete(g.open, stream)
+	return []bufferedLogLine{record.bufferedLogLine}
+}
+
+// full returns whether the record is too large to add the line to.
+func (record openRecord) full(line string) bool {
+	return record.lines >= maxRecordLines || len(record.message)+len(line)+1 > maxRecordBytes
+}
+
+// expire returns the records whose first line arrived more than
+// multilineTimeout ago. Records aren't held longer if continuation lines keep
+// arriving, so that a service that continuously logs indented lines is still
+// printed.
+func (g *multilineGrouper) expire(now time.Time) (records []bufferedLogLine) {
+	for container, record := range g.open {
+		if !now.Before(record.receivedAt.Add(multilineTimeout)) {
+			records = append(records, record.bufferedLogLine)
+			delete(g.open, container)
+		}
+	}
+	return records
+}
+
+// nextDeadline returns when the next open record expires.
+func (g *multilineGrouper) nextDeadline() (deadline time.Time, ok bool) {
+	for _, record := range g.open {
+		expiresAt := record.receivedAt.Add(multilineTimeout)
+		if !ok || expiresAt.Before(deadline) {
+			deadline, ok = expiresAt, true
+		}
+	}
+	return deadline, ok
+}
+
+// flush returns all the open records.
+func (g *multilineGrouper) flush() (records []bufferedLogLine) {
+	for container, record := range g.open {
+		records = append(records, record.bufferedLogLine)
+		delete(g.open, container)
+	}
+	return records
+}
+
+// prefixLines adds the prefix to each line of a multi-line message.
+func prefixLines(prefix, message string) string {
+	return prefix + strings.Replace(message, "\n", "\n"+prefix, -1)
+}
@@ -0,0 +1,167 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsContinuation(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		previous string
+		exp      bool
+	}{
+		{
+			name:     "Java stack frame",
+			message:  "\tat com.example.Main(Main.java:10)",
+			previous: "java.lang.NullPointerException",
+			exp:      true,
+		},
+		{
+			name:     "Java cause",
+			message:  "Caused by: java.io.IOException",
+			previous: "\tat com.example.Main(Main.java:10)",
+			exp:      true,
+		},
+		{
+			name:     "Python exception after a frame",
+			message:  "ValueError: invalid literal",
+			previous: "    int(x)",
+			exp:      true,
+		},
+		{
+			name:     "Python exception without a frame",
+			message:  "ValueError: invalid literal",
+			previous: "Starting server",
+			exp:      false,
+		},
+		{
+			name:     "Regular line",
+			message:  "GET /index.html 200",
+			previous: "Starting server",
+			exp:      false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.exp, isContinuation(test.message, test.previous))
+		})
+	}
+}
+
+func TestGetContinuationFilter(t *testing.T) {
+	filter, err := getContinuationFilter(false, "", "")
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = getContinuationFilter(true, `^\+`, "")
+	assert.NoError(t, err)
+	assert.True(t, filter("+ continued", ""))
+	assert.False(t, filter("  indented", ""))
+
+	filter, err = getContinuationFilter(true, "", MultilineStartTimestamp)
+	assert.NoError(t, err)
+	assert.False(t, filter("2020-06-01T15:04:05Z Starting server", ""))
+	assert.True(t, filter("Starting server", ""))
+
+	// The patterns enable grouping without --multiline.
+	filter, err = getContinuationFilter(false, `^\+`, "")
+	assert.NoError(t, err)
+	assert.True(t, filter("+ continued", ""))
+
+	filter, err = getContinuationFilter(false, "", `^\[`)
+	assert.NoError(t, err)
+	assert.False(t, filter("[INFO] Starting server", ""))
+	assert.True(t, filter("  at main.go:10", ""))
+
+	_, err = getContinuationFilter(true, "a", "b")
+	assert.Error(t, err)
+
+	_, err = getContinuationFilter(true, "(", "")
+	assert.Error(t, err)
+}
+
+func TestMultilineGrouper(t *testing.T) {
+	start := time.Date(2020, 6, 1, 15, 4, 5, 0, time.UTC)
+	line := func(container, message string) parsedLogLine {
+		return parsedLogLine{fromContainer: container, message: message, loggedAt: start}
+	}
+	messages := func(records []bufferedLogLine) (msgs []string) {
+		for _, record := range records {
+			msgs = append(msgs, record.message)
+		}
+		return msgs
+	}
+
+	t.Run("Groups continuation lines", func(t *testing.T) {
+		g := newMultilineGrouper(isContinuation)
+		assert.Empty(t, g.add(line("web", "Exception"), start))
+		assert.Empty(t, g.add(line("web", "\tat Main"), start))
+		assert.Empty(t, g.add(line("api", "Starting"), start))
+		assert.Equal(t, []string{"Exception\n\tat Main"},
+			messages(g.add(line("web", "GET /"), start)))
+		assert.ElementsMatch(t, []string{"GET /", "Starting"}, messages(g.flush()))
+		assert.Empty(t, g.flush())
+	})
+
+	t.Run("Expires from the first line", func(t *testing.T) {
+		g := newMultilineGrouper(isContinuation)
+		g.add(line("web", "Exception"), start)
+
+		// Continuation lines don't extend the deadline.
+		for i := 1; i < 10; i++ {
+			assert.Empty(t, g.add(line("web", "\tat Main"), start.Add(time.Duration(i)*multilineTimeout/10)))
+		}
+
+		deadline, ok := g.nextDeadline()
+		assert.True(t, ok)
+		assert.Equal(t, start.Add(multilineTimeout), deadline)
+
+		assert.Empty(t, g.expire(deadline.Add(-time.Nanosecond)))
+		records := g.expire(deadline)
+		assert.Len(t, records, 1)
+		assert.Equal(t, 10, strings.Count(records[0].message, "\n")+1)
+
+		_, ok = g.nextDeadline()
+		assert.False(t, ok)
+	})
+
+	t.Run("Ends streams", func(t *testing.T) {
+		g := newMultilineGrouper(isContinuation)
+		g.add(line("web", "Starting"), start)
+		g.add(line("api", "Starting"), start)
+		assert.Empty(t, g.end("worker"))
+		assert.Equal(t, []string{"Starting"}, messages(g.end("web")))
+		assert.Len(t, g.open, 1)
+	})
+
+	t.Run("Limits the record size", func(t *testing.T) {
+		g := newMultilineGrouper(isContinuation)
+		g.add(line("web", "Exception"), start)
+
+		var completed []bufferedLogLine
+		for i := 0; i < maxRecordLines; i++ {
+			completed = append(completed, g.add(line("web", "\tat Main"), start)...)
+		}
+		assert.Len(t, completed, 1)
+		assert.Equal(t, maxRecordLines, strings.Count(completed[0].message, "\n")+1)
+		assert.Equal(t, []string{"\tat Main"}, messages(g.flush()))
+
+		g.add(line("web", "Exception"), start)
+		long := "\t" + strings.Repeat("a", maxRecordBytes/2)
+		assert.Empty(t, g.add(line("web", long), start))
+		assert.Len(t, g.add(line("web", long), start), 1)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		g := newMultilineGrouper(nil)
+		assert.Equal(t, []string{"\tat Main"}, messages(g.add(line("web", "\tat Main"), start)))
+		assert.Empty(t, g.flush())
+	})
+}
@@ -0,0 +1,130 @@
+package logs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+const (
+	// DefaultOutputMaxSize is the size at which files in the output
+	// directory are rotated.
+	DefaultOutputMaxSize = 100 * 1024 * 1024
+
+	// outputMaxBackups is the number of rotated files that are kept for each
+	// service, in addition to the current file.
+	outputMaxBackups = 5
+)
+
+// outputDir writes the logs of each service to DIR/<service>.log. Files are
+// rotated to <service>.log.1, <service>.log.2, etc once they reach maxSize.
+type outputDir struct {
+	dir     string
+	maxSize int64
+	files   map[string]*rotatingFile
+}
+
+func newOutputDir(dir string, maxSize int64) (*outputDir, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.WithContext("create output directory", err)
+	}
+	return &outputDir{dir: dir, maxSize: maxSize, files: map[string]*rotatingFile{}}, nil
+}
+
+// write appends the log line to its service's file. The lines are written
+// without colors, and with the timestamp that the line was logged at, so
+// that the files can be processed by other tools.
+func (out *outputDir) write(line parsedLogLine) {
+	f, ok := out.files[line.fromContainer]
+	if !ok {
+		f = &rotatingFile{
+			path:    filepath.Join(out.dir, line.fromContainer+".log"),
+			maxSize: out.maxSize,
+		}
+		out.files[line.fromContainer] = f
+	}
+
+	msg := fmt.Sprintf("%s %s\n", line.loggedAt.Format(time.RFC3339Nano), line.message)
+	if err := f.write([]byte(msg)); err != nil {
+		log.WithError(err).WithField("path", f.path).Warn("Failed to write logs to output directory")
+	}
+}
+
+func (out *outputDir) Close() {
+	for _, f := range out.files {
+		if f.file != nil {
+			f.file.Close()
+		}
+	}
+}
+
+type rotatingFile struct {
+	path    string
+	maxSize int64
+
+	file *os.File
+	size int64
+}
+
+func (f *rotatingFile) write(b []byte) error {
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+
+	if f.maxSize > 0 && f.size+int64(len(b)) > f.maxSize && f.size > 0 {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(b)
+	f.size += int64(n)
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = fi.Size()
+	return nil
+}
+
+// rotate shifts the existing backups, moves the current file to the first
+// backup, and starts a new file. The oldest backup is removed.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	for i := outputMaxBackups - 1; i > 0; i-- {
+		src := fmt.Sprintf("%s.%d", f.path, i)
+		if _, err := os.Stat(src); err == nil {
+			if err := os.Rename(src, fmt.Sprintf("%s.%d", f.path, i+1)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return err
+	}
+	return f.open()
+}
@@ -0,0 +1,65 @@
+package logs
+
+import (
+	"strings"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// The output streams that containers log to. Kubernetes merges them when
+// logs are read through its API, so the stream is only known for logs that
+// are read from the log capture sidecar, or through the manager.
+const (
+	OutputStreamStdout = "stdout"
+	OutputStreamStderr = "stderr"
+)
+
+// splitOutputStream parses the stream from messages in the CRI logging
+// format, such as "stderr F message", which the log capture sidecar writes
+// after the timestamp. The "P" tag marks lines that the runtime split
+// because they were too long. Messages in any other format are returned
+// unchanged, with an empty stream.
+func splitOutputStream(message string) (stream, rest string) {
+	parts := strings.SplitN(message, " ", 3)
+	if len(parts) < 2 {
+		return "", message
+	}
+
+	switch parts[0] {
+	case OutputStreamStdout, OutputStreamStderr:
+	default:
+		return "", message
+	}
+
+	switch parts[1] {
+	case "F", "P":
+	default:
+		return "", message
+	}
+
+	if len(parts) == 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[2]
+}
+
+// getOutputStreamFilter returns a filter that only prints the lines logged
+// to the given stream, or nil if both streams should be printed. Lines whose
+// stream isn't known are always printed.
+func getOutputStreamFilter(stdoutOnly, stderrOnly bool) (func(parsedLogLine) bool, error) {
+	var stream string
+	switch {
+	case stdoutOnly && stderrOnly:
+		return nil, errors.NewFriendlyError("--stdout-only and --stderr-only can't be used together.")
+	case stdoutOnly:
+		stream = OutputStreamStdout
+	case stderrOnly:
+		stream = OutputStreamStderr
+	default:
+		return nil, nil
+	}
+
+	return func(log parsedLogLine) bool {
+		return log.outputStream == "" || log.outputStream == stream
+	}, nil
+}
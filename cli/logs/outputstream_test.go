@@ -0,0 +1,84 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitOutputStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		expStream string
+		expRest   string
+	}{
+		{
+			name:      "Stdout",
+			message:   "stdout F Starting server",
+			expStream: OutputStreamStdout,
+			expRest:   "Starting server",
+		},
+		{
+			name:      "Partial stderr line",
+			message:   "stderr P Traceback",
+			expStream: OutputStreamStderr,
+			expRest:   "Traceback",
+		},
+		{
+			name:      "Empty message",
+			message:   "stdout F",
+			expStream: OutputStreamStdout,
+			expRest:   "",
+		},
+		{
+			name:    "Unknown stream",
+			message: "stdin F Starting server",
+			expRest: "stdin F Starting server",
+		},
+		{
+			name:    "Unknown tag",
+			message: "stdout is ready",
+			expRest: "stdout is ready",
+		},
+		{
+			name:    "Plain message",
+			message: "Starting",
+			expRest: "Starting",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			stream, rest := splitOutputStream(test.message)
+			assert.Equal(t, test.expStream, stream)
+			assert.Equal(t, test.expRest, rest)
+		})
+	}
+}
+
+func TestGetOutputStreamFilter(t *testing.T) {
+	stdout := parsedLogLine{outputStream: OutputStreamStdout}
+	stderr := parsedLogLine{outputStream: OutputStreamStderr}
+	unknown := parsedLogLine{}
+
+	filter, err := getOutputStreamFilter(false, false)
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = getOutputStreamFilter(true, false)
+	assert.NoError(t, err)
+	assert.True(t, filter(stdout))
+	assert.False(t, filter(stderr))
+	assert.True(t, filter(unknown))
+
+	filter, err = getOutputStreamFilter(false, true)
+	assert.NoError(t, err)
+	assert.False(t, filter(stdout))
+	assert.True(t, filter(stderr))
+	assert.True(t, filter(unknown))
+
+	_, err = getOutputStreamFilter(true, true)
+	assert.Error(t, err)
+}
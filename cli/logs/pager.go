@@ -0,0 +1,90 @@
+package logs
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// DefaultPager is the pager used if $PAGER isn't set. -R passes the colors
+// through to the terminal.
+const DefaultPager = "less -R"
+
+// pager pipes the logs into a pager process, the same as `git log`.
+type pager struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	// exited is closed once the pager exits, such as because the user quit
+	// it before all the logs were written.
+	exited chan struct{}
+}
+
+// shouldPage returns whether the logs should be piped into a pager. Logs are
+// only paged when they're printed to a terminal, and won't be followed.
+func (cmd LogsCommand) shouldPage() bool {
+	if cmd.NoPager || cmd.Opts.Follow || cmd.Stats || cmd.Replay != "" || cmd.NoStdout {
+		return false
+	}
+	return terminal.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// startPager starts the pager configured by $PAGER. It returns nil if the
+// pager is disabled, such as with `PAGER=cat`.
+func startPager() (*pager, error) {
+	pagerCmd := strings.Fields(os.Getenv("PAGER"))
+	if len(pagerCmd) == 0 {
+		pagerCmd = strings.Fields(DefaultPager)
+	}
+	if pagerCmd[0] == "cat" {
+		return nil, nil
+	}
+
+	cmd := exec.Command(pagerCmd[0], pagerCmd[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// Like Git, exit immediately if the logs fit on one screen, and don't
+	// clear the screen when exiting.
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errors.WithContext("create pager stdin", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.WithContext("start pager", err)
+	}
+
+	p := &pager{cmd: cmd, stdin: stdin, exited: make(chan struct{})}
+	go func() {
+		// The exit code is ignored since pagers exit with an error when
+		// their input is closed early, which isn't a problem.
+		_ = cmd.Wait()
+		close(p.exited)
+	}()
+	return p, nil
+}
+
+// Write writes to the pager's input. It returns an error once the pager has
+// exited.
+func (p *pager) Write(b []byte) (int, error) {
+	return p.stdin.Write(b)
+}
+
+// Close signals the end of the logs, and waits for the user to quit the
+// pager.
+func (p *pager) Close() error {
+	err := p.stdin.Close()
+	<-p.exited
+	return err
+}
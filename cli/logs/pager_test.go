@@ -0,0 +1,143 @@
+package logs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestShouldPage(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  LogsCommand
+	}{
+		{
+			name: "NoPager",
+			cmd:  LogsCommand{NoPager: true},
+		},
+		{
+			name: "Follow",
+			cmd:  LogsCommand{Opts: corev1.PodLogOptions{Follow: true}},
+		},
+		{
+			name: "Stats",
+			cmd:  LogsCommand{Stats: true},
+		},
+		{
+			name: "Replay",
+			cmd:  LogsCommand{Replay: "2x"},
+		},
+		{
+			name: "NoStdout",
+			cmd:  LogsCommand{NoStdout: true},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.False(t, test.cmd.shouldPage())
+		})
+	}
+}
+
+func TestPager(t *testing.T) {
+	setEnv := func(key, val string, ok bool) func() {
+		oldVal, oldOK := os.LookupEnv(key)
+		if ok {
+			os.Setenv(key, val)
+		} else {
+			os.Unsetenv(key)
+		}
+		return func() {
+			if oldOK {
+				os.Setenv(key, oldVal)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "blimp-logs-pager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	t.Run("Disabled with cat", func(t *testing.T) {
+		defer setEnv("PAGER", "cat", true)()
+
+		p, err := startPager()
+		assert.NoError(t, err)
+		assert.Nil(t, p)
+	})
+
+	t.Run("Writes to the pager", func(t *testing.T) {
+		path := filepath.Join(dir, "paged")
+		defer setEnv("PAGER", fmt.Sprintf("sh -c cat>%s", path), true)()
+
+		p, err := startPager()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintln(p, "web › Starting server")
+		fmt.Fprintln(p, "worker › Starting worker")
+		assert.NoError(t, p.Close())
+
+		paged, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "web › Starting server\nworker › Starting worker\n", string(paged))
+	})
+
+	t.Run("Sets LESS if it's unset", func(t *testing.T) {
+		path := filepath.Join(dir, "env")
+		defer setEnv("PAGER", fmt.Sprintf("sh -c env>%s", path), true)()
+		defer setEnv("LESS", "", false)()
+
+		p, err := startPager()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, p.Close())
+
+		env, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(env), "LESS=FRX\n")
+	})
+
+	t.Run("Keeps the user's LESS", func(t *testing.T) {
+		path := filepath.Join(dir, "env")
+		defer setEnv("PAGER", fmt.Sprintf("sh -c env>%s", path), true)()
+		defer setEnv("LESS", "R", true)()
+
+		p, err := startPager()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, p.Close())
+
+		env, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(env), "LESS=R\n")
+		assert.NotContains(t, string(env), "LESS=FRX")
+	})
+
+	t.Run("Writes fail once the pager exits", func(t *testing.T) {
+		defer setEnv("PAGER", "true", true)()
+
+		p, err := startPager()
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-p.exited
+
+		_, err = fmt.Fprintln(p, "web › Starting server")
+		assert.Error(t, err)
+		_ = p.Close()
+	})
+}
@@ -0,0 +1,124 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buger/goterm"
+)
+
+// The keys that are promoted to the start of pretty-printed JSON logs, in
+// order of preference. The first key that's present is used, and the others
+// are printed with the remaining fields.
+var (
+	jsonTimeKeys    = []string{"time", "ts", "timestamp", "@timestamp"}
+	jsonLevelKeys   = []string{"level", "lvl", "severity"}
+	jsonMessageKeys = []string{"msg", "message"}
+)
+
+// levelColors are the colors of the levels in pretty-printed JSON logs,
+// indexed by their position in logLevels.
+var levelColors = []int{goterm.WHITE, goterm.WHITE, goterm.BLUE, goterm.YELLOW, goterm.RED, goterm.RED}
+
+// withParsedJSON wraps `format` to pretty-print log messages that are JSON
+// objects. Other messages are formatted as is.
+func withParsedJSON(format func(parsedLogLine) string, colors colorizer) func(parsedLogLine) string {
+	return func(line parsedLogLine) string {
+		if pretty, ok := prettyJSON(line.message, colors); ok {
+			line.message = pretty
+		}
+		return format(line)
+	}
+}
+
+// prettyJSON renders a JSON log message as `TIME LEVEL MESSAGE key=value`,
+// with the keys sorted. It returns false if the message isn't a JSON object.
+func prettyJSON(message string, colors colorizer) (string, bool) {
+	trimmed := strings.TrimSpace(message)
+	if !strings.HasPrefix(trimmed, "{") {
+		return "", false
+	}
+
+	// Decode numbers as json.Number so that they're printed exactly as they
+	// were logged.
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil || decoder.More() {
+		return "", false
+	}
+
+	var parts []string
+	if key, ok := findKey(fields, jsonTimeKeys); ok {
+		parts = append(parts, formatJSONValue(fields[key]))
+		delete(fields, key)
+	}
+
+	if key, ok := findKey(fields, jsonLevelKeys); ok {
+		level := formatJSONValue(fields[key])
+		delete(fields, key)
+
+		levelStr := strings.ToUpper(level)
+		if idx, ok := parseLevel(level); ok {
+			levelStr = colors.color(levelStr, levelColors[idx])
+		}
+		parts = append(parts, levelStr)
+	}
+
+	if key, ok := findKey(fields, jsonMessageKeys); ok {
+		parts = append(parts, colors.bold(formatJSONValue(fields[key])))
+		delete(fields, key)
+	}
+
+	var keys []string
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// Quote strings that would be ambiguous in key=value form. Nested
+		// values are already printed as JSON.
+		value := formatJSONValue(fields[key])
+		if _, isString := fields[key].(string); isString && strings.ContainsAny(value, " \t=\"") {
+			value = fmt.Sprintf("%q", value)
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", colors.color(key, goterm.CYAN), value))
+	}
+	return strings.Join(parts, " "), true
+}
+
+// findKey returns the first of the candidate keys that's in the fields.
+func findKey(fields map[string]interface{}, candidates []string) (string, bool) {
+	for _, key := range candidates {
+		if _, ok := fields[key]; ok {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// formatJSONValue returns the string representation of a decoded JSON value.
+// Nested objects and arrays are printed as compact JSON.
+func formatJSONValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case nil:
+		return "null"
+	case bool:
+		return fmt.Sprintf("%t", value)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
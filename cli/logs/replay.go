@@ -0,0 +1,78 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// parseReplaySpeed parses replay speeds such as "2x" or "0.5".
+func parseReplaySpeed(str string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(str, "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, errors.NewFriendlyError("Invalid --replay speed %q. "+
+			"It must be a positive number, such as 1x or 2x.", str)
+	}
+	return speed, nil
+}
+
+// replayLogs reads all the logs from `rawLogs`, and then prints them with the
+// same spacing as when they were originally logged, divided by `speed`.
+// Unlike printLogs, the logs are all read before printing, so that they're
+// printed in order even if some services' logs are slow to load.
+func replayLogs(ctx context.Context, rawLogs <-chan rawLogLine, proc logProcessor, speed float64) error {
+
+	var logs []parsedLogLine
+	grouper := newMultilineGrouper(proc.isContinuation)
+	add := func(records []bufferedLogLine) {
+		for _, record := range records {
+			if proc.shouldPrint(record.parsedLogLine) {
+				logs = append(logs, record.parsedLogLine)
+			}
+		}
+	}
+	for logLine := range rawLogs {
+		if logLine.readError != nil && logLine.readError != io.EOF {
+			return errors.WithContext(fmt.Sprintf("read logs for %s", logLine.fromContainer), logLine.readError)
+		}
+
+		// The final read before EOF returns an empty line if the log ended
+		// with a newline.
+		if logLine.readError != io.EOF || logLine.message != "" {
+			add(grouper.add(proc.parse(logLine), logLine.receivedAt))
+		}
+		if logLine.readError == io.EOF {
+			add(grouper.end(logLine.stream()))
+		}
+	}
+	add(grouper.flush())
+
+	if len(logs) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].loggedAt.Before(logs[j].loggedAt)
+	})
+
+	firstLoggedAt := logs[0].loggedAt
+	replayStart := time.Now()
+	for _, log := range logs {
+		offset := time.Duration(float64(log.loggedAt.Sub(firstLoggedAt)) / speed)
+		select {
+		case <-time.After(time.Until(replayStart.Add(offset))):
+		case <-ctx.Done():
+			return nil
+		}
+
+		proc.output(log)
+	}
+	proc.flush()
+	return nil
+}
@@ -0,0 +1,180 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func TestParseReplaySpeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		speed    string
+		expSpeed float64
+		expErr   bool
+	}{
+		{
+			name:     "Multiplier",
+			speed:    "2x",
+			expSpeed: 2,
+		},
+		{
+			name:     "Without suffix",
+			speed:    "0.5",
+			expSpeed: 0.5,
+		},
+		{
+			name:   "Zero",
+			speed:  "0x",
+			expErr: true,
+		},
+		{
+			name:   "Negative",
+			speed:  "-1",
+			expErr: true,
+		},
+		{
+			name:   "Not a number",
+			speed:  "fast",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			speed, err := parseReplaySpeed(test.speed)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expSpeed, speed)
+		})
+	}
+}
+
+func TestReplayLogs(t *testing.T) {
+	line := func(container, timestamp, message string) rawLogLine {
+		return rawLogLine{
+			fromContainer: container,
+			message:       timestamp + " " + message,
+			receivedAt:    time.Now(),
+		}
+	}
+	eof := func(container string) rawLogLine {
+		return rawLogLine{fromContainer: container, readError: io.EOF}
+	}
+
+	tests := []struct {
+		name      string
+		lines     []rawLogLine
+		filter    func(parsedLogLine) bool
+		expOutput string
+		expErr    bool
+	}{
+		{
+			name: "Ordered by timestamp",
+			lines: []rawLogLine{
+				line("web", "2020-06-01T12:00:00Z", "web 1"),
+				line("web", "2020-06-01T12:00:20Z", "web 2"),
+				eof("web"),
+				line("worker", "2020-06-01T12:00:10Z", "worker 1"),
+				line("worker", "2020-06-01T12:00:30Z", "worker 2"),
+				eof("worker"),
+			},
+			expOutput: "web 1\nworker 1\nweb 2\nworker 2\n",
+		},
+		{
+			name: "Filtered",
+			lines: []rawLogLine{
+				line("web", "2020-06-01T12:00:00Z", "GET /healthz"),
+				line("web", "2020-06-01T12:00:10Z", "GET /index.html"),
+				eof("web"),
+			},
+			filter:    func(log parsedLogLine) bool { return log.message != "GET /healthz" },
+			expOutput: "GET /index.html\n",
+		},
+		{
+			name: "No logs",
+			lines: []rawLogLine{
+				eof("web"),
+			},
+		},
+		{
+			name: "Read error",
+			lines: []rawLogLine{
+				line("web", "2020-06-01T12:00:00Z", "web 1"),
+				{fromContainer: "web", readError: errors.New("connection reset")},
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			proc := logProcessor{
+				parse:  parseRawLog,
+				filter: test.filter,
+				format: func(line parsedLogLine) string { return line.message },
+				out:    &out,
+			}
+
+			rawLogs := make(chan rawLogLine, len(test.lines))
+			for _, line := range test.lines {
+				rawLogs <- line
+			}
+			close(rawLogs)
+
+			// The logs span 30 seconds, so they're replayed in 30ms.
+			err := replayLogs(context.Background(), rawLogs, proc, 1000)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expOutput, out.String())
+		})
+	}
+}
+
+func TestReplayLogsSpacing(t *testing.T) {
+	var out bytes.Buffer
+	proc := logProcessor{
+		parse:  parseRawLog,
+		format: func(line parsedLogLine) string { return line.message },
+		out:    &out,
+	}
+
+	rawLogs := make(chan rawLogLine, 2)
+	rawLogs <- rawLogLine{fromContainer: "web", message: "2020-06-01T12:00:00Z first"}
+	rawLogs <- rawLogLine{fromContainer: "web", message: "2020-06-01T12:00:01Z second"}
+	close(rawLogs)
+
+	// The lines were logged a second apart, so replaying them at 10x should
+	// take 100ms.
+	start := time.Now()
+	assert.NoError(t, replayLogs(context.Background(), rawLogs, proc, 10))
+	assert.True(t, time.Since(start) >= 100*time.Millisecond, "replay took %s", time.Since(start))
+	assert.Equal(t, "first\nsecond\n", out.String())
+
+	// Nothing is printed once the context is cancelled.
+	out.Reset()
+	rawLogs = make(chan rawLogLine, 2)
+	rawLogs <- rawLogLine{fromContainer: "web", message: "2020-06-01T12:00:00Z first"}
+	rawLogs <- rawLogLine{fromContainer: "web", message: "2020-06-01T12:01:00Z second"}
+	close(rawLogs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, replayLogs(ctx, rawLogs, proc, 1))
+	assert.NotContains(t, out.String(), "second")
+}
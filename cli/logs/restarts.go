@@ -0,0 +1,166 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// containerInstance is one run of a service's container, between when it
+// started and when it exited or was restarted. Instances are numbered by the
+// number of restarts before them, so the first instance is 0.
+type containerInstance struct {
+	index     int
+	startedAt time.Time
+
+	// endedAt is zero if the instance is still running.
+	endedAt time.Time
+
+	// endReason is the event that ended the instance.
+	endReason cluster.ServiceEvent_Type
+	endMsg    string
+}
+
+// getContainerInstances reconstructs the service's container instances from
+// the events recorded by the node controller.
+func getContainerInstances(authToken, svc string) ([]containerInstance, error) {
+	resp, err := manager.C.GetServiceHistory(context.Background(), &cluster.GetServiceHistoryRequest{
+		Token:   authToken,
+		Service: svc,
+	})
+	if err != nil {
+		return nil, errors.WithContext("get service history", err)
+	}
+
+	var instances []containerInstance
+	isRunning := func() bool {
+		return len(instances) != 0 && instances[len(instances)-1].endedAt.IsZero()
+	}
+	end := func(event *cluster.ServiceEvent) {
+		last := &instances[len(instances)-1]
+		last.endedAt = time.Unix(event.GetTimestamp(), 0)
+		last.endReason = event.GetType()
+		last.endMsg = event.GetMsg()
+	}
+	start := func(event *cluster.ServiceEvent) {
+		instances = append(instances, containerInstance{
+			index:     len(instances),
+			startedAt: time.Unix(event.GetTimestamp(), 0),
+		})
+	}
+
+	for _, event := range resp.GetEvents() {
+		switch event.GetType() {
+		case cluster.ServiceEvent_STARTED:
+			if !isRunning() {
+				start(event)
+			}
+		case cluster.ServiceEvent_RESTARTED:
+			// Restarts aren't always preceded by an exit event, such as
+			// when the container was restarted by `blimp restart`.
+			if isRunning() {
+				end(event)
+			}
+			start(event)
+		case cluster.ServiceEvent_EXITED, cluster.ServiceEvent_OOM_KILLED, cluster.ServiceEvent_RESCHEDULED:
+			if isRunning() {
+				end(event)
+			}
+		}
+	}
+	return instances, nil
+}
+
+// endReasons describes the events that end container instances.
+var endReasons = map[cluster.ServiceEvent_Type]string{
+	cluster.ServiceEvent_EXITED:      "Exited",
+	cluster.ServiceEvent_OOM_KILLED:  "OOMKilled",
+	cluster.ServiceEvent_RESTARTED:   "Restarted",
+	cluster.ServiceEvent_RESCHEDULED: "Moved to a new node",
+}
+
+func printContainerInstances(out io.Writer, svc string, instances []containerInstance) {
+	if len(instances) == 0 {
+		fmt.Fprintf(out, "%s hasn't started yet.\n", svc)
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "RESTART\tSTARTED\tENDED\tREASON")
+	for _, instance := range instances {
+		ended, reason := "-", "Still running"
+		if !instance.endedAt.IsZero() {
+			ended = instance.endedAt.Format(time.Stamp)
+			reason = endReasons[instance.endReason]
+			if instance.endMsg != "" {
+				reason += ": " + instance.endMsg
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", instance.index, instance.startedAt.Format(time.Stamp), ended, reason)
+	}
+}
+
+// selectRestart configures the command to read the logs of the container
+// instance selected with --restart. Kubernetes only keeps the logs of the
+// current and previous containers, so the logs of older instances are read
+// from the log capture sidecar, and filtered to the time that the instance
+// was running with the returned filter.
+func (cmd *LogsCommand) selectRestart(kubeClient kubernetes.Interface) (func(parsedLogLine) bool, error) {
+	if len(cmd.Containers) != 1 {
+		return nil, errors.NewFriendlyError("--restart can only be used with a single service.")
+	}
+
+	if cmd.Opts.Follow || cmd.Opts.Previous || cmd.History == HistoryFull {
+		return nil, errors.NewFriendlyError("--restart can't be used with --follow, --previous, or `--history %s`.",
+			HistoryFull)
+	}
+
+	svc := cmd.Containers[0]
+	instances, err := getContainerInstances(cmd.Auth.AuthToken, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	index := *cmd.Restart
+	if index < 0 || index >= len(instances) {
+		return nil, errors.NewFriendlyError("%s doesn't have a restart %d. "+
+			"Run `blimp logs %s --list-restarts` to see its restarts.", svc, index, svc)
+	}
+
+	instance := instances[index]
+	switch {
+	case index == len(instances)-1:
+		return nil, nil
+	case index == len(instances)-2 && instance.endReason != cluster.ServiceEvent_RESCHEDULED:
+		// Kubernetes still has the logs of the previous container, unless
+		// the pod was recreated on another node.
+		cmd.Opts.Previous = true
+		return nil, nil
+	}
+
+	if !hasLogCapture(kubeClient, cmd.Auth.KubeNamespace, svc) {
+		return nil, errors.NewFriendlyError("Kubernetes only keeps the logs of the current and previous "+
+			"containers, so reading older restarts requires full log history.\n"+
+			"To enable it, add the label `%s: \"true\"` to the service, and restart it with `blimp up`.",
+			names.LogCaptureLabel)
+	}
+
+	// The events are recorded with second precision once the node controller
+	// notices them, so lines logged shortly before the exit was observed are
+	// still included.
+	cmd.History = HistoryFull
+	until := instance.endedAt.Add(time.Second)
+	return func(line parsedLogLine) bool {
+		return !line.loggedAt.Before(instance.startedAt) && line.loggedAt.Before(until)
+	}, nil
+}
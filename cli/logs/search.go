@@ -0,0 +1,248 @@
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// grepOutputPattern matches the lines printed by `grep -n`. Matching lines
+// are separated from their line number by a colon, and context lines by a
+// dash. Each captured line starts with the timestamp added by Kubernetes.
+var grepOutputPattern = regexp.MustCompile(`^(\d+)([:-])(\S+) ?(.*)$`)
+
+// SearchCommand searches the logs persisted by the log capture sidecars.
+type SearchCommand struct {
+	Auth     authstore.Store
+	Pattern  string
+	Services []string
+
+	// Whether Services was explicitly set by the user. If it wasn't,
+	// services without log capture are skipped rather than causing an error.
+	ExplicitServices bool
+
+	Since        time.Duration
+	ContextLines int
+	IgnoreCase   bool
+	FixedStrings bool
+
+	// Color controls when colors are printed. See the Color constants.
+	Color string
+}
+
+func NewSearchCommand() *cobra.Command {
+	cmd := &SearchCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "search PATTERN [SERVICE ...]",
+		Short: "Search the full log history of services",
+		Long: "Search the full log history of services for a regular expression.\n\n" +
+			"The search runs in the sandbox against the logs persisted for services with the " +
+			names.LogCaptureLabel + " label, so the logs don't have to be downloaded first. " +
+			"If no services are provided, all services with persisted logs are searched.\n\n" +
+			"Only the matches and their context are sent over the network, so searching is much " +
+			"faster than piping `blimp logs` into grep for services that log gigabytes of output. " +
+			"It's also available as `blimp logs search`.",
+		Example: `  blimp search "connection refused" --since 24h
+  blimp logs search -i timeout web worker`,
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) == 0 {
+				fmt.Fprintln(os.Stderr, "A search pattern is required")
+				errors.Exit(1)
+			}
+
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse auth store")
+			}
+
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			projectCfg, err := projectcfg.Load(".")
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			cmd.Auth = auth
+			cmd.Pattern = args[0]
+			cmd.Services, err = manager.ResolveServices(auth.AuthToken, projectCfg.ExpandGroups(args[1:]))
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			cmd.ExplicitServices = len(cmd.Services) != 0
+			if !cmd.ExplicitServices {
+				cmd.Services, err = getAllServices(auth.AuthToken, nil)
+				if err != nil {
+					errors.HandleFatalError(err)
+				}
+			}
+
+			if err := cmd.Run(); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+
+	cobraCmd.Flags().DurationVarP(&cmd.Since, "since", "", 0,
+		"Only print matches newer than a relative duration like 5s, 2m, or 24h.")
+	cobraCmd.Flags().IntVarP(&cmd.ContextLines, "context", "C", 2,
+		"The number of lines to print before and after each match.")
+	cobraCmd.Flags().BoolVarP(&cmd.IgnoreCase, "ignore-case", "i", false,
+		"Ignore case when matching.")
+	cobraCmd.Flags().BoolVarP(&cmd.FixedStrings, "fixed-strings", "F", false,
+		"Treat the pattern as a literal string rather than a regular expression.")
+	cobraCmd.Flags().StringVarP(&cmd.Color, "color", "", ColorAuto,
+		fmt.Sprintf("When to print colors. Either %q, %q, or %q.", ColorAuto, ColorAlways, ColorNever))
+	return cobraCmd
+}
+
+func (cmd SearchCommand) Run() error {
+	kubeClient, restConfig, err := cmd.Auth.KubeClient()
+	if err != nil {
+		return errors.WithContext("connect to cluster", err)
+	}
+
+	useColors, err := useColor(cmd.Color, os.Stdout)
+	if err != nil {
+		return err
+	}
+	colors := colorizer{enabled: useColors, palette: colorList}
+
+	var since time.Time
+	if cmd.Since != 0 {
+		since = time.Now().Add(-cmd.Since)
+	}
+
+	var skipped []string
+	var matches int
+	for _, svc := range cmd.Services {
+		if !cmd.ExplicitServices && !hasLogCapture(kubeClient, cmd.Auth.KubeNamespace, svc) {
+			skipped = append(skipped, svc)
+			continue
+		}
+
+		n, err := cmd.searchService(kubeClient, restConfig, colors, svc, since)
+		if err != nil {
+			return errors.WithContext(fmt.Sprintf("search %s", svc), err)
+		}
+		matches += n
+	}
+
+	if len(skipped) != 0 {
+		fmt.Fprintf(os.Stderr, "Skipped services without full log history: %s\n", strings.Join(skipped, ", "))
+	}
+	if matches == 0 {
+		fmt.Fprintln(os.Stderr, "No matches found.")
+	}
+	return nil
+}
+
+// searchService prints the matches in the service's captured logs, and
+// returns the number of matches.
+func (cmd SearchCommand) searchService(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	colors colorizer, svc string, since time.Time) (int, error) {
+
+	grepCmd := []string{"grep", "-n", "-C", strconv.Itoa(cmd.ContextLines)}
+	if cmd.IgnoreCase {
+		grepCmd = append(grepCmd, "-i")
+	}
+	if cmd.FixedStrings {
+		grepCmd = append(grepCmd, "-F")
+	} else {
+		grepCmd = append(grepCmd, "-E")
+	}
+	grepCmd = append(grepCmd, "-e", cmd.Pattern, names.LogCapturePath)
+
+	// grep exits with 1 if there aren't any matches.
+	stdout, err := execInCaptureSidecar(kubeClient, restConfig, cmd.Auth.KubeNamespace, svc, grepCmd, []int{1})
+	if err != nil {
+		return 0, err
+	}
+	defer stdout.Close()
+
+	// Buffer each group of matches so that groups that are older than
+	// `since` can be skipped.
+	var group []string
+	var groupMatches int
+	var groupIsRecent bool
+	var printedGroup bool
+	var total int
+	flush := func() {
+		if groupMatches != 0 && groupIsRecent {
+			if printedGroup {
+				fmt.Println("--")
+			}
+			for _, line := range group {
+				fmt.Println(line)
+			}
+			printedGroup = true
+			total += groupMatches
+		}
+		group, groupMatches, groupIsRecent = nil, 0, false
+	}
+
+	prefix := colors.service(svc)
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "--" {
+			flush()
+			continue
+		}
+
+		parts := grepOutputPattern.FindStringSubmatch(line)
+		if parts == nil {
+			continue
+		}
+
+		isMatch := parts[2] == ":"
+		message := parts[4]
+		if isMatch {
+			groupMatches++
+			if loggedAt, err := time.Parse(time.RFC3339Nano, parts[3]); err != nil || !loggedAt.Before(since) {
+				groupIsRecent = true
+			}
+			message = colors.bold(message)
+		}
+		group = append(group, fmt.Sprintf("%s › %s %s", prefix, parts[3], message))
+	}
+	flush()
+	return total, scanner.Err()
+}
+
+// hasLogCapture returns whether the service's pod has the log capture
+// sidecar.
+func hasLogCapture(kubeClient kubernetes.Interface, namespace, svc string) bool {
+	pod, err := kubeClient.CoreV1().Pods(namespace).Get(names.PodName(svc), metav1.GetOptions{})
+	if err != nil {
+		log.WithError(err).WithField("service", svc).Debug("Failed to get pod")
+		return false
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name == names.LogCaptureContainer {
+			return true
+		}
+	}
+	return false
+}
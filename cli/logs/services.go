@@ -0,0 +1,44 @@
+package logs
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// getAllServices returns the services in the sandbox that have logs, in the
+// same way as `docker-compose logs` without arguments. Services that the
+// project excludes from the combined logs are skipped.
+func getAllServices(authToken string, serviceConfigs map[string]projectcfg.ServiceLogsConfig) ([]string, error) {
+	statusResp, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return nil, errors.WithContext("get status", err)
+	}
+
+	status := statusResp.GetStatus()
+	if status.GetPhase() != cluster.SandboxStatus_RUNNING {
+		return nil, errors.NewFriendlyError(
+			"Your sandbox is not booted. Please run `blimp up` first.")
+	}
+
+	// Services that haven't started yet don't have any logs.
+	var services []string
+	for name, svcStatus := range status.GetServices() {
+		if svcStatus.GetHasStarted() && !serviceConfigs[name].Exclude {
+			services = append(services, name)
+		}
+	}
+
+	if len(services) == 0 {
+		return nil, errors.NewFriendlyError("None of the services in your sandbox have started yet.")
+	}
+
+	sort.Strings(services)
+	return services, nil
+}
@@ -0,0 +1,100 @@
+package logs
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kelda/blimp/cli/util"
+)
+
+// DefaultStatsInterval is how often --stats prints the log rates.
+const DefaultStatsInterval = 5 * time.Second
+
+// logStats counts the lines and bytes logged by each stream, so that
+// `--stats` can show which services are flooding the logs. Lines are counted
+// before they're filtered, since noisy services are often the ones being
+// filtered out.
+type logStats struct {
+	out      io.Writer
+	interval time.Duration
+
+	// since is when the current interval started.
+	since   time.Time
+	streams map[string]*streamStats
+}
+
+type streamStats struct {
+	// The lines and bytes logged during the current interval.
+	lines, bytes int64
+
+	// The lines and bytes logged since the command started.
+	totalLines, totalBytes int64
+}
+
+func newLogStats(out io.Writer, interval time.Duration) *logStats {
+	return &logStats{
+		out:      out,
+		interval: interval,
+		since:    time.Now(),
+		streams:  map[string]*streamStats{},
+	}
+}
+
+func (stats *logStats) add(line parsedLogLine) {
+	s, ok := stats.streams[line.stream()]
+	if !ok {
+		s = &streamStats{}
+		stats.streams[line.stream()] = s
+	}
+
+	// Include the newline that ends each line.
+	size := int64(len(line.message) + 1)
+	s.lines++
+	s.bytes += size
+	s.totalLines++
+	s.totalBytes += size
+}
+
+// print prints the rates since the previous call, and starts a new interval.
+// The noisiest streams are printed first.
+func (stats *logStats) print(now time.Time) {
+	elapsed := now.Sub(stats.since).Seconds()
+	stats.since = now
+	if elapsed <= 0 {
+		return
+	}
+
+	fmt.Fprintf(stats.out, "\n%s\n", now.Format(time.Stamp))
+	if len(stats.streams) == 0 {
+		fmt.Fprintln(stats.out, "No logs yet.")
+		return
+	}
+
+	var names []string
+	for name := range stats.streams {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := stats.streams[names[i]], stats.streams[names[j]]
+		if a.bytes != b.bytes {
+			return a.bytes > b.bytes
+		}
+		return names[i] < names[j]
+	})
+
+	w := tabwriter.NewWriter(stats.out, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "SERVICE\tLINES/SEC\tBYTES/SEC\tTOTAL LINES\tTOTAL BYTES")
+	for _, name := range names {
+		s := stats.streams[name]
+		fmt.Fprintf(w, "%s\t%.1f\t%s\t%d\t%s\n", name,
+			float64(s.lines)/elapsed,
+			util.FormatBytes(int64(float64(s.bytes)/elapsed)),
+			s.totalLines,
+			util.FormatBytes(s.totalBytes))
+		s.lines, s.bytes = 0, 0
+	}
+	w.Flush()
+}
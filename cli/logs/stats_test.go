@@ -0,0 +1,50 @@
+package logs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogStats(t *testing.T) {
+	var out bytes.Buffer
+	stats := newLogStats(&out, DefaultStatsInterval)
+	start := stats.since
+
+	// No time has passed, so there's no rate to print.
+	stats.print(start)
+	assert.Empty(t, out.String())
+
+	stats.print(start.Add(time.Second))
+	assert.Equal(t, "\n"+start.Add(time.Second).Format(time.Stamp)+"\nNo logs yet.\n", out.String())
+	out.Reset()
+
+	// Each line is counted with its newline.
+	for i := 0; i < 4; i++ {
+		stats.add(parsedLogLine{fromContainer: "web", message: strings.Repeat("a", 1023)})
+	}
+	stats.add(parsedLogLine{fromContainer: "worker", message: "hi"})
+	stats.add(parsedLogLine{fromContainer: "worker", initContainer: "wait", message: "waiting"})
+
+	stats.print(start.Add(3 * time.Second))
+	assert.Equal(t, "\n"+start.Add(3*time.Second).Format(time.Stamp)+"\n"+
+		"SERVICE                LINES/SEC    BYTES/SEC    TOTAL LINES    TOTAL BYTES\n"+
+		"web                    2.0          2.0KiB       4              4.0KiB\n"+
+		"worker (init: wait)    0.5          4B           1              8B\n"+
+		"worker                 0.5          1B           1              3B\n",
+		out.String())
+	out.Reset()
+
+	// The rates are reset for each interval, but the totals are kept.
+	stats.add(parsedLogLine{fromContainer: "worker", message: "hi"})
+	stats.print(start.Add(4 * time.Second))
+	assert.Equal(t, "\n"+start.Add(4*time.Second).Format(time.Stamp)+"\n"+
+		"SERVICE                LINES/SEC    BYTES/SEC    TOTAL LINES    TOTAL BYTES\n"+
+		"worker                 1.0          3B           2              6B\n"+
+		"web                    0.0          0B           4              4.0KiB\n"+
+		"worker (init: wait)    0.0          0B           1              8B\n",
+		out.String())
+}
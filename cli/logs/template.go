@@ -0,0 +1,95 @@
+package logs
+
+import (
+	"io/ioutil"
+	"strings"
+	"text/template"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// templateLogLine is the data passed to --format templates. The fields are
+// part of the CLI's interface, so they shouldn't be renamed.
+type templateLogLine struct {
+	// Service is the name of the service that logged the line.
+	Service string
+
+	// InitContainer is the init container that logged the line, if any.
+	InitContainer string
+
+	// Stream is the stream that the line was logged to, either "stdout" or
+	// "stderr", or empty if it isn't known.
+	Stream string
+
+	// Time is the time the line was logged, formatted according to
+	// --timestamp-format and --tz.
+	Time string
+
+	// Timestamp is the time the line was logged, for templates that format
+	// it themselves, such as `{{.Timestamp.Unix}}`.
+	Timestamp time.Time
+
+	// Level is the line's log level, such as "warn", or empty if it doesn't
+	// have a recognizable level.
+	Level string
+
+	// Message is the log message.
+	Message string
+}
+
+// formatTemplate returns a function that formats log lines with the
+// --format template. Each line of multi-line records is formatted
+// separately, so that post-processing tools see the same prefix on every
+// line.
+func formatTemplate(text, timestampFormat string, loc *time.Location) (func(parsedLogLine) string, error) {
+	tmpl, err := template.New("format").Parse(text)
+	if err != nil {
+		return nil, errors.NewFriendlyError("Failed to parse --format template %q: %s", text, err)
+	}
+
+	// Execute the template once so that references to unknown fields are
+	// reported before any logs are printed.
+	if err := tmpl.Execute(ioutil.Discard, templateLogLine{}); err != nil {
+		return nil, errors.NewFriendlyError("Invalid --format template %q: %s", text, err)
+	}
+
+	layout, ok := timestampFormats[timestampFormat]
+	if !ok {
+		layout = timestampFormat
+	}
+
+	return func(line parsedLogLine) string {
+		loggedAt := line.loggedAt
+		if loc != nil {
+			loggedAt = loggedAt.In(loc)
+		}
+
+		var level string
+		if idx, ok := detectLevel(line.message); ok {
+			level = logLevels[idx]
+		}
+
+		var formatted []string
+		for _, message := range strings.Split(line.message, "\n") {
+			var out strings.Builder
+			err := tmpl.Execute(&out, templateLogLine{
+				Service:       line.fromContainer,
+				InitContainer: line.initContainer,
+				Stream:        line.outputStream,
+				Time:          loggedAt.Format(layout),
+				Timestamp:     loggedAt,
+				Level:         level,
+				Message:       message,
+			})
+			if err != nil {
+				log.WithError(err).Warn("Failed to execute --format template")
+				return line.message
+			}
+			formatted = append(formatted, out.String())
+		}
+		return strings.Join(formatted, "\n")
+	}, nil
+}
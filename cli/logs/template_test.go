@@ -0,0 +1,106 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTemplate(t *testing.T) {
+	loggedAt := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		template        string
+		timestampFormat string
+		loc             *time.Location
+		line            parsedLogLine
+		expErr          bool
+		expOutput       string
+	}{
+		{
+			name:      "Service and message",
+			template:  "{{.Service}} | {{.Message}}",
+			line:      parsedLogLine{fromContainer: "web", message: "Starting server"},
+			expOutput: "web | Starting server",
+		},
+		{
+			name:      "Init container and stream",
+			template:  "{{.Service}}/{{.InitContainer}} {{.Stream}}: {{.Message}}",
+			line:      parsedLogLine{fromContainer: "web", initContainer: "wait", outputStream: OutputStreamStderr, message: "Waiting"},
+			expOutput: "web/wait stderr: Waiting",
+		},
+		{
+			name:            "Named timestamp format",
+			template:        "[{{.Time}}] {{.Message}}",
+			timestampFormat: "time",
+			line:            parsedLogLine{message: "Starting server", loggedAt: loggedAt},
+			expOutput:       "[12:00:00.000] Starting server",
+		},
+		{
+			name:            "Go time layout and timezone",
+			template:        "[{{.Time}}] {{.Message}}",
+			timestampFormat: "2006-01-02 15:04 MST",
+			loc:             newYork,
+			line:            parsedLogLine{message: "Starting server", loggedAt: loggedAt},
+			expOutput:       "[2020-06-01 08:00 EDT] Starting server",
+		},
+		{
+			name:      "Raw timestamp",
+			template:  "{{.Timestamp.Unix}} {{.Message}}",
+			line:      parsedLogLine{message: "Starting server", loggedAt: loggedAt},
+			expOutput: "1591012800 Starting server",
+		},
+		{
+			name:      "Level",
+			template:  "{{.Level}}: {{.Message}}",
+			line:      parsedLogLine{message: "level=warning Disk is almost full"},
+			expOutput: "warn: level=warning Disk is almost full",
+		},
+		{
+			name:      "No level",
+			template:  "[{{.Level}}] {{.Message}}",
+			line:      parsedLogLine{message: "Starting server"},
+			expOutput: "[] Starting server",
+		},
+		{
+			name:     "Multi-line records are formatted line by line",
+			template: "{{.Service}} {{.Level}} | {{.Message}}",
+			line: parsedLogLine{
+				fromContainer: "web",
+				message:       "ERROR Request failed\n  at handler.go:10\n  at main.go:5",
+			},
+			expOutput: "web error | ERROR Request failed\n" +
+				"web error |   at handler.go:10\n" +
+				"web error |   at main.go:5",
+		},
+		{
+			name:     "Parse error",
+			template: "{{.Message",
+			expErr:   true,
+		},
+		{
+			name:     "Unknown field",
+			template: "{{.Container}} {{.Message}}",
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			format, err := formatTemplate(test.template, test.timestampFormat, test.loc)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expOutput, format(test.line))
+		})
+	}
+}
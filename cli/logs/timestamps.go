@@ -0,0 +1,137 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+const (
+	// TimestampSourceCluster orders logs by the time that the cluster
+	// received them.
+	TimestampSourceCluster = "cluster"
+
+	// TimestampSourceApp orders logs by the timestamp that the application
+	// prepended to the message, if any. Logs without a recognizable
+	// timestamp fall back to the cluster's timestamp.
+	TimestampSourceApp = "app"
+)
+
+// appTimestampPattern matches timestamps at the start of a log message, such
+// as `2020-06-01T12:00:00.123Z`, `[2020-06-01 12:00:00,123]`, or
+// `2020-06-01 12:00:00 +0000`.
+var appTimestampPattern = regexp.MustCompile(
+	`^\[?(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?: ?(?:Z|[+-]\d{2}:?\d{2}))?)\]?(?:\s+|$)`)
+
+// appTimestampLayouts are the layouts tried when parsing the timestamps
+// matched by appTimestampPattern. The date and time are always separated by a
+// T, and commas in fractional seconds are normalized to periods before
+// parsing.
+var appTimestampLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999 Z07:00",
+	"2006-01-02T15:04:05.999999999 -0700",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// parseAppTimestamp detects a timestamp that the application prepended to
+// the message. It returns the timestamp, and the message with the timestamp
+// removed. Timestamps without a timezone are assumed to be UTC, since that's
+// the default timezone in containers.
+func parseAppTimestamp(message string) (time.Time, string, bool) {
+	match := appTimestampPattern.FindStringSubmatchIndex(message)
+	if match == nil {
+		return time.Time{}, "", false
+	}
+
+	rawTimestamp := message[match[2]:match[3]]
+	rawTimestamp = strings.Replace(rawTimestamp, ",", ".", 1)
+	rawTimestamp = rawTimestamp[:10] + "T" + rawTimestamp[11:]
+	for _, layout := range appTimestampLayouts {
+		if timestamp, err := time.Parse(layout, rawTimestamp); err == nil {
+			return timestamp, message[match[1]:], true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// withAppTimestamps wraps `parse` to handle timestamps prepended by the
+// application according to the user's preferences.
+func withAppTimestamps(parse func(rawLogLine) parsedLogLine, source string, strip bool) func(rawLogLine) parsedLogLine {
+	if source != TimestampSourceApp && !strip {
+		return parse
+	}
+
+	return func(rawLog rawLogLine) parsedLogLine {
+		parsed := parse(rawLog)
+		timestamp, stripped, ok := parseAppTimestamp(parsed.message)
+		if !ok {
+			return parsed
+		}
+
+		if source == TimestampSourceApp {
+			parsed.loggedAt = timestamp
+		}
+		if strip {
+			parsed.message = stripped
+		}
+		return parsed
+	}
+}
+
+// timestampFormats are the named formats accepted by --timestamp-format. Any
+// other value is used as a Go time layout.
+var timestampFormats = map[string]string{
+	"rfc3339": "2006-01-02T15:04:05.000Z07:00",
+	"time":    "15:04:05.000",
+}
+
+// DefaultTimestampFormat is the format used for --timestamps if no format is
+// specified.
+const DefaultTimestampFormat = "rfc3339"
+
+// TimezoneLocal converts timestamps to the machine's local timezone.
+const TimezoneLocal = "local"
+
+// parseTimezone returns the location for the --tz flag, which is either
+// TimezoneLocal or an IANA timezone name such as "America/New_York". It
+// returns nil if `name` is empty, in which case timestamps are printed in the
+// timezone they were logged in.
+func parseTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return nil, nil
+	case TimezoneLocal:
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.NewFriendlyError("Unknown --tz value %q. It must be %q, or a timezone "+
+			"name such as UTC or America/New_York.", name, TimezoneLocal)
+	}
+	return loc, nil
+}
+
+// withTimestamps prefixes each formatted log line with the time it was
+// logged, so that events can be correlated across services. If `loc` is
+// set, the timestamps are converted to it.
+func withTimestamps(format func(parsedLogLine) string, timestampFormat string,
+	loc *time.Location) func(parsedLogLine) string {
+
+	layout, ok := timestampFormats[timestampFormat]
+	if !ok {
+		layout = timestampFormat
+	}
+
+	return func(line parsedLogLine) string {
+		loggedAt := line.loggedAt
+		if loc != nil {
+			loggedAt = loggedAt.In(loc)
+		}
+		return loggedAt.Format(layout) + " " + format(line)
+	}
+}
@@ -0,0 +1,134 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppTimestamp(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		expOK      bool
+		expTime    time.Time
+		expMessage string
+	}{
+		{
+			name:       "RFC3339",
+			message:    "2020-06-01T12:00:00.123Z Starting server",
+			expOK:      true,
+			expTime:    time.Date(2020, 6, 1, 12, 0, 0, 123000000, time.UTC),
+			expMessage: "Starting server",
+		},
+		{
+			name:       "Bracketed with comma",
+			message:    "[2020-06-01 12:00:00,123] Starting server",
+			expOK:      true,
+			expTime:    time.Date(2020, 6, 1, 12, 0, 0, 123000000, time.UTC),
+			expMessage: "Starting server",
+		},
+		{
+			name:       "Numeric offset",
+			message:    "2020-06-01 12:00:00 +0200 Starting server",
+			expOK:      true,
+			expTime:    time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+			expMessage: "Starting server",
+		},
+		{
+			name:       "No timezone",
+			message:    "2020-06-01T12:00:00 Starting server",
+			expOK:      true,
+			expTime:    time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+			expMessage: "Starting server",
+		},
+		{
+			name:    "No timestamp",
+			message: "Starting server",
+		},
+		{
+			name:    "Timestamp in middle of message",
+			message: "Started at 2020-06-01T12:00:00Z",
+		},
+		{
+			name:    "Date without time",
+			message: "2020-06-01 Starting server",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			timestamp, message, ok := parseAppTimestamp(test.message)
+			assert.Equal(t, test.expOK, ok)
+			if test.expOK {
+				assert.True(t, test.expTime.Equal(timestamp), "expected %s, got %s", test.expTime, timestamp)
+				assert.Equal(t, test.expMessage, message)
+			}
+		})
+	}
+}
+
+func TestWithAppTimestamps(t *testing.T) {
+	clusterTime := time.Date(2020, 6, 1, 12, 0, 5, 0, time.UTC)
+	appTime := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	parse := func(rawLogLine) parsedLogLine {
+		return parsedLogLine{
+			message:  "2020-06-01T12:00:00Z Starting server",
+			loggedAt: clusterTime,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		source     string
+		strip      bool
+		expTime    time.Time
+		expMessage string
+	}{
+		{
+			name:       "Cluster source",
+			source:     TimestampSourceCluster,
+			expTime:    clusterTime,
+			expMessage: "2020-06-01T12:00:00Z Starting server",
+		},
+		{
+			name:       "App source",
+			source:     TimestampSourceApp,
+			expTime:    appTime,
+			expMessage: "2020-06-01T12:00:00Z Starting server",
+		},
+		{
+			name:       "App source and strip",
+			source:     TimestampSourceApp,
+			strip:      true,
+			expTime:    appTime,
+			expMessage: "Starting server",
+		},
+		{
+			name:       "Cluster source and strip",
+			source:     TimestampSourceCluster,
+			strip:      true,
+			expTime:    clusterTime,
+			expMessage: "Starting server",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			parsed := withAppTimestamps(parse, test.source, test.strip)(rawLogLine{})
+			assert.True(t, test.expTime.Equal(parsed.loggedAt), "expected %s, got %s", test.expTime, parsed.loggedAt)
+			assert.Equal(t, test.expMessage, parsed.message)
+		})
+	}
+
+	// Messages without a timestamp keep the cluster's timestamp.
+	noTimestamp := func(rawLogLine) parsedLogLine {
+		return parsedLogLine{message: "Starting server", loggedAt: clusterTime}
+	}
+	parsed := withAppTimestamps(noTimestamp, TimestampSourceApp, true)(rawLogLine{})
+	assert.True(t, clusterTime.Equal(parsed.loggedAt))
+	assert.Equal(t, "Starting server", parsed.message)
+}
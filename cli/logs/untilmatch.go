@@ -0,0 +1,39 @@
+package logs
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// untilMatcher stops following the logs once a printed line matches
+// --until-match, so that scripts can wait for a service to log that it's
+// ready.
+type untilMatcher struct {
+	pattern *regexp.Regexp
+
+	// stop cancels the context that printLogs is running with.
+	stop context.CancelFunc
+
+	// matched is set once a line matched.
+	matched bool
+}
+
+func newUntilMatcher(pattern string, stop context.CancelFunc) (*untilMatcher, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.NewFriendlyError("Invalid --until-match pattern %q: %s", pattern, err)
+	}
+	return &untilMatcher{pattern: re, stop: stop}, nil
+}
+
+// check stops the logs if the printed line matches.
+func (m *untilMatcher) check(log parsedLogLine) {
+	if m.matched || !m.pattern.MatchString(log.message) {
+		return
+	}
+
+	m.matched = true
+	m.stop()
+}
@@ -0,0 +1,30 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUntilMatcher(t *testing.T) {
+	var stops int
+	stop := func() { stops++ }
+
+	m, err := newUntilMatcher(`listening on :\d+`, stop)
+	assert.NoError(t, err)
+
+	m.check(parsedLogLine{message: "Starting server"})
+	assert.False(t, m.matched)
+	assert.Equal(t, 0, stops)
+
+	m.check(parsedLogLine{message: "Server listening on :8080"})
+	assert.True(t, m.matched)
+	assert.Equal(t, 1, stops)
+
+	// Later matches don't stop the logs again.
+	m.check(parsedLogLine{message: "Server listening on :8081"})
+	assert.Equal(t, 1, stops)
+
+	_, err = newUntilMatcher("(", stop)
+	assert.Error(t, err)
+}
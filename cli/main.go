@@ -9,18 +9,36 @@ import (
 	"github.com/buger/goterm"
 	"github.com/spf13/cobra"
 
+	"github.com/kelda/blimp/cli/api"
+	"github.com/kelda/blimp/cli/attach"
+	"github.com/kelda/blimp/cli/audit"
 	"github.com/kelda/blimp/cli/authstore"
 	"github.com/kelda/blimp/cli/bugtool"
+	"github.com/kelda/blimp/cli/clone"
+	"github.com/kelda/blimp/cli/completion"
 	"github.com/kelda/blimp/cli/cp"
+	"github.com/kelda/blimp/cli/curl"
 	"github.com/kelda/blimp/cli/down"
+	"github.com/kelda/blimp/cli/env"
 	"github.com/kelda/blimp/cli/exec"
+	"github.com/kelda/blimp/cli/expose"
+	"github.com/kelda/blimp/cli/graph"
+	"github.com/kelda/blimp/cli/history"
+	"github.com/kelda/blimp/cli/image"
 	"github.com/kelda/blimp/cli/login"
 	"github.com/kelda/blimp/cli/loginpw"
 	"github.com/kelda/blimp/cli/logs"
 	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/prune"
 	"github.com/kelda/blimp/cli/ps"
+	"github.com/kelda/blimp/cli/restart"
+	"github.com/kelda/blimp/cli/schedule"
 	"github.com/kelda/blimp/cli/ssh"
+	"github.com/kelda/blimp/cli/status"
+	"github.com/kelda/blimp/cli/sync"
+	"github.com/kelda/blimp/cli/tunnel"
 	"github.com/kelda/blimp/cli/up"
+	"github.com/kelda/blimp/cli/usage"
 	"github.com/kelda/blimp/pkg/analytics"
 	"github.com/kelda/blimp/pkg/auth"
 	"github.com/kelda/blimp/pkg/cfgdir"
@@ -48,33 +66,83 @@ func main() {
 	rootCmd := &cobra.Command{
 		Use: "blimp",
 
-		PersistentPreRun:  setupAnalytics,
-		PersistentPostRun: closeManager,
+		PersistentPreRun:  preRun,
+		PersistentPostRun: postRun,
 
 		// The call to rootCmd.Execute prints the error, so we silence errors
 		// here to avoid double printing.
 		SilenceErrors: true,
 	}
 	rootCmd.AddCommand(
+		api.New(),
+		attach.New(),
+		audit.New(),
 		bugtool.New(),
+		clone.New(),
+		completion.New(),
 		cp.New(),
+		curl.New(),
 		down.New(),
+		env.New(),
 		exec.New(),
+		expose.New(),
+		graph.New(),
+		history.New(),
+		history.NewReplayCommand(),
+		image.New(),
 		login.New(),
 		loginpw.New(),
 		logs.New(),
+		prune.New(),
 		ps.New(),
+		restart.New(),
+		schedule.New(),
+		logs.NewSearchCommand(),
 		ssh.New(),
+		status.New(),
+		sync.New(),
+		tunnel.New(),
 		up.New(),
+		usage.New(),
 	)
 
+	// Commands that exit early, such as through errors.HandleFatalError or
+	// log.Fatal, don't run PersistentPostRun, so their result is recorded
+	// when they exit.
+	errors.RegisterExitHandler(func(code int) {
+		var err error
+		if code != 0 {
+			err = errors.New("exited with code %d", code)
+		}
+		history.Finish(err)
+	})
+	log.StandardLogger().ExitFunc = errors.Exit
+
 	if err := rootCmd.Execute(); err != nil {
+		history.Finish(err)
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+func preRun(cmd *cobra.Command, args []string) {
+	history.Start(cmd)
+	setupAnalytics(cmd, args)
+}
+
+func postRun(cmd *cobra.Command, args []string) {
+	history.Finish(nil)
+	closeManager(cmd, args)
+}
+
 func setupAnalytics(cmd *cobra.Command, _ []string) {
+	// Shell completions run on every keypress, and should work offline. The
+	// version check could also print a message that corrupts the output.
+	switch cmd.Name() {
+	case "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return
+	}
+
 	if err := manager.SetupClient(); err != nil {
 		log.WithError(err).Fatal("Failed to connect to the Blimp cluster")
 	}
@@ -117,7 +185,10 @@ func getNamespace() string {
 }
 
 func closeManager(_ *cobra.Command, _ []string) {
-	manager.C.Close()
+	// The client isn't set up for shell completions.
+	if manager.C.ClientConn != nil {
+		manager.C.Close()
+	}
 }
 
 func configureLogrus() {
@@ -216,6 +287,8 @@ func (f formatter) Format(e *log.Entry) ([]byte, error) {
 	fmt.Fprintf(os.Stderr,
 		goterm.Color("[Error] Get help at https://kelda.io/blimp/docs/help/", goterm.RED)+"\n"+
 			body)
-	os.Exit(1)
-	return nil, errors.New("unreached")
+
+	// Logrus exits through errors.Exit after the entry is written. Exiting
+	// here would run the exit handlers while Logrus holds its lock.
+	return nil, nil
 }
@@ -0,0 +1,39 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// healthService is the name that the manager reports its health under in
+// the standard grpc.health.v1 service. It's the fully qualified name of the
+// Manager service, as recommended by the gRPC health checking protocol.
+const healthService = "blimp.cluster.v0.Manager"
+
+// healthCheckTimeout is how long to wait for the manager's health when
+// explaining why a request failed.
+const healthCheckTimeout = 5 * time.Second
+
+// checkHealth returns a friendly error if the manager reports that it isn't
+// ready to serve requests, such as while a self-hosted manager is starting
+// up. It returns nil if the manager is serving, or if its health can't be
+// determined, such as when the manager predates the health service.
+func checkHealth(c Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(c.ClientConn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: healthService,
+	})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		return nil
+	}
+
+	return errors.NewFriendlyError("The Blimp manager at %s isn't ready to serve requests yet. "+
+		"If it was just started, wait a minute and try again. Otherwise, ask your "+
+		"administrator to check the manager's readiness probe.", Host)
+}
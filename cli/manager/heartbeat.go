@@ -0,0 +1,163 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+const (
+	// The heartbeat is sent frequently while the connection is unhealthy so
+	// that reconnects are noticed quickly, and backs off to
+	// maxHeartbeatInterval while the connection is healthy.
+	minHeartbeatInterval = 5 * time.Second
+	maxHeartbeatInterval = 30 * time.Second
+
+	// heartbeatTimeout is how long to wait for a heartbeat response before
+	// considering the connection stale. Without it, requests over a
+	// connection that broke while the machine was asleep can hang for
+	// minutes.
+	heartbeatTimeout = 10 * time.Second
+
+	// sleepThreshold is how much further the wall clock has to advance than
+	// the monotonic clock between heartbeats for the machine to be
+	// considered to have been asleep. The monotonic clock doesn't advance
+	// while the machine is suspended.
+	sleepThreshold = 10 * time.Second
+)
+
+// Reconnect describes what happened while the CLI was disconnected from the
+// manager.
+type Reconnect struct {
+	// How long the CLI was disconnected, or asleep.
+	Downtime time.Duration
+
+	// The services that restarted, exited, or were rescheduled while the
+	// CLI was disconnected.
+	RestartedServices []string
+}
+
+func (r Reconnect) String() string {
+	msg := fmt.Sprintf("Reconnected to your sandbox after %s", r.Downtime.Round(time.Second))
+	switch len(r.RestartedServices) {
+	case 0:
+		return msg + "."
+	case 1:
+		return fmt.Sprintf("%s; %s restarted while you were away.", msg, r.RestartedServices[0])
+	default:
+		return fmt.Sprintf("%s; %d services restarted while you were away (%s).",
+			msg, len(r.RestartedServices), strings.Join(r.RestartedServices, ", "))
+	}
+}
+
+// restartEvents are the events that mean that a service's containers were
+// restarted.
+var restartEvents = map[cluster.ServiceEvent_Type]struct{}{
+	cluster.ServiceEvent_EXITED:      {},
+	cluster.ServiceEvent_OOM_KILLED:  {},
+	cluster.ServiceEvent_RESTARTED:   {},
+	cluster.ServiceEvent_RESCHEDULED: {},
+}
+
+// WatchConnection periodically checks that the manager is reachable, and
+// calls `onReconnect` once it becomes reachable again after the connection
+// was lost, or the machine was asleep. Long-running commands use it so that
+// they can tell the user what changed in the sandbox rather than appearing
+// to hang. It returns once `ctx` is cancelled.
+func WatchConnection(ctx context.Context, authToken string, onReconnect func(Reconnect)) {
+	interval := minHeartbeatInterval
+
+	// The last time that the manager was known to be reachable. It's zero
+	// while the connection is healthy.
+	var staleSince time.Time
+	lastHeartbeat := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		// Round(0) strips the monotonic clock reading, so the difference
+		// between the two durations is the time spent asleep.
+		now := time.Now()
+		wallElapsed := now.Round(0).Sub(lastHeartbeat.Round(0))
+		if staleSince.IsZero() && wallElapsed-now.Sub(lastHeartbeat) > sleepThreshold {
+			log.WithField("elapsed", wallElapsed).Debug("Detected that the machine was asleep")
+			staleSince = lastHeartbeat
+		}
+
+		status, err := heartbeat(ctx, authToken)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.WithError(err).Debug("Manager heartbeat failed")
+			if staleSince.IsZero() {
+				staleSince = lastHeartbeat
+			}
+			interval = minHeartbeatInterval
+			continue
+		}
+
+		if !staleSince.IsZero() {
+			onReconnect(Reconnect{
+				Downtime:          time.Since(staleSince),
+				RestartedServices: getRestartedServices(ctx, authToken, status, staleSince),
+			})
+			staleSince = time.Time{}
+		}
+
+		lastHeartbeat = time.Now()
+		interval *= 2
+		if interval > maxHeartbeatInterval {
+			interval = maxHeartbeatInterval
+		}
+	}
+}
+
+func heartbeat(ctx context.Context, authToken string) (*cluster.SandboxStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, heartbeatTimeout)
+	defer cancel()
+
+	resp, err := C.GetStatus(ctx, &cluster.GetStatusRequest{Token: authToken})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetStatus(), nil
+}
+
+// getRestartedServices returns the services that restarted since the given
+// time, according to the service history recorded by the node controller.
+func getRestartedServices(ctx context.Context, authToken string,
+	status *cluster.SandboxStatus, since time.Time) []string {
+
+	var restarted []string
+	for svc := range status.GetServices() {
+		resp, err := C.GetServiceHistory(ctx, &cluster.GetServiceHistoryRequest{
+			Token:   authToken,
+			Service: svc,
+		})
+		if err != nil {
+			log.WithError(err).WithField("service", svc).Debug("Failed to get service history")
+			continue
+		}
+
+		for _, event := range resp.GetEvents() {
+			if _, ok := restartEvents[event.GetType()]; ok && event.GetTimestamp() >= since.Unix() {
+				restarted = append(restarted, svc)
+				break
+			}
+		}
+	}
+	sort.Strings(restarted)
+	return restarted
+}
@@ -62,6 +62,11 @@ func dial() (Client, error) {
 		Version: version.Version,
 	})
 	if err != nil {
+		// The manager might be reachable but still starting up, in which
+		// case its health service explains the failure better.
+		if healthErr := checkHealth(client); healthErr != nil {
+			return client, healthErr
+		}
 		return client, errors.WithContext("check version", err)
 	}
 
@@ -72,9 +77,9 @@ func dial() (Client, error) {
 	switch resp.Action {
 	case cluster.CLIAction_OK:
 	case cluster.CLIAction_EXIT:
-		os.Exit(1)
+		errors.Exit(1)
 	default:
-		os.Exit(1)
+		errors.Exit(1)
 	}
 
 	return client, nil
@@ -0,0 +1,132 @@
+package manager
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// globChars are the characters that make a service argument a glob pattern,
+// rather than a service name.
+const globChars = "*?["
+
+// ResolveServices expands the glob patterns in `args`, such as 'web-*',
+// against the services in the sandbox. Arguments without glob characters are
+// returned unchanged, so the sandbox's status is only fetched if there's a
+// pattern to expand. The order of the arguments is preserved, and services
+// matched by multiple arguments are only returned once.
+func ResolveServices(authToken string, args []string) ([]string, error) {
+	var hasGlob bool
+	for _, arg := range args {
+		if isGlob(arg) {
+			hasGlob = true
+			break
+		}
+	}
+	if !hasGlob {
+		return args, nil
+	}
+
+	statusResp, err := C.GetStatus(context.Background(), &cluster.GetStatusRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return nil, errors.WithContext("get status", err)
+	}
+
+	var services []string
+	for name := range statusResp.GetStatus().GetServices() {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+
+	return expandServiceGlobs(args, services)
+}
+
+func expandServiceGlobs(args, services []string) ([]string, error) {
+	var resolved []string
+	seen := map[string]bool{}
+	add := func(svc string) {
+		if !seen[svc] {
+			seen[svc] = true
+			resolved = append(resolved, svc)
+		}
+	}
+
+	for _, arg := range args {
+		if !isGlob(arg) {
+			add(arg)
+			continue
+		}
+
+		if err := checkPattern(arg); err != nil {
+			return nil, err
+		}
+
+		var matched bool
+		for _, svc := range services {
+			if matchPattern(arg, svc) {
+				matched = true
+				add(svc)
+			}
+		}
+
+		if !matched {
+			return nil, errors.NewFriendlyError("No services in your sandbox match %q. "+
+				"You can check which services are running with `blimp ps`.", arg)
+		}
+	}
+	return resolved, nil
+}
+
+// ExcludeServices returns the services that don't match any of the
+// patterns, such as the patterns passed to `blimp logs --exclude`. Patterns
+// are either service names, or globs such as 'worker-*'.
+func ExcludeServices(services, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if err := checkPattern(pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	var included []string
+	for _, svc := range services {
+		if !MatchesAny(svc, patterns) {
+			included = append(included, svc)
+		}
+	}
+	return included, nil
+}
+
+// MatchesAny returns whether the service name matches any of the patterns.
+// Invalid patterns don't match any services, so the patterns should be
+// checked beforehand, such as with ExcludeServices.
+func MatchesAny(svc string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, svc) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGlob(arg string) bool {
+	return strings.ContainsAny(arg, globChars)
+}
+
+func checkPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return errors.NewFriendlyError("Invalid service pattern %q. "+
+			"It must be a service name, or a glob such as 'web-*'.", pattern)
+	}
+	return nil
+}
+
+func matchPattern(pattern, svc string) bool {
+	ok, _ := path.Match(pattern, svc)
+	return ok
+}
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandServiceGlobs(t *testing.T) {
+	services := []string{"api", "web-1", "web-2", "worker"}
+	tests := []struct {
+		name   string
+		args   []string
+		exp    []string
+		expErr bool
+	}{
+		{
+			name: "Plain names",
+			args: []string{"worker", "api"},
+			exp:  []string{"worker", "api"},
+		},
+		{
+			name: "Glob",
+			args: []string{"web-*"},
+			exp:  []string{"web-1", "web-2"},
+		},
+		{
+			name: "Duplicates",
+			args: []string{"web-1", "web-?", "w*"},
+			exp:  []string{"web-1", "web-2", "worker"},
+		},
+		{
+			name:   "No matches",
+			args:   []string{"db-*"},
+			expErr: true,
+		},
+		{
+			name:   "Invalid pattern",
+			args:   []string{"web-["},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			resolved, err := expandServiceGlobs(test.args, services)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.exp, resolved)
+		})
+	}
+}
+
+func TestExcludeServices(t *testing.T) {
+	services := []string{"api", "web-1", "web-2", "worker"}
+	tests := []struct {
+		name     string
+		patterns []string
+		exp      []string
+		expErr   bool
+	}{
+		{
+			name: "No patterns",
+			exp:  services,
+		},
+		{
+			name:     "Name",
+			patterns: []string{"api"},
+			exp:      []string{"web-1", "web-2", "worker"},
+		},
+		{
+			name:     "Glob",
+			patterns: []string{"web-*", "worker"},
+			exp:      []string{"api"},
+		},
+		{
+			name:     "Unknown service",
+			patterns: []string{"db"},
+			exp:      services,
+		},
+		{
+			name:     "Everything",
+			patterns: []string{"*"},
+			exp:      nil,
+		},
+		{
+			name:     "Invalid pattern",
+			patterns: []string{"web-["},
+			expErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			included, err := ExcludeServices(services, test.patterns)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.exp, included)
+		})
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	patterns := []string{"api", "web-*"}
+	assert.True(t, MatchesAny("api", patterns))
+	assert.True(t, MatchesAny("web-1", patterns))
+	assert.False(t, MatchesAny("worker", patterns))
+	assert.False(t, MatchesAny("api", nil))
+}
@@ -0,0 +1,64 @@
+package projectcfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// LockFilename is the name of the file that pins the digests of the images
+// used by the project. Like the project config, it's meant to be checked into
+// source control so that everyone on the team boots the same images.
+const LockFilename = "blimp.lock"
+
+const lockHeader = "# This file was generated by `blimp up --pin`. Commit it so that everyone\n" +
+	"# boots the same images. Delete it, or run `blimp up --pin` again, to update the images.\n"
+
+// Lock pins image references to the digests that they resolved to when the
+// lockfile was generated.
+type Lock struct {
+	// Images maps image references, such as `redis:latest`, to digests, such
+	// as `sha256:...`.
+	Images map[string]string `json:"images"`
+}
+
+// LoadLock parses the lockfile in the given project directory. If the project
+// doesn't have a lockfile, an empty lock is returned.
+func LoadLock(projectDir string) (Lock, error) {
+	lockPath := filepath.Join(projectDir, LockFilename)
+	lockContents, err := ioutil.ReadFile(lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Lock{Images: map[string]string{}}, nil
+		}
+		return Lock{}, errors.WithContext("read lockfile", err)
+	}
+
+	var lock Lock
+	if err := yaml.Unmarshal(lockContents, &lock); err != nil {
+		return Lock{}, errors.NewFriendlyError("Failed to parse %s.\n\n"+
+			"The full error was:\n%s", lockPath, err)
+	}
+	if lock.Images == nil {
+		lock.Images = map[string]string{}
+	}
+	return lock, nil
+}
+
+// Save writes the lockfile to the given project directory.
+func (lock Lock) Save(projectDir string) error {
+	lockBytes, err := yaml.Marshal(lock)
+	if err != nil {
+		return errors.WithContext("marshal lockfile", err)
+	}
+
+	lockPath := filepath.Join(projectDir, LockFilename)
+	if err := ioutil.WriteFile(lockPath, append([]byte(lockHeader), lockBytes...), 0644); err != nil {
+		return errors.WithContext("write lockfile", err)
+	}
+	return nil
+}
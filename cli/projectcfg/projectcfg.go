@@ -0,0 +1,161 @@
+package projectcfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// Filename is the name of the project-level config file. It's stored in the
+// project directory, alongside the project's Docker Compose file, so that it
+// can be checked into source control and shared by the team.
+const Filename = "blimp.yaml"
+
+// Config is the project-level Blimp configuration. Settings that apply to a
+// single user are stored in ~/.blimp/blimp.yaml instead (see pkg/cfgdir).
+type Config struct {
+	// RegistryMirror is a registry that mirrors Docker Hub. If set, images
+	// from Docker Hub are pulled from the mirror instead. It takes precedence
+	// over the mirror configured for the organization.
+	RegistryMirror string `json:"registry_mirror"`
+
+	// EnvOverrides sets environment variables in the services when they're
+	// booted, without having to modify the Compose file. They take precedence
+	// over the environment in the Compose file, but `blimp up --env` takes
+	// precedence over them.
+	EnvOverrides []EnvOverride `json:"env_overrides"`
+
+	// ManagedTLS enables certificates for TLS between services. Each service
+	// gets a certificate for its service name, signed by a CA that's unique
+	// to the sandbox.
+	ManagedTLS bool `json:"managed_tls"`
+
+	// Strict makes `blimp up` fail if the Compose file uses features that
+	// Blimp would ignore. It can be overridden with `blimp up --strict`.
+	Strict bool `json:"strict"`
+
+	// StickyNode asks the cluster to schedule the sandbox on the same node
+	// each time it's recreated, so that the image layers and volume caches
+	// on that node are reused. It can be overridden with `blimp up
+	// --sticky-node`.
+	StickyNode bool `json:"sticky_node"`
+
+	// GitMetadataEnv sets the GIT_COMMIT and GIT_BRANCH environment
+	// variables in all services. The same values, along with BUILD_TIME, are
+	// always passed to image builds as build args.
+	GitMetadataEnv bool `json:"git_metadata_env"`
+
+	// Groups are named sets of services, such as `backend: [api, worker]`.
+	// Group names can be used anywhere a list of services is accepted, and
+	// are expanded with ExpandGroups.
+	Groups map[string][]string `json:"groups"`
+
+	// PortRemaps forwards published ports to different local ports, such as
+	// `web:3000: 3001`, so that projects that publish the same ports can run
+	// at the same time. The keys are in the form SERVICE:PUBLISHED_PORT.
+	PortRemaps map[string]uint32 `json:"port_remaps"`
+
+	// OnPortCollision is what `blimp up` does when a published port is
+	// already forwarded by another `blimp up`: "prompt", "remap", or "fail".
+	// It can be overridden with `blimp up --on-port-collision`.
+	OnPortCollision string `json:"on_port_collision"`
+
+	// Parallel limits how many operations of each kind `blimp up` runs at
+	// once, such as `push: 2`. The limits are capped by `blimp up
+	// --parallel`.
+	Parallel map[string]int `json:"parallel"`
+
+	// Logs configures the defaults for `blimp logs`, so that the team's
+	// conventions don't need to be passed as flags.
+	Logs LogsConfig `json:"logs"`
+}
+
+type LogsConfig struct {
+	// Services configures the logs of individual services, keyed by service
+	// name.
+	Services map[string]ServiceLogsConfig `json:"services"`
+
+	// Palette is the list of colors that services are assigned from, such
+	// as ["blue", "yellow", "white"]. Services with a Color are unaffected.
+	Palette []string `json:"palette"`
+
+	// Format is the default template for formatting log lines, such as
+	// "{{.Service}} [{{.Time}}] {{.Message}}". See `blimp logs --format`.
+	Format string `json:"format"`
+}
+
+type ServiceLogsConfig struct {
+	// Exclude hides the service's logs when the logs for all services are
+	// printed, such as after `blimp up`. They're still printed if the service
+	// is requested by name.
+	Exclude bool `json:"exclude"`
+
+	// MinLevel hides log lines below the given level, such as "warn". Lines
+	// without a recognizable level are always shown.
+	MinLevel string `json:"min_level"`
+
+	// Color is the color of the service's name in the log output, such as
+	// "blue". "none" prints the name without color.
+	Color string `json:"color"`
+}
+
+// EnvOverride sets environment variables in a set of services.
+type EnvOverride struct {
+	// Services are the services to set the variables in. If it's empty, the
+	// variables are set in all services.
+	Services []string `json:"services"`
+
+	Environment map[string]string `json:"environment"`
+}
+
+// Load parses the project config in the given project directory. If the
+// project doesn't have a config file, an empty config is returned.
+func Load(projectDir string) (Config, error) {
+	cfgPath := filepath.Join(projectDir, Filename)
+	cfgContents, err := ioutil.ReadFile(cfgPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Config{}, nil
+		}
+		return Config{}, errors.WithContext("read project config", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(cfgContents, &cfg); err != nil {
+		return Config{}, errors.NewFriendlyError("Failed to parse %s.\n\n"+
+			"The full error was:\n%s", cfgPath, err)
+	}
+	return cfg, nil
+}
+
+// ExpandGroups replaces the names of service groups with the services in
+// them. Names that aren't groups are assumed to be services, and returned
+// as is. Duplicate services are removed, while preserving the order in which
+// they were first referenced.
+func (cfg Config) ExpandGroups(names []string) []string {
+	var services []string
+	seen := map[string]struct{}{}
+	add := func(svc string) {
+		if _, ok := seen[svc]; !ok {
+			seen[svc] = struct{}{}
+			services = append(services, svc)
+		}
+	}
+
+	for _, name := range names {
+		group, ok := cfg.Groups[name]
+		if !ok {
+			add(name)
+			continue
+		}
+
+		for _, svc := range group {
+			add(svc)
+		}
+	}
+	return services
+}
@@ -0,0 +1,180 @@
+package prune
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/cfgdir"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	var dryRun bool
+	cobraCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove unused resources",
+		Long: "Remove unused resources.\n\n" +
+			"Prune removes volumes that aren't used by any service, unused images in " +
+			"the sandbox registry, expired public URLs, and stale local state in ~/.blimp.",
+		Run: func(_ *cobra.Command, args []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			if err := run(auth.AuthToken, dryRun); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&dryRun, "dry-run", "", false,
+		"Print what would be removed without removing anything")
+	return cobraCmd
+}
+
+func run(authToken string, dryRun bool) error {
+	resp, err := manager.C.Prune(context.Background(), &cluster.PruneRequest{
+		Token:  authToken,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return errors.WithContext("prune sandbox", err)
+	}
+
+	localFiles, err := pruneLocalState(dryRun)
+	if err != nil {
+		return errors.WithContext("prune local state", err)
+	}
+
+	if len(resp.Resources) == 0 && len(localFiles) == 0 {
+		fmt.Println("Nothing to prune.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME\tSIZE")
+	var total int64
+	for _, resource := range resp.Resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", getTypeString(resource.Type), resource.Name,
+			formatSize(resource.SizeBytes))
+		total += resource.SizeBytes
+	}
+	for _, file := range localFiles {
+		fmt.Fprintf(w, "Local state\t%s\t%s\n", file.path, formatSize(file.size))
+		total += file.size
+	}
+	w.Flush()
+
+	if dryRun {
+		fmt.Printf("\nWould free %s. Run without --dry-run to remove the resources.\n",
+			util.FormatBytes(total))
+	} else {
+		fmt.Printf("\nFreed %s.\n", util.FormatBytes(total))
+	}
+	return nil
+}
+
+type localFile struct {
+	path string
+	size int64
+}
+
+// staleLocalState are the files in the config directory that can be safely
+// removed when `blimp up` isn't running. Syncthing recreates its database on
+// the next run by rescanning the synced folders.
+var staleLocalState = []string{
+	"index-*.db",
+	"syncthing.log",
+	"syncthing.log.*",
+}
+
+// pruneLocalState removes stale files in the config directory, and returns
+// the files that were removed.
+func pruneLocalState(dryRun bool) ([]localFile, error) {
+	// The Syncthing state is in use while `blimp up` is running.
+	if util.UpRunning() {
+		fmt.Fprintln(os.Stderr, "Skipping local state since `blimp up` is running.")
+		return nil, nil
+	}
+
+	var paths []string
+	for _, pattern := range staleLocalState {
+		matches, err := filepath.Glob(cfgdir.Expand(pattern))
+		if err != nil {
+			return nil, errors.WithContext("glob", err)
+		}
+		paths = append(paths, matches...)
+	}
+
+	// The pidfile is stale since `blimp up` isn't running.
+	if _, err := os.Stat(cfgdir.Expand("up.pid")); err == nil {
+		paths = append(paths, cfgdir.Expand("up.pid"))
+	}
+
+	var removed []localFile
+	for _, path := range paths {
+		size, err := getSize(path)
+		if err != nil {
+			return nil, errors.WithContext("get size", err)
+		}
+
+		if !dryRun {
+			if err := os.RemoveAll(path); err != nil {
+				return nil, errors.WithContext("remove", err)
+			}
+		}
+		removed = append(removed, localFile{path, size})
+	}
+	return removed, nil
+}
+
+// getSize returns the size of the file, or the total size of the files in the
+// directory.
+func getSize(path string) (size int64, err error) {
+	err = filepath.Walk(path, func(_ string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func formatSize(size int64) string {
+	if size == 0 {
+		return "-"
+	}
+	return util.FormatBytes(size)
+}
+
+func getTypeString(resourceType cluster.PrunedResource_Type) string {
+	switch resourceType {
+	case cluster.PrunedResource_VOLUME:
+		return "Volume"
+	case cluster.PrunedResource_IMAGE:
+		return "Image"
+	case cluster.PrunedResource_EXPOSE_URL:
+		return "Public URL"
+	default:
+		return "Unknown"
+	}
+}
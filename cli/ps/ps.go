@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"text/tabwriter"
 
 	log "github.com/sirupsen/logrus"
@@ -29,7 +30,7 @@ func New() *cobra.Command {
 			// TODO: Prompt to login again if token is expired.
 			if auth.AuthToken == "" {
 				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
-				os.Exit(1)
+				errors.Exit(1)
 			}
 
 			if err := run(auth.AuthToken); err != nil {
@@ -48,6 +49,10 @@ func run(authToken string) error {
 	}
 
 	printStatus(*status.Status)
+	if violations := status.Status.GetPolicyViolations(); len(violations) != 0 {
+		fmt.Println()
+		PrintPolicyViolations(os.Stdout, violations)
+	}
 	return nil
 }
 
@@ -64,6 +69,21 @@ func printStatus(status cluster.SandboxStatus) {
 
 	for _, name := range serviceNames {
 		statusStr, _, _ := GetStatusString(status.Services[name])
+		if overrides := status.Services[name].GetEnvOverrides(); len(overrides) != 0 {
+			statusStr += fmt.Sprintf(" (temporary env: %s)", formatEnvOverrides(overrides))
+		}
 		fmt.Fprintf(w, "%s\t%s\n", name, statusStr)
 	}
 }
+
+// formatEnvOverrides formats the temporary environment variables set by
+// `blimp restart`, so that it's clear why a service behaves differently
+// from its Compose file.
+func formatEnvOverrides(env map[string]string) string {
+	var vars []string
+	for key, val := range env {
+		vars = append(vars, key+"="+val)
+	}
+	sort.Strings(vars)
+	return strings.Join(vars, ", ")
+}
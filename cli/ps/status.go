@@ -2,9 +2,12 @@ package ps
 
 import (
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/buger/goterm"
 
+	"github.com/kelda/blimp/cli/util"
 	"github.com/kelda/blimp/pkg/proto/cluster"
 	"github.com/kelda/blimp/pkg/syncthing"
 )
@@ -30,6 +33,12 @@ func GetStatusString(svcStatus *cluster.ServiceStatus) (msg string, color int, b
 	case cluster.ServicePhase_EXITED:
 		msg = "Exited"
 		color = goterm.RED
+		if svcStatus.IsJob {
+			msg = fmt.Sprintf("Failed with exit code %d", svcStatus.ExitCode)
+		}
+	case cluster.ServicePhase_COMPLETED:
+		msg = "Completed"
+		color = goterm.GREEN
 	}
 
 	if svcStatus.Msg != "" {
@@ -37,3 +46,24 @@ func GetStatusString(svcStatus *cluster.ServiceStatus) (msg string, color int, b
 	}
 	return msg, color, svcStatus.HasStarted
 }
+
+// GetUsageString returns a short summary of the given resource usage sample,
+// or the empty string if no sample is available.
+func GetUsageString(usage *cluster.ResourceUsage) string {
+	if usage == nil {
+		return ""
+	}
+	return fmt.Sprintf("CPU: %dm, Memory: %s",
+		usage.GetCpuMillicores(), util.FormatBytes(usage.GetMemoryBytes()))
+}
+
+// PrintPolicyViolations warns about the cleanup policies that the sandbox
+// violates, so that users can resolve them before they're enforced.
+func PrintPolicyViolations(out io.Writer, violations []*cluster.PolicyViolation) {
+	for _, violation := range violations {
+		enforceAt := time.Unix(violation.GetEnforceAt(), 0)
+		fmt.Fprintln(out, goterm.Color(fmt.Sprintf("Warning: %s The cluster will %s in %s, at %s.",
+			violation.GetMessage(), violation.GetAction(),
+			time.Until(enforceAt).Round(time.Minute), enforceAt.Format(time.Stamp)), goterm.YELLOW))
+	}
+}
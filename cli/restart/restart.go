@@ -0,0 +1,92 @@
+package restart
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	var envFlags []string
+	var untilDown bool
+	cobraCmd := &cobra.Command{
+		Use:   "restart SERVICE",
+		Short: "Restart a service, optionally with temporary environment variables",
+		Long: "Restart a single service without redeploying the rest of the sandbox.\n\n" +
+			"Environment variables passed with -e are set in addition to the ones in the " +
+			"Compose file, such as to turn on debug logging. They last until the next " +
+			"`blimp up` or `blimp down`, so they require --until-down to make that explicit. " +
+			"Services with temporary environment variables are marked in `blimp ps`. " +
+			"Restarting a service without -e clears its temporary environment variables.",
+		Example: `  blimp restart web -e DEBUG=1 --until-down`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			env, err := util.ParseEnv(envFlags)
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if len(env) != 0 && !untilDown {
+				errors.HandleFatalError(errors.NewFriendlyError(
+					"Temporary environment variables last until the next `blimp up` or `blimp down`.\n" +
+						"Please pass --until-down to confirm, or add them to your Compose file instead."))
+			}
+
+			if err := run(auth.AuthToken, args[0], env); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().StringArrayVarP(&envFlags, "env", "e", nil,
+		"Set a temporary environment variable in the form KEY=VALUE")
+	cobraCmd.Flags().BoolVarP(&untilDown, "until-down", "", false,
+		"Keep the environment variables until the next 'blimp up' or 'blimp down'")
+	return cobraCmd
+}
+
+func run(authToken, service string, env map[string]string) error {
+	_, err := manager.C.RestartService(context.Background(), &cluster.RestartServiceRequest{
+		Token:        authToken,
+		Service:      service,
+		EnvOverrides: env,
+	})
+	if err != nil {
+		return errors.WithContext("restart service", err)
+	}
+
+	if len(env) == 0 {
+		fmt.Printf("Restarted %s.\n", service)
+		return nil
+	}
+
+	var keys []string
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Printf("Restarted %s with %s until the next `blimp up` or `blimp down`.\n",
+		service, strings.Join(keys, ", "))
+	return nil
+}
@@ -0,0 +1,195 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/cron"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// supportedActions are the commands that can be run on a schedule.
+var supportedActions = []string{"up"}
+
+func New() *cobra.Command {
+	var clear bool
+	var timezone string
+	cobraCmd := &cobra.Command{
+		Use:   "schedule [CRON up]",
+		Short: "Boot your sandbox on a schedule",
+		Long: "Boot your sandbox on a schedule, so that it's warm before you start working.\n\n" +
+			"The cluster redeploys the most recently deployed Compose file at the times given by " +
+			"CRON, a five field cron expression such as \"0 8 * * 1-5\" (8am on weekdays). " +
+			"Sandboxes that aren't used are still shut down when they become idle.\n\n" +
+			"Run without arguments to show the current schedule.",
+		Example: `  blimp schedule "0 8 * * 1-5" up`,
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) != 0 && len(args) != 2 {
+				fmt.Fprintln(os.Stderr, "Expected a cron expression and an action, such as `blimp schedule \"0 8 * * 1-5\" up`")
+				errors.Exit(1)
+			}
+
+			if clear && len(args) != 0 {
+				fmt.Fprintln(os.Stderr, "A schedule can't be set and cleared at the same time")
+				errors.Exit(1)
+			}
+
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			switch {
+			case clear:
+				err = clearSchedule(auth.AuthToken)
+			case len(args) == 2:
+				err = setSchedule(auth.AuthToken, args[0], args[1], timezone)
+			default:
+				err = printSchedule(auth.AuthToken)
+			}
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&clear, "clear", "", false,
+		"Stop booting the sandbox on a schedule.")
+	cobraCmd.Flags().StringVarP(&timezone, "timezone", "", localTimezone(),
+		"The time zone to evaluate the schedule in, such as 'America/Los_Angeles'.")
+	return cobraCmd
+}
+
+func setSchedule(authToken, expr, action, timezone string) error {
+	if !isSupportedAction(action) {
+		return errors.NewFriendlyError("Unsupported action %q. Only %s can be scheduled.",
+			action, strings.Join(supportedActions, ", "))
+	}
+
+	if _, err := cron.Parse(expr); err != nil {
+		return errors.NewFriendlyError("Invalid cron expression %q: %s\n\n"+
+			"The expression must have five fields: minute, hour, day of month, month, and day of week. "+
+			"For example, \"0 8 * * 1-5\" runs at 8am on weekdays.", expr, err)
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.NewFriendlyError("Unknown time zone %q. "+
+			"It should be an IANA time zone name, such as 'America/Los_Angeles'.", timezone)
+	}
+
+	resp, err := manager.C.SetBootSchedule(context.Background(), &cluster.SetBootScheduleRequest{
+		Token:    authToken,
+		Cron:     expr,
+		Timezone: timezone,
+	})
+	if err != nil {
+		return errors.WithContext("set schedule", err)
+	}
+
+	if err := errors.Unmarshal(nil, resp.Error); err != nil {
+		return err
+	}
+
+	fmt.Printf("Your sandbox will be booted on the schedule %q (%s).\n", expr, timezone)
+	if resp.Schedule.GetNextBoot() != 0 {
+		fmt.Printf("Next boot: %s\n", formatTime(resp.Schedule.NextBoot, timezone))
+	}
+	return nil
+}
+
+func clearSchedule(authToken string) error {
+	resp, err := manager.C.SetBootSchedule(context.Background(), &cluster.SetBootScheduleRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return errors.WithContext("clear schedule", err)
+	}
+
+	if err := errors.Unmarshal(nil, resp.Error); err != nil {
+		return err
+	}
+
+	fmt.Println("Your sandbox will no longer be booted on a schedule.")
+	return nil
+}
+
+func printSchedule(authToken string) error {
+	resp, err := manager.C.GetBootSchedule(context.Background(), &cluster.GetBootScheduleRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return errors.WithContext("get schedule", err)
+	}
+
+	if err := errors.Unmarshal(nil, resp.Error); err != nil {
+		return err
+	}
+
+	schedule := resp.Schedule
+	if schedule == nil {
+		fmt.Println("Your sandbox isn't booted on a schedule.")
+		return nil
+	}
+
+	fmt.Printf("Schedule:  %s (%s)\n", schedule.Cron, schedule.Timezone)
+	if schedule.NextBoot != 0 {
+		fmt.Printf("Next boot: %s\n", formatTime(schedule.NextBoot, schedule.Timezone))
+	}
+	if schedule.LastBoot != 0 {
+		fmt.Printf("Last boot: %s\n", formatTime(schedule.LastBoot, schedule.Timezone))
+	}
+	if schedule.LastError != "" {
+		fmt.Printf("The last scheduled boot failed: %s\n", schedule.LastError)
+	}
+	return nil
+}
+
+func isSupportedAction(action string) bool {
+	for _, supported := range supportedActions {
+		if action == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func formatTime(unix int64, timezone string) string {
+	t := time.Unix(unix, 0)
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format("Mon Jan 2 15:04 MST")
+}
+
+// localTimezone returns the IANA name of the user's time zone, so that
+// schedules follow daylight saving time. It falls back to UTC if the name
+// can't be determined.
+func localTimezone() string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		return tz
+	}
+
+	// On Linux and macOS, /etc/localtime is a symlink into the zoneinfo
+	// database, such as /usr/share/zoneinfo/America/Los_Angeles.
+	link, err := filepath.EvalSymlinks("/etc/localtime")
+	if err == nil {
+		if i := strings.Index(link, "zoneinfo/"); i != -1 {
+			return link[i+len("zoneinfo/"):]
+		}
+	}
+	return "UTC"
+}
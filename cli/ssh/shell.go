@@ -0,0 +1,152 @@
+package ssh
+
+import (
+	"bytes"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/names"
+)
+
+// candidateShells are the shells that are tried, in order, when the user
+// doesn't specify a shell.
+var candidateShells = []string{"/bin/bash", "/bin/sh", "/bin/ash", "/busybox/sh"}
+
+const (
+	// debugContainer is the name of the ephemeral container that's injected
+	// into services that don't have a shell.
+	debugContainer = "blimp-debug"
+
+	// debugImage is the image for debugContainer. It contains a statically
+	// linked shell and core utilities.
+	debugImage = "busybox:1.32-musl"
+)
+
+// findShell returns the first candidate shell that exists in the service's
+// container, or false if none of them exist.
+func findShell(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	namespace, svc string) (string, bool) {
+	for _, shell := range candidateShells {
+		execOpts := core.PodExecOptions{
+			Command: []string{shell, "-c", "exit 0"},
+			Stdout:  true,
+			Stderr:  true,
+		}
+		exec, err := newExecutor(kubeClient, restConfig, namespace, svc, execOpts)
+		if err != nil {
+			log.WithError(err).Debug("Failed to setup shell probe")
+			continue
+		}
+
+		var output bytes.Buffer
+		err = exec.Stream(remotecommand.StreamOptions{Stdout: &output, Stderr: &output})
+		if err == nil {
+			return shell, true
+		}
+		log.WithError(err).WithField("shell", shell).WithField("output", output.String()).
+			Debug("Shell not found")
+	}
+	return "", false
+}
+
+// injectDebugContainer adds an ephemeral container with a shell to the
+// service's pod, and returns once it's running. The container shares the
+// service's process namespace, so the service's filesystem is available at
+// /proc/1/root.
+func injectDebugContainer(kubeClient kubernetes.Interface, namespace, svc string) error {
+	pods := kubeClient.CoreV1().Pods(namespace)
+	podName := names.PodName(svc)
+	pod, err := pods.Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return errors.WithContext("get pod", err)
+	}
+
+	var injected bool
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == debugContainer {
+			injected = true
+			break
+		}
+	}
+
+	if !injected {
+		var target string
+		for _, container := range pod.Spec.Containers {
+			if container.Name != names.LogCaptureContainer {
+				target = container.Name
+				break
+			}
+		}
+
+		ephemeral, err := pods.GetEphemeralContainers(podName, metav1.GetOptions{})
+		if err != nil {
+			return errors.WithContext("get ephemeral containers", err)
+		}
+
+		ephemeral.EphemeralContainers = append(ephemeral.EphemeralContainers, core.EphemeralContainer{
+			EphemeralContainerCommon: core.EphemeralContainerCommon{
+				Name:    debugContainer,
+				Image:   debugImage,
+				Command: []string{"sleep", "infinity"},
+			},
+			TargetContainerName: target,
+		})
+		if _, err := pods.UpdateEphemeralContainers(podName, ephemeral); err != nil {
+			return errors.WithContext("inject debug container", err)
+		}
+	}
+
+	// waitingReason is why the debug container hasn't started yet, such as
+	// ImagePullBackOff, so that timeouts can be explained.
+	var waitingReason string
+	err = wait.PollImmediate(time.Second, 2*time.Minute, func() (bool, error) {
+		pod, err := pods.Get(podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name != debugContainer {
+				continue
+			}
+
+			if status.State.Running != nil {
+				return true, nil
+			}
+			if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+				waitingReason = waiting.Reason
+				if waiting.Message != "" {
+					waitingReason += ": " + waiting.Message
+				}
+			}
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout && waitingReason != "" {
+		return errors.NewFriendlyError("Timed out waiting for the debug container to start (%s).", waitingReason)
+	}
+	if err != nil {
+		return errors.WithContext("wait for debug container", err)
+	}
+	return nil
+}
+
+func newExecutor(kubeClient kubernetes.Interface, restConfig *rest.Config,
+	namespace, svc string, execOpts core.PodExecOptions) (remotecommand.Executor, error) {
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		SubResource("exec").
+		Name(names.PodName(svc)).
+		Namespace(namespace).
+		VersionedParams(&execOpts, scheme.ParameterCodec)
+	return remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+}
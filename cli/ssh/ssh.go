@@ -3,37 +3,47 @@ package ssh
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 	core "k8s.io/api/core/v1"
-	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
 
+	"github.com/kelda/blimp/cli/audit"
 	"github.com/kelda/blimp/cli/authstore"
 	"github.com/kelda/blimp/cli/manager"
 	"github.com/kelda/blimp/pkg/errors"
-	"github.com/kelda/blimp/pkg/names"
 )
 
 func New() *cobra.Command {
-	return &cobra.Command{
+	var shell string
+	cobraCmd := &cobra.Command{
 		Use:   "ssh SERVICE",
 		Short: "Get a shell in a service",
+		Long: "Get a shell in a service.\n\n" +
+			"The service may be a glob such as 'web-*', as long as it matches exactly one service. " +
+			"By default, the first shell found out of bash, sh, and ash is used. If the " +
+			"service doesn't have a shell, a debug container with a busybox shell is " +
+			"attached to the service. The service's filesystem is available at /proc/1/root " +
+			"within the debug container.",
 		Run: func(_ *cobra.Command, args []string) {
 			if len(args) != 1 {
 				fmt.Fprintf(os.Stderr, "Exactly one service is required")
-				os.Exit(1)
+				errors.Exit(1)
 			}
 
-			if err := run(args[0]); err != nil {
+			if err := run(args[0], shell); err != nil {
 				errors.HandleFatalError(err)
 			}
 		},
 	}
+	cobraCmd.Flags().StringVarP(&shell, "shell", "", "",
+		"The shell to run, such as /bin/zsh. By default, the first available shell is used")
+	return cobraCmd
 }
 
-func run(svc string) error {
+func run(svc, shell string) error {
 	auth, err := authstore.New()
 	if err != nil {
 		return errors.WithContext("parse auth config", err)
@@ -44,6 +54,18 @@ func run(svc string) error {
 		return nil
 	}
 
+	// The service may be a glob, such as 'web-*', as long as it matches
+	// exactly one service.
+	services, err := manager.ResolveServices(auth.AuthToken, []string{svc})
+	if err != nil {
+		return err
+	}
+	if len(services) != 1 {
+		return errors.NewFriendlyError("%q matches multiple services: %s.\n"+
+			"Please choose one of them.", svc, strings.Join(services, ", "))
+	}
+	svc = services[0]
+
 	// Make sure the pod is actually booted.
 	err = manager.CheckServiceRunning(svc, auth.AuthToken)
 	if err != nil {
@@ -55,6 +77,20 @@ func run(svc string) error {
 		return errors.WithContext("get kube client", err)
 	}
 
+	var container string
+	if shell == "" {
+		var ok bool
+		shell, ok = findShell(kubeClient, restConfig, auth.KubeNamespace, svc)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "%s doesn't have a shell. Attaching a debug container with a busybox shell.\n"+
+				"The service's filesystem is available at /proc/1/root.\n", svc)
+			if err := injectDebugContainer(kubeClient, auth.KubeNamespace, svc); err != nil {
+				return errors.WithContext("attach debug container", err)
+			}
+			shell, container = "sh", debugContainer
+		}
+	}
+
 	// Put the terminal into raw mode to prevent it echoing characters twice.
 	oldState, err := terminal.MakeRaw(0)
 	if err != nil {
@@ -66,26 +102,24 @@ func run(svc string) error {
 	}()
 
 	execOpts := core.PodExecOptions{
-		Command: []string{"sh"},
-		Stdin:   true,
-		Stdout:  true,
-		Stderr:  true,
-		TTY:     true,
+		Container: container,
+		Command:   []string{shell},
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    true,
+		TTY:       true,
 	}
+	recorder := audit.StartRecording(auth.AuthToken, svc, execOpts.Command)
+	defer recorder.Close()
+
 	streamOpts := remotecommand.StreamOptions{
-		Stdin:  os.Stdin,
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
+		Stdin:  recorder.Stdin(os.Stdin),
+		Stdout: recorder.Stdout(os.Stdout),
+		Stderr: recorder.Stdout(os.Stderr),
 		Tty:    true,
 	}
 
-	req := kubeClient.CoreV1().RESTClient().Post().
-		Resource("pods").
-		SubResource("exec").
-		Name(names.PodName(svc)).
-		Namespace(auth.KubeNamespace).
-		VersionedParams(&execOpts, scheme.ParameterCodec)
-	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	exec, err := newExecutor(kubeClient, restConfig, auth.KubeNamespace, svc, execOpts)
 	if err != nil {
 		return errors.WithContext("setup remote shell", err)
 	}
@@ -0,0 +1,92 @@
+package status
+
+import (
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// The conditions that --wait-for accepts.
+const (
+	// ConditionStarted waits for the services' containers to start.
+	ConditionStarted = "started"
+
+	// ConditionRunning waits for the services to be running, whether or not
+	// they pass their healthchecks.
+	ConditionRunning = "running"
+
+	// ConditionHealthy waits for the services to be running and pass their
+	// healthchecks.
+	ConditionHealthy = "healthy"
+)
+
+// The reasons derived from the service's phase when the manager doesn't
+// report the readiness itself. They're part of the JSON output, so they
+// shouldn't be renamed.
+var phaseReasons = map[cluster.ServicePhase]string{
+	cluster.ServicePhase_UNKNOWN:              "Unknown",
+	cluster.ServicePhase_INITIALIZING_VOLUMES: "InitializingVolumes",
+	cluster.ServicePhase_WAIT_DEPENDS_ON:      "WaitingForDependencies",
+	cluster.ServicePhase_WAIT_SYNC_BIND:       "SyncingFiles",
+	cluster.ServicePhase_PENDING:              "Pending",
+	cluster.ServicePhase_RUNNING:              "Healthy",
+	cluster.ServicePhase_EXITED:               "Exited",
+	cluster.ServicePhase_UNHEALTHY:            "Unhealthy",
+	cluster.ServicePhase_COMPLETED:            "Completed",
+}
+
+// ServiceReadiness is the JSON representation of a service's readiness.
+type ServiceReadiness struct {
+	Phase string `json:"phase"`
+
+	// Ready is whether the service meets the condition that was waited
+	// for, or is healthy if no condition was given.
+	Ready bool `json:"ready"`
+
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+
+	// Failed is true if the service can't become ready without being
+	// redeployed, such as a job that exited with an error.
+	Failed bool `json:"failed,omitempty"`
+}
+
+// GetReadiness returns the service's readiness according to `condition`. If
+// the condition is empty, the service must be healthy to be ready. The
+// reason explains what the service is waiting for, so that commands that
+// wait on services can tell the user why, rather than blocking silently.
+func GetReadiness(svcStatus *cluster.ServiceStatus, condition string) ServiceReadiness {
+	phase := svcStatus.GetPhase()
+	readiness := ServiceReadiness{
+		Phase:   phase.String(),
+		Reason:  phaseReasons[phase],
+		Message: svcStatus.GetMsg(),
+		Failed:  svcStatus.GetIsJob() && phase == cluster.ServicePhase_EXITED,
+	}
+	if readiness.Failed {
+		readiness.Reason = "JobFailed"
+	}
+
+	// The manager's reasons are more specific, such as why a pending pod
+	// hasn't started.
+	if managerReadiness := svcStatus.GetReadiness(); managerReadiness != nil {
+		readiness.Reason = managerReadiness.GetReason()
+		if managerReadiness.GetMessage() != "" {
+			readiness.Message = managerReadiness.GetMessage()
+		}
+	}
+
+	switch condition {
+	case ConditionStarted:
+		readiness.Ready = svcStatus.GetHasStarted()
+	case ConditionRunning:
+		readiness.Ready = phase == cluster.ServicePhase_RUNNING ||
+			phase == cluster.ServicePhase_UNHEALTHY ||
+			phase == cluster.ServicePhase_COMPLETED
+	default:
+		if managerReadiness := svcStatus.GetReadiness(); managerReadiness != nil {
+			readiness.Ready = managerReadiness.GetReady()
+		} else {
+			readiness.Ready = phase == cluster.ServicePhase_RUNNING || phase == cluster.ServicePhase_COMPLETED
+		}
+	}
+	return readiness
+}
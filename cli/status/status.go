@@ -0,0 +1,304 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// The formats accepted by --output.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// Status is the JSON representation of the sandbox's readiness. It's
+// intended for tools that wait on the sandbox, so fields shouldn't be
+// renamed.
+type Status struct {
+	// SandboxPhase is the phase of the sandbox as a whole, such as RUNNING.
+	SandboxPhase string `json:"sandbox_phase"`
+
+	// Ready is true if the sandbox is running, and all the services are
+	// ready.
+	Ready bool `json:"ready"`
+
+	// Failed is true if any of the services failed, and won't become ready.
+	Failed bool `json:"failed"`
+
+	Services map[string]ServiceReadiness `json:"services"`
+}
+
+func New() *cobra.Command {
+	var output, waitFor string
+	var timeout time.Duration
+	var watch bool
+	cobraCmd := &cobra.Command{
+		Use:   "status [SERVICE...]",
+		Short: "Print whether services are ready, in a format for scripts",
+		Long: "Print whether the services in the sandbox are ready, along with the reason " +
+			"that services aren't ready.\n\n" +
+			"This is intended for scripts and tools such as test frameworks, which can use " +
+			"`--output json` rather than parsing the output of `blimp ps`. The reasons are stable " +
+			"codes such as WaitingForDependencies or ImagePullBackOff.\n\n" +
+			"With --wait-for, the command blocks until the services meet the condition. It exits " +
+			"with a non-zero code if the timeout expires first, or a service fails in a way " +
+			"that it can't recover from, such as a job exiting with an error.\n\n" +
+			"With --watch, the status is printed each time it changes until the command is " +
+			"interrupted. Combined with `--output json`, each status is printed as a single line, " +
+			"so that tools such as editor plugins can follow the sandbox from one long-running " +
+			"process rather than polling.",
+		Example: `  blimp status -o json --wait-for healthy --timeout 10m
+  blimp status web worker --wait-for running
+  blimp status -o json --watch`,
+		ValidArgsFunction: util.CompleteServices,
+		Run: func(_ *cobra.Command, services []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			if watch {
+				if waitFor != "" {
+					fmt.Fprintln(os.Stderr, "--watch can't be used with --wait-for")
+					errors.Exit(1)
+				}
+
+				if err := watchStatus(auth.AuthToken, services, output); err != nil {
+					errors.HandleFatalError(err)
+				}
+				return
+			}
+
+			ready, err := run(auth.AuthToken, services, output, waitFor, timeout)
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+			if waitFor != "" && !ready {
+				errors.Exit(1)
+			}
+		},
+	}
+	cobraCmd.Flags().StringVarP(&output, "output", "o", OutputText,
+		fmt.Sprintf("The output format. Either %q or %q.", OutputText, OutputJSON))
+	cobraCmd.Flags().StringVarP(&waitFor, "wait-for", "", "",
+		fmt.Sprintf("Wait until the services meet the condition before printing their status. "+
+			"Either %q, %q, or %q. Jobs meet all conditions once they complete successfully.",
+			ConditionStarted, ConditionRunning, ConditionHealthy))
+	cobraCmd.Flags().DurationVarP(&timeout, "timeout", "", 0,
+		"How long to wait with --wait-for. 0 waits forever.")
+	cobraCmd.Flags().BoolVarP(&watch, "watch", "w", false,
+		"Print the status each time it changes, until interrupted.")
+	return cobraCmd
+}
+
+// run prints the status of the services, and returns whether they're ready.
+func run(authToken string, services []string, output, waitFor string, timeout time.Duration) (bool, error) {
+	if err := checkOutput(output); err != nil {
+		return false, err
+	}
+
+	switch waitFor {
+	case "", ConditionStarted, ConditionRunning, ConditionHealthy:
+	default:
+		return false, errors.NewFriendlyError("Unknown --wait-for value %q. "+
+			"It must be %q, %q, or %q.", waitFor, ConditionStarted, ConditionRunning, ConditionHealthy)
+	}
+
+	var status Status
+	var err error
+	if waitFor == "" {
+		status, err = getStatus(authToken, services)
+	} else {
+		status, err = waitForStatus(authToken, services, waitFor, timeout)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if output == OutputJSON {
+		statusJSON, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return false, errors.WithContext("marshal status", err)
+		}
+		fmt.Println(string(statusJSON))
+	} else {
+		printStatus(os.Stdout, status)
+	}
+	return status.Ready, nil
+}
+
+func checkOutput(output string) error {
+	switch output {
+	case OutputText, OutputJSON:
+		return nil
+	default:
+		return errors.NewFriendlyError("Unknown --output value %q. "+
+			"It must be either %q or %q.", output, OutputText, OutputJSON)
+	}
+}
+
+// watchStatus prints the status of the services each time it changes. JSON
+// statuses are printed on a single line each, so that they can be read as a
+// stream of events. It only returns if the status stream fails.
+func watchStatus(authToken string, services []string, output string) error {
+	if err := checkOutput(output); err != nil {
+		return err
+	}
+
+	stream, err := manager.C.WatchStatus(context.Background(), &cluster.GetStatusRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return errors.WithContext("watch status", err)
+	}
+
+	var prev *Status
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case err == io.EOF:
+			return errors.New("status stream ended unexpectedly")
+		case err != nil:
+			return errors.WithContext("watch status", err)
+		}
+
+		// Updates that don't change the readiness of the services aren't
+		// printed.
+		status := makeStatus(resp.GetStatus(), services, "")
+		if prev != nil && reflect.DeepEqual(*prev, status) {
+			continue
+		}
+		prev = &status
+
+		if output == OutputJSON {
+			statusJSON, err := json.Marshal(status)
+			if err != nil {
+				return errors.WithContext("marshal status", err)
+			}
+			fmt.Println(string(statusJSON))
+		} else {
+			printStatus(os.Stdout, status)
+			fmt.Println()
+		}
+	}
+}
+
+func getStatus(authToken string, services []string) (Status, error) {
+	resp, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return Status{}, errors.WithContext("get status", err)
+	}
+	return makeStatus(resp.GetStatus(), services, ""), nil
+}
+
+// waitForStatus waits until the services meet the condition, or one of them
+// fails, and returns their final status. If the timeout expires first, the
+// most recent status is returned.
+func waitForStatus(authToken string, services []string, condition string, timeout time.Duration) (Status, error) {
+	ctx := context.Background()
+	if timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	stream, err := manager.C.WatchStatus(ctx, &cluster.GetStatusRequest{
+		Token: authToken,
+	})
+	if err != nil {
+		return Status{}, errors.WithContext("watch status", err)
+	}
+
+	var status Status
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case ctx.Err() == context.DeadlineExceeded:
+			fmt.Fprintf(os.Stderr, "Timed out after %s waiting for the services to be %s.\n", timeout, condition)
+			return status, nil
+		case err == io.EOF:
+			return Status{}, errors.New("status stream ended unexpectedly")
+		case err != nil:
+			return Status{}, errors.WithContext("watch status", err)
+		}
+
+		status = makeStatus(resp.GetStatus(), services, condition)
+		if status.Ready || status.Failed {
+			return status, nil
+		}
+	}
+}
+
+// makeStatus converts the status from the manager. If `services` is empty,
+// all the services in the sandbox are included.
+func makeStatus(sandbox *cluster.SandboxStatus, services []string, condition string) Status {
+	status := Status{
+		SandboxPhase: sandbox.GetPhase().String(),
+		Ready:        sandbox.GetPhase() == cluster.SandboxStatus_RUNNING,
+		Services:     map[string]ServiceReadiness{},
+	}
+
+	if len(services) == 0 {
+		for name := range sandbox.GetServices() {
+			services = append(services, name)
+		}
+	}
+
+	for _, name := range services {
+		svcStatus, ok := sandbox.GetServices()[name]
+		if !ok {
+			status.Services[name] = ServiceReadiness{
+				Phase:  cluster.ServicePhase_UNKNOWN.String(),
+				Reason: "NotDeployed",
+			}
+			status.Ready = false
+			continue
+		}
+
+		readiness := GetReadiness(svcStatus, condition)
+		status.Services[name] = readiness
+		status.Ready = status.Ready && readiness.Ready
+		status.Failed = status.Failed || readiness.Failed
+	}
+	return status
+}
+
+func printStatus(out io.Writer, status Status) {
+	var names []string
+	for name := range status.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "SERVICE\tREADY\tREASON\tMESSAGE")
+	for _, name := range names {
+		svc := status.Services[name]
+		fmt.Fprintf(w, "%s\t%t\t%s\t%s\n", name, svc.Ready, svc.Reason, svc.Message)
+	}
+}
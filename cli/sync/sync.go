@@ -0,0 +1,170 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/syncthing"
+)
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Control file syncing for bind volumes",
+		Long: "Control file syncing for bind volumes.\n\n" +
+			"These commands only work while `blimp up` is running.",
+	}
+	cobraCmd.AddCommand(newPauseCommand(), newResumeCommand())
+	return cobraCmd
+}
+
+func newPauseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause [VOLUME...]",
+		Short: "Temporarily stop syncing files to the sandbox",
+		Long: "Temporarily stop syncing files to the sandbox.\n\n" +
+			"This is useful when running commands that change lots of files locally, such as a large build. " +
+			"Changes made while syncing is paused are synced when it's resumed with `blimp sync resume`.\n\n" +
+			"VOLUME is the local path of a bind volume. If no volumes are specified, all volumes are paused.",
+		Run: func(_ *cobra.Command, volumes []string) {
+			if err := pause(volumes); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+}
+
+func newResumeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume [VOLUME...]",
+		Short: "Resume syncing files to the sandbox",
+		Long: "Resume syncing files to the sandbox, and wait for the changes made while paused to sync.\n\n" +
+			"VOLUME is the local path of a bind volume. If no volumes are specified, all volumes are resumed.",
+		Run: func(_ *cobra.Command, volumes []string) {
+			if err := resume(volumes); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+}
+
+func pause(volumes []string) error {
+	api, folders, err := getFolders(volumes)
+	if err != nil {
+		return err
+	}
+
+	if err := api.SetPaused(getIDs(folders), true); err != nil {
+		return errors.WithContext("pause syncing", err)
+	}
+
+	for _, folder := range folders {
+		fmt.Printf("Paused syncing for %s\n", folder.Path)
+	}
+	return nil
+}
+
+func resume(volumes []string) error {
+	api, folders, err := getFolders(volumes)
+	if err != nil {
+		return err
+	}
+
+	ids := getIDs(folders)
+	if err := api.SetPaused(ids, false); err != nil {
+		return errors.WithContext("resume syncing", err)
+	}
+
+	pp := util.NewProgressPrinter(os.Stdout, "Syncing changes made while paused")
+	go pp.Run()
+	summary, err := api.WaitForResume(context.Background(), ids)
+	pp.Stop()
+	if err != nil {
+		return errors.WithContext("wait for sync", err)
+	}
+
+	for _, folder := range folders {
+		fmt.Printf("Resumed syncing for %s\n", folder.Path)
+	}
+	fmt.Printf("Synced %d changed items (%s) and %d deletions\n",
+		summary.Items, util.FormatBytes(int64(summary.Bytes)), summary.Deletes)
+	return nil
+}
+
+// getFolders returns the Syncthing folders that contain the given volumes. If
+// no volumes are specified, it returns all folders.
+func getFolders(volumes []string) (syncthing.APIClient, []syncthing.Folder, error) {
+	api := syncthing.LocalAPI()
+	if !util.UpRunning() || api.Ping() != nil {
+		return api, nil, errors.NewFriendlyError(
+			"File syncing isn't running. `blimp sync` only works while `blimp up` is running.")
+	}
+
+	allFolders, err := api.GetFolders()
+	if err != nil {
+		return api, nil, errors.WithContext("get synced folders", err)
+	}
+
+	if len(allFolders) == 0 {
+		return api, nil, errors.NewFriendlyError("There aren't any bind volumes to sync.")
+	}
+
+	if len(volumes) == 0 {
+		return api, allFolders, nil
+	}
+
+	var folders []syncthing.Folder
+	added := map[string]struct{}{}
+	for _, volume := range volumes {
+		folder, err := findFolder(allFolders, volume)
+		if err != nil {
+			return api, nil, err
+		}
+
+		if _, ok := added[folder.ID]; !ok {
+			added[folder.ID] = struct{}{}
+			folders = append(folders, folder)
+		}
+	}
+	return api, folders, nil
+}
+
+func findFolder(folders []syncthing.Folder, volume string) (syncthing.Folder, error) {
+	path, err := filepath.Abs(volume)
+	if err != nil {
+		return syncthing.Folder{}, errors.WithContext("get absolute path", err)
+	}
+
+	// Volumes that are nested in other volumes are synced by the parent's
+	// folder, so we check whether the volume is within each folder, rather
+	// than only looking for exact matches.
+	for _, folder := range folders {
+		relPath, err := filepath.Rel(folder.Path, path)
+		if err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			return folder, nil
+		}
+	}
+
+	var paths []string
+	for _, folder := range folders {
+		paths = append(paths, "  "+folder.Path)
+	}
+	return syncthing.Folder{}, errors.NewFriendlyError(
+		"%s isn't synced to the sandbox. The synced volumes are:\n%s",
+		volume, strings.Join(paths, "\n"))
+}
+
+func getIDs(folders []syncthing.Folder) []string {
+	var ids []string
+	for _, folder := range folders {
+		ids = append(ids, folder.ID)
+	}
+	return ids
+}
@@ -0,0 +1,83 @@
+package tunnel
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/kelda/blimp/pkg/cfgdir"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// reportFile is the file in the config directory that `blimp up` writes the
+// tunnel statistics to.
+const reportFile = "tunnel-stats.json"
+
+// ReportInterval is how often `blimp up` updates the report.
+const ReportInterval = 2 * time.Second
+
+// Report is the state of the tunnels opened by `blimp up`.
+type Report struct {
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Latency is the round-trip time to the sandbox's node, or zero if it
+	// hasn't been measured yet.
+	Latency time.Duration `json:"latency"`
+
+	// Reconnects is how many times the connection to the sandbox had to be
+	// reestablished.
+	Reconnects int `json:"reconnects"`
+
+	Ports []PortReport `json:"ports"`
+}
+
+// PortReport is the traffic through a forwarded port.
+type PortReport struct {
+	Service       string `json:"service"`
+	HostIP        string `json:"host_ip"`
+	HostPort      uint32 `json:"host_port"`
+	ContainerPort uint32 `json:"container_port"`
+
+	tunnel.StatsSnapshot
+}
+
+// WriteReport saves the report so that it can be read by `blimp tunnel
+// stats`.
+func WriteReport(report Report) error {
+	reportBytes, err := json.Marshal(report)
+	if err != nil {
+		return errors.WithContext("marshal", err)
+	}
+
+	// Write to a temporary file and rename it so that readers never see a
+	// partially written report.
+	path := cfgdir.Expand(reportFile)
+	if err := ioutil.WriteFile(path+".tmp", reportBytes, 0644); err != nil {
+		return errors.WithContext("write", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// RemoveReport removes the report once the tunnels are closed.
+func RemoveReport() error {
+	err := os.Remove(cfgdir.Expand(reportFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func readReport() (Report, error) {
+	reportBytes, err := ioutil.ReadFile(cfgdir.Expand(reportFile))
+	if err != nil {
+		return Report{}, err
+	}
+
+	var report Report
+	if err := json.Unmarshal(reportBytes, &report); err != nil {
+		return Report{}, errors.WithContext("parse", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,80 @@
+package tunnel
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func New() *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:   "tunnel",
+		Short: "Inspect the tunnels that forward ports to your sandbox",
+	}
+	cobraCmd.AddCommand(newStatsCommand())
+	return cobraCmd
+}
+
+func newStatsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Print traffic statistics for the ports forwarded by blimp up",
+		Long: "Print traffic statistics for the ports forwarded by blimp up.\n\n" +
+			"The statistics include the bytes forwarded in each direction, the open connections, " +
+			"the round-trip latency to the sandbox, and how many times the connection to the " +
+			"sandbox was reestablished. They help tell whether slowness is caused by the " +
+			"application or the tunnel.",
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := printStats(); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+}
+
+func printStats() error {
+	report, err := readReport()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.NewFriendlyError("No tunnels are open. " +
+				"Tunnels are only open while `blimp up` is running.")
+		}
+		return errors.WithContext("read tunnel stats", err)
+	}
+
+	// `blimp up` removes the report when it exits, but it might not have
+	// been able to if it crashed.
+	if time.Since(report.UpdatedAt) > 5*ReportInterval {
+		return errors.NewFriendlyError("The tunnel statistics haven't been updated since %s. "+
+			"Make sure that `blimp up` is running.", report.UpdatedAt.Format(time.Kitchen))
+	}
+
+	latency := "unknown"
+	if report.Latency != 0 {
+		latency = report.Latency.Round(time.Millisecond).String()
+	}
+	fmt.Printf("Latency to sandbox: %s\n", latency)
+	fmt.Printf("Reconnects: %d\n\n", report.Reconnects)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "SERVICE\tLOCAL ADDRESS\tPORT\tSENT\tRECEIVED\tACTIVE CONNECTIONS\tTOTAL CONNECTIONS")
+	for _, port := range report.Ports {
+		hostIP := port.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+
+		fmt.Fprintf(w, "%s\t%s:%d\t%d\t%s\t%s\t%d\t%d\n",
+			port.Service, hostIP, port.HostPort, port.ContainerPort,
+			util.FormatBytes(port.BytesSent), util.FormatBytes(port.BytesReceived),
+			port.ActiveConnections, port.TotalConnections)
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,8 +22,10 @@ import (
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh/terminal"
 
+	"github.com/kelda/blimp/cli/buildlog"
 	"github.com/kelda/blimp/pkg/errors"
 	"github.com/kelda/blimp/pkg/hash"
+	"github.com/kelda/blimp/pkg/mirror"
 )
 
 var errNoCachedImage = errors.New("no cached image")
@@ -30,6 +34,10 @@ var errNoCachedImage = errors.New("no cached image")
 // Each cached image is identified by a tag appended to the imageCacheRepo.
 const imageCacheRepo = "blimp-cache"
 
+// mirroredDockerfile is the path within the build context of the Dockerfile
+// that's been rewritten to use the registry mirror.
+const mirroredDockerfile = ".blimp-mirrored.Dockerfile"
+
 type image struct {
 	// The ImageID of the image.
 	id string
@@ -50,12 +58,27 @@ func (cmd *up) buildImages(composeFile composeTypes.Config) (map[string]string,
 	images := map[string]image{}
 	svcToImageName := map[string]string{}
 	var imageNames []string
+	var sessionID string
 	for _, svc := range composeFile.Services {
 		if svc.Build == nil {
 			continue
 		}
 
-		imageID, err := cmd.buildImage(*svc.Build, svc.Name)
+		// Only start the BuildKit session if there are images to build, and
+		// the user asked to expose credentials to the build.
+		if sessionID == "" && (len(cmd.buildSSH) != 0 || len(cmd.buildSecrets) != 0) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sess, err := cmd.startBuildSession(ctx)
+			if err != nil {
+				return nil, errors.WithContext("start build session", err)
+			}
+			defer sess.Close()
+			sessionID = sess.ID()
+		}
+
+		imageID, err := cmd.buildImage(*svc.Build, svc.Name, sessionID)
 		if err != nil {
 			return nil, errors.WithContext(fmt.Sprintf("build %s", svc.Name), err)
 		}
@@ -70,24 +93,37 @@ func (cmd *up) buildImages(composeFile composeTypes.Config) (map[string]string,
 	// When pushing an image, we first check to see if the remote manifest
 	// already exists. This is more efficient than doing a full image push
 	// because we don't compare each individual layer.
-	pushedImages := getPushedImages(imageNames, cmd.auth.AuthToken)
+	pushedImages := cmd.getPushedImages(imageNames)
+	var toPush []string
 	for svc, img := range images {
 		if _, exists := pushedImages[img]; exists {
 			log.WithField("service", svc).Debug("Skipping push. Remote image already exists.")
 			continue
 		}
+		toPush = append(toPush, svc)
+	}
 
-		fmt.Printf("Pushing image for %s:\n", svc)
-		err := cmd.pushImage(img.id, img.name)
-		if err != nil {
-			return nil, errors.WithContext(fmt.Sprintf("push %s", img.name), err)
+	// Progress can only be updated in place if there's a single push running
+	// at a time.
+	concurrent := len(toPush) > 1 && cmd.pool.limit(OperationPush) > 1
+	err := cmd.pool.forEachErr(OperationPush, toPush, func(svc string) error {
+		img := images[svc]
+		if err := cmd.pushImage(svc, img.id, img.name, concurrent); err != nil {
+			return errors.WithContext(fmt.Sprintf("push %s", img.name), err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return svcToImageName, nil
 }
 
-func (cmd *up) buildImage(spec composeTypes.BuildConfig, svc string) (string, error) {
+// buildImage builds the image for the given service. If sessionID is set, the
+// image is built with BuildKit, and can access the credentials exposed by the
+// session.
+func (cmd *up) buildImage(spec composeTypes.BuildConfig, svc, sessionID string) (string, error) {
 	// If we've built the image already on a previous run, just use the cached
 	// version.
 	if !cmd.alwaysBuild {
@@ -101,11 +137,12 @@ func (cmd *up) buildImage(spec composeTypes.BuildConfig, svc string) (string, er
 	}
 
 	// Do a full image build.
+	buildArgs := withGitMetadata(spec.Args, cmd.gitMetadata)
 	opts := types.ImageBuildOptions{
 		Dockerfile:  spec.Dockerfile,
 		Tags:        []string{cmd.getCachedImageName(svc)},
 		AuthConfigs: cmd.regCreds,
-		BuildArgs:   cmd.dockerConfig.ParseProxyConfig(cmd.dockerClient.DaemonHost(), spec.Args),
+		BuildArgs:   cmd.dockerConfig.ParseProxyConfig(cmd.dockerClient.DaemonHost(), buildArgs),
 		Target:      spec.Target,
 		Labels:      spec.Labels,
 		CacheFrom:   spec.CacheFrom,
@@ -113,11 +150,40 @@ func (cmd *up) buildImage(spec composeTypes.BuildConfig, svc string) (string, er
 	if opts.Dockerfile == "" {
 		opts.Dockerfile = "Dockerfile"
 	}
+	if sessionID != "" {
+		opts.Version = types.BuilderBuildKit
+		opts.SessionID = sessionID
+	}
+
+	// Build contexts from Compose files outside the project directory are
+	// already absolute.
+	contextPath := spec.Context
+	if !filepath.IsAbs(contextPath) {
+		contextPath = filepath.Join(cmd.projectDir, contextPath)
+	}
 
-	contextPath := filepath.Join(
-		filepath.Dir(cmd.composePath),
-		spec.Context)
-	buildContextTar, err := makeTar(contextPath)
+	// Pull the base images through the registry mirror by building from a
+	// rewritten copy of the Dockerfile.
+	var extraFiles map[string][]byte
+	if cmd.registryMirror != "" {
+		dockerfilePath := opts.Dockerfile
+		if !filepath.IsAbs(dockerfilePath) {
+			dockerfilePath = filepath.Join(contextPath, dockerfilePath)
+		}
+
+		dockerfile, err := ioutil.ReadFile(dockerfilePath)
+		if err == nil {
+			extraFiles = map[string][]byte{
+				mirroredDockerfile: mirror.RewriteDockerfile(dockerfile, cmd.registryMirror),
+			}
+			opts.Dockerfile = mirroredDockerfile
+		} else {
+			log.WithError(err).WithField("service", svc).
+				Debug("Failed to read Dockerfile. Base images won't be pulled through the registry mirror.")
+		}
+	}
+
+	buildContextTar, err := makeTar(contextPath, extraFiles)
 	if err != nil {
 		return "", errors.WithContext("tar context", err)
 	}
@@ -128,10 +194,30 @@ func (cmd *up) buildImage(spec composeTypes.BuildConfig, svc string) (string, er
 	}
 	defer buildResp.Body.Close()
 
+	// The full build output is written to the build log, and a summary of
+	// each step is printed as it completes.
+	buildLog, err := buildlog.Create(svc)
+	if err != nil {
+		return "", errors.WithContext("create build log", err)
+	}
+	defer buildLog.Close()
+
+	progressOut := io.Writer(os.Stderr)
+	if cmd.quiet {
+		progressOut = ioutil.Discard
+	}
+
 	// Block until the build completes, and return any errors that happen
 	// during the build.
 	var imageID string
+	classicSteps := newClassicStepWriter(svc, progressOut)
+	buildkitPrinter := newBuildkitPrinter(svc, progressOut, buildLog)
 	callback := func(msg jsonmessage.JSONMessage) {
+		if msg.ID == buildkitTraceID {
+			buildkitPrinter.print(msg)
+			return
+		}
+
 		var id struct{ ID string }
 		if err := json.Unmarshal(*msg.Aux, &id); err != nil {
 			log.WithError(err).Warn("Failed to parse build ID")
@@ -143,44 +229,20 @@ func (cmd *up) buildImage(spec composeTypes.BuildConfig, svc string) (string, er
 		}
 	}
 
-	isTerminal := terminal.IsTerminal(int(os.Stderr.Fd()))
-	err = jsonmessage.DisplayJSONMessagesStream(buildResp.Body, os.Stderr, os.Stderr.Fd(), isTerminal, callback)
+	err = jsonmessage.DisplayJSONMessagesStream(buildResp.Body,
+		io.MultiWriter(buildLog, classicSteps), buildLog.Fd(), false, callback)
+	classicSteps.Finish()
 	if err != nil {
 		return "", errors.NewFriendlyError(
 			"Image build for %q failed. This is likely an error with the Dockerfile, rather than Blimp.\n"+
-				"Make sure that the image successfully builds with `docker build`.\n\n"+
-				"The full error was:\n%s", svc, err)
+				"Make sure that the image successfully builds with `docker build`.\n"+
+				"Run `blimp logs --build %s` to see the full build output.\n\n"+
+				"The full error was:\n%s", svc, svc, err)
 	}
 
 	return imageID, nil
 }
 
-func (cmd *up) pushImage(imageID, remoteImageName string) error {
-	if err := cmd.dockerClient.ImageTag(context.TODO(), imageID, remoteImageName); err != nil {
-		return errors.WithContext("tag image", err)
-	}
-
-	registryAuth, err := makeRegistryAuthHeader(cmd.auth.AuthToken)
-	if err != nil {
-		return errors.WithContext("make registry auth header", err)
-	}
-
-	pushResp, err := cmd.dockerClient.ImagePush(context.TODO(), remoteImageName, types.ImagePushOptions{
-		RegistryAuth: registryAuth,
-	})
-	if err != nil {
-		return errors.WithContext("start image push", err)
-	}
-	defer pushResp.Close()
-
-	isTerminal := terminal.IsTerminal(int(os.Stderr.Fd()))
-	err = jsonmessage.DisplayJSONMessagesStream(pushResp, os.Stderr, os.Stderr.Fd(), isTerminal, nil)
-	if err != nil {
-		return errors.WithContext("push image", err)
-	}
-	return nil
-}
-
 func (cmd *up) getCachedImages() ([]types.ImageSummary, error) {
 	opts := types.ImageListOptions{
 		Filters: filters.NewArgs(filters.KeyValuePair{
@@ -207,62 +269,56 @@ func getImage(images []types.ImageSummary, exp string) (id string, ok bool) {
 	return "", false
 }
 
-func getPushedImages(images []string, authToken string) map[image]struct{} {
-	var wg sync.WaitGroup
+func (cmd *up) getPushedImages(images []string) map[image]struct{} {
 	var pushedImagesLock sync.Mutex
 	pushedImages := map[image]struct{}{}
-	checkImageReqChan := make(chan string)
-
-	for i := 0; i < 32; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for imgName := range checkImageReqChan {
-				imageRef, err := name.NewTag(imgName)
-				if err != nil {
-					log.WithError(err).WithField("name", imgName).Debug("Failed to parse image name")
-					continue
-				}
-
-				img, err := remote.Image(imageRef,
-					remote.WithAuth(&authn.Basic{Username: "ignored", Password: authToken}))
-				if err != nil {
-					isDoesNotExist := false
-					if err, ok := err.(*transport.Error); ok {
-						for _, code := range err.Errors {
-							if code.Code == transport.ManifestUnknownErrorCode {
-								isDoesNotExist = true
-								break
-							}
-						}
-					}
+	cmd.pool.forEach(OperationRegistry, images, func(imgName string) {
+		imageRef, err := name.NewTag(imgName)
+		if err != nil {
+			log.WithError(err).WithField("name", imgName).Debug("Failed to parse image name")
+			return
+		}
 
-					if !isDoesNotExist {
-						log.WithError(err).
-							WithField("name", imgName).
-							Debug("Failed to get remote image")
+		img, err := remote.Image(imageRef,
+			remote.WithAuth(&authn.Basic{Username: "ignored", Password: cmd.auth.AuthToken}))
+		if err != nil {
+			isDoesNotExist := false
+			if err, ok := err.(*transport.Error); ok {
+				for _, code := range err.Errors {
+					if code.Code == transport.ManifestUnknownErrorCode {
+						isDoesNotExist = true
+						break
 					}
-					continue
-				}
-
-				imageID, err := img.ConfigName()
-				if err != nil {
-					log.WithError(err).WithField("name", imgName).Debug("Failed to get remote image ID")
-					continue
 				}
+			}
 
-				pushedImagesLock.Lock()
-				pushedImages[image{id: imageID.String(), name: imgName}] = struct{}{}
-				pushedImagesLock.Unlock()
+			if !isDoesNotExist {
+				log.WithError(err).
+					WithField("name", imgName).
+					Debug("Failed to get remote image")
 			}
-		}()
-	}
+			return
+		}
 
-	for _, image := range images {
-		checkImageReqChan <- image
-	}
-	close(checkImageReqChan)
-	wg.Wait()
+		imageID, err := img.ConfigName()
+		if err != nil {
+			log.WithError(err).WithField("name", imgName).Debug("Failed to get remote image ID")
+			return
+		}
+
+		pushedImagesLock.Lock()
+		pushedImages[image{id: imageID.String(), name: imgName}] = struct{}{}
+		pushedImagesLock.Unlock()
+	})
 
 	return pushedImages
 }
+
+// getProgressOutput returns where to print the progress of image builds and
+// pushes, and whether it's a terminal.
+func (cmd *up) getProgressOutput() (io.Writer, bool) {
+	if cmd.quiet {
+		return ioutil.Discard, false
+	}
+	return os.Stderr, terminal.IsTerminal(int(os.Stderr.Fd()))
+}
@@ -0,0 +1,202 @@
+package up
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	controlapi "github.com/moby/buildkit/api/services/control"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	digest "github.com/opencontainers/go-digest"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// buildkitTraceID is the ID of the JSON messages that contain BuildKit's
+// progress updates.
+const buildkitTraceID = "moby.buildkit.trace"
+
+// startBuildSession starts a BuildKit session that gives image builds access
+// to the user's SSH agent and secret files. The credentials are streamed to
+// the Docker daemon on demand, so they never end up in the image layers.
+// The session runs until the context is cancelled.
+func (cmd *up) startBuildSession(ctx context.Context) (*session.Session, error) {
+	sess, err := session.NewSession(ctx, "blimp", "")
+	if err != nil {
+		return nil, errors.WithContext("create session", err)
+	}
+
+	if len(cmd.buildSSH) != 0 {
+		configs, err := parseSSHSpecs(cmd.buildSSH)
+		if err != nil {
+			return nil, err
+		}
+
+		sshProvider, err := sshprovider.NewSSHAgentProvider(configs)
+		if err != nil {
+			return nil, errors.WithContext("load ssh agent", err)
+		}
+		sess.Allow(sshProvider)
+	}
+
+	if len(cmd.buildSecrets) != 0 {
+		sources, err := parseSecretSpecs(cmd.buildSecrets)
+		if err != nil {
+			return nil, err
+		}
+
+		store, err := secretsprovider.NewFileStore(sources)
+		if err != nil {
+			return nil, errors.WithContext("load secrets", err)
+		}
+		sess.Allow(secretsprovider.NewSecretProvider(store))
+	}
+
+	dialSession := func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+		return cmd.dockerClient.DialHijack(ctx, "/session", proto, meta)
+	}
+	go func() {
+		if err := sess.Run(ctx, dialSession); err != nil {
+			log.WithError(err).Debug("Build session exited")
+		}
+	}()
+	return sess, nil
+}
+
+// parseSSHSpecs parses SSH specs in the same format as `docker build --ssh`:
+// `default|<id>[=<socket>|<key>[,<key>]]`.
+func parseSSHSpecs(specs []string) ([]sshprovider.AgentConfig, error) {
+	var configs []sshprovider.AgentConfig
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		config := sshprovider.AgentConfig{ID: parts[0]}
+		if config.ID == "" {
+			return nil, errors.NewFriendlyError("Invalid --ssh value %q. "+
+				"It should be of the form `default|<id>[=<socket>|<key>[,<key>]]`.", spec)
+		}
+
+		if len(parts) == 2 {
+			config.Paths = strings.Split(parts[1], ",")
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// parseSecretSpecs parses secret specs in the same format as `docker build
+// --secret`: `id=<id>,src=<path>`.
+func parseSecretSpecs(specs []string) ([]secretsprovider.FileSource, error) {
+	var sources []secretsprovider.FileSource
+	for _, spec := range specs {
+		var source secretsprovider.FileSource
+		for _, field := range strings.Split(spec, ",") {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, errors.NewFriendlyError("Invalid --secret value %q. "+
+					"It should be of the form `id=<id>,src=<path>`.", spec)
+			}
+
+			switch strings.ToLower(kv[0]) {
+			case "id":
+				source.ID = kv[1]
+			case "src", "source":
+				source.FilePath = kv[1]
+			case "type":
+				if kv[1] != "file" {
+					return nil, errors.NewFriendlyError("Unsupported secret type %q. "+
+						"Only file secrets are supported.", kv[1])
+				}
+			default:
+				return nil, errors.NewFriendlyError("Unknown field %q in --secret value %q.", kv[0], spec)
+			}
+		}
+
+		if source.ID == "" {
+			return nil, errors.NewFriendlyError("Invalid --secret value %q. The id field is required.", spec)
+		}
+
+		// Default to a file with the same name as the ID, like Docker does.
+		if source.FilePath == "" {
+			source.FilePath = source.ID
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// buildkitPrinter prints a condensed version of the progress updates sent by
+// BuildKit, and writes the full output to the build log. BuildKit sends its
+// progress as protobuf-encoded status updates, rather than as regular JSON
+// messages, so they need to be decoded before they can be shown to the user.
+type buildkitPrinter struct {
+	svc      string
+	out      io.Writer
+	buildLog io.Writer
+
+	started   map[digest.Digest]struct{}
+	completed map[digest.Digest]struct{}
+}
+
+func newBuildkitPrinter(svc string, out, buildLog io.Writer) *buildkitPrinter {
+	return &buildkitPrinter{
+		svc:       svc,
+		out:       out,
+		buildLog:  buildLog,
+		started:   map[digest.Digest]struct{}{},
+		completed: map[digest.Digest]struct{}{},
+	}
+}
+
+func (p *buildkitPrinter) print(msg jsonmessage.JSONMessage) {
+	var encoded []byte
+	if err := json.Unmarshal(*msg.Aux, &encoded); err != nil {
+		log.WithError(err).Debug("Failed to parse BuildKit trace")
+		return
+	}
+
+	var status controlapi.StatusResponse
+	if err := status.Unmarshal(encoded); err != nil {
+		log.WithError(err).Debug("Failed to parse BuildKit status")
+		return
+	}
+
+	for _, vertex := range status.Vertexes {
+		if vertex.Started == nil {
+			continue
+		}
+
+		if _, ok := p.started[vertex.Digest]; !ok {
+			p.started[vertex.Digest] = struct{}{}
+			fmt.Fprintf(p.buildLog, "#%s\n", vertex.Name)
+		}
+
+		if vertex.Completed == nil {
+			continue
+		}
+
+		if _, ok := p.completed[vertex.Digest]; ok {
+			continue
+		}
+		p.completed[vertex.Digest] = struct{}{}
+
+		if vertex.Error != "" {
+			fmt.Fprintf(p.buildLog, "#%s: %s\n", vertex.Name, vertex.Error)
+		}
+		printBuildStep(p.out, p.svc, buildStep{
+			name:   vertex.Name,
+			cached: vertex.Cached,
+			start:  *vertex.Started,
+		}, *vertex.Completed)
+	}
+
+	for _, vertexLog := range status.Logs {
+		p.buildLog.Write(vertexLog.Msg)
+	}
+}
@@ -0,0 +1,86 @@
+package up
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// classicStepPattern matches the start of a step in the output of the classic
+// (non-BuildKit) builder, such as `Step 2/5 : RUN npm install`.
+var classicStepPattern = regexp.MustCompile(`^Step (\d+/\d+) : (.*)$`)
+
+// classicCacheHit is printed by the classic builder when a step is cached.
+const classicCacheHit = "---> Using cache"
+
+// buildStep is a step of an image build.
+type buildStep struct {
+	name   string
+	cached bool
+	start  time.Time
+}
+
+// printBuildStep prints a one line summary of a completed step.
+func printBuildStep(out io.Writer, svc string, step buildStep, end time.Time) {
+	cache := "cache miss"
+	if step.cached {
+		cache = "cached"
+	}
+	fmt.Fprintf(out, "[%s] %s (%s, %s)\n", svc, step.name, cache,
+		end.Sub(step.start).Round(100*time.Millisecond))
+}
+
+// classicStepWriter parses the output of the classic builder, and prints a
+// summary of each step once it completes.
+type classicStepWriter struct {
+	svc string
+	out io.Writer
+
+	current *buildStep
+	buf     bytes.Buffer
+}
+
+func newClassicStepWriter(svc string, out io.Writer) *classicStepWriter {
+	return &classicStepWriter{svc: svc, out: out}
+}
+
+func (w *classicStepWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// Save the partial line until the rest of it is written.
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			return len(p), nil
+		}
+		w.handleLine(strings.TrimSpace(line))
+	}
+}
+
+func (w *classicStepWriter) handleLine(line string) {
+	if match := classicStepPattern.FindStringSubmatch(line); match != nil {
+		w.Finish()
+		w.current = &buildStep{
+			name:  fmt.Sprintf("Step %s: %s", match[1], match[2]),
+			start: time.Now(),
+		}
+		return
+	}
+
+	if w.current != nil && strings.Contains(line, classicCacheHit) {
+		w.current.cached = true
+	}
+}
+
+// Finish prints the summary of the step that's in progress, if any. It should
+// be called once the build completes.
+func (w *classicStepWriter) Finish() {
+	if w.current != nil {
+		printBuildStep(w.out, w.svc, *w.current, time.Now())
+		w.current = nil
+	}
+}
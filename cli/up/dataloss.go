@@ -0,0 +1,88 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// confirmDataLoss asks the user to confirm the deploy if it would destroy
+// the data in any volumes, such as a seeded database. The prompt is skipped
+// with --yes. If the cluster can't check for data loss, the deploy continues
+// without confirmation, the same as before the check existed.
+func (cmd *up) confirmDataLoss(composeFile string) error {
+	resp, err := manager.C.CheckDataLoss(context.Background(), &cluster.CheckDataLossRequest{
+		Token:       cmd.auth.AuthToken,
+		ComposeFile: composeFile,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			log.Debug("The cluster doesn't support checking for data loss. Skipping confirmation.")
+			return nil
+		}
+		return errors.WithContext("check data loss", err)
+	}
+
+	if err := errors.Unmarshal(nil, resp.Error); err != nil {
+		return err
+	}
+
+	if len(resp.AtRisk) == 0 || cmd.yes {
+		return nil
+	}
+
+	fmt.Println("Deploying these changes will delete the data in the following volumes:")
+	printDataAtRisk(resp.AtRisk)
+
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.NewFriendlyError("Refusing to delete data without confirmation. " +
+			"Run with --yes to deploy anyway.")
+	}
+
+	fmt.Printf("\nAre you sure you want to continue? (y/N) ")
+	var response string
+	num, err := fmt.Scanln(&response)
+	if err != nil || num != 1 ||
+		(strings.ToLower(response) != "y" && strings.ToLower(response) != "yes") {
+		fmt.Printf("Aborting.\n")
+		errors.Exit(1)
+	}
+	return nil
+}
+
+func printDataAtRisk(atRisk []*cluster.DataAtRisk) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "VOLUME\tSERVICES\tSIZE\tREASON")
+	for _, data := range atRisk {
+		size := "-"
+		if data.SizeBytes != 0 {
+			size = util.FormatBytes(data.SizeBytes)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", data.Volume, strings.Join(data.Services, ", "),
+			size, getReasonString(data.Reason))
+	}
+	w.Flush()
+}
+
+func getReasonString(reason cluster.DataAtRisk_Reason) string {
+	switch reason {
+	case cluster.DataAtRisk_VOLUME_RESET:
+		return "The volume's configuration changed, so it will be recreated empty"
+	case cluster.DataAtRisk_PERSISTENCE_CHANGED:
+		return "The volume is no longer persistent, so it will be reset with its services"
+	default:
+		return "The volume will be reset"
+	}
+}
@@ -0,0 +1,156 @@
+package up
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/buger/goterm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/pkg/names"
+)
+
+const (
+	// The maximum number of events to show for each service.
+	diagnosisMaxEvents = 3
+
+	// The number of log lines to show for each service.
+	diagnosisLogLines = 10
+)
+
+// diagnoseBoot gathers the evidence most likely to explain why the given
+// services failed to boot, and returns it as a human readable summary.
+// Failures to gather evidence are included in the summary rather than
+// returned, since the diagnosis is best effort.
+func diagnoseBoot(kubeClient kubernetes.Interface, namespace string, services []string) string {
+	var diagnosis bytes.Buffer
+	fmt.Fprintln(&diagnosis, goterm.Bold("Diagnosis"))
+
+	if quotaMsg := diagnoseQuota(kubeClient, namespace); quotaMsg != "" {
+		fmt.Fprintf(&diagnosis, "\nYour sandbox is out of resources: %s\n", quotaMsg)
+	}
+
+	for _, svc := range services {
+		fmt.Fprintf(&diagnosis, "\n%s:\n", goterm.Bold(svc))
+		for _, line := range diagnoseService(kubeClient, namespace, svc) {
+			fmt.Fprintf(&diagnosis, "  %s\n", line)
+		}
+	}
+	return diagnosis.String()
+}
+
+func diagnoseService(kubeClient kubernetes.Interface, namespace, svc string) (evidence []string) {
+	podName := names.PodName(svc)
+	pod, err := kubeClient.CoreV1().Pods(namespace).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return []string{fmt.Sprintf("The service hasn't been created yet (%s).", err)}
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse {
+			evidence = append(evidence, fmt.Sprintf("Not scheduled: %s", cond.Message))
+		}
+	}
+
+	var restartCount int32
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == names.LogCaptureContainer {
+			continue
+		}
+
+		restartCount = status.RestartCount
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+			msg := fmt.Sprintf("Waiting: %s", waiting.Reason)
+			if waiting.Message != "" {
+				msg += fmt.Sprintf(" (%s)", waiting.Message)
+			}
+			evidence = append(evidence, msg)
+		}
+
+		if terminated := status.LastTerminationState.Terminated; terminated != nil {
+			evidence = append(evidence, fmt.Sprintf("Last exited with code %d (%s), restarted %d times",
+				terminated.ExitCode, terminated.Reason, status.RestartCount))
+		}
+	}
+
+	events, err := kubeClient.CoreV1().Events(namespace).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", podName).String(),
+	})
+	if err == nil {
+		var warnings []corev1.Event
+		for _, event := range events.Items {
+			if event.Type == corev1.EventTypeWarning {
+				warnings = append(warnings, event)
+			}
+		}
+
+		sort.Slice(warnings, func(i, j int) bool {
+			return warnings[i].LastTimestamp.Before(&warnings[j].LastTimestamp)
+		})
+		if len(warnings) > diagnosisMaxEvents {
+			warnings = warnings[len(warnings)-diagnosisMaxEvents:]
+		}
+		for _, event := range warnings {
+			evidence = append(evidence, fmt.Sprintf("Event: %s: %s", event.Reason, event.Message))
+		}
+	}
+
+	if logs := getLastLogs(kubeClient, namespace, podName, restartCount > 0); logs != "" {
+		evidence = append(evidence, "Last log lines:")
+		for _, line := range strings.Split(logs, "\n") {
+			evidence = append(evidence, "  "+line)
+		}
+	}
+
+	if len(evidence) == 0 {
+		evidence = append(evidence, fmt.Sprintf("No problems found. The pod is %s.", pod.Status.Phase))
+	}
+	return evidence
+}
+
+// getLastLogs returns the last lines logged by the pod. If the container has
+// restarted, the logs of the crashed container are returned, since they're
+// most likely to contain the cause of the crash.
+func getLastLogs(kubeClient kubernetes.Interface, namespace, podName string, previous bool) string {
+	tailLines := int64(diagnosisLogLines)
+	opts := corev1.PodLogOptions{TailLines: &tailLines, Previous: previous}
+	stream, err := kubeClient.CoreV1().Pods(namespace).GetLogs(podName, &opts).Stream()
+	if err != nil {
+		return ""
+	}
+	defer stream.Close()
+
+	logs, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(logs))
+}
+
+// diagnoseQuota returns a description of the resource quotas that have been
+// exhausted, if any.
+func diagnoseQuota(kubeClient kubernetes.Interface, namespace string) string {
+	quotas, err := kubeClient.CoreV1().ResourceQuotas(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return ""
+	}
+
+	var exhausted []string
+	for _, quota := range quotas.Items {
+		for resource, hard := range quota.Status.Hard {
+			used, ok := quota.Status.Used[resource]
+			if ok && used.Cmp(hard) >= 0 {
+				exhausted = append(exhausted, fmt.Sprintf("%s (%s/%s)",
+					resource, used.String(), hard.String()))
+			}
+		}
+	}
+	sort.Strings(exhausted)
+	return strings.Join(exhausted, ", ")
+}
@@ -0,0 +1,146 @@
+package up
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	composeTypes "github.com/kelda/compose-go/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/pkg/cfgdir"
+)
+
+// deployedDigestsPath is where the digests of the images deployed by previous
+// runs of `blimp up` are recorded, so that we can warn when floating tags
+// change. The digests are keyed by the path to the Compose file.
+var deployedDigestsPath = cfgdir.Expand("deployed-digests.yaml")
+
+// pinImages rewrites the images in the Compose config to reference the digests
+// in the project's lockfile. If `updateLock` is true, the lockfile is first
+// regenerated from the digests that the images currently resolve to.
+// Otherwise, a warning is printed for each unpinned image whose digest changed
+// since the last deploy.
+func (cmd *up) pinImages(cfg *composeTypes.Config, updateLock bool) error {
+	lock, err := projectcfg.LoadLock(cmd.projectDir)
+	if err != nil {
+		return err
+	}
+
+	var images []string
+	for _, svc := range cfg.Services {
+		if svc.Build == nil && isFloatingImage(svc.Image) {
+			images = append(images, svc.Image)
+		}
+	}
+	currDigests := cmd.resolveDigests(images)
+
+	if updateLock {
+		lock.Images = currDigests
+		if err := lock.Save(cmd.projectDir); err != nil {
+			return err
+		}
+		fmt.Printf("Pinned %d images in %s\n", len(currDigests), projectcfg.LockFilename)
+	} else {
+		cmd.warnDigestChanges(lock, currDigests)
+	}
+
+	for i, svc := range cfg.Services {
+		if svc.Build != nil {
+			continue
+		}
+
+		if digest, ok := lock.Images[svc.Image]; ok {
+			cfg.Services[i].Image = withDigest(svc.Image, digest)
+		}
+	}
+	return nil
+}
+
+// warnDigestChanges warns about the unpinned images that resolve to
+// different digests than when they were last deployed, and records the
+// current digests.
+func (cmd *up) warnDigestChanges(lock projectcfg.Lock, currDigests map[string]string) {
+	deployed := map[string]map[string]string{}
+	if deployedBytes, err := ioutil.ReadFile(deployedDigestsPath); err == nil {
+		if err := yaml.Unmarshal(deployedBytes, &deployed); err != nil {
+			log.WithError(err).Debug("Failed to parse deployed digests")
+		}
+	} else if !os.IsNotExist(err) {
+		log.WithError(err).Debug("Failed to read deployed digests")
+	}
+
+	prevDigests := deployed[cmd.composePath]
+	for image, digest := range currDigests {
+		if _, ok := lock.Images[image]; ok {
+			continue
+		}
+
+		prevDigest, ok := prevDigests[image]
+		if ok && prevDigest != digest {
+			log.Warnf("%s now resolves to %s, but %s was deployed previously. "+
+				"Run `blimp up --pin` to pin the images to specific versions.",
+				image, digest, prevDigest)
+		}
+	}
+
+	deployed[cmd.composePath] = currDigests
+	deployedBytes, err := yaml.Marshal(deployed)
+	if err != nil {
+		log.WithError(err).Debug("Failed to marshal deployed digests")
+		return
+	}
+	if err := ioutil.WriteFile(deployedDigestsPath, deployedBytes, 0644); err != nil {
+		log.WithError(err).Debug("Failed to record deployed digests")
+	}
+}
+
+// resolveDigests looks up the digests that the given images currently resolve
+// to. Images that can't be resolved, such as private images that the user
+// doesn't have credentials for, are omitted.
+func (cmd *up) resolveDigests(images []string) map[string]string {
+	var digestsLock sync.Mutex
+	digests := map[string]string{}
+	cmd.pool.forEach(OperationRegistry, images, func(image string) {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			log.WithError(err).WithField("image", image).Debug("Failed to parse image name")
+			return
+		}
+
+		desc, err := remote.Get(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+		if err != nil {
+			log.WithError(err).WithField("image", image).Debug("Failed to resolve image digest")
+			return
+		}
+
+		digestsLock.Lock()
+		digests[image] = desc.Digest.String()
+		digestsLock.Unlock()
+	})
+	return digests
+}
+
+// isFloatingImage returns whether the image reference can resolve to
+// different images over time. References that already include a digest, or
+// that use variables, aren't floating.
+func isFloatingImage(image string) bool {
+	return image != "" && !strings.Contains(image, "@") && !strings.Contains(image, "$")
+}
+
+// withDigest returns the image reference with its tag replaced by the digest.
+// For example, `redis:6` becomes `redis@sha256:...`.
+func withDigest(image, digest string) string {
+	repo := image
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		repo = image[:idx]
+	}
+	return fmt.Sprintf("%s@%s", repo, digest)
+}
@@ -0,0 +1,92 @@
+package up
+
+import (
+	"strings"
+
+	composeTypes "github.com/kelda/compose-go/types"
+
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// loadInjectedEnv parses the dotenv files passed with --inject-env into
+// overrides that apply to all services. Later files take precedence.
+func loadInjectedEnv(paths []string) ([]projectcfg.EnvOverride, error) {
+	var overrides []projectcfg.EnvOverride
+	for _, path := range paths {
+		env, err := dockercompose.ParseEnvFile(path)
+		if err != nil {
+			return nil, errors.NewFriendlyError("Failed to parse --inject-env file %s.\n\n"+
+				"The full error was:\n%s", path, err)
+		}
+		overrides = append(overrides, projectcfg.EnvOverride{Environment: env})
+	}
+	return overrides, nil
+}
+
+// parseEnvOverrides parses the --env flags, which are in the form
+// `[SERVICE[,SERVICE...]:]KEY=VALUE`.
+func parseEnvOverrides(flags []string) ([]projectcfg.EnvOverride, error) {
+	var overrides []projectcfg.EnvOverride
+	for _, flag := range flags {
+		kv := flag
+		var services []string
+
+		// Variable names can't contain colons, so a colon before the first
+		// equals sign separates the services from the variable.
+		eqIdx := strings.Index(flag, "=")
+		if colonIdx := strings.Index(flag, ":"); colonIdx >= 0 && (eqIdx < 0 || colonIdx < eqIdx) {
+			services = strings.Split(flag[:colonIdx], ",")
+			kv = flag[colonIdx+1:]
+		}
+
+		parts := strings.SplitN(kv, "=", 2)
+		if parts[0] == "" || len(parts) != 2 {
+			return nil, errors.NewFriendlyError("Malformed environment variable %q. "+
+				"It should be in the form KEY=VALUE or SERVICE:KEY=VALUE.", flag)
+		}
+
+		overrides = append(overrides, projectcfg.EnvOverride{
+			Services:    services,
+			Environment: map[string]string{parts[0]: parts[1]},
+		})
+	}
+	return overrides, nil
+}
+
+// applyEnvOverrides sets the environment variables in the overrides. Later
+// overrides take precedence over earlier ones.
+func applyEnvOverrides(cfg *composeTypes.Config, overrides []projectcfg.EnvOverride) error {
+	for _, override := range overrides {
+		for _, svc := range override.Services {
+			if !contains(cfg.ServiceNames(), svc) {
+				return errors.NewFriendlyError("Unknown service %q in environment override.", svc)
+			}
+		}
+
+		for i, svc := range cfg.Services {
+			if len(override.Services) != 0 && !contains(override.Services, svc.Name) {
+				continue
+			}
+
+			if svc.Environment == nil {
+				cfg.Services[i].Environment = composeTypes.MappingWithEquals{}
+			}
+			for key, val := range override.Environment {
+				val := val
+				cfg.Services[i].Environment[key] = &val
+			}
+		}
+	}
+	return nil
+}
+
+func contains(slc []string, str string) bool {
+	for _, s := range slc {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
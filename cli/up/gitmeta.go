@@ -0,0 +1,78 @@
+package up
+
+import (
+	"os/exec"
+	"strings"
+	"time"
+
+	composeTypes "github.com/kelda/compose-go/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// The build args that are set from the project's Git repository. They match
+// the names commonly used by CI systems, so that Dockerfiles that embed the
+// version work the same in sandboxes.
+const (
+	gitCommitArg = "GIT_COMMIT"
+	gitBranchArg = "GIT_BRANCH"
+	buildTimeArg = "BUILD_TIME"
+)
+
+// getGitMetadata returns the commit and branch checked out in the project
+// directory, and the current time. The Git values are omitted if the project
+// isn't in a Git repository, or Git isn't installed.
+func getGitMetadata(projectDir string) map[string]string {
+	metadata := map[string]string{
+		buildTimeArg: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if commit, ok := runGit(projectDir, "rev-parse", "HEAD"); ok {
+		metadata[gitCommitArg] = commit
+	}
+
+	// `--abbrev-ref` prints HEAD when the commit is checked out directly, in
+	// which case there's no branch.
+	if branch, ok := runGit(projectDir, "rev-parse", "--abbrev-ref", "HEAD"); ok && branch != "HEAD" {
+		metadata[gitBranchArg] = branch
+	}
+	return metadata
+}
+
+// getGitMetadataEnv returns the Git metadata that's set in the services'
+// environment with `git_metadata_env`. BUILD_TIME is left out, since it
+// changes on every run, and would cause every service to be recreated even if
+// nothing else changed.
+func getGitMetadataEnv(metadata map[string]string) map[string]string {
+	env := map[string]string{}
+	for key, val := range metadata {
+		if key != buildTimeArg {
+			env[key] = val
+		}
+	}
+	return env
+}
+
+func runGit(dir string, args ...string) (string, bool) {
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Dir = dir
+	out, err := gitCmd.Output()
+	if err != nil {
+		log.WithError(err).WithField("args", args).Debug("Failed to get Git metadata")
+		return "", false
+	}
+	return strings.TrimSpace(string(out)), true
+}
+
+// withGitMetadata adds the Git metadata to the build args. Args that are set
+// in the Compose file take precedence.
+func withGitMetadata(args composeTypes.MappingWithEquals, metadata map[string]string) composeTypes.MappingWithEquals {
+	merged := composeTypes.MappingWithEquals{}
+	for key, val := range metadata {
+		val := val
+		merged[key] = &val
+	}
+	for key, val := range args {
+		merged[key] = val
+	}
+	return merged
+}
@@ -0,0 +1,186 @@
+package up
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	composeTypes "github.com/kelda/compose-go/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/node"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// splitLocalServices removes the services that the user is running locally
+// from the Compose config, and returns them. References to the local services
+// are also removed from the remaining services, so that they don't wait on
+// services that will never boot in the sandbox.
+func splitLocalServices(cfg *composeTypes.Config, localNames []string) ([]composeTypes.ServiceConfig, error) {
+	isLocal := map[string]bool{}
+	for _, name := range localNames {
+		isLocal[name] = true
+	}
+
+	var local, remote []composeTypes.ServiceConfig
+	for _, svc := range cfg.Services {
+		if isLocal[svc.Name] {
+			local = append(local, svc)
+			delete(isLocal, svc.Name)
+		} else {
+			remote = append(remote, svc)
+		}
+	}
+
+	for name := range isLocal {
+		return nil, errors.NewFriendlyError("Unknown service %q passed to --local.", name)
+	}
+
+	for i, svc := range remote {
+		dependsOn := composeTypes.DependsOnConfig{}
+		for dep, config := range svc.DependsOn {
+			if !containsService(local, dep) {
+				dependsOn[dep] = config
+			}
+		}
+		remote[i].DependsOn = dependsOn
+
+		var links []string
+		for _, link := range svc.Links {
+			if !containsService(local, strings.SplitN(link, ":", 2)[0]) {
+				links = append(links, link)
+			}
+		}
+		remote[i].Links = links
+	}
+
+	cfg.Services = remote
+	return local, nil
+}
+
+func containsService(services []composeTypes.ServiceConfig, name string) bool {
+	for _, svc := range services {
+		if svc.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getServicePorts returns the ports that the service listens on, according to
+// its published and exposed ports.
+func getServicePorts(svc composeTypes.ServiceConfig) (ports []uint32) {
+	for _, mapping := range svc.Ports {
+		if mapping.Protocol == "tcp" {
+			ports = append(ports, mapping.Target)
+		}
+	}
+
+	for _, expose := range svc.Expose {
+		port, err := strconv.ParseUint(expose, 10, 32)
+		if err != nil {
+			// Port ranges aren't supported.
+			continue
+		}
+		ports = append(ports, uint32(port))
+	}
+
+	var unique []uint32
+	seen := map[uint32]bool{}
+	for _, port := range ports {
+		if !seen[port] {
+			seen[port] = true
+			unique = append(unique, port)
+		}
+	}
+	return unique
+}
+
+// startLocalServiceTunnels connects the services that are running locally to
+// the sandbox. The local services are exposed to the sandbox under their
+// service names, and the sandbox services that they depend on are forwarded
+// to localhost.
+func (cmd *up) startLocalServiceTunnels(ncc node.ControllerClient,
+	remoteCfg composeTypes.Config, local []composeTypes.ServiceConfig) {
+
+	for _, svc := range local {
+		localAddrs := map[uint32]string{}
+		for _, port := range getServicePorts(svc) {
+			localAddrs[port] = fmt.Sprintf("127.0.0.1:%d", port)
+		}
+
+		go func(name string) {
+			err := tunnel.ReverseClient(ncc, cmd.getAuthToken, name, localAddrs)
+			if err != nil {
+				log.WithError(err).WithField("service", name).
+					Warn("Stopped forwarding connections from the sandbox to the local service")
+			}
+		}(svc.Name)
+	}
+
+	// Ports that are already published by the sandbox services don't need
+	// to be forwarded again.
+	published := map[uint32]bool{}
+	for _, svc := range remoteCfg.Services {
+		for _, mapping := range svc.Ports {
+			published[mapping.Published] = true
+		}
+	}
+
+	var deps []string
+	for _, svc := range local {
+		for dep := range svc.DependsOn {
+			deps = append(deps, dep)
+		}
+		deps = append(deps, svc.Links...)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "LOCAL SERVICE DEPENDENCY\tADDRESS")
+	for _, svc := range remoteCfg.Services {
+		if !isDependency(deps, svc.Name) {
+			continue
+		}
+
+		for _, port := range getServicePorts(svc) {
+			addr := fmt.Sprintf("127.0.0.1:%d", port)
+			if published[port] {
+				fmt.Fprintf(w, "%s\t%s\n", svc.Name, addr)
+				continue
+			}
+
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				log.WithError(err).WithField("service", svc.Name).
+					Warnf("Failed to forward %s to the local services", addr)
+				continue
+			}
+			published[port] = true
+			fmt.Fprintf(w, "%s\t%s\n", svc.Name, addr)
+
+			go func(name string, port uint32) {
+				err := tunnel.Client(ncc, ln, cmd.getAuthToken, name, port)
+				if err != nil {
+					log.WithError(err).WithField("service", name).
+						Warn("Stopped forwarding to the local services")
+				}
+			}(svc.Name, port)
+		}
+	}
+}
+
+// isDependency returns whether the dependency list includes the service.
+// Links may be in the form `service:alias`.
+func isDependency(deps []string, svc string) bool {
+	for _, dep := range deps {
+		if dep == svc || strings.HasPrefix(dep, svc+":") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package up
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// DefaultParallel is the default for --parallel.
+const DefaultParallel = 8
+
+// The operations whose concurrency can be limited with the `parallel` setting
+// in blimp.yaml.
+const (
+	// OperationPush is pushing built images to the sandbox's registry.
+	OperationPush = "push"
+
+	// OperationRegistry is looking up images in registries, such as to
+	// resolve their digests, or to check whether they've already been
+	// pushed.
+	OperationRegistry = "registry"
+)
+
+var operations = []string{OperationPush, OperationRegistry}
+
+// workerPool limits how many network operations `blimp up` runs at once, so
+// that Compose files with many services don't trip the rate limits of the
+// manager and registries.
+//
+// All operations share the pool's slots. Operations that are waiting for a
+// slot are admitted in roughly the order that they started waiting, so a
+// phase with many operations can't starve operations from other phases.
+type workerPool struct {
+	slots chan struct{}
+
+	// limits caps the concurrency of individual operations below the size
+	// of the pool. Operations without a limit can use the whole pool.
+	limits map[string]int
+}
+
+// newWorkerPool returns a pool that runs up to `size` operations at once.
+func newWorkerPool(size int, limits map[string]int) (*workerPool, error) {
+	if size < 1 {
+		return nil, errors.NewFriendlyError("Invalid --parallel %d. It must be at least 1.", size)
+	}
+
+	for op, limit := range limits {
+		if !isOperation(op) {
+			return nil, errors.NewFriendlyError("Unknown operation %q in the parallel setting. "+
+				"It must be one of %s.", op, strings.Join(operations, ", "))
+		}
+		if limit < 1 {
+			return nil, errors.NewFriendlyError("Invalid parallel limit %d for %s. It must be at least 1.",
+				limit, op)
+		}
+	}
+
+	return &workerPool{
+		slots:  make(chan struct{}, size),
+		limits: limits,
+	}, nil
+}
+
+// limit returns how many instances of the operation can run at once.
+func (p *workerPool) limit(op string) int {
+	if limit, ok := p.limits[op]; ok && limit < cap(p.slots) {
+		return limit
+	}
+	return cap(p.slots)
+}
+
+// forEach calls `fn` for each item, and waits for the calls to complete. The
+// calls run concurrently, within the limits of the pool and the operation.
+func (p *workerPool) forEach(op string, items []string, fn func(string)) {
+	workers := p.limit(op)
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	itemsChan := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range itemsChan {
+				p.slots <- struct{}{}
+				fn(item)
+				<-p.slots
+			}
+		}()
+	}
+
+	for _, item := range items {
+		itemsChan <- item
+	}
+	close(itemsChan)
+	wg.Wait()
+}
+
+// forEachErr is like forEach, but for functions that can fail. It returns
+// the first error, after all the calls have completed.
+func (p *workerPool) forEachErr(op string, items []string, fn func(string) error) error {
+	var firstErr error
+	var errLock sync.Mutex
+	p.forEach(op, items, func(item string) {
+		if err := fn(item); err != nil {
+			errLock.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			errLock.Unlock()
+		}
+	})
+	return firstErr
+}
+
+func isOperation(op string) bool {
+	for _, known := range operations {
+		if op == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package up
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	composeTypes "github.com/kelda/compose-go/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/cfgdir"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+const (
+	// PortCollisionPrompt asks the user whether to remap each port that's
+	// already forwarded by another `blimp up`.
+	PortCollisionPrompt = "prompt"
+
+	// PortCollisionRemap remaps colliding ports to the next free port
+	// without asking.
+	PortCollisionRemap = "remap"
+
+	// PortCollisionFail exits if any ports collide.
+	PortCollisionFail = "fail"
+)
+
+// portClaimsPath is where each running `blimp up` records the local ports
+// that it forwards, so that other projects can detect collisions before
+// their tunnels fail to listen.
+var portClaimsPath = cfgdir.Expand("port-claims.yaml")
+
+// portRemapsPath is where the ports remapped because of collisions are
+// recorded, so that services keep the same local ports on subsequent runs.
+// The remaps are keyed by the path to the Compose file, and then by
+// portKey.
+var portRemapsPath = cfgdir.Expand("port-remaps.yaml")
+
+// portClaim is a local port forwarded by a running `blimp up`.
+type portClaim struct {
+	PID      int    `json:"pid"`
+	Project  string `json:"project"`
+	Service  string `json:"service"`
+	HostIP   string `json:"host_ip"`
+	HostPort uint32 `json:"host_port"`
+}
+
+// portKey identifies a published port in remaps, such as "web:3000".
+func portKey(service string, published uint32) string {
+	return fmt.Sprintf("%s:%d", service, published)
+}
+
+// resolvePortCollisions remaps the published ports that are already
+// forwarded by another `blimp up`, and claims the resulting ports for this
+// process. Ports that were remapped by previous runs, or in blimp.yaml, are
+// remapped first so that they stay stable.
+func (cmd *up) resolvePortCollisions(cfg *composeTypes.Config, projectCfg projectcfg.Config) error {
+	mode := cmd.onPortCollision
+	if mode == "" {
+		mode = projectCfg.OnPortCollision
+	}
+	switch mode {
+	case "", PortCollisionPrompt, PortCollisionRemap, PortCollisionFail:
+	default:
+		return errors.NewFriendlyError("Unknown --on-port-collision value %q. It must be one of %q, %q, or %q.",
+			mode, PortCollisionPrompt, PortCollisionRemap, PortCollisionFail)
+	}
+
+	allRemaps := readPortRemaps()
+	remaps := allRemaps[cmd.composePath]
+	if remaps == nil {
+		remaps = map[string]uint32{}
+	}
+	for key, port := range projectCfg.PortRemaps {
+		remaps[key] = port
+	}
+
+	others := readPortClaims()
+	var claims []portClaim
+	for i, svc := range cfg.Services {
+		for j, mapping := range svc.Ports {
+			if mapping.Protocol != "tcp" {
+				continue
+			}
+
+			key := portKey(svc.Name, mapping.Published)
+			port, ok := remaps[key]
+			if !ok {
+				port = mapping.Published
+			}
+
+			if owner, ok := findClaim(others, mapping.HostIP, port); ok {
+				newPort, err := cmd.remapPort(mode, svc.Name, mapping.HostIP, port, owner, append(others, claims...))
+				if err != nil {
+					return err
+				}
+				port = newPort
+				remaps[key] = port
+			}
+
+			if port != mapping.Published {
+				fmt.Printf("Forwarding %s's port %d to localhost:%d instead.\n",
+					svc.Name, mapping.Published, port)
+			}
+			cfg.Services[i].Ports[j].Published = port
+			claims = append(claims, portClaim{
+				PID:      os.Getpid(),
+				Project:  cmd.getProjectName(),
+				Service:  svc.Name,
+				HostIP:   mapping.HostIP,
+				HostPort: port,
+			})
+		}
+	}
+
+	allRemaps[cmd.composePath] = remaps
+	writeYAML(portRemapsPath, allRemaps)
+	writeYAML(portClaimsPath, append(others, claims...))
+	return nil
+}
+
+// remapPort picks a new local port for a port that's claimed by `owner`,
+// according to the --on-port-collision mode.
+func (cmd *up) remapPort(mode, service, hostIP string, port uint32, owner portClaim,
+	claims []portClaim) (uint32, error) {
+
+	collision := fmt.Sprintf("%s's port %d is already forwarded for %s in the %s project.",
+		service, port, owner.Service, owner.Project)
+	newPort, ok := findFreePort(claims, hostIP, port)
+	if !ok || mode == PortCollisionFail {
+		return 0, errors.NewFriendlyError("%s\nStop the other `blimp up`, or remap the port with "+
+			"port_remaps in %s.", collision, projectcfg.Filename)
+	}
+
+	if mode == PortCollisionRemap {
+		return newPort, nil
+	}
+
+	fmt.Printf("%s\nForward it to localhost:%d instead? The new port will be used on future runs. (Y/n) ",
+		collision, newPort)
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return 0, errors.NewFriendlyError("%s\nRun with `--on-port-collision %s` to remap it "+
+			"without asking.", collision, PortCollisionRemap)
+	}
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "", "y", "yes":
+		return newPort, nil
+	default:
+		return 0, errors.NewFriendlyError("Aborting.")
+	}
+}
+
+// releasePortClaims removes the ports claimed by this process, so that other
+// projects can use them.
+func releasePortClaims() {
+	writeYAML(portClaimsPath, readPortClaims())
+}
+
+// readPortClaims returns the ports claimed by other `blimp up` processes
+// that are still running.
+func readPortClaims() []portClaim {
+	var claims []portClaim
+	readYAML(portClaimsPath, &claims)
+
+	var alive []portClaim
+	for _, claim := range claims {
+		if claim.PID != os.Getpid() && util.ProcessAlive(claim.PID) {
+			alive = append(alive, claim)
+		}
+	}
+	return alive
+}
+
+func readPortRemaps() map[string]map[string]uint32 {
+	remaps := map[string]map[string]uint32{}
+	readYAML(portRemapsPath, &remaps)
+	return remaps
+}
+
+// findClaim returns the claim for the given local address, if any.
+func findClaim(claims []portClaim, hostIP string, port uint32) (portClaim, bool) {
+	for _, claim := range claims {
+		if claim.HostPort == port && hostIPsOverlap(claim.HostIP, hostIP) {
+			return claim, true
+		}
+	}
+	return portClaim{}, false
+}
+
+// hostIPsOverlap returns whether listening on both addresses would conflict.
+// Listening on all interfaces conflicts with listening on any one of them.
+func hostIPsOverlap(a, b string) bool {
+	isWildcard := func(ip string) bool {
+		return ip == "" || ip == "0.0.0.0" || ip == "::"
+	}
+	return a == b || isWildcard(a) || isWildcard(b)
+}
+
+// findFreePort returns the first port after `port` that isn't claimed, and
+// that can be listened on.
+func findFreePort(claims []portClaim, hostIP string, port uint32) (uint32, bool) {
+	for candidate := port + 1; candidate <= 65535; candidate++ {
+		if _, ok := findClaim(claims, hostIP, candidate); ok {
+			continue
+		}
+
+		ln, err := net.Listen("tcp", fmt.Sprintf("%s:%d", hostIP, candidate))
+		if err != nil {
+			continue
+		}
+		ln.Close()
+		return candidate, true
+	}
+	return 0, false
+}
+
+// readYAML parses the state file at `path` into `out`. The state is only
+// used to improve the user experience, so errors are logged rather than
+// returned.
+func readYAML(path string, out interface{}) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithError(err).WithField("path", path).Debug("Failed to read state")
+		}
+		return
+	}
+
+	if err := yaml.Unmarshal(contents, out); err != nil {
+		log.WithError(err).WithField("path", path).Debug("Failed to parse state")
+	}
+}
+
+func writeYAML(path string, in interface{}) {
+	contents, err := yaml.Marshal(in)
+	if err != nil {
+		log.WithError(err).WithField("path", path).Debug("Failed to marshal state")
+		return
+	}
+
+	if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+		log.WithError(err).WithField("path", path).Debug("Failed to write state")
+	}
+}
@@ -0,0 +1,251 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+const (
+	// pushAttempts is the number of times a push is attempted before giving
+	// up. Blobs that were uploaded by previous attempts are skipped, so each
+	// retry only uploads the layers that haven't been pushed yet.
+	pushAttempts = 5
+
+	// pushChunkSize is the largest write to the registry connection. Small
+	// chunks keep the rate limit smooth, and the progress bar responsive.
+	pushChunkSize = 32 * 1024
+
+	pushProgressInterval = 500 * time.Millisecond
+)
+
+// pushImage uploads the image to the sandbox's registry. The image is pushed
+// directly rather than through the Docker daemon so that the upload can be
+// rate limited and retried. If other images are being pushed concurrently,
+// the progress isn't updated in place, since the lines would overwrite each
+// other.
+func (cmd *up) pushImage(svc, imageID, remoteImageName string, concurrent bool) error {
+	ref, err := name.NewTag(remoteImageName)
+	if err != nil {
+		return errors.WithContext("parse image name", err)
+	}
+
+	// Export the image to disk so that retries don't have to export it again.
+	imagePath, err := cmd.saveImage(imageID)
+	if err != nil {
+		return errors.WithContext("export image", err)
+	}
+	defer os.Remove(imagePath)
+
+	img, err := tarball.ImageFromPath(imagePath, nil)
+	if err != nil {
+		return errors.WithContext("read image", err)
+	}
+
+	progress := &pushProgress{svc: svc}
+	if layers, err := img.Layers(); err == nil {
+		for _, layer := range layers {
+			if size, err := layer.Size(); err == nil {
+				progress.total += size
+			}
+		}
+	}
+
+	transport := &pushTransport{
+		base:     http.DefaultTransport,
+		limiter:  cmd.pushLimiter,
+		progress: progress,
+	}
+
+	out, isTerminal := cmd.getProgressOutput()
+	stopProgress := progress.run(out, isTerminal && !concurrent)
+	defer stopProgress()
+
+	for attempt := 1; ; attempt++ {
+		progress.setAttempt(attempt)
+		err = remote.Write(ref, img,
+			remote.WithAuth(&authn.Basic{Username: "ignored", Password: cmd.auth.AuthToken}),
+			remote.WithTransport(transport))
+		if err == nil {
+			return nil
+		}
+
+		if attempt == pushAttempts {
+			return errors.WithContext(fmt.Sprintf("push image (gave up after %d attempts)", pushAttempts), err)
+		}
+
+		log.WithError(err).WithField("service", svc).Debug("Image push failed. Retrying.")
+		time.Sleep(time.Duration(attempt) * 2 * time.Second)
+	}
+}
+
+// saveImage exports the image from the local Docker daemon to a temporary
+// file, and returns the path to the file.
+func (cmd *up) saveImage(imageID string) (string, error) {
+	saved, err := cmd.dockerClient.ImageSave(context.TODO(), []string{imageID})
+	if err != nil {
+		return "", err
+	}
+	defer saved.Close()
+
+	f, err := ioutil.TempFile("", "blimp-push-*.tar")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, saved); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// pushTransport rate limits and tracks the progress of the blobs uploaded
+// to the registry.
+type pushTransport struct {
+	base     http.RoundTripper
+	limiter  *rateLimiter
+	progress *pushProgress
+}
+
+func (t *pushTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && (req.Method == http.MethodPatch || req.Method == http.MethodPut) {
+		req.Body = &pushBody{
+			ReadCloser: req.Body,
+			limiter:    t.limiter,
+			progress:   t.progress,
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+type pushBody struct {
+	io.ReadCloser
+	limiter  *rateLimiter
+	progress *pushProgress
+}
+
+func (b *pushBody) Read(p []byte) (int, error) {
+	if len(p) > pushChunkSize {
+		p = p[:pushChunkSize]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	b.limiter.wait(n)
+	b.progress.add(n)
+	return n, err
+}
+
+// rateLimiter limits the rate at which bytes are sent. A nil rateLimiter
+// doesn't limit anything.
+type rateLimiter struct {
+	bytesPerSecond int64
+
+	// The time that the next byte can be sent.
+	next time.Time
+	sync.Mutex
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{bytesPerSecond: bytesPerSecond}
+}
+
+// wait blocks until the given number of bytes can be sent without exceeding
+// the rate limit.
+func (l *rateLimiter) wait(n int) {
+	if l == nil || n == 0 {
+		return
+	}
+
+	l.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(int64(n) * int64(time.Second) / l.bytesPerSecond))
+	delay := l.next.Sub(now)
+	l.Unlock()
+
+	time.Sleep(delay)
+}
+
+// pushProgress tracks how much of an image has been uploaded. The count
+// restarts with each attempt, so the progress goes backwards when a push is
+// retried rather than counting re-sent bytes twice. Layers uploaded by an
+// earlier attempt are skipped, so a retried push can finish below the total.
+type pushProgress struct {
+	svc   string
+	total int64
+
+	sent    int64
+	attempt int32
+}
+
+func (p *pushProgress) add(n int) {
+	atomic.AddInt64(&p.sent, int64(n))
+}
+
+func (p *pushProgress) setAttempt(attempt int) {
+	atomic.StoreInt64(&p.sent, 0)
+	atomic.StoreInt32(&p.attempt, int32(attempt))
+}
+
+func (p *pushProgress) String() string {
+	msg := fmt.Sprintf("Pushing %s: %s", p.svc, util.FormatBytes(atomic.LoadInt64(&p.sent)))
+	if p.total != 0 {
+		msg += " / " + util.FormatBytes(p.total)
+	}
+	if attempt := atomic.LoadInt32(&p.attempt); attempt > 1 {
+		msg += fmt.Sprintf(" (retry %d/%d)", attempt-1, pushAttempts-1)
+	}
+	return msg
+}
+
+// run prints the progress until the returned function is called. When the
+// output is a terminal, the progress is updated in place. Otherwise, it's
+// only printed once the push completes so that logs aren't flooded.
+func (p *pushProgress) run(out io.Writer, isTerminal bool) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(pushProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				fmt.Fprintf(out, "\r%s\n", p)
+				return
+			case <-ticker.C:
+				if isTerminal {
+					fmt.Fprintf(out, "\r%s", p)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -0,0 +1,120 @@
+package up
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushProgressString(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  int64
+		sent   int
+		retry  int
+		expMsg string
+	}{
+		{
+			name:   "Unknown total",
+			sent:   512,
+			expMsg: "Pushing web: 512B",
+		},
+		{
+			name:   "Known total",
+			total:  10 * 1024 * 1024,
+			sent:   1536 * 1024,
+			expMsg: "Pushing web: 1.5MiB / 10.0MiB",
+		},
+		{
+			name:   "Retry",
+			total:  10 * 1024 * 1024,
+			sent:   1024,
+			retry:  2,
+			expMsg: "Pushing web: 1.0KiB / 10.0MiB (retry 1/4)",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			progress := &pushProgress{svc: "web", total: test.total}
+			if test.retry != 0 {
+				progress.setAttempt(test.retry)
+			}
+			progress.add(test.sent)
+			assert.Equal(t, test.expMsg, progress.String())
+		})
+	}
+}
+
+func TestPushProgressSetAttempt(t *testing.T) {
+	progress := &pushProgress{svc: "web", total: 4096}
+	progress.setAttempt(1)
+	progress.add(2048)
+	assert.Equal(t, "Pushing web: 2.0KiB / 4.0KiB", progress.String())
+
+	// Bytes sent by the failed attempt aren't counted again.
+	progress.setAttempt(2)
+	progress.add(1024)
+	assert.Equal(t, "Pushing web: 1.0KiB / 4.0KiB (retry 1/4)", progress.String())
+}
+
+func TestPushBody(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 3*pushChunkSize+10)
+	progress := &pushProgress{svc: "web"}
+	body := &pushBody{
+		ReadCloser: ioutil.NopCloser(bytes.NewReader(data)),
+		progress:   progress,
+	}
+
+	// Reads are split into chunks so that the rate limit stays smooth.
+	buf := make([]byte, 2*pushChunkSize)
+	n, err := body.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, pushChunkSize, n)
+
+	read, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, data[pushChunkSize:], read)
+	assert.Equal(t, int64(len(data)), progress.sent)
+}
+
+func TestRateLimiter(t *testing.T) {
+	assert.Nil(t, newRateLimiter(0))
+	assert.Nil(t, newRateLimiter(-1))
+
+	// A nil limiter doesn't block.
+	var unlimited *rateLimiter
+	unlimited.wait(1024 * 1024)
+
+	limiter := newRateLimiter(10 * 1024)
+	start := time.Now()
+	limiter.wait(512)
+	limiter.wait(512)
+	assert.True(t, time.Since(start) >= 100*time.Millisecond, "wait took %s", time.Since(start))
+}
+
+func TestPushProgressRun(t *testing.T) {
+	// When the output isn't a terminal, the progress is only printed once
+	// the push completes.
+	var out bytes.Buffer
+	progress := &pushProgress{svc: "web", total: 2048}
+	stop := progress.run(&out, false)
+	progress.add(2048)
+	time.Sleep(2 * pushProgressInterval)
+	stop()
+	assert.Equal(t, "\rPushing web: 2.0KiB / 2.0KiB\n", out.String())
+
+	// On a terminal, the progress is also updated in place while pushing.
+	out.Reset()
+	progress = &pushProgress{svc: "web", total: 2048}
+	stop = progress.run(&out, true)
+	time.Sleep(2 * pushProgressInterval)
+	stop()
+	assert.True(t, strings.Count(out.String(), "\rPushing web: 0B / 2.0KiB") > 1, "output: %q", out.String())
+	assert.True(t, strings.HasSuffix(out.String(), "\rPushing web: 0B / 2.0KiB\n"), "output: %q", out.String())
+}
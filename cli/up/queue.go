@@ -0,0 +1,93 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/buger/goterm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// waitForCapacity blocks until the cluster has capacity for the user's
+// sandbox, printing the user's position in the queue in the meantime. If
+// `noQueue` is true, an error is returned instead of waiting.
+func waitForCapacity(authToken string, noQueue bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := manager.C.WaitForCapacity(ctx, &cluster.WaitForCapacityRequest{
+		Token:   authToken,
+		NoQueue: noQueue,
+	})
+	if err != nil {
+		return errors.WithContext("join queue", err)
+	}
+
+	var printedStatus bool
+	for {
+		msg, err := stream.Recv()
+		switch {
+		// Managers that don't queue sandboxes always have capacity.
+		case status.Code(err) == codes.Unimplemented:
+			return nil
+		// The manager closes the stream after admitting the user, so EOF
+		// shouldn't happen before then.
+		case err == io.EOF:
+			return errors.New("queue closed unexpectedly")
+		case err != nil:
+			return errors.WithContext("read queue status", err)
+		}
+
+		if err := errors.Unmarshal(nil, msg.Error); err != nil {
+			return err
+		}
+
+		if printedStatus {
+			fmt.Print(goterm.ResetLine(""))
+		}
+
+		if msg.Admitted {
+			if printedStatus {
+				fmt.Println("The cluster has capacity for your sandbox.")
+			}
+			return nil
+		}
+
+		if noQueue {
+			return errors.NewFriendlyError("The cluster is at capacity, and --no-queue was set. "+
+				"You would have been %s in the queue.", formatPosition(msg.Position))
+		}
+
+		queueMsg := fmt.Sprintf("The cluster is at capacity. You're %s in the queue",
+			formatPosition(msg.Position))
+		if msg.EtaSeconds != 0 {
+			eta := time.Duration(msg.EtaSeconds) * time.Second
+			queueMsg += fmt.Sprintf(", ~%s", eta.Round(time.Second))
+		}
+		fmt.Fprint(os.Stdout, goterm.Color(queueMsg, goterm.YELLOW)+"\r")
+		printedStatus = true
+	}
+}
+
+// formatPosition formats the queue position as an ordinal, such as "3rd".
+func formatPosition(position int32) string {
+	suffix := "th"
+	switch {
+	case position%100 >= 11 && position%100 <= 13:
+	case position%10 == 1:
+		suffix = "st"
+	case position%10 == 2:
+		suffix = "nd"
+	case position%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", position, suffix)
+}
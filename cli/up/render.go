@@ -0,0 +1,79 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// renderSandbox prints the Kubernetes objects that the manager would deploy
+// for the Compose file, without modifying the sandbox.
+func (cmd *up) renderSandbox(composeFile string) error {
+	resp, err := cmd.fetchRender(composeFile)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range resp.Warnings {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+	}
+
+	for i, obj := range resp.Objects {
+		if i != 0 {
+			fmt.Println("---")
+		}
+		fmt.Println(strings.TrimSpace(obj.Yaml))
+	}
+	return nil
+}
+
+// checkStrict returns an error listing the parts of the Compose file that
+// the manager would ignore when deploying. The manager's renderer is the
+// source of truth for what's supported, so the check is done by rendering
+// the Compose file rather than by a list of keys in the CLI.
+func (cmd *up) checkStrict(composeFile string) error {
+	resp, err := cmd.fetchRender(composeFile)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Warnings) == 0 {
+		return nil
+	}
+
+	var unsupported []string
+	for _, warning := range resp.Warnings {
+		unsupported = append(unsupported, "- "+warning)
+	}
+	return errors.NewFriendlyError("Strict mode is enabled, and the Compose file uses features "+
+		"that Blimp doesn't support:\n%s\n\n"+
+		"Remove them from the Compose file, or run without --strict to ignore them.",
+		strings.Join(unsupported, "\n"))
+}
+
+func (cmd *up) fetchRender(composeFile string) (*cluster.RenderSandboxResponse, error) {
+	resp, err := manager.C.RenderSandbox(context.Background(), &cluster.RenderSandboxRequest{
+		Token:       cmd.auth.AuthToken,
+		ComposeFile: composeFile,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return nil, errors.NewFriendlyError("The cluster doesn't support rendering Compose files. " +
+				"Ask your administrator to upgrade the Blimp manager.")
+		}
+		return nil, errors.WithContext("render sandbox", err)
+	}
+
+	if err := errors.Unmarshal(nil, resp.Error); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,61 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/buger/goterm"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// watchRescheduling notifies the user when services are moved to new nodes,
+// such as when their node is drained or preempted. Otherwise, the services
+// would appear to restart without explanation. Only reschedules after `since`
+// are reported. The events are shown with `notify`.
+func watchRescheduling(ctx context.Context, clusterManager manager.Client, authToken string, since time.Time,
+	notify func(string)) {
+	lastSeen := map[string]int64{}
+	watch := func() error {
+		stream, err := clusterManager.WatchStatus(ctx, &cluster.GetStatusRequest{
+			Token: authToken,
+		})
+		if err != nil {
+			return errors.WithContext("start status watch", err)
+		}
+
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return errors.WithContext("read stream", err)
+			}
+
+			for svc, svcStatus := range msg.GetStatus().GetServices() {
+				event := svcStatus.GetLastRescheduled()
+				if event == nil || event.Timestamp < since.Unix() || event.Timestamp <= lastSeen[svc] {
+					continue
+				}
+
+				lastSeen[svc] = event.Timestamp
+				notify(goterm.Color(fmt.Sprintf("%s was moved to a new node: %s", svc, event.Msg),
+					goterm.YELLOW))
+			}
+		}
+	}
+
+	for {
+		err := watch()
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		log.WithError(err).Debug("Failed to watch for rescheduled services")
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -16,42 +16,185 @@ import (
 
 	"github.com/kelda/blimp/cli/manager"
 	"github.com/kelda/blimp/cli/ps"
+	"github.com/kelda/blimp/cli/util"
 	"github.com/kelda/blimp/pkg/errors"
 	"github.com/kelda/blimp/pkg/proto/cluster"
+	"github.com/kelda/blimp/pkg/proto/node"
 )
 
 type statusPrinter struct {
 	services []string
 
 	currStatus map[string]*cluster.ServiceStatus
+	violations []*cluster.PolicyViolation
+	pulls      map[string]*pullProgress
 	sync.Mutex
 
 	prevLinesPrinted int
 	spinnerIdx       int
+
+	// notes are messages for the user, such as why a service isn't healthy
+	// yet, that haven't been printed yet. They're printed above the status
+	// when it's next redrawn so that they don't get overwritten by it. Once
+	// the services have booted, notes are printed immediately.
+	notes  []string
+	booted bool
+
+	// If quiet is set, the status isn't redrawn. Instead, a line is printed
+	// when each service boots, and a summary is printed once all the
+	// services have booted.
+	quiet bool
+
+	// startTime is when `blimp up` started, and deployTime is when the
+	// services were deployed. They're used to report how long booting took.
+	startTime  time.Time
+	deployTime time.Time
+	bootTimes  map[string]time.Duration
 }
 
 var spinnerChars = []string{"/", "-", "\\", "|"}
 
+// pullProgress tracks the progress of an image pull so that we can estimate
+// how long it will take to complete.
+type pullProgress struct {
+	latest *node.ImagePullProgress
+
+	// The time and number of downloaded bytes when we first saw the pull.
+	startTime  time.Time
+	startBytes int64
+}
+
 func newStatusPrinter(services []string) *statusPrinter {
-	sp := &statusPrinter{services: services}
+	sp := &statusPrinter{
+		services:  services,
+		pulls:     map[string]*pullProgress{},
+		bootTimes: map[string]time.Duration{},
+	}
 	sort.Strings(sp.services)
 	return sp
 }
 
-func (sp *statusPrinter) Run(clusterManager manager.Client, authToken string) {
+// errBootTimeout is returned by statusPrinter.Run if the services don't boot
+// within the timeout.
+var errBootTimeout = errors.New("timed out waiting for services to boot")
+
+// Run prints the status of the services until they've all booted. If
+// `timeout` is non-zero, and the services don't boot in time, errBootTimeout is
+// returned.
+func (sp *statusPrinter) Run(clusterManager manager.Client, nodeController node.ControllerClient,
+	authToken string, timeout time.Duration) error {
 	// Stop watching the status after we're done printing the status.
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
 
+	sp.deployTime = time.Now()
 	go sp.syncStatus(ctx, clusterManager, authToken)
+	go sp.syncImagePulls(ctx, nodeController, authToken)
 
-	for {
-		if sp.printStatus() {
-			break
+	var timeoutChan <-chan time.Time
+	if timeout != 0 {
+		timeoutChan = time.After(timeout)
+	}
+
+	printStatus := sp.printStatus
+	if sp.quiet {
+		printStatus = sp.printBootedServices
+	}
+
+	// Print the notes that arrive after boot directly, including when
+	// booting fails.
+	defer sp.finishBoot()
+
+	for !printStatus() {
+		// The services that depend on a failed job will never start, so
+		// there's no point in waiting for them.
+		if err := sp.checkFailedJobs(); err != nil {
+			return err
+		}
+
+		select {
+		case <-timeoutChan:
+			return errBootTimeout
+		case <-time.After(1 * time.Second):
+		}
+	}
+	if sp.quiet {
+		sp.printSummary()
+	} else {
+		fmt.Println(goterm.Color("All containers successfully started", goterm.GREEN))
+	}
+
+	sp.Lock()
+	ps.PrintPolicyViolations(os.Stdout, sp.violations)
+	sp.Unlock()
+	return nil
+}
+
+// notify shows `msg` to the user. Printing it directly while the status is
+// being redrawn would corrupt the status, so it's deferred until the next
+// redraw if the services haven't booted yet.
+func (sp *statusPrinter) notify(msg string) {
+	sp.Lock()
+	defer sp.Unlock()
+
+	if sp.booted {
+		fmt.Println(msg)
+		return
+	}
+	sp.notes = append(sp.notes, msg)
+}
+
+// printNotes prints the notes that have arrived since the last redraw.
+func (sp *statusPrinter) printNotes() {
+	sp.Lock()
+	defer sp.Unlock()
+
+	for _, note := range sp.notes {
+		fmt.Println(note)
+	}
+	sp.notes = nil
+}
+
+// finishBoot prints the pending notes, and makes future notes print
+// immediately.
+func (sp *statusPrinter) finishBoot() {
+	sp.Lock()
+	defer sp.Unlock()
+
+	for _, note := range sp.notes {
+		fmt.Println(note)
+	}
+	sp.notes = nil
+	sp.booted = true
+}
+
+// checkFailedJobs returns an error if any of the jobs exited unsuccessfully.
+func (sp *statusPrinter) checkFailedJobs() error {
+	sp.Lock()
+	defer sp.Unlock()
+
+	for _, svc := range sp.services {
+		svcStatus := sp.currStatus[svc]
+		if svcStatus.GetIsJob() && svcStatus.GetPhase() == cluster.ServicePhase_EXITED {
+			return errors.NewFriendlyError("The job %s failed with exit code %d. "+
+				"The services that depend on it won't start.\n"+
+				"Run `blimp logs %s` to see why it failed.", svc, svcStatus.GetExitCode(), svc)
 		}
-		time.Sleep(1 * time.Second)
 	}
-	fmt.Println(goterm.Color("All containers successfully started", goterm.GREEN))
+	return nil
+}
+
+// unbootedServices returns the services that haven't started yet.
+func (sp *statusPrinter) unbootedServices() (services []string) {
+	sp.Lock()
+	defer sp.Unlock()
+
+	for _, svc := range sp.services {
+		if !sp.currStatus[svc].GetHasStarted() {
+			services = append(services, svc)
+		}
+	}
+	return services
 }
 
 func (sp *statusPrinter) syncStatus(ctx context.Context,
@@ -73,6 +216,7 @@ func (sp *statusPrinter) syncStatus(ctx context.Context,
 
 			sp.Lock()
 			sp.currStatus = msg.Status.Services
+			sp.violations = msg.Status.PolicyViolations
 			sp.Unlock()
 		}
 	}
@@ -96,6 +240,51 @@ func (sp *statusPrinter) syncStatus(ctx context.Context,
 	}
 }
 
+func (sp *statusPrinter) syncImagePulls(ctx context.Context,
+	nodeController node.ControllerClient, authToken string) {
+	syncStream := func(stream node.Controller_WatchImagePullsClient) error {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+
+			sp.Lock()
+			if pull, ok := sp.pulls[msg.Service]; ok && pull.latest.Image == msg.Image {
+				pull.latest = msg
+			} else {
+				sp.pulls[msg.Service] = &pullProgress{
+					latest:     msg,
+					startTime:  time.Now(),
+					startBytes: msg.BytesDone,
+				}
+			}
+			sp.Unlock()
+		}
+	}
+
+	for {
+		stream, err := nodeController.WatchImagePulls(ctx, &node.WatchImagePullsRequest{
+			Token: authToken,
+		})
+		if err == nil {
+			err = syncStream(stream)
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		// Older node controllers don't report pull progress. Fall back to
+		// the status message from the manager.
+		case status.Code(err) == codes.Unimplemented:
+			return
+		}
+
+		log.WithError(err).Debug("Failed to watch image pulls")
+		time.Sleep(5 * time.Second)
+	}
+}
+
 func (sp *statusPrinter) printStatus() bool {
 	// Reset the cursor so that we'll write over the previous status update.
 	// TODO: Doesn't properly work if the previous print spanned multiple lines.
@@ -104,6 +293,7 @@ func (sp *statusPrinter) printStatus() bool {
 		goterm.Flush()
 		fmt.Printf(goterm.ResetLine(""))
 	}
+	sp.printNotes()
 
 	sp.spinnerIdx = (sp.spinnerIdx + 1) % len(spinnerChars)
 	spinner := spinnerChars[sp.spinnerIdx]
@@ -118,13 +308,86 @@ func (sp *statusPrinter) printStatus() bool {
 			allReady = false
 		}
 
-		fmt.Fprintf(out, "%s\t%s\n", svc, goterm.Color(statusStr, color))
+		// Show the resource usage so that users can tell when a service is
+		// stuck, e.g. spinning at 100% CPU while booting. If the service's
+		// image is still being pulled, show the pull progress instead.
+		details := sp.getPullString(svc)
+		if details == "" {
+			details = sp.getServiceUsage(svc)
+		}
+		fmt.Fprintf(out, "%s\t%s\t%s\n", svc, goterm.Color(statusStr, color), details)
 	}
 
 	sp.prevLinesPrinted = len(sp.services)
 	return allReady
 }
 
+// printBootedServices prints a line for each service that has booted since
+// the last call. It returns whether all the services have booted.
+func (sp *statusPrinter) printBootedServices() bool {
+	sp.printNotes()
+
+	allReady := true
+	for _, svc := range sp.services {
+		statusStr, _, done := sp.getServiceStatus(svc)
+		if !done {
+			allReady = false
+			continue
+		}
+
+		if _, ok := sp.bootTimes[svc]; ok {
+			continue
+		}
+
+		bootTime := time.Since(sp.deployTime).Round(time.Second)
+		sp.bootTimes[svc] = bootTime
+		fmt.Printf("%s: %s after %s\n", svc, statusStr, bootTime)
+	}
+	return allReady
+}
+
+// printSummary prints how long each service took to boot.
+func (sp *statusPrinter) printSummary() {
+	fmt.Println()
+	out := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(out, "SERVICE\tSTATUS\tBOOT TIME")
+	for _, svc := range sp.services {
+		statusStr, _, _ := sp.getServiceStatus(svc)
+		fmt.Fprintf(out, "%s\t%s\t%s\n", svc, statusStr, sp.bootTimes[svc])
+	}
+	out.Flush()
+
+	fmt.Printf("\nAll containers successfully started in %s (%s since deploying)\n",
+		time.Since(sp.startTime).Round(time.Second), time.Since(sp.deployTime).Round(time.Second))
+	if summary := sp.getCacheSummary(); summary != "" {
+		fmt.Println(summary)
+	}
+}
+
+// getCacheSummary returns how many of the pulled images were served by the
+// cluster's pull-through cache, or the empty string if no images were pulled
+// through the cache.
+func (sp *statusPrinter) getCacheSummary() string {
+	sp.Lock()
+	defer sp.Unlock()
+
+	var hits, misses int
+	for _, pull := range sp.pulls {
+		switch pull.latest.CacheStatus {
+		case node.ImageCacheStatus_HIT:
+			hits++
+		case node.ImageCacheStatus_MISS:
+			misses++
+		}
+	}
+
+	if hits == 0 && misses == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Image cache: %d of %d pulled images were already cached in the cluster",
+		hits, hits+misses)
+}
+
 func (sp *statusPrinter) getServiceStatus(svc string) (msg string, color int, booted bool) {
 	sp.Lock()
 	defer sp.Unlock()
@@ -136,3 +399,45 @@ func (sp *statusPrinter) getServiceStatus(svc string) (msg string, color int, bo
 
 	return ps.GetStatusString(svcStatus)
 }
+
+func (sp *statusPrinter) getServiceUsage(svc string) string {
+	sp.Lock()
+	defer sp.Unlock()
+
+	return ps.GetUsageString(sp.currStatus[svc].GetUsage())
+}
+
+// getPullString returns a summary of the progress of the service's image
+// pull, or the empty string if the image isn't being pulled.
+func (sp *statusPrinter) getPullString(svc string) string {
+	sp.Lock()
+	defer sp.Unlock()
+
+	pull, ok := sp.pulls[svc]
+	if !ok || pull.latest.Done {
+		return ""
+	}
+
+	progress := pull.latest
+	summary := fmt.Sprintf("Pulling image: %d/%d layers, %s/%s",
+		progress.LayersDone, progress.LayersTotal,
+		util.FormatBytes(progress.BytesDone), util.FormatBytes(progress.BytesTotal))
+	switch progress.CacheStatus {
+	case node.ImageCacheStatus_HIT:
+		summary += " from the cluster's cache"
+	case node.ImageCacheStatus_MISS:
+		summary += " from upstream (not cached yet)"
+	}
+
+	// Estimate the time remaining based on the average download rate since
+	// we started watching the pull.
+	elapsed := time.Since(pull.startTime)
+	downloaded := progress.BytesDone - pull.startBytes
+	if elapsed > 0 && downloaded > 0 {
+		rate := float64(downloaded) / elapsed.Seconds()
+		remaining := float64(progress.BytesTotal-progress.BytesDone) / rate
+		eta := time.Duration(remaining * float64(time.Second)).Round(time.Second)
+		summary += fmt.Sprintf(", ETA %s", eta)
+	}
+	return summary
+}
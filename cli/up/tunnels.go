@@ -0,0 +1,238 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/status"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+	"github.com/kelda/blimp/pkg/proto/node"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// readinessWatcher tracks which services have passed their readiness checks,
+// so that tunnels to the services can wait to start forwarding. Otherwise,
+// browsers may cache the connection errors that happen while services boot.
+type readinessWatcher struct {
+	ready map[string]chan struct{}
+
+	// reasons are the most recent reasons that the services that aren't
+	// ready are waiting, such as ImagePullBackOff.
+	reasons map[string]status.ServiceReadiness
+
+	// onWaiting, if set, is called whenever the reason that a service isn't
+	// ready changes.
+	onWaiting func(svc string, readiness status.ServiceReadiness)
+
+	sync.Mutex
+}
+
+func newReadinessWatcher(services []string) *readinessWatcher {
+	rw := &readinessWatcher{
+		ready:   map[string]chan struct{}{},
+		reasons: map[string]status.ServiceReadiness{},
+	}
+	for _, svc := range services {
+		rw.ready[svc] = make(chan struct{})
+	}
+	return rw
+}
+
+// Run watches the status of the sandbox until all the services are ready, or
+// the context is cancelled.
+func (rw *readinessWatcher) Run(ctx context.Context, clusterManager manager.Client, authToken string) {
+	for {
+		stream, err := clusterManager.WatchStatus(ctx, &cluster.GetStatusRequest{
+			Token: authToken,
+		})
+		if err == nil {
+			for {
+				msg, err := stream.Recv()
+				if err != nil {
+					break
+				}
+
+				if rw.update(msg.Status.GetServices()) {
+					return
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// update marks the services that are ready, and returns whether all the
+// services are ready.
+func (rw *readinessWatcher) update(statuses map[string]*cluster.ServiceStatus) bool {
+	rw.Lock()
+	defer rw.Unlock()
+
+	for svc, ready := range rw.ready {
+		if statuses[svc].GetPhase() == cluster.ServicePhase_RUNNING {
+			close(ready)
+			delete(rw.ready, svc)
+			delete(rw.reasons, svc)
+			continue
+		}
+
+		readiness := status.GetReadiness(statuses[svc], "")
+		prev, ok := rw.reasons[svc]
+		if ok && prev.Reason == readiness.Reason && prev.Message == readiness.Message {
+			continue
+		}
+
+		rw.reasons[svc] = readiness
+		if rw.onWaiting != nil {
+			rw.onWaiting(svc, readiness)
+		}
+	}
+	return len(rw.ready) == 0
+}
+
+// Reason returns why the service isn't ready yet, such as
+// "ImagePullBackOff: Back-off pulling image". It's empty if the service is
+// ready, or its status hasn't been received yet.
+func (rw *readinessWatcher) Reason(svc string) string {
+	rw.Lock()
+	defer rw.Unlock()
+
+	readiness, ok := rw.reasons[svc]
+	if !ok {
+		return ""
+	}
+	return formatReadinessReason(readiness)
+}
+
+func formatReadinessReason(readiness status.ServiceReadiness) string {
+	if readiness.Message == "" {
+		return readiness.Reason
+	}
+	return fmt.Sprintf("%s: %s", readiness.Reason, readiness.Message)
+}
+
+// Ready returns a channel that's closed once the service is ready.
+func (rw *readinessWatcher) Ready(svc string) <-chan struct{} {
+	rw.Lock()
+	defer rw.Unlock()
+
+	if ready, ok := rw.ready[svc]; ok {
+		return ready
+	}
+
+	// The service is already ready.
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+// startGatedTunnel starts a tunnel once the service is ready. If `placeholder`
+// is true, a page explaining that the service is starting is served on the
+// local port in the meantime.
+func startGatedTunnel(ncc node.ControllerClient, tokenSource tunnel.TokenSource,
+	readiness *readinessWatcher, placeholder bool, name, hostIP string, hostPort, containerPort uint32) {
+
+	ready := readiness.Ready(name)
+	if placeholder {
+		ln := listen(name, hostIP, hostPort)
+		srv := &http.Server{Handler: placeholderHandler(name, func() string {
+			return readiness.Reason(name)
+		})}
+		go srv.Serve(ln)
+
+		<-ready
+
+		// Closing the server also closes the listener, so that the tunnel
+		// can listen on the same port.
+		if err := srv.Close(); err != nil {
+			log.WithError(err).WithField("service", name).Debug("Failed to stop placeholder page")
+		}
+	} else {
+		<-ready
+	}
+
+	startTunnel(ncc, tokenSource, name, hostIP, hostPort, containerPort)
+}
+
+// placeholderHandler serves a page that reloads itself until the service is
+// ready, along with the reason that it isn't ready yet. The response isn't
+// cacheable, so browsers show the real service once the tunnel starts.
+func placeholderHandler(name string, getReason func() string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reason string
+		if current := getReason(); current != "" {
+			reason = fmt.Sprintf("<p>Current status: %s</p>", html.EscapeString(current))
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Retry-After", "2")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head><meta http-equiv="refresh" content="2"><title>%[1]s is starting</title></head>
+<body><p>Waiting for %[1]s to become healthy. This page will reload automatically.</p>%[2]s</body>
+</html>
+`, name, reason)
+	})
+}
+
+func startTunnel(ncc node.ControllerClient, tokenSource tunnel.TokenSource, name, hostIP string,
+	hostPort, containerPort uint32) {
+
+	ln := tunnelStats.track(listen(name, hostIP, hostPort), name, hostIP, hostPort, containerPort)
+	err := tunnel.Client(ncc, ln, tokenSource, name, containerPort)
+	if err != nil {
+		// TODO.  Same question about Fatal.  Also if accept errors
+		// maybe wes hould have retried inside accept tunnels instead of
+		// fatal out here?
+		log.WithFields(log.Fields{
+			"error":   err,
+			"address": ln.Addr().String(),
+			"network": "tcp",
+		}).Fatal("failed to listen for connections")
+		return
+	}
+}
+
+func listen(name, hostIP string, hostPort uint32) net.Listener {
+	addr := fmt.Sprintf("%s:%d", hostIP, hostPort)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "permission denied"):
+			err = errors.NewFriendlyError("Permission denied while listening for connections\n"+
+				"Make sure that the local port for the service %q is above 1024.\n\n"+
+				"The full error was:\n%s", name, err)
+		case strings.Contains(err.Error(), "address already in use"):
+			err = errors.NewFriendlyError("Another process is already listening on the same port\n"+
+				"If you have been using docker-compose, make sure to run docker-compose down.\n"+
+				"Make sure that the there aren't any other "+
+				"services listening locally on port %d. This can be checked with the following command:\n"+
+				"sudo lsof -i -P -n | grep :%d\n\n"+
+				"The full error was:\n%s", hostPort, hostPort, err)
+		}
+
+		// TODO.  It's appropriate that this error is fatal, but we need
+		// a better way of handling it.  Log messages are ugly, and we
+		// need to do some cleanup.
+		log.WithError(err).
+			WithField("address", addr).
+			Fatal("Failed to started tunnels")
+	}
+	return ln
+}
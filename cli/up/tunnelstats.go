@@ -0,0 +1,144 @@
+package up
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	tunnelstats "github.com/kelda/blimp/cli/tunnel"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// latencyInterval is how often the latency to the sandbox is measured.
+const latencyInterval = 10 * time.Second
+
+// tunnelStats tracks the traffic through the forwarded ports so that it can
+// be reported by `blimp tunnel stats`.
+var tunnelStats = &statsRegistry{}
+
+type statsRegistry struct {
+	ports      []trackedPort
+	latency    time.Duration
+	reconnects int
+	sync.Mutex
+}
+
+type trackedPort struct {
+	service       string
+	hostIP        string
+	hostPort      uint32
+	containerPort uint32
+	stats         *tunnel.Stats
+}
+
+// track returns a listener that records its traffic in the registry.
+func (r *statsRegistry) track(ln net.Listener, service, hostIP string, hostPort, containerPort uint32) net.Listener {
+	stats := &tunnel.Stats{}
+
+	r.Lock()
+	r.ports = append(r.ports, trackedPort{service, hostIP, hostPort, containerPort, stats})
+	r.Unlock()
+	return stats.WrapListener(ln)
+}
+
+// Run measures the connection to the sandbox, and writes the report for
+// `blimp tunnel stats` until the context is cancelled.
+func (r *statsRegistry) Run(ctx context.Context, nodeConn *grpc.ClientConn, nodeAddr string) {
+	defer func() {
+		if err := tunnelstats.RemoveReport(); err != nil {
+			log.WithError(err).Debug("Failed to remove tunnel stats")
+		}
+	}()
+
+	go r.countReconnects(ctx, nodeConn)
+	go r.measureLatency(ctx, nodeAddr)
+
+	ticker := time.NewTicker(tunnelstats.ReportInterval)
+	defer ticker.Stop()
+	for {
+		if err := tunnelstats.WriteReport(r.report()); err != nil {
+			log.WithError(err).Debug("Failed to write tunnel stats")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *statsRegistry) report() tunnelstats.Report {
+	r.Lock()
+	defer r.Unlock()
+
+	report := tunnelstats.Report{
+		UpdatedAt:  time.Now(),
+		Latency:    r.latency,
+		Reconnects: r.reconnects,
+	}
+	for _, port := range r.ports {
+		report.Ports = append(report.Ports, tunnelstats.PortReport{
+			Service:       port.service,
+			HostIP:        port.hostIP,
+			HostPort:      port.hostPort,
+			ContainerPort: port.containerPort,
+			StatsSnapshot: port.stats.Snapshot(),
+		})
+	}
+	return report
+}
+
+// countReconnects counts the number of times that the connection to the node
+// controller becomes ready after the first time.
+func (r *statsRegistry) countReconnects(ctx context.Context, nodeConn *grpc.ClientConn) {
+	wasReady := false
+	state := nodeConn.GetState()
+	for {
+		if state == connectivity.Ready {
+			if wasReady {
+				r.Lock()
+				r.reconnects++
+				r.Unlock()
+			}
+			wasReady = true
+		}
+
+		if !nodeConn.WaitForStateChange(ctx, state) {
+			return
+		}
+		state = nodeConn.GetState()
+	}
+}
+
+// measureLatency periodically measures the time to open a TCP connection to
+// the node controller, which is one round trip to the sandbox.
+func (r *statsRegistry) measureLatency(ctx context.Context, nodeAddr string) {
+	ticker := time.NewTicker(latencyInterval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		conn, err := net.DialTimeout("tcp", nodeAddr, latencyInterval)
+		if err == nil {
+			latency := time.Since(start)
+			conn.Close()
+
+			r.Lock()
+			r.latency = latency
+			r.Unlock()
+		} else {
+			log.WithError(err).Debug("Failed to measure latency to sandbox")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
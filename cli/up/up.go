@@ -4,17 +4,19 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"io"
-	"net"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/buger/goterm"
 	"github.com/docker/cli/cli/config"
 	"github.com/docker/cli/cli/config/configfile"
 	clitypes "github.com/docker/cli/cli/config/types"
@@ -29,27 +31,69 @@ import (
 	"github.com/kelda/blimp/cli/down"
 	"github.com/kelda/blimp/cli/logs"
 	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/cli/status"
 	"github.com/kelda/blimp/cli/util"
 	"github.com/kelda/blimp/pkg/analytics"
+	"github.com/kelda/blimp/pkg/cfgdir"
 	"github.com/kelda/blimp/pkg/dockercompose"
 	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/mirror"
+	"github.com/kelda/blimp/pkg/names"
 	"github.com/kelda/blimp/pkg/proto/cluster"
 	"github.com/kelda/blimp/pkg/proto/node"
 	"github.com/kelda/blimp/pkg/syncthing"
-	"github.com/kelda/blimp/pkg/tunnel"
 )
 
+// sandboxCAFile is the file in the config directory that the CA for managed
+// TLS is saved to.
+const sandboxCAFile = "sandbox-ca.crt"
+
+const (
+	// minLogsRetryDelay and maxLogsRetryDelay bound the exponential backoff
+	// between attempts to resume interrupted logs.
+	minLogsRetryDelay = time.Second
+	maxLogsRetryDelay = 30 * time.Second
+
+	// logsRetryTimeout is how long to try resuming interrupted logs before
+	// giving up.
+	logsRetryTimeout = 10 * time.Minute
+)
+
+// projectNameDisallowedChars matches the characters that Docker Compose strips
+// from directory names when generating the default project name.
+var projectNameDisallowedChars = regexp.MustCompile("[^a-z0-9]")
+
 func New() *cobra.Command {
 	var composePaths []string
+	var projectDir string
 	var alwaysBuild bool
 	var detach bool
+	var buildSSH, buildSecrets []string
+	var placeholderPage bool
+	var bootTimeout time.Duration
+	var localServices []string
+	var pin bool
+	var envFlags []string
+	var injectEnvFiles []string
+	var noQueue bool
+	var render bool
+	var quiet bool
+	var strict bool
+	var stickyNode bool
+	var yes bool
+	var pushRateLimit string
+	var onPortCollision string
+	var parallel int
 	cobraCmd := &cobra.Command{
 		Use:   "up [options] [SERVICE...]",
 		Short: "Create and start containers",
 		Long: "Create and start containers\n\n" +
 			"Up boots the docker-compose.yml in the current directory. " +
-			"If service are specified, `up` boots the services, as well as their dependencies.",
-		Run: func(_ *cobra.Command, services []string) {
+			"If service are specified, `up` boots the services, as well as their dependencies. " +
+			"Service groups defined in " + projectcfg.Filename + " are expanded to their services.",
+		ValidArgsFunction: util.CompleteServices,
+		Run: func(cobraCmd *cobra.Command, services []string) {
 			auth, err := authstore.New()
 			if err != nil {
 				log.WithError(err).Fatal("Failed to parse local authentication store")
@@ -58,13 +102,43 @@ func New() *cobra.Command {
 			// TODO: Prompt to login again if token is expired.
 			if auth.AuthToken == "" {
 				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
-				os.Exit(1)
+				errors.Exit(1)
 			}
 
 			cmd := up{
-				auth:        auth,
-				alwaysBuild: alwaysBuild,
-				detach:      detach,
+				auth:         auth,
+				alwaysBuild:  alwaysBuild,
+				detach:       detach,
+				buildSSH:     buildSSH,
+				buildSecrets: buildSecrets,
+
+				placeholderPage: placeholderPage,
+				bootTimeout:     bootTimeout,
+				localServices:   localServices,
+				pin:             pin,
+				envFlags:        envFlags,
+				injectEnvFiles:  injectEnvFiles,
+				noQueue:         noQueue,
+				render:          render,
+				quiet:           quiet,
+				yes:             yes,
+
+				onPortCollision: onPortCollision,
+				parallel:        parallel,
+			}
+			if pushRateLimit != "" {
+				cmd.pushRateLimit, err = util.ParseBytes(pushRateLimit)
+				if err != nil {
+					errors.HandleFatalError(errors.NewFriendlyError(
+						"Invalid --push-rate-limit %q. It should be a size per second, such as 5MiB.",
+						pushRateLimit))
+				}
+			}
+			if cobraCmd.Flags().Changed("strict") {
+				cmd.strict = &strict
+			}
+			if cobraCmd.Flags().Changed("sticky-node") {
+				cmd.stickyNode = &stickyNode
 			}
 
 			dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
@@ -78,7 +152,7 @@ func New() *cobra.Command {
 			// Convert the compose path to an absolute path so that the code
 			// that makes identifiers for bind volumes are unique for relative
 			// paths.
-			composePath, overridePaths, err := getComposePaths(composePaths)
+			composePath, overridePaths, err := util.GetComposePaths(projectDir, composePaths)
 			if err != nil {
 				if os.IsNotExist(err) {
 					log.Fatal("Docker Compose file not found.\n" +
@@ -91,6 +165,13 @@ func New() *cobra.Command {
 
 			cmd.composePath = composePath
 			cmd.overridePaths = overridePaths
+			cmd.projectDir = filepath.Dir(composePath)
+			if projectDir != "" {
+				cmd.projectDir, err = filepath.Abs(projectDir)
+				if err != nil {
+					log.WithError(err).Fatal("Failed to get absolute path to project directory")
+				}
+			}
 			//import the docker config
 			cfg, err := config.Load(config.Dir())
 			if err != nil {
@@ -105,10 +186,60 @@ func New() *cobra.Command {
 	}
 	cobraCmd.Flags().StringSliceVarP(&composePaths, "file", "f", nil,
 		"Specify an alternate compose file\nDefaults to docker-compose.yml and docker-compose.yaml")
+	cobraCmd.Flags().StringVarP(&projectDir, "project-dir", "", "",
+		"Specify an alternate working directory\nDefaults to the directory of the first compose file. "+
+			"Relative paths in each compose file are resolved against that file's directory")
 	cobraCmd.Flags().BoolVarP(&alwaysBuild, "build", "", false,
 		"Build images before starting containers")
 	cobraCmd.Flags().BoolVarP(&detach, "detach", "d", false,
 		"Leave containers running after blimp up exits")
+	cobraCmd.Flags().StringArrayVarP(&buildSSH, "ssh", "", nil,
+		"SSH agent socket or keys to expose to image builds, in the same format as 'docker build --ssh'\n"+
+			"(default|<id>[=<socket>|<key>[,<key>]]). Requires BuildKit syntax in the Dockerfile.")
+	cobraCmd.Flags().StringArrayVarP(&buildSecrets, "secret", "", nil,
+		"Secret file to expose to image builds, in the same format as 'docker build --secret'\n"+
+			"(id=<id>,src=<path>). Requires BuildKit syntax in the Dockerfile.")
+	cobraCmd.Flags().StringVarP(&onPortCollision, "on-port-collision", "", "",
+		fmt.Sprintf("What to do when a published port is already forwarded by another 'blimp up'. "+
+			"Either %q, %q, or %q. Remapped ports are reused on future runs.\n"+
+			"Defaults to the on_port_collision setting in %s, or %q",
+			PortCollisionPrompt, PortCollisionRemap, PortCollisionFail, projectcfg.Filename, PortCollisionPrompt))
+	cobraCmd.Flags().BoolVarP(&placeholderPage, "placeholder-page", "", false,
+		"Serve a placeholder page on published ports until the service becomes healthy")
+	cobraCmd.Flags().DurationVarP(&bootTimeout, "boot-timeout", "", 10*time.Minute,
+		"How long to wait for services to boot before diagnosing the failure. 0 waits forever")
+	cobraCmd.Flags().StringSliceVarP(&localServices, "local", "", nil,
+		"Services that you're running locally. They aren't deployed to the sandbox, but are reachable\n"+
+			"from it by their service names, and the sandbox services they depend on are forwarded to localhost")
+	cobraCmd.Flags().StringArrayVarP(&envFlags, "env", "e", nil,
+		"Set an environment variable in all services for this boot, in the form KEY=VALUE.\n"+
+			"Prefix it with 'SERVICE[,SERVICE...]:' to only set it in specific services")
+	cobraCmd.Flags().StringArrayVarP(&injectEnvFiles, "inject-env", "", nil,
+		"Set the environment variables in the given dotenv file in all services, such as a file\n"+
+			"created by 'blimp env export'. Variables set with --env take precedence")
+	cobraCmd.Flags().BoolVarP(&noQueue, "no-queue", "", false,
+		"Fail immediately if the cluster is at capacity, rather than waiting in the queue")
+	cobraCmd.Flags().BoolVarP(&quiet, "quiet", "q", false,
+		"Suppress progress output. Only print a line when each service boots, and a summary once they've all booted")
+	cobraCmd.Flags().StringVarP(&pushRateLimit, "push-rate-limit", "", "",
+		"Limit the upload bandwidth used to push built images, in bytes per second, such as 5MiB.\n"+
+			"By default, pushes aren't limited")
+	cobraCmd.Flags().IntVarP(&parallel, "parallel", "", DefaultParallel,
+		"The maximum number of image pushes and registry lookups to run at once.\n"+
+			"Individual operations can be limited further with the 'parallel' setting in "+projectcfg.Filename)
+	cobraCmd.Flags().BoolVarP(&strict, "strict", "", false,
+		"Fail if the Compose file uses features that Blimp would ignore, rather than booting without them.\n"+
+			"Defaults to the 'strict' setting in "+projectcfg.Filename)
+	cobraCmd.Flags().BoolVarP(&stickyNode, "sticky-node", "", false,
+		"Schedule the sandbox on the same node as its previous boot when possible, so that cached "+
+			"image layers and volumes are reused.\n"+
+			"Defaults to the 'sticky_node' setting in "+projectcfg.Filename)
+	cobraCmd.Flags().BoolVarP(&yes, "yes", "y", false,
+		"Deploy without asking for confirmation, even if the deploy would delete the data in volumes")
+	cobraCmd.Flags().BoolVarP(&render, "render", "", false,
+		"Print the Kubernetes objects that would be deployed for the Compose file, and exit without deploying")
+	cobraCmd.Flags().BoolVarP(&pin, "pin", "", false,
+		"Pin images to the digests they currently resolve to by writing "+projectcfg.LockFilename+" to the project directory")
 	return cobraCmd
 }
 
@@ -116,8 +247,11 @@ type up struct {
 	auth           authstore.Store
 	composePath    string
 	overridePaths  []string
+	projectDir     string
 	alwaysBuild    bool
 	detach         bool
+	buildSSH       []string
+	buildSecrets   []string
 	dockerClient   *client.Client
 	dockerConfig   *configfile.ConfigFile
 	regCreds       map[string]types.AuthConfig
@@ -125,36 +259,241 @@ type up struct {
 	nodeAddr       string
 	nodeCert       string
 
+	// The registry that mirrors Docker Hub, if any. Base images referenced by
+	// built images are pulled through it.
+	registryMirror string
+
 	// The images in the image cache from previous Blimp runs.
 	cachedImages []types.ImageSummary
+
+	// Whether to serve a placeholder page on published ports while waiting
+	// for services to become healthy.
+	placeholderPage bool
+
+	// How long to wait for the services to boot. If they don't boot in time,
+	// the likely cause is diagnosed and printed.
+	bootTimeout time.Duration
+
+	// The services that the user is running locally rather than in the
+	// sandbox.
+	localServices []string
+
+	// Whether to regenerate the lockfile that pins images to digests.
+	pin bool
+
+	// The --env flags, which override environment variables in the
+	// services.
+	envFlags []string
+
+	// The dotenv files passed with --inject-env, whose variables are set in
+	// all services.
+	injectEnvFiles []string
+
+	// Whether to fail rather than wait when the cluster is at capacity.
+	noQueue bool
+
+	// Whether to issue certificates for TLS between services.
+	managedTLS bool
+
+	// The Git commit, branch, and build time that are passed to image builds
+	// as build args.
+	gitMetadata map[string]string
+
+	// The resolver config for the sandbox's pods, from the Compose file's
+	// x-blimp section.
+	dnsConfig *dockercompose.DNSConfig
+
+	// The x-blimp configuration of individual services.
+	serviceExtensions map[string]dockercompose.ServiceExtension
+
+	// Whether to print the rendered Kubernetes objects rather than deploying.
+	render bool
+
+	// Whether to suppress progress output.
+	quiet bool
+
+	// The maximum number of bytes per second to upload when pushing images.
+	// Zero means unlimited.
+	pushRateLimit int64
+
+	// Whether to fail if the Compose file uses unsupported features. It's
+	// nil if --strict wasn't set, in which case the project's default is
+	// used.
+	strict *bool
+
+	// Whether to ask for the sandbox to be scheduled on the node it last ran
+	// on. It's nil if --sticky-node wasn't set, in which case the project's
+	// default is used.
+	stickyNode *bool
+
+	// Whether to deploy without confirmation when the deploy would delete
+	// the data in volumes.
+	yes bool
+
+	// When `blimp up` started, for the boot summary.
+	startTime time.Time
+
+	// The project's defaults for printing logs.
+	logsConfig projectcfg.LogsConfig
+
+	// How to handle published ports that are already forwarded by another
+	// `blimp up`. See the PortCollision constants. If it's empty, the
+	// project's default is used.
+	onPortCollision string
+
+	// The maximum number of network operations to run at once, and the pool
+	// that enforces it.
+	parallel int
+	pool     *workerPool
+
+	// The rate limiter shared by all image pushes, so that --push-rate-limit
+	// applies to the total bandwidth when images are pushed in parallel.
+	pushLimiter *rateLimiter
+
+	// Guards renewing the auth token, which is requested concurrently by the
+	// tunnels.
+	authLock sync.Mutex
+
+	// Whether the user has been told that the auth token couldn't be
+	// renewed, so that the warning isn't repeated for every tunnel.
+	warnedAuthRefresh bool
+
+	// Shows warnings without corrupting the status output. It's nil until
+	// the status printer is created, in which case warnings are logged. It's
+	// guarded by authLock.
+	notify func(string)
 }
 
 func (cmd *up) run(services []string) error {
-	// TODO: Make locking atomic. Currently there could be TOCTTOU problems.
-	if util.UpRunning() {
-		fmt.Printf("It looks like `blimp up` is already running.\n" +
-			"Are you sure you want to continue, even though things might break? (y/N) ")
-		var response string
-		num, err := fmt.Scanln(&response)
-		if err != nil || num != 1 ||
-			(strings.ToLower(response) != "y" && strings.ToLower(response) != "yes") {
-			fmt.Printf("Aborting.\n")
-			os.Exit(1)
-		}
-	}
-	util.TakeUpLock()
-	defer util.ReleaseUpLock()
-
-	parsedCompose, err := dockercompose.Load(cmd.composePath, cmd.overridePaths, services)
+	cmd.startTime = time.Now()
+
+	// Rendering doesn't modify the sandbox, so it's safe to run alongside
+	// another `blimp up`.
+	if !cmd.render {
+		// TODO: Make locking atomic. Currently there could be TOCTTOU problems.
+		if util.UpRunning() {
+			fmt.Printf("It looks like `blimp up` is already running.\n" +
+				"Are you sure you want to continue, even though things might break? (y/N) ")
+			var response string
+			num, err := fmt.Scanln(&response)
+			if err != nil || num != 1 ||
+				(strings.ToLower(response) != "y" && strings.ToLower(response) != "yes") {
+				fmt.Printf("Aborting.\n")
+				errors.Exit(1)
+			}
+		}
+		util.TakeUpLock()
+		defer util.ReleaseUpLock()
+	}
+
+	projectCfg, err := projectcfg.Load(cmd.projectDir)
+	if err != nil {
+		return err
+	}
+
+	cmd.pool, err = newWorkerPool(cmd.parallel, projectCfg.Parallel)
+	if err != nil {
+		return err
+	}
+	cmd.pushLimiter = newRateLimiter(cmd.pushRateLimit)
+
+	services = projectCfg.ExpandGroups(services)
+	parsedCompose, err := dockercompose.LoadProject(cmd.projectDir, cmd.composePath, cmd.overridePaths, services)
 	if err != nil {
 		return errors.WithContext("load compose file", err)
 	}
 
+	extension, err := dockercompose.LoadExtension(cmd.composePath, cmd.overridePaths)
+	if err != nil {
+		return err
+	}
+	cmd.dnsConfig = extension.DNS
+
+	localCompose, err := splitLocalServices(&parsedCompose, cmd.localServices)
+	if err != nil {
+		return err
+	}
+
+	// Only pass the configuration of the services that are deployed to the
+	// sandbox.
+	cmd.serviceExtensions = map[string]dockercompose.ServiceExtension{}
+	for _, svc := range parsedCompose.Services {
+		if svcExt, ok := extension.Services[svc.Name]; ok {
+			cmd.serviceExtensions[svc.Name] = svcExt
+		}
+	}
+
+	envOverrides, err := parseEnvOverrides(cmd.envFlags)
+	if err != nil {
+		return err
+	}
+
+	injectedEnv, err := loadInjectedEnv(cmd.injectEnvFiles)
+	if err != nil {
+		return err
+	}
+
+	cmd.gitMetadata = getGitMetadata(cmd.projectDir)
+	envOverrides = append(append(injectedEnv, projectCfg.EnvOverrides...), envOverrides...)
+	if projectCfg.GitMetadataEnv {
+		envOverrides = append([]projectcfg.EnvOverride{{Environment: getGitMetadataEnv(cmd.gitMetadata)}},
+			envOverrides...)
+	}
+	if err := applyEnvOverrides(&parsedCompose, envOverrides); err != nil {
+		return err
+	}
+
+	if !cmd.render {
+		if err := cmd.resolvePortCollisions(&parsedCompose, projectCfg); err != nil {
+			return err
+		}
+		defer releasePortClaims()
+	}
+
+	if err := cmd.pinImages(&parsedCompose, cmd.pin); err != nil {
+		return errors.WithContext("pin images", err)
+	}
+
+	// The manager applies the organization's mirror when deploying, so we
+	// only need to rewrite the images if the project overrides it.
+	cmd.managedTLS = projectCfg.ManagedTLS
+	cmd.logsConfig = projectCfg.Logs
+	if projectCfg.RegistryMirror != "" {
+		cmd.registryMirror = projectCfg.RegistryMirror
+		for i, svc := range parsedCompose.Services {
+			if svc.Build == nil {
+				parsedCompose.Services[i].Image = mirror.RewriteImage(svc.Image, cmd.registryMirror)
+			}
+		}
+	}
+
 	parsedComposeBytes, err := dockercompose.Marshal(parsedCompose)
 	if err != nil {
 		return err
 	}
 
+	if cmd.render {
+		return cmd.renderSandbox(string(parsedComposeBytes))
+	}
+
+	strict := projectCfg.Strict
+	if cmd.strict != nil {
+		strict = *cmd.strict
+	}
+	if strict {
+		if err := cmd.checkStrict(string(parsedComposeBytes)); err != nil {
+			return err
+		}
+	}
+
+	if err := cmd.confirmDataLoss(string(parsedComposeBytes)); err != nil {
+		return err
+	}
+
+	if cmd.stickyNode == nil {
+		cmd.stickyNode = &projectCfg.StickyNode
+	}
+
 	stClient := cmd.makeSyncthingClient(parsedCompose)
 	idPathMap := stClient.GetIDPathMap()
 
@@ -165,10 +504,14 @@ func (cmd *up) run(services []string) error {
 	}
 	cmd.regCreds = regCreds
 
+	if err := waitForCapacity(cmd.auth.AuthToken, cmd.noQueue); err != nil {
+		return err
+	}
+
 	// Start creating the sandbox immediately so that the systems services
 	// start booting as soon as possible.
 	if err := cmd.createSandbox(string(parsedComposeBytes), idPathMap); err != nil {
-		log.WithError(err).Fatal("Failed to create development sandbox")
+		return errors.WithContext("create development sandbox", err)
 	}
 
 	cachedImages, err := cmd.getCachedImages()
@@ -184,7 +527,7 @@ func (cmd *up) run(services []string) error {
 	}
 
 	// Send the boot request to the cluster manager.
-	pp := util.NewProgressPrinter(os.Stdout, "Deploying Docker Compose file to sandbox")
+	pp := cmd.newProgressPrinter("Deploying Docker Compose file to sandbox")
 	go pp.Run()
 
 	_, err = manager.C.DeployToSandbox(context.Background(), &cluster.DeployRequest{
@@ -204,11 +547,61 @@ func (cmd *up) run(services []string) error {
 	defer nodeConn.Close()
 	nodeController := node.NewControllerClient(nodeConn)
 
-	// Start the tunnels.
+	statsCtx, cancelStats := context.WithCancel(context.Background())
+	defer cancelStats()
+	go tunnelStats.Run(statsCtx, nodeConn, cmd.nodeAddr)
+
+	if len(localCompose) != 0 {
+		cmd.startLocalServiceTunnels(nodeController, parsedCompose, localCompose)
+	}
+
+	// Start the tunnels once the services are ready.
+	readinessCtx, cancelReadiness := context.WithCancel(context.Background())
+	defer cancelReadiness()
+	readiness := newReadinessWatcher(parsedCompose.ServiceNames())
+
+	// Messages printed while the services boot go through the status
+	// printer so that they don't corrupt the status.
+	statusPrinter := newStatusPrinter(parsedCompose.ServiceNames())
+	statusPrinter.quiet = cmd.quiet
+	statusPrinter.startTime = cmd.startTime
+	cmd.authLock.Lock()
+	cmd.notify = statusPrinter.notify
+	cmd.authLock.Unlock()
+
+	// Explain why the services with tunnels aren't ready yet, since their
+	// tunnels are blocked until they are.
+	gated := map[string]bool{}
 	for _, svc := range parsedCompose.Services {
 		for _, mapping := range svc.Ports {
 			if mapping.Protocol == "tcp" {
-				go startTunnel(nodeController, cmd.auth.AuthToken, svc.Name,
+				gated[svc.Name] = true
+			}
+		}
+	}
+	readiness.onWaiting = func(svc string, svcReadiness status.ServiceReadiness) {
+		if gated[svc] {
+			statusPrinter.notify(fmt.Sprintf("%s isn't healthy yet: %s", svc, formatReadinessReason(svcReadiness)))
+		}
+	}
+	go readiness.Run(readinessCtx, manager.C, cmd.auth.AuthToken)
+	go watchRescheduling(readinessCtx, manager.C, cmd.auth.AuthToken, cmd.startTime, statusPrinter.notify)
+	go manager.WatchConnection(readinessCtx, cmd.auth.AuthToken, func(reconnect manager.Reconnect) {
+		// New connections to the tunnels are made over the restored
+		// connection, but connections that were open during the outage
+		// were dropped.
+		statusPrinter.notify(goterm.Color(reconnect.String()+
+			" Connections to your services that were open beforehand were dropped.", goterm.YELLOW))
+	})
+	for _, svc := range parsedCompose.Services {
+		for _, mapping := range svc.Ports {
+			if mapping.Protocol == "tcp" {
+				if !cmd.quiet {
+					fmt.Printf("Waiting for %s to become healthy before forwarding :%d\n",
+						svc.Name, mapping.Published)
+				}
+				go startGatedTunnel(nodeController, cmd.getAuthToken,
+					readiness, cmd.placeholderPage, svc.Name,
 					mapping.HostIP, mapping.Published, mapping.Target)
 			}
 		}
@@ -219,9 +612,9 @@ func (cmd *up) run(services []string) error {
 	defer cancelSyncthing()
 	if len(idPathMap) != 0 {
 		tunneledRemoteAPIPort := uint32(8385)
-		go startTunnel(nodeController, cmd.auth.AuthToken, "syncthing",
+		go startTunnel(nodeController, cmd.getAuthToken, "syncthing",
 			"127.0.0.1", syncthing.Port, syncthing.Port)
-		go startTunnel(nodeController, cmd.auth.AuthToken, "syncthing",
+		go startTunnel(nodeController, cmd.getAuthToken, "syncthing",
 			"127.0.0.1", tunneledRemoteAPIPort, syncthing.APIPort)
 		go func() {
 			defer close(syncthingError)
@@ -246,7 +639,7 @@ func (cmd *up) run(services []string) error {
 
 	guiError := make(chan error, 1)
 	go func() {
-		guiError <- cmd.runGUI(parsedCompose)
+		guiError <- cmd.runGUI(statusPrinter, parsedCompose, nodeController)
 	}()
 
 	exit := make(chan os.Signal, 1)
@@ -299,7 +692,7 @@ func (cmd *up) run(services []string) error {
 }
 
 func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) error {
-	pp := util.NewProgressPrinter(os.Stdout, "Booting cloud sandbox")
+	pp := cmd.newProgressPrinter("Booting cloud sandbox")
 	go pp.Run()
 	defer pp.Stop()
 
@@ -309,11 +702,21 @@ func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) err
 			ComposeFile:         string(composeCfg),
 			RegistryCredentials: registryCredentialsToProtobuf(cmd.regCreds),
 			SyncedFolders:       idPathMap,
+			Project:             cmd.getProjectName(),
+			ManagedTls:          cmd.managedTLS,
+			DnsConfig:           dnsConfigToProtobuf(cmd.dnsConfig),
+			SecurityProfiles:    securityProfilesToProtobuf(cmd.serviceExtensions),
+			StickyNode:          *cmd.stickyNode,
+			CronSchedules:       cronSchedulesToProtobuf(cmd.serviceExtensions),
 		})
 	if err != nil {
 		return err
 	}
 
+	if violations := resp.GetPreflightViolations(); len(violations) != 0 {
+		return preflightError(violations)
+	}
+
 	if resp.Message != "" {
 		fmt.Printf("\n" + resp.Message)
 	}
@@ -321,15 +724,31 @@ func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) err
 	switch resp.Action {
 	case cluster.CLIAction_OK:
 	case cluster.CLIAction_EXIT:
-		os.Exit(1)
+		errors.Exit(1)
 	default:
-		os.Exit(1)
+		errors.Exit(1)
 	}
 
 	cmd.imageNamespace = resp.ImageNamespace
+	if cmd.registryMirror == "" {
+		cmd.registryMirror = resp.RegistryMirror
+	}
 	cmd.nodeAddr = resp.NodeAddress
 	cmd.nodeCert = resp.NodeCert
 
+	if resp.TlsCa != "" {
+		// Save the CA so that it can be trusted locally when connecting to
+		// services through tunnels.
+		caPath := cfgdir.Expand(sandboxCAFile)
+		if err := ioutil.WriteFile(caPath, []byte(resp.TlsCa), 0644); err != nil {
+			log.WithError(err).Warn("Failed to save the sandbox's CA certificate")
+		} else {
+			fmt.Printf("Managed TLS is enabled. Service certificates are mounted at %s.\n"+
+				"To trust the certificates locally, use the CA certificate at %s.\n",
+				names.TLSDir, caPath)
+		}
+	}
+
 	// Save the Kubernetes API credentials for use by other Blimp commands.
 	kubeCreds := resp.GetKubeCredentials()
 	cmd.auth.KubeToken = kubeCreds.Token
@@ -342,118 +761,177 @@ func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) err
 	return nil
 }
 
-func (cmd *up) runGUI(parsedCompose composeTypes.Config) error {
+// preflightError describes the policies that the Compose file violates, so
+// that users can fix them before running `blimp up` again.
+func preflightError(violations []*cluster.PolicyViolation) error {
+	msg := "Your Compose file violates the cluster's policies:"
+	for _, violation := range violations {
+		msg += fmt.Sprintf("\n  - %s: %s (%s)",
+			violation.GetService(), violation.GetMessage(), violation.GetRule())
+	}
+	return errors.NewFriendlyError("%s\nPlease fix them, and run `blimp up` again.", msg)
+}
+
+// getProjectName returns the Docker Compose project name, using the same
+// defaults as Docker Compose.
+func (cmd *up) getProjectName() string {
+	if name := os.Getenv("COMPOSE_PROJECT_NAME"); name != "" {
+		return name
+	}
+
+	dirName := strings.ToLower(filepath.Base(cmd.projectDir))
+	return projectNameDisallowedChars.ReplaceAllString(dirName, "")
+}
+
+func (cmd *up) runGUI(statusPrinter *statusPrinter, parsedCompose composeTypes.Config,
+	nodeController node.ControllerClient) error {
 	services := parsedCompose.ServiceNames()
-	statusPrinter := newStatusPrinter(services)
-	statusPrinter.Run(manager.C, cmd.auth.AuthToken)
+	err := statusPrinter.Run(manager.C, nodeController, cmd.auth.AuthToken, cmd.bootTimeout)
+	if err == errBootTimeout {
+		analytics.Log.Info("Containers failed to boot")
+		return cmd.diagnoseBootFailure(statusPrinter.unbootedServices())
+	}
 	analytics.Log.Info("Containers booted")
 
-	return logs.LogsCommand{
-		Containers: services,
-		Opts:       corev1.PodLogOptions{Follow: true},
-		Auth:       cmd.auth,
-	}.Run()
-}
+	// Skip the services that the project excludes from the combined logs.
+	var logServices []string
+	for _, svc := range services {
+		if !cmd.logsConfig.Services[svc].Exclude {
+			logServices = append(logServices, svc)
+		}
+	}
 
-func startTunnel(ncc node.ControllerClient, token, name, hostIP string,
-	hostPort, containerPort uint32) {
+	// Keep running until the user exits, since returning signals that the
+	// containers have completed.
+	if len(logServices) == 0 {
+		fmt.Printf("All services are excluded from the logs in %s.\n", projectcfg.Filename)
+		select {}
+	}
 
-	addr := fmt.Sprintf("%s:%d", hostIP, hostPort)
-	ln, err := net.Listen("tcp", addr)
-	if err != nil {
-		switch {
-		case strings.Contains(err.Error(), "permission denied"):
-			err = errors.NewFriendlyError("Permission denied while listening for connections\n"+
-				"Make sure that the local port for the service %q is above 1024.\n\n"+
-				"The full error was:\n%s", name, err)
-		case strings.Contains(err.Error(), "address already in use"):
-			err = errors.NewFriendlyError("Another process is already listening on the same port\n"+
-				"If you have been using docker-compose, make sure to run docker-compose down.\n"+
-				"Make sure that the there aren't any other "+
-				"services listening locally on port %d. This can be checked with the following command:\n"+
-				"sudo lsof -i -P -n | grep :%d\n\n"+
-				"The full error was:\n%s", hostPort, hostPort, err)
-		}
-
-		// TODO.  It's appropriate that this error is fatal, but we need
-		// a better way of handling it.  Log messages are ugly, and we
-		// need to do some cleanup.
-		log.WithError(err).
-			WithField("address", addr).
-			Fatal("Failed to started tunnels")
-	}
-
-	err = tunnel.Client(ncc, ln, token, name, containerPort)
-	if err != nil {
-		// TODO.  Same question about Fatal.  Also if accept errors
-		// maybe wes hould have retried inside accept tunnels instead of
-		// fatal out here?
-		log.WithFields(log.Fields{
-			"error":   err,
-			"address": addr,
-			"network": "tcp",
-		}).Fatal("failed to listen for connections")
-		return
+	logsCmd := logs.LogsCommand{
+		Containers:     logServices,
+		Opts:           corev1.PodLogOptions{Follow: true},
+		Auth:           cmd.auth,
+		ServiceConfigs: cmd.logsConfig.Services,
+		Palette:        cmd.logsConfig.Palette,
+		Format:         cmd.logsConfig.Format,
+
+		// The connection is already watched for the lifetime of `blimp up`.
+		NoConnectionWatch: true,
 	}
-}
 
-func (cmd *up) makeSyncthingClient(dcCfg composeTypes.Config) syncthing.Client {
-	var bindVolumes []string
-	for _, svc := range dcCfg.Services {
-		for _, v := range svc.Volumes {
-			if v.Type != "bind" {
-				continue
+	// Once the logs are interrupted, keep trying to resume them, since
+	// the connection may take a while to recover, such as after the
+	// machine was asleep.
+	var interruptedSince time.Time
+	retryDelay := minLogsRetryDelay
+	for {
+		attemptStart := time.Now()
+		err := logsCmd.Run()
+		if logsCmd.Resume(err) {
+			log.WithError(err).Debug("Log stream interrupted")
+
+			// The logs were streaming before they broke, so the
+			// connection recovered since the last interruption.
+			if time.Since(attemptStart) > maxLogsRetryDelay {
+				interruptedSince = time.Time{}
+				retryDelay = minLogsRetryDelay
 			}
+		} else if err == nil || interruptedSince.IsZero() {
+			return err
+		} else {
+			log.WithError(err).Debug("Failed to resume logs")
+		}
 
-			bindVolumes = append(bindVolumes, v.Source)
+		if interruptedSince.IsZero() {
+			interruptedSince = time.Now()
+			statusPrinter.notify(goterm.Color("The connection to the logs was interrupted. Reconnecting...",
+				goterm.YELLOW))
 		}
-	}
-	return syncthing.NewClient(bindVolumes)
-}
 
-func getComposePaths(composePaths []string) (string, []string, error) {
-	getYamlFile := func(prefix string) (string, error) {
-		paths := []string{
-			prefix + ".yaml",
-			prefix + ".yml",
+		if time.Since(interruptedSince) > logsRetryTimeout {
+			statusPrinter.notify(goterm.Color(fmt.Sprintf("Stopped showing logs since the connection "+
+				"to them couldn't be restored after %s. Your services are still running. "+
+				"Use `blimp logs -f` to view their logs.", logsRetryTimeout), goterm.YELLOW))
+			select {}
 		}
 
-		var err error
-		for _, path := range paths {
-			if _, err = os.Stat(path); err == nil {
-				return filepath.Abs(path)
-			}
+		time.Sleep(retryDelay)
+		retryDelay *= 2
+		if retryDelay > maxLogsRetryDelay {
+			retryDelay = maxLogsRetryDelay
 		}
+	}
+}
+
+// diagnoseBootFailure returns an error describing why the given services
+// failed to boot.
+func (cmd *up) diagnoseBootFailure(services []string) error {
+	msg := fmt.Sprintf("Timed out after %s waiting for %s to boot.",
+		cmd.bootTimeout, strings.Join(services, ", "))
 
-		// Return the error from the last path we tried to stat.
-		return "", err
+	kubeClient, _, err := cmd.auth.KubeClient()
+	if err != nil {
+		return errors.NewFriendlyError("%s\n\nFailed to connect to the cluster to diagnose the failure:\n%s",
+			msg, err)
 	}
 
-	// If the user doesn't explicitly specify any files, try to get the
-	// default files.
-	if len(composePaths) == 0 {
-		composePath, err := getYamlFile("docker-compose")
-		if err != nil {
-			return "", nil, err
-		}
+	return errors.NewFriendlyError("%s\n\n%s\nThe services will continue booting in the background. "+
+		"Run `blimp ps` to check their status, or `blimp logs` to see their logs.",
+		msg, diagnoseBoot(kubeClient, cmd.auth.KubeNamespace, services))
+}
 
-		var overridePaths []string
-		if overridePath, err := getYamlFile("docker-compose.override"); err == nil {
-			overridePaths = []string{overridePath}
-		}
-		return composePath, overridePaths, nil
+func (cmd *up) newProgressPrinter(msg string) util.ProgressPrinter {
+	if cmd.quiet {
+		return util.NewQuietProgressPrinter()
 	}
+	return util.NewProgressPrinter(os.Stdout, msg)
+}
 
-	var absPaths []string
-	for _, composePath := range composePaths {
-		p, err := filepath.Abs(composePath)
-		if err != nil {
-			return "", nil, err
+// getAuthToken returns the user's current auth token. The token is re-read
+// from disk so that long-lived tunnels pick up the new token if the user logs
+// in again while `blimp up` is running. If the token is about to expire, it's
+// renewed, so that the tunnels can send fresh credentials before the node
+// controller closes them.
+func (cmd *up) getAuthToken() string {
+	cmd.authLock.Lock()
+	defer cmd.authLock.Unlock()
+
+	store, err := authstore.New()
+	if err != nil || store.AuthToken == "" {
+		return cmd.auth.AuthToken
+	}
+
+	if err := store.RefreshAuthToken(); err != nil {
+		if !cmd.warnedAuthRefresh {
+			cmd.warnedAuthRefresh = true
+			msg := errors.GetPrintableMessage(err) +
+				"\nPort forwarding will stop working once the session expires."
+			if cmd.notify != nil {
+				cmd.notify(goterm.Color(msg, goterm.YELLOW))
+			} else {
+				log.Warn(msg)
+			}
 		}
-		absPaths = append(absPaths, p)
+		return store.AuthToken
 	}
 
-	return absPaths[0], absPaths[1:], nil
+	cmd.warnedAuthRefresh = false
+	return store.AuthToken
+}
+
+func (cmd *up) makeSyncthingClient(dcCfg composeTypes.Config) syncthing.Client {
+	var bindVolumes []string
+	for _, svc := range dcCfg.Services {
+		for _, v := range svc.Volumes {
+			if v.Type != "bind" {
+				continue
+			}
+
+			bindVolumes = append(bindVolumes, v.Source)
+		}
+	}
+	return syncthing.NewClient(bindVolumes)
 }
 
 func getHeader(fi os.FileInfo, path string) (*tar.Header, error) {
@@ -469,7 +947,9 @@ func getHeader(fi os.FileInfo, path string) (*tar.Header, error) {
 	return tar.FileInfoHeader(fi, link)
 }
 
-func makeTar(dir string) (io.Reader, error) {
+// makeTar creates a tar archive of the given directory. The files in
+// extraFiles are added to the root of the archive.
+func makeTar(dir string, extraFiles map[string][]byte) (io.Reader, error) {
 	var out bytes.Buffer
 	tw := tar.NewWriter(&out)
 	defer tw.Close()
@@ -512,19 +992,26 @@ func makeTar(dir string) (io.Reader, error) {
 		}
 		return nil
 	})
-	return &out, err
-}
-
-func makeRegistryAuthHeader(idToken string) (string, error) {
-	authJSON, err := json.Marshal(types.AuthConfig{
-		Username: "ignored",
-		Password: idToken,
-	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return base64.URLEncoding.EncodeToString(authJSON), nil
+	for name, contents := range extraFiles {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(contents)),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, errors.WithContext(fmt.Sprintf("write header %q", name), err)
+		}
+
+		if _, err := tw.Write(contents); err != nil {
+			return nil, errors.WithContext(fmt.Sprintf("write file %q", name), err)
+		}
+	}
+	return &out, nil
 }
 
 // getLocalRegistryCredentials reads the user's registry credentials from their
@@ -577,3 +1064,49 @@ func registryCredentialsToProtobuf(creds map[string]types.AuthConfig) map[string
 	}
 	return pb
 }
+
+func dnsConfigToProtobuf(cfg *dockercompose.DNSConfig) *cluster.DNSConfig {
+	if cfg == nil {
+		return nil
+	}
+
+	pb := &cluster.DNSConfig{
+		Searches: cfg.Searches,
+		Options:  cfg.Options,
+	}
+	if cfg.Ndots != nil {
+		pb.HasNdots = true
+		pb.Ndots = int32(*cfg.Ndots)
+	}
+	return pb
+}
+
+func securityProfilesToProtobuf(svcExts map[string]dockercompose.ServiceExtension) map[string]*cluster.SecurityProfiles {
+	pb := map[string]*cluster.SecurityProfiles{}
+	for svc, svcExt := range svcExts {
+		if svcExt.Seccomp == "" && svcExt.AppArmor == "" {
+			continue
+		}
+
+		profiles := &cluster.SecurityProfiles{
+			Seccomp:  svcExt.Seccomp,
+			Apparmor: svcExt.AppArmor,
+		}
+		if svcExt.SeccompProfile != nil {
+			profiles.Seccomp = "localhost"
+			profiles.SeccompProfile = string(svcExt.SeccompProfile)
+		}
+		pb[svc] = profiles
+	}
+	return pb
+}
+
+func cronSchedulesToProtobuf(svcExts map[string]dockercompose.ServiceExtension) map[string]string {
+	schedules := map[string]string{}
+	for svc, svcExt := range svcExts {
+		if svcExt.Cron != "" {
+			schedules[svc] = svcExt.Cron
+		}
+	}
+	return schedules
+}
@@ -0,0 +1,143 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+type usageCommand struct {
+	authToken string
+	org       bool
+	since     time.Duration
+	json      bool
+}
+
+// usageRecord is the JSON representation of a cluster.UsageRecord.
+type usageRecord struct {
+	User         string  `json:"user"`
+	Project      string  `json:"project"`
+	NodeHours    float64 `json:"node_hours"`
+	StorageBytes int64   `json:"storage_bytes"`
+	EgressBytes  int64   `json:"egress_bytes"`
+}
+
+func New() *cobra.Command {
+	cmd := usageCommand{}
+	cobraCmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Print the resources used by your sandboxes",
+		Long: "Print the node hours, storage, and egress used by your sandboxes, " +
+			"broken down by user and project.\n\n" +
+			"Use --org to see the usage for everyone in your organization, " +
+			"and --json to export the usage to other tools.",
+		Run: func(_ *cobra.Command, _ []string) {
+			auth, err := authstore.New()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to parse local authentication store")
+			}
+
+			// TODO: Prompt to login again if token is expired.
+			if auth.AuthToken == "" {
+				fmt.Fprintln(os.Stderr, "Not logged in. Please run `blimp login`.")
+				errors.Exit(1)
+			}
+
+			cmd.authToken = auth.AuthToken
+			if err := cmd.run(); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&cmd.org, "org", "", false,
+		"Show the usage for all users in your organization")
+	cobraCmd.Flags().DurationVarP(&cmd.since, "since", "", 30*24*time.Hour,
+		"Only include usage within this long of the current time")
+	cobraCmd.Flags().BoolVarP(&cmd.json, "json", "", false,
+		"Print the usage as JSON")
+	return cobraCmd
+}
+
+func (cmd usageCommand) run() error {
+	resp, err := manager.C.GetUsage(context.Background(), &cluster.GetUsageRequest{
+		Token:     cmd.authToken,
+		Org:       cmd.org,
+		StartTime: time.Now().Add(-cmd.since).Unix(),
+	})
+	if err != nil {
+		return errors.WithContext("get usage", err)
+	}
+
+	records := resp.GetRecords()
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].User != records[j].User {
+			return records[i].User < records[j].User
+		}
+		return records[i].Project < records[j].Project
+	})
+
+	if cmd.json {
+		return printJSON(records)
+	}
+
+	if len(records) == 0 {
+		fmt.Println("No usage has been recorded.")
+		return nil
+	}
+	printTable(records)
+	return nil
+}
+
+func printJSON(records []*cluster.UsageRecord) error {
+	jsonRecords := []usageRecord{}
+	for _, record := range records {
+		jsonRecords = append(jsonRecords, usageRecord{
+			User:         record.User,
+			Project:      record.Project,
+			NodeHours:    record.NodeHours,
+			StorageBytes: record.StorageBytes,
+			EgressBytes:  record.EgressBytes,
+		})
+	}
+
+	out, err := json.MarshalIndent(jsonRecords, "", "  ")
+	if err != nil {
+		return errors.WithContext("marshal usage", err)
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func printTable(records []*cluster.UsageRecord) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "USER\tPROJECT\tNODE HOURS\tSTORAGE\tEGRESS")
+
+	var total usageRecord
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%s\t%s\n", record.User, record.Project, record.NodeHours,
+			util.FormatBytes(record.StorageBytes), util.FormatBytes(record.EgressBytes))
+
+		total.NodeHours += record.NodeHours
+		total.StorageBytes += record.StorageBytes
+		total.EgressBytes += record.EgressBytes
+	}
+
+	if len(records) > 1 {
+		fmt.Fprintf(w, "TOTAL\t\t%.2f\t%s\t%s\n", total.NodeHours,
+			util.FormatBytes(total.StorageBytes), util.FormatBytes(total.EgressBytes))
+	}
+}
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/projectcfg"
+	"github.com/kelda/blimp/pkg/dockercompose"
+)
+
+// CompleteServices completes the names of the services in the Compose file in
+// the current directory, and the service groups defined in the project's
+// blimp.yaml. It's meant to be used as the ValidArgsFunction of commands
+// that accept a list of services.
+func CompleteServices(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	candidates := map[string]struct{}{}
+	if projectCfg, err := projectcfg.Load("."); err == nil {
+		for group := range projectCfg.Groups {
+			candidates[group] = struct{}{}
+		}
+	}
+
+	if composePath, overridePaths, err := GetComposePaths("", nil); err == nil {
+		if cfg, err := dockercompose.Load(composePath, overridePaths, nil); err == nil {
+			for _, svc := range cfg.Services {
+				candidates[svc.Name] = struct{}{}
+			}
+		}
+	}
+
+	// Don't suggest services that were already provided.
+	for _, arg := range args {
+		delete(candidates, arg)
+	}
+
+	var completions []string
+	for candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			completions = append(completions, candidate)
+		}
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
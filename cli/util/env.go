@@ -0,0 +1,23 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// ParseEnv parses environment variables in the form KEY=VALUE, such as the
+// temporary environment variables passed to `blimp restart -e` and
+// `blimp env set`.
+func ParseEnv(vars []string) (map[string]string, error) {
+	env := map[string]string{}
+	for _, v := range vars {
+		parts := strings.SplitN(v, "=", 2)
+		if parts[0] == "" || len(parts) != 2 {
+			return nil, errors.NewFriendlyError("Malformed environment variable %q. "+
+				"It should be in the form KEY=VALUE.", v)
+		}
+		env[parts[0]] = parts[1]
+	}
+	return env, nil
+}
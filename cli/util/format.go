@@ -0,0 +1,46 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatBytes returns a human readable representation of the given number of
+// bytes, such as 1.5MiB.
+func FormatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// ParseBytes parses a human readable number of bytes, such as 500K, 1.5MiB,
+// or 2MB. Units are powers of 1024, to match FormatBytes.
+func ParseBytes(s string) (int64, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(s), "B"), "i")
+	if trimmed == "" {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	multiplier := int64(1)
+	if idx := strings.IndexByte("KMGTPE", trimmed[len(trimmed)-1]); idx != -1 {
+		for i := 0; i <= idx; i++ {
+			multiplier *= 1024
+		}
+		trimmed = trimmed[:len(trimmed)-1]
+	}
+
+	val, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil || val < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(val * float64(multiplier)), nil
+}
@@ -26,7 +26,11 @@ func UpRunning() bool {
 		log.WithError(err).Warn("Corrupt pidfile.")
 		return false
 	}
+	return ProcessAlive(pid)
+}
 
+// ProcessAlive returns whether a process with the given PID is running.
+func ProcessAlive(pid int) bool {
 	// FindProcess will return successfully even when the process doesn't exist.
 	process, err := os.FindProcess(pid)
 	if err != nil {
@@ -15,11 +15,21 @@ type ProgressPrinter struct {
 	msg     string
 	stop    chan struct{}
 	stopped chan struct{}
+
+	// If quiet is set, nothing is printed.
+	quiet bool
 }
 
 // NewProgressPrinter creates a new ProgressPrinter.
 func NewProgressPrinter(out io.Writer, msg string) ProgressPrinter {
-	return ProgressPrinter{out, msg, make(chan struct{}), make(chan struct{})}
+	return ProgressPrinter{out, msg, make(chan struct{}), make(chan struct{}), false}
+}
+
+// NewQuietProgressPrinter creates a ProgressPrinter that doesn't print
+// anything. It's used when the user disables progress output, so that callers
+// don't need to special case it.
+func NewQuietProgressPrinter() ProgressPrinter {
+	return ProgressPrinter{nil, "", make(chan struct{}), make(chan struct{}), true}
 }
 
 var spinnerChars = []string{"/", "-", "\\", "|"}
@@ -27,6 +37,11 @@ var spinnerChars = []string{"/", "-", "\\", "|"}
 // Run starts printing to the output.
 func (pp ProgressPrinter) Run() {
 	defer close(pp.stopped)
+	if pp.quiet {
+		<-pp.stop
+		return
+	}
+
 	poll := time.NewTicker(1 * time.Second)
 	defer poll.Stop()
 
@@ -51,6 +66,10 @@ func (pp ProgressPrinter) Run() {
 func (pp ProgressPrinter) Stop() {
 	close(pp.stop)
 	<-pp.stopped
+	if pp.quiet {
+		return
+	}
+
 	goterm.MoveCursorBackward(1)
 	goterm.Flush()
 	fmt.Fprint(pp.out, " \n")
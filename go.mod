@@ -16,20 +16,22 @@ require (
 	github.com/buger/goterm v0.0.0-20200322175922-2f3e71b85129
 	github.com/coreos/go-oidc v2.2.1+incompatible
 	github.com/docker/cli v0.0.0-20191017083524-a8ff7f821017
-	github.com/docker/docker v1.13.1
+	github.com/docker/docker v1.14.0-0.20190319215453-e7b5f7dbe98c
 	github.com/ghodss/yaml v1.0.0
 	github.com/golang/protobuf v1.4.2
 	github.com/google/go-containerregistry v0.1.0
 	github.com/kelda/compose-go v0.0.0-20200514165240-955c80c756a9
 	github.com/lithammer/dedent v1.1.0
 	github.com/mitchellh/go-homedir v1.1.0
-	github.com/opencontainers/runc v0.1.1 // indirect
+	github.com/moby/buildkit v0.6.3
+	github.com/opencontainers/go-digest v1.0.0-rc1
 	github.com/sirupsen/logrus v1.6.0
 	github.com/spf13/afero v1.2.2
 	github.com/spf13/cobra v1.0.0
 	golang.org/x/crypto v0.0.0-20200604202706-70a84ac30bf9
 	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
 	google.golang.org/grpc v1.29.1
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/api v0.17.4
 	k8s.io/apimachinery v0.17.4
 	k8s.io/cli-runtime v0.17.3
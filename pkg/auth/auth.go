@@ -2,6 +2,9 @@ package auth
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc"
@@ -14,6 +17,9 @@ import (
 type User struct {
 	ID        string `json:"sub"`
 	Namespace string
+
+	// Expiry is when the token used to authenticate the user expires.
+	Expiry time.Time `json:"-"`
 }
 
 const (
@@ -49,6 +55,10 @@ func GetOAuthConfig(clientSecret string) oauth2.Config {
 		Endpoint:     Endpoint,
 		Scopes: []string{
 			"openid",
+
+			// Request a refresh token so that the CLI can get a new ID
+			// token when the current one expires.
+			"offline_access",
 		},
 	}
 }
@@ -70,9 +80,51 @@ func ParseIDToken(token string) (User, error) {
 	}
 
 	user.Namespace = hash.DnsCompliant(user.ID)
+	user.Expiry = idToken.Expiry
 	return user, nil
 }
 
+// RefreshIDToken exchanges the refresh token for a new ID token. It also
+// returns the refresh token to use next time, since refresh tokens may be
+// rotated on each use.
+func RefreshIDToken(refreshToken string) (idToken, newRefreshToken string, err error) {
+	oauthConfig := GetOAuthConfig("")
+	token, err := oauthConfig.TokenSource(context.Background(),
+		&oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		return "", "", err
+	}
+
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", "", errors.New("missing id token")
+	}
+	return idToken, token.RefreshToken, nil
+}
+
+// IDTokenExpiry returns when the ID token expires. It doesn't verify the
+// token, so it should only be used to decide when to refresh the user's own
+// token.
+func IDTokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("malformed token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, errors.WithContext("decode payload", err)
+	}
+
+	var claims struct {
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, errors.WithContext("parse claims", err)
+	}
+	return time.Unix(claims.Expiry, 0), nil
+}
+
 // PasswordLogin obtains an authentication token by directly exchanging the
 // provided username and password, rather than using OAuth. It should only
 // be used for authenticating test accounts during continuous integration tests.
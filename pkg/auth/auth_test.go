@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDTokenExpiry(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user","exp":1591023845}`))
+	expiry, err := IDTokenExpiry("header." + payload + ".signature")
+	assert.NoError(t, err)
+	assert.True(t, time.Unix(1591023845, 0).Equal(expiry))
+
+	_, err = IDTokenExpiry("not-a-token")
+	assert.Error(t, err)
+
+	_, err = IDTokenExpiry("header.!!!.signature")
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+// Package cron parses schedules in the standard five field cron format
+// (minute, hour, day of month, month, day of week). It's shared by the CLI,
+// which validates schedules before sending them, and the cluster manager,
+// which runs them.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds how far Next looks for a matching time, so that schedules
+// that can never match, such as "0 0 31 2 *", don't loop forever.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed cron expression. Each field is a bitset of the values
+// that match.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// Standard cron matches either the day of month or the day of week if
+	// both are restricted, so we need to track whether they were wildcards.
+	domWildcard, dowWildcard bool
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField = field{"minute", 0, 59}
+	hourField   = field{"hour", 0, 23}
+	domField    = field{"day of month", 1, 31}
+	monthField  = field{"month", 1, 12}
+	dowField    = field{"day of week", 0, 7}
+)
+
+// Parse parses a five field cron expression, such as "0 8 * * 1-5". Each
+// field may be a wildcard, a value, a range, or a comma separated list of
+// them, optionally with a step (e.g. "*/15"). Sunday is either 0 or 7.
+func Parse(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, err = parseField(fields[0], minuteField); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = parseField(fields[1], hourField); err != nil {
+		return Schedule{}, err
+	}
+	if s.dom, err = parseField(fields[2], domField); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = parseField(fields[3], monthField); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow, err = parseField(fields[4], dowField); err != nil {
+		return Schedule{}, err
+	}
+
+	// Treat 7 as Sunday.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	s.domWildcard = fields[2] == "*"
+	s.dowWildcard = fields[4] == "*"
+	return s, nil
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %q", f.name, part)
+			}
+		}
+
+		start, end := f.min, f.max
+		if rangeExpr != "*" {
+			var err error
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			start, err = parseValue(bounds[0], f)
+			if err != nil {
+				return 0, err
+			}
+
+			end = start
+			if len(bounds) == 2 {
+				end, err = parseValue(bounds[1], f)
+				if err != nil {
+					return 0, err
+				}
+			} else if step != 1 {
+				// "5/10" means every 10 starting at 5.
+				end = f.max
+			}
+
+			if end < start {
+				return 0, fmt.Errorf("invalid range in %s field: %q", f.name, part)
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	val, err := strconv.Atoi(expr)
+	if err != nil || val < f.min || val > f.max {
+		return 0, fmt.Errorf("invalid value in %s field: %q (must be between %d and %d)",
+			f.name, expr, f.min, f.max)
+	}
+	return val, nil
+}
+
+// Next returns the first time after `t` that matches the schedule. The
+// schedule is evaluated in t's location. The zero time is returned if the
+// schedule never matches.
+func (s Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.Add(maxSearch)
+
+	for t.Before(end) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domWildcard || s.dowWildcard {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		expErr bool
+	}{
+		{name: "Weekday mornings", expr: "0 8 * * 1-5"},
+		{name: "Steps and lists", expr: "*/15 9,17 1-15/2 * 0,7"},
+		{name: "Too few fields", expr: "0 8 * *", expErr: true},
+		{name: "Out of range", expr: "60 8 * * *", expErr: true},
+		{name: "Backwards range", expr: "0 8 * * 5-1", expErr: true},
+		{name: "Bad step", expr: "*/0 * * * *", expErr: true},
+		{name: "Not a number", expr: "0 8 * * mon", expErr: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.expr)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// A Friday.
+	start := time.Date(2020, 6, 5, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expr    string
+		expNext time.Time
+	}{
+		{
+			name:    "Weekday mornings skip the weekend",
+			expr:    "0 8 * * 1-5",
+			expNext: time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Every 15 minutes",
+			expr:    "*/15 * * * *",
+			expNext: time.Date(2020, 6, 5, 8, 45, 0, 0, time.UTC),
+		},
+		{
+			name:    "Sunday as 7",
+			expr:    "0 0 * * 7",
+			expNext: time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Day of month or day of week",
+			expr:    "0 0 1 * 6",
+			expNext: time.Date(2020, 6, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Next year",
+			expr:    "0 0 1 1 *",
+			expNext: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Never matches",
+			expr: "0 0 31 2 *",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := Parse(test.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expNext, schedule.Next(start))
+		})
+	}
+}
@@ -25,7 +25,17 @@ var fs = afero.NewOsFs()
 // Load loads and merges the given compose files. If `services` is non-empty,
 // the return config only includes the services specified in `services`.
 func Load(composePath string, overridePaths, services []string) (types.Config, error) {
+	return LoadProject(filepath.Dir(composePath), composePath, overridePaths, services)
+}
+
+// LoadProject is like Load, but allows the project directory to be set
+// explicitly. The project directory is used to find the .env file, and to
+// name containers. Relative paths in each Compose file are resolved against
+// the directory containing that file, so files from different repositories
+// can be combined.
+func LoadProject(projectDir, composePath string, overridePaths, services []string) (types.Config, error) {
 	var configFiles []types.ConfigFile
+	var rawConfigFiles [][]byte
 	for _, path := range append([]string{composePath}, overridePaths...) {
 		b, err := afero.ReadFile(fs, path)
 		if err != nil {
@@ -42,16 +52,21 @@ func Load(composePath string, overridePaths, services []string) (types.Config, e
 			return types.Config{}, errors.NewFriendlyError(msg)
 		}
 
+		if dir := filepath.Dir(path); dir != projectDir {
+			resolveRelativePaths(configIntf, dir)
+		}
+
 		configFiles = append(configFiles, types.ConfigFile{
 			Filename: filepath.Base(path),
 			Config:   configIntf,
 		})
+		rawConfigFiles = append(rawConfigFiles, b)
 	}
 
 	env := map[string]string{}
-	dotenvPath := filepath.Join(filepath.Dir(composePath), ".env")
+	dotenvPath := filepath.Join(projectDir, ".env")
 	if _, err := os.Stat(dotenvPath); err == nil {
-		dotenv, err := parseEnvFile(dotenvPath)
+		dotenv, err := ParseEnvFile(dotenvPath)
 		if err != nil {
 			return types.Config{}, errors.NewFriendlyError(
 				"Failed to parse .env file at %s.\n\n"+
@@ -84,7 +99,7 @@ func Load(composePath string, overridePaths, services []string) (types.Config, e
 	}
 
 	cfgPtr, err := load(types.ConfigDetails{
-		WorkingDir:  filepath.Dir(composePath),
+		WorkingDir:  projectDir,
 		ConfigFiles: configFiles,
 		Environment: env,
 	}, opts...)
@@ -99,6 +114,13 @@ func Load(composePath string, overridePaths, services []string) (types.Config, e
 				strings.Join(tips, "\n"))
 		}
 
+		// The errors from unmarshalling the Compose file into structs
+		// don't include the location of the error, so try to give a more
+		// helpful error by checking the file against the schema.
+		if err := validateSchema(configFiles, rawConfigFiles); err != nil {
+			return types.Config{}, err
+		}
+
 		debugCmd := []string{"docker-compose"}
 		for _, path := range append([]string{composePath}, overridePaths...) {
 			debugCmd = append(debugCmd, "-f", path)
@@ -109,13 +131,18 @@ func Load(composePath string, overridePaths, services []string) (types.Config, e
 			"The full error was:\n%s", strings.Join(debugCmd, " "), err)
 	}
 
+	addLinkAliases(cfgPtr)
+	if err := validateAliases(*cfgPtr); err != nil {
+		return types.Config{}, err
+	}
+
 	for svcIdx, svc := range cfgPtr.Services {
 		if svc.ContainerName != "" {
 			continue
 		}
 
 		cfgPtr.Services[svcIdx].ContainerName = fmt.Sprintf("%s_%s_1",
-			filepath.Base(filepath.Dir(composePath)), svc.Name)
+			filepath.Base(projectDir), svc.Name)
 	}
 
 	for svcIdx, svc := range cfgPtr.Services {
@@ -180,7 +207,8 @@ func Load(composePath string, overridePaths, services []string) (types.Config, e
 	return *cfgPtr, nil
 }
 
-func parseEnvFile(path string) (map[string]string, error) {
+// ParseEnvFile parses the dotenv file at the given path.
+func ParseEnvFile(path string) (map[string]string, error) {
 	parsed, err := envfile.Parse(path)
 	if err != nil {
 		return nil, err
@@ -230,22 +258,25 @@ func withSkipInterpolation(opts *loader.Options) {
 }
 
 func getErrorContext(file []byte, errMsg string) (string, bool) {
-	matches := regexp.MustCompile(`yaml: line ?(\d+):`).FindSubmatch([]byte(errMsg))
-	if len(matches) != 2 {
-		return "", false
-	}
-
-	errorLine, err := strconv.Atoi(string(matches[1]))
-	if err != nil {
+	errorLine, ok := getErrorLine(file, errMsg)
+	if !ok {
 		return "", false
 	}
+	return formatErrorContext(file, errorLine)
+}
 
+// formatErrorContext returns the lines surrounding `errorLine`, with the
+// error line highlighted.
+func formatErrorContext(file []byte, errorLine int) (string, bool) {
 	lines := strings.Split(string(file), "\n")
 	inRange := func(line int) bool {
 		return line <= len(lines)
 	}
 
 	startLine := errorLine - 1
+	if startLine < 1 {
+		startLine = 1
+	}
 	if !inRange(startLine) {
 		return "", false
 	}
@@ -270,6 +301,19 @@ func getErrorContext(file []byte, errMsg string) (string, bool) {
 	return strings.Join(printLines, "\n"), true
 }
 
+func getErrorLine(file []byte, errMsg string) (int, bool) {
+	matches := regexp.MustCompile(`yaml: line ?(\d+):`).FindSubmatch([]byte(errMsg))
+	if len(matches) != 2 {
+		return locateYAMLError(file, errMsg)
+	}
+
+	errorLine, err := strconv.Atoi(string(matches[1]))
+	if err != nil {
+		return 0, false
+	}
+	return errorLine, true
+}
+
 // The compose-go library panics when the provided YAML file contains
 // unexpected types. For example, the following Compose file causes a panic:
 // ```
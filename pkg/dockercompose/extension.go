@@ -0,0 +1,254 @@
+package dockercompose
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/afero"
+
+	"github.com/kelda/blimp/pkg/cron"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// ExtensionKey is the top-level Compose key for Blimp-specific settings.
+// Compose ignores keys that start with `x-`, so the same file still works
+// with docker-compose.
+const ExtensionKey = "x-blimp"
+
+const (
+	// maxNdots is the largest ndots value that the resolver honors.
+	maxNdots = 15
+
+	// maxSearchDomains is the number of search domains Kubernetes allows
+	// in a pod's DNS config.
+	maxSearchDomains = 6
+)
+
+var searchDomainPattern = regexp.MustCompile(
+	`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// Extension is the `x-blimp` section of a Compose file.
+type Extension struct {
+	// DNS tunes how names are resolved in the sandbox's pods. It's nil if
+	// the default resolver config should be used.
+	DNS *DNSConfig `json:"dns"`
+
+	// Services configures individual services, keyed by service name.
+	Services map[string]ServiceExtension `json:"services"`
+}
+
+// The security profiles that are built into the container runtime, and can
+// be used for both Seccomp and AppArmor.
+const (
+	SecurityProfileRuntimeDefault = "runtime/default"
+	SecurityProfileUnconfined     = "unconfined"
+)
+
+// appArmorLocalhostPrefix is the prefix of AppArmor profiles that are loaded
+// on the cluster's nodes, such as `localhost/k8s-nginx`.
+const appArmorLocalhostPrefix = "localhost/"
+
+// ServiceExtension is the Blimp-specific configuration of a single service.
+type ServiceExtension struct {
+	// Seccomp is the seccomp profile that the service's containers run with,
+	// so that sandboxes can match production hardening. It's either one of
+	// the SecurityProfile constants, or the path to a JSON seccomp profile,
+	// relative to the Compose file. If it's empty, the cluster's default is
+	// used.
+	Seccomp string `json:"seccomp"`
+
+	// SeccompProfile is the contents of the profile file, if Seccomp is a
+	// path. The path is resolved by LoadExtension.
+	SeccompProfile []byte `json:"-"`
+
+	// AppArmor is the AppArmor profile that the service's containers run
+	// with. It's either one of the SecurityProfile constants, or
+	// `localhost/NAME` for a profile that's loaded on the cluster's nodes. If
+	// it's empty, the cluster's default is used.
+	AppArmor string `json:"apparmor"`
+
+	// Cron is a five field cron schedule, such as "*/15 * * * *". If it's
+	// set, the service isn't kept running. Instead, the node controller runs
+	// it as a CronJob within the sandbox, so that periodic workers can be
+	// tested without a separate scheduler container.
+	Cron string `json:"cron"`
+}
+
+// DNSConfig is applied to the dnsConfig of each pod in the sandbox. It can
+// be used to match docker-compose, which resolves single-label names without
+// going through search domains, for apps with their own resolvers.
+type DNSConfig struct {
+	// Ndots is the number of dots a name must have to be resolved as an
+	// absolute name before the search domains are tried. It's nil if the
+	// cluster's default should be used.
+	Ndots *int `json:"ndots"`
+
+	// Searches are additional search domains appended to the pods' default
+	// search domains.
+	Searches []string `json:"search"`
+
+	// Options are additional resolver options, such as "single-request".
+	// Options with values are written as "name:value".
+	Options []string `json:"options"`
+}
+
+// LoadExtension parses the `x-blimp` sections of the given Compose files.
+// Later files override the settings in earlier files, in the same way as
+// Compose override files.
+func LoadExtension(composePath string, overridePaths []string) (Extension, error) {
+	var ext Extension
+	for _, path := range append([]string{composePath}, overridePaths...) {
+		b, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return Extension{}, errors.WithContext("read compose file", err)
+		}
+
+		var file struct {
+			Extension *Extension `json:"x-blimp"`
+		}
+		if err := yaml.Unmarshal(b, &file); err != nil {
+			return Extension{}, errors.NewFriendlyError("Failed to parse %s in %s.\n\n"+
+				"The full error was:\n%s", ExtensionKey, path, err)
+		}
+
+		if file.Extension != nil && file.Extension.DNS != nil {
+			ext.DNS = mergeDNSConfig(ext.DNS, file.Extension.DNS)
+		}
+
+		if file.Extension != nil {
+			for svc, svcExt := range file.Extension.Services {
+				if err := svcExt.loadSeccompProfile(filepath.Dir(path)); err != nil {
+					return Extension{}, errors.NewFriendlyError("Invalid %s.services.%s.seccomp in %s: %s",
+						ExtensionKey, svc, path, err)
+				}
+
+				if ext.Services == nil {
+					ext.Services = map[string]ServiceExtension{}
+				}
+				ext.Services[svc] = mergeServiceExtension(ext.Services[svc], svcExt)
+			}
+		}
+	}
+
+	if err := ext.DNS.validate(); err != nil {
+		return Extension{}, errors.NewFriendlyError("Invalid %s.dns: %s", ExtensionKey, err)
+	}
+
+	for svc, svcExt := range ext.Services {
+		if err := svcExt.validate(); err != nil {
+			return Extension{}, errors.NewFriendlyError("Invalid %s.services.%s: %s", ExtensionKey, svc, err)
+		}
+	}
+	return ext, nil
+}
+
+// loadSeccompProfile reads the seccomp profile if Seccomp is a path. Paths
+// are relative to `dir`, the directory of the Compose file that set it.
+func (svcExt *ServiceExtension) loadSeccompProfile(dir string) error {
+	switch svcExt.Seccomp {
+	case "", SecurityProfileRuntimeDefault, SecurityProfileUnconfined:
+		return nil
+	}
+
+	path := svcExt.Seccomp
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	profile, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return fmt.Errorf("read profile: %s", err)
+	}
+
+	if !json.Valid(profile) {
+		return fmt.Errorf("%s isn't a JSON seccomp profile", path)
+	}
+
+	svcExt.Seccomp = path
+	svcExt.SeccompProfile = profile
+	return nil
+}
+
+func mergeServiceExtension(base, override ServiceExtension) ServiceExtension {
+	merged := base
+	if override.Seccomp != "" {
+		merged.Seccomp = override.Seccomp
+		merged.SeccompProfile = override.SeccompProfile
+	}
+	if override.AppArmor != "" {
+		merged.AppArmor = override.AppArmor
+	}
+	if override.Cron != "" {
+		merged.Cron = override.Cron
+	}
+	return merged
+}
+
+func (svcExt ServiceExtension) validate() error {
+	switch {
+	case svcExt.AppArmor == "", svcExt.AppArmor == SecurityProfileRuntimeDefault,
+		svcExt.AppArmor == SecurityProfileUnconfined:
+	case strings.HasPrefix(svcExt.AppArmor, appArmorLocalhostPrefix) &&
+		len(svcExt.AppArmor) > len(appArmorLocalhostPrefix):
+	default:
+		return fmt.Errorf("apparmor must be %q, %q, or %q, but it was %q",
+			SecurityProfileRuntimeDefault, SecurityProfileUnconfined, appArmorLocalhostPrefix+"NAME",
+			svcExt.AppArmor)
+	}
+
+	if svcExt.Cron != "" {
+		if _, err := cron.Parse(svcExt.Cron); err != nil {
+			return fmt.Errorf("cron %q: %s", svcExt.Cron, err)
+		}
+	}
+	return nil
+}
+
+func mergeDNSConfig(base, override *DNSConfig) *DNSConfig {
+	if base == nil {
+		return override
+	}
+
+	merged := *base
+	if override.Ndots != nil {
+		merged.Ndots = override.Ndots
+	}
+	if override.Searches != nil {
+		merged.Searches = override.Searches
+	}
+	if override.Options != nil {
+		merged.Options = override.Options
+	}
+	return &merged
+}
+
+func (cfg *DNSConfig) validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.Ndots != nil && (*cfg.Ndots < 0 || *cfg.Ndots > maxNdots) {
+		return fmt.Errorf("ndots must be between 0 and %d", maxNdots)
+	}
+
+	if len(cfg.Searches) > maxSearchDomains {
+		return fmt.Errorf("at most %d search domains are allowed", maxSearchDomains)
+	}
+
+	for _, domain := range cfg.Searches {
+		if !searchDomainPattern.MatchString(domain) {
+			return fmt.Errorf("%q isn't a valid search domain", domain)
+		}
+	}
+
+	for _, opt := range cfg.Options {
+		if opt == "" {
+			return fmt.Errorf("options can't be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadExtension(t *testing.T) {
+	one, five := 1, 5
+	tests := []struct {
+		name         string
+		composeFile  string
+		overrideFile string
+		profiles     map[string]string
+		expExtension Extension
+		expError     bool
+	}{
+		{
+			name: "No extension",
+			composeFile: `version: "3"
+services:
+  web:
+    image: nginx`,
+		},
+		{
+			name: "DNS config",
+			composeFile: `version: "3"
+x-blimp:
+  dns:
+    ndots: 1
+    search: [corp.example.com]
+    options: [single-request]`,
+			expExtension: Extension{DNS: &DNSConfig{
+				Ndots:    &one,
+				Searches: []string{"corp.example.com"},
+				Options:  []string{"single-request"},
+			}},
+		},
+		{
+			name: "Override file takes precedence",
+			composeFile: `version: "3"
+x-blimp:
+  dns:
+    ndots: 1
+    search: [corp.example.com]`,
+			overrideFile: `version: "3"
+x-blimp:
+  dns:
+    ndots: 5`,
+			expExtension: Extension{DNS: &DNSConfig{
+				Ndots:    &five,
+				Searches: []string{"corp.example.com"},
+			}},
+		},
+		{
+			name: "Invalid ndots",
+			composeFile: `version: "3"
+x-blimp:
+  dns:
+    ndots: 20`,
+			expError: true,
+		},
+		{
+			name: "Invalid search domain",
+			composeFile: `version: "3"
+x-blimp:
+  dns:
+    search: ["-bad.example.com"]`,
+			expError: true,
+		},
+		{
+			name: "Security profiles",
+			composeFile: `version: "3"
+x-blimp:
+  services:
+    web:
+      seccomp: profiles/web.json
+      apparmor: localhost/k8s-nginx
+    worker:
+      seccomp: runtime/default`,
+			profiles: map[string]string{"profiles/web.json": `{"defaultAction": "SCMP_ACT_ERRNO"}`},
+			expExtension: Extension{Services: map[string]ServiceExtension{
+				"web": {
+					Seccomp:        "profiles/web.json",
+					SeccompProfile: []byte(`{"defaultAction": "SCMP_ACT_ERRNO"}`),
+					AppArmor:       "localhost/k8s-nginx",
+				},
+				"worker": {Seccomp: SecurityProfileRuntimeDefault},
+			}},
+		},
+		{
+			name: "Override file merges security profiles",
+			composeFile: `version: "3"
+x-blimp:
+  services:
+    web:
+      seccomp: runtime/default
+      apparmor: runtime/default`,
+			overrideFile: `version: "3"
+x-blimp:
+  services:
+    web:
+      apparmor: unconfined`,
+			expExtension: Extension{Services: map[string]ServiceExtension{
+				"web": {Seccomp: SecurityProfileRuntimeDefault, AppArmor: SecurityProfileUnconfined},
+			}},
+		},
+		{
+			name: "Missing seccomp profile",
+			composeFile: `version: "3"
+x-blimp:
+  services:
+    web:
+      seccomp: profiles/web.json`,
+			expError: true,
+		},
+		{
+			name: "Seccomp profile isn't JSON",
+			composeFile: `version: "3"
+x-blimp:
+  services:
+    web:
+      seccomp: profiles/web.json`,
+			profiles: map[string]string{"profiles/web.json": "defaultAction: SCMP_ACT_ERRNO"},
+			expError: true,
+		},
+		{
+			name: "Cron schedule",
+			composeFile: `version: "3"
+x-blimp:
+  services:
+    report:
+      cron: "*/15 * * * *"`,
+			expExtension: Extension{Services: map[string]ServiceExtension{
+				"report": {Cron: "*/15 * * * *"},
+			}},
+		},
+		{
+			name: "Invalid cron schedule",
+			composeFile: `version: "3"
+x-blimp:
+  services:
+    report:
+      cron: "every 15 minutes"`,
+			expError: true,
+		},
+		{
+			name: "Invalid AppArmor profile",
+			composeFile: `version: "3"
+x-blimp:
+  services:
+    web:
+      apparmor: k8s-nginx`,
+			expError: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fs = afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(fs, "docker-compose.yml", []byte(test.composeFile), 0644))
+
+			var overridePaths []string
+			if test.overrideFile != "" {
+				assert.NoError(t, afero.WriteFile(fs, "docker-compose.override.yml", []byte(test.overrideFile), 0644))
+				overridePaths = []string{"docker-compose.override.yml"}
+			}
+
+			for path, profile := range test.profiles {
+				assert.NoError(t, afero.WriteFile(fs, path, []byte(profile), 0644))
+			}
+
+			ext, err := LoadExtension("docker-compose.yml", overridePaths)
+			if test.expError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expExtension, ext)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package dockercompose
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var additionalPropertyRegex = regexp.MustCompile(`^Additional property (\S+) is not allowed`)
+
+// parseSchemaError splits a schema validation error from the compose-go
+// library into the path of the offending key, and a description of the
+// problem. The errors are formatted as `services.web.ports.0 must be a string`.
+func parseSchemaError(msg string) (path []string, desc string) {
+	parts := strings.SplitN(strings.TrimSpace(msg), " ", 2)
+	if len(parts) != 2 {
+		return nil, msg
+	}
+
+	if parts[0] != "(root)" {
+		path = strings.Split(parts[0], ".")
+	}
+	desc = parts[1]
+
+	// Point at the unexpected key itself, rather than its parent.
+	if matches := additionalPropertyRegex.FindStringSubmatch(desc); len(matches) == 2 {
+		path = append(path, matches[1])
+	}
+	return path, desc
+}
+
+// locateKeyPath returns the position of the value at the given key path in a
+// YAML file, along with the value's node. Integer path elements index into
+// sequences. If the full path can't be found, such as when part of it comes
+// from a merge key, the position of the deepest key that could be found is
+// returned. Keys are located by their key node, and sequence items by their
+// item node. The returned line and column are one-indexed.
+func locateKeyPath(file []byte, path []string) (line, col int, value *yaml.Node, ok bool) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(file, &root); err != nil || len(root.Content) == 0 {
+		return 0, 0, nil, false
+	}
+
+	node := root.Content[0]
+	for _, key := range path {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		pos, child, found := getChild(node, key)
+		if !found {
+			break
+		}
+
+		line, col, value, ok = pos.Line, pos.Column, child, true
+		node = child
+	}
+	return line, col, value, ok
+}
+
+// getChild returns the node that identifies the child at `key`, and the
+// child's value. For mappings, the identifying node is the key, and for
+// sequences, it's the item itself.
+func getChild(node *yaml.Node, key string) (pos, value *yaml.Node, ok bool) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i], node.Content[i+1], true
+			}
+		}
+
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(key)
+		if err == nil && idx >= 0 && idx < len(node.Content) {
+			return node.Content[idx], node.Content[idx], true
+		}
+	}
+	return nil, nil, false
+}
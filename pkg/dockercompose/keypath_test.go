@@ -0,0 +1,158 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchemaError(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		expPath []string
+		expDesc string
+	}{
+		{
+			name:    "Invalid type",
+			msg:     "services.web.ports.0 must be a string or number",
+			expPath: []string{"services", "web", "ports", "0"},
+			expDesc: "must be a string or number",
+		},
+		{
+			name:    "Additional property",
+			msg:     "services.web Additional property enviroment is not allowed",
+			expPath: []string{"services", "web", "enviroment"},
+			expDesc: "Additional property enviroment is not allowed",
+		},
+		{
+			name:    "Root",
+			msg:     "(root) Additional property service is not allowed",
+			expPath: []string{"service"},
+			expDesc: "Additional property service is not allowed",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			path, desc := parseSchemaError(test.msg)
+			assert.Equal(t, test.expPath, path)
+			assert.Equal(t, test.expDesc, desc)
+		})
+	}
+}
+
+func TestLocateKeyPath(t *testing.T) {
+	file := `version: '3'
+
+services:
+  # The web service.
+  web:
+    image: nginx # healthcheck: disabled
+    ports:
+    - "80:80"
+    - target: 443
+      published: 443
+  db: &db
+    image: postgres
+    environment: {POSTGRES_PASSWORD: password}
+    volumes:
+      - type: volume
+        source: data
+      -
+        type: bind
+        source: ./init
+  worker:
+    <<: *db
+volumes:
+  "data": {}
+`
+
+	tests := []struct {
+		name    string
+		path    []string
+		expLine int
+		expCol  int
+		expOK   bool
+	}{
+		{
+			name:    "Top level key",
+			path:    []string{"services"},
+			expLine: 3,
+			expCol:  1,
+			expOK:   true,
+		},
+		{
+			name:    "Nested key",
+			path:    []string{"services", "db", "image"},
+			expLine: 12,
+			expCol:  5,
+			expOK:   true,
+		},
+		{
+			name:    "Sequence with same indentation as parent",
+			path:    []string{"services", "web", "ports", "1", "published"},
+			expLine: 10,
+			expCol:  7,
+			expOK:   true,
+		},
+		{
+			name:    "Sequence item",
+			path:    []string{"services", "web", "ports", "0"},
+			expLine: 8,
+			expCol:  7,
+			expOK:   true,
+		},
+		{
+			name:    "Sequence item on separate line",
+			path:    []string{"services", "db", "volumes", "1", "source"},
+			expLine: 19,
+			expCol:  9,
+			expOK:   true,
+		},
+		{
+			name:    "Flow mapping",
+			path:    []string{"services", "db", "environment", "POSTGRES_PASSWORD"},
+			expLine: 13,
+			expCol:  19,
+			expOK:   true,
+		},
+		{
+			name:    "Comment containing a key",
+			path:    []string{"services", "web", "healthcheck"},
+			expLine: 5,
+			expCol:  3,
+			expOK:   true,
+		},
+		{
+			name:    "Key within a merge falls back to parent",
+			path:    []string{"services", "worker", "image"},
+			expLine: 20,
+			expCol:  3,
+			expOK:   true,
+		},
+		{
+			name:    "Quoted key",
+			path:    []string{"volumes", "data"},
+			expLine: 23,
+			expCol:  3,
+			expOK:   true,
+		},
+		{
+			name:  "Missing key",
+			path:  []string{"networks"},
+			expOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			line, col, _, ok := locateKeyPath([]byte(file), test.path)
+			assert.Equal(t, test.expOK, ok)
+			assert.Equal(t, test.expLine, line)
+			assert.Equal(t, test.expCol, col)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package dockercompose
+
+import (
+	"strings"
+
+	"github.com/kelda/compose-go/types"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/strs"
+)
+
+// defaultNetwork is the network that services are connected to if they don't
+// specify any networks.
+const defaultNetwork = "default"
+
+// addLinkAliases implements the aliases in legacy `links` entries, such as
+// `db:database`, by adding the alias as a network alias of the linked service.
+// This way, the alias resolves to the linked service the same way that service
+// names do.
+func addLinkAliases(cfg *types.Config) {
+	svcIndex := map[string]int{}
+	for i, svc := range cfg.Services {
+		svcIndex[svc.Name] = i
+	}
+
+	for _, svc := range cfg.Services {
+		for _, link := range svc.Links {
+			parts := strings.SplitN(link, ":", 2)
+			if len(parts) != 2 || parts[1] == "" || parts[0] == parts[1] {
+				continue
+			}
+
+			target, alias := parts[0], parts[1]
+			targetIdx, ok := svcIndex[target]
+			if !ok {
+				continue
+			}
+
+			targetSvc := &cfg.Services[targetIdx]
+			if len(targetSvc.Networks) == 0 {
+				targetSvc.Networks = map[string]*types.ServiceNetworkConfig{
+					defaultNetwork: nil,
+				}
+			}
+
+			for name, network := range targetSvc.Networks {
+				if network == nil {
+					network = &types.ServiceNetworkConfig{}
+					targetSvc.Networks[name] = network
+				}
+
+				network.Aliases = strs.Unique(append(network.Aliases, alias))
+			}
+		}
+	}
+}
+
+// validateAliases rejects network aliases that Blimp can't resolve.
+func validateAliases(cfg types.Config) error {
+	for _, svc := range cfg.Services {
+		for _, network := range svc.Networks {
+			if network == nil {
+				continue
+			}
+
+			for _, alias := range network.Aliases {
+				if err := validateAlias(alias); err != nil {
+					return errors.NewFriendlyError("Invalid network alias for %s: %s", svc.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateAlias returns an error if the alias contains a wildcard, such as
+// `*.web`. Aliases are resolved by exact name, so a wildcard alias would
+// silently never match.
+func validateAlias(alias string) error {
+	if strings.Contains(alias, "*") {
+		return errors.New("wildcard aliases such as %q aren't supported. "+
+			"Please list each hostname as its own alias instead", alias)
+	}
+	return nil
+}
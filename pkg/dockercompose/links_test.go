@@ -0,0 +1,117 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/kelda/compose-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddLinkAliases(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  types.Config
+		exp  types.Config
+	}{
+		{
+			name: "Alias on default network",
+			cfg: types.Config{
+				Services: []types.ServiceConfig{
+					{Name: "web", Links: []string{"db:database", "cache"}},
+					{Name: "db"},
+					{Name: "cache"},
+				},
+			},
+			exp: types.Config{
+				Services: []types.ServiceConfig{
+					{Name: "web", Links: []string{"db:database", "cache"}},
+					{
+						Name: "db",
+						Networks: map[string]*types.ServiceNetworkConfig{
+							"default": {Aliases: []string{"database"}},
+						},
+					},
+					{Name: "cache"},
+				},
+			},
+		},
+		{
+			name: "Alias on explicit networks",
+			cfg: types.Config{
+				Services: []types.ServiceConfig{
+					{Name: "web", Links: []string{"db:database"}},
+					{Name: "worker", Links: []string{"db:database", "db:postgres"}},
+					{
+						Name: "db",
+						Networks: map[string]*types.ServiceNetworkConfig{
+							"backend": {Aliases: []string{"pg"}},
+							"admin":   nil,
+						},
+					},
+				},
+			},
+			exp: types.Config{
+				Services: []types.ServiceConfig{
+					{Name: "web", Links: []string{"db:database"}},
+					{Name: "worker", Links: []string{"db:database", "db:postgres"}},
+					{
+						Name: "db",
+						Networks: map[string]*types.ServiceNetworkConfig{
+							"backend": {Aliases: []string{"pg", "database", "postgres"}},
+							"admin":   {Aliases: []string{"database", "postgres"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Unknown service",
+			cfg: types.Config{
+				Services: []types.ServiceConfig{
+					{Name: "web", Links: []string{"db:database"}},
+				},
+			},
+			exp: types.Config{
+				Services: []types.ServiceConfig{
+					{Name: "web", Links: []string{"db:database"}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			addLinkAliases(&test.cfg)
+			assert.Equal(t, test.exp, test.cfg)
+		})
+	}
+}
+
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		alias  string
+		expErr bool
+	}{
+		{alias: "web"},
+		{alias: "api.web"},
+		{alias: "*.web", expErr: true},
+		{alias: "*.tenants.web", expErr: true},
+		{alias: "*.", expErr: true},
+		{alias: "*", expErr: true},
+		{alias: "tenant.*.web", expErr: true},
+		{alias: "*.*.web", expErr: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.alias, func(t *testing.T) {
+			err := validateAlias(test.alias)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package dockercompose
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var unknownAnchorMsg = regexp.MustCompile(`unknown anchor '([^']+)' referenced`)
+
+// mergeTag is the tag that the YAML parser assigns to merge keys (`<<`).
+const mergeTag = "!!merge"
+
+// locateYAMLError returns the line in `file` that caused the YAML parse error.
+// The YAML library includes the line number for syntax errors, but not for
+// errors caused by anchors, aliases, and merge keys, so we find those lines
+// ourselves. The lines are found by parsing the file rather than by searching
+// its text, so that `&` and `*` within scalars, such as URLs and cron
+// schedules, aren't mistaken for anchors and aliases. The returned line is
+// one-indexed.
+func locateYAMLError(file []byte, errMsg string) (int, bool) {
+	if matches := unknownAnchorMsg.FindStringSubmatch(errMsg); len(matches) == 2 {
+		return findUnknownAlias(file, matches[0])
+	}
+
+	if strings.Contains(errMsg, "map merge requires map") {
+		var root yaml.Node
+		if err := yaml.Unmarshal(file, &root); err != nil {
+			return 0, false
+		}
+		return findInvalidMerge(&root)
+	}
+	return 0, false
+}
+
+// findUnknownAlias returns the line of the alias that references an anchor
+// that doesn't exist. The parser doesn't return the location of the alias,
+// and fails before building the node tree, so instead we look for the
+// shortest prefix of the file that fails with the same error. The parser
+// reads the file in order, so that prefix ends with the alias.
+func findUnknownAlias(file []byte, aliasErr string) (int, bool) {
+	lines := strings.Split(string(file), "\n")
+	for i := range lines {
+		var root yaml.Node
+		err := yaml.Unmarshal([]byte(strings.Join(lines[:i+1], "\n")), &root)
+		if err != nil && strings.Contains(err.Error(), aliasErr) {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// findInvalidMerge returns the first merge key (`<<:`) that doesn't reference
+// a mapping. A common cause is merging an `environment` block that's written
+// as a list rather than a map.
+func findInvalidMerge(node *yaml.Node) (int, bool) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.ShortTag() == mergeTag && !isValidMergeValue(value) {
+				return key.Line, true
+			}
+		}
+	}
+
+	// Aliases aren't followed since the anchored node is checked where it's
+	// defined.
+	for _, child := range node.Content {
+		if line, ok := findInvalidMerge(child); ok {
+			return line, true
+		}
+	}
+	return 0, false
+}
+
+// isValidMergeValue returns whether `value` can be merged into a mapping. It
+// must be a mapping, or a sequence of mappings, either of which may be
+// referenced through aliases.
+func isValidMergeValue(value *yaml.Node) bool {
+	isMapping := func(node *yaml.Node) bool {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		return node != nil && node.Kind == yaml.MappingNode
+	}
+
+	if value.Kind != yaml.SequenceNode {
+		return isMapping(value)
+	}
+
+	for _, item := range value.Content {
+		if !isMapping(item) {
+			return false
+		}
+	}
+	return true
+}
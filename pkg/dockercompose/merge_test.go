@@ -0,0 +1,116 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocateYAMLError(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		errMsg  string
+		expLine int
+		expOK   bool
+	}{
+		{
+			name: "valid merge",
+			file: `x-env: &env
+  FOO: bar
+services:
+  web:
+    environment:
+      <<: *env
+      BAZ: qux`,
+			errMsg: "yaml: map merge requires map or sequence of maps as the value",
+			expOK:  false,
+		},
+		{
+			name: "merge of list",
+			file: `x-env: &env
+  - FOO=bar
+services:
+  web:
+    environment:
+      <<: *env`,
+			errMsg:  "yaml: map merge requires map or sequence of maps as the value",
+			expLine: 6,
+			expOK:   true,
+		},
+		{
+			name: "merge of scalar in sequence",
+			file: `x-image: &image nginx
+x-env: &env {FOO: bar}
+services:
+  web:
+    <<: [*env, *image]`,
+			errMsg:  "yaml: map merge requires map or sequence of maps as the value",
+			expLine: 5,
+			expOK:   true,
+		},
+		{
+			name: "unknown anchor",
+			file: `services:
+  web:
+    # Make sure *comments are ignored.
+    environment: *env`,
+			errMsg:  "yaml: unknown anchor 'env' referenced",
+			expLine: 4,
+			expOK:   true,
+		},
+		{
+			name: "ampersand in quoted scalar",
+			file: `x-env: &env
+  FOO: bar
+services:
+  web:
+    healthcheck:
+      test: "curl http://localhost/?a=1 &env b"
+    environment:
+      <<: *env`,
+			errMsg: "yaml: map merge requires map or sequence of maps as the value",
+			expOK:  false,
+		},
+		{
+			name: "asterisk in quoted scalar",
+			file: `x-env: &env
+  - FOO=bar
+services:
+  web:
+    command: ["ls", "*env"]
+    environment:
+      <<: *env`,
+			errMsg:  "yaml: map merge requires map or sequence of maps as the value",
+			expLine: 7,
+			expOK:   true,
+		},
+		{
+			name: "unknown anchor after quoted asterisk",
+			file: `services:
+  worker:
+    command: 'run --schedule "*/15 * * * *" --only *env'
+    labels:
+      glob: "*env"
+    environment: *env`,
+			errMsg:  "yaml: unknown anchor 'env' referenced",
+			expLine: 6,
+			expOK:   true,
+		},
+		{
+			name:   "unrelated error",
+			file:   `services: []`,
+			errMsg: "yaml: did not find expected key",
+			expOK:  false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			line, ok := locateYAMLError([]byte(test.file), test.errMsg)
+			assert.Equal(t, test.expOK, ok)
+			assert.Equal(t, test.expLine, line)
+		})
+	}
+}
@@ -29,6 +29,35 @@ ignoreme:
 					"3 |   foo\n" +
 					"\x1b[33m4 |   bar:\x1b[0m"),
 		},
+		{
+			name: "merge key references a list",
+			composeFile: `version: "3"
+x-env: &env
+  - FOO=bar
+services:
+  web:
+    image: nginx
+    environment:
+      <<: *env`,
+			expError: errors.NewFriendlyError(
+				"Failed to parse Compose file (docker-compose.yml)\n" +
+					"Error: yaml: map merge requires map or sequence of maps as the value\n\n" +
+					"7 |     environment:\n" +
+					"\x1b[33m8 |       <<: *env\x1b[0m"),
+		},
+		{
+			name: "schema violation",
+			composeFile: `version: "3"
+services:
+  web:
+    image: nginx
+    ports: 80`,
+			expError: errors.NewFriendlyError(
+				"Compose file doesn't match the Compose specification:\n\n" +
+					"docker-compose.yml:5:5: services.web.ports: must be a list\n" +
+					"4 |     image: nginx\n" +
+					"\x1b[33m5 |     ports: 80\x1b[0m"),
+		},
 	}
 
 	for _, test := range tests {
@@ -36,7 +65,7 @@ ignoreme:
 		t.Run(test.name, func(t *testing.T) {
 			fs = afero.NewMemMapFs()
 			assert.NoError(t, afero.WriteFile(fs, "docker-compose.yml", []byte(test.composeFile), 0644))
-			config, err := Load("docker-compose.yml", nil)
+			config, err := Load("docker-compose.yml", nil, nil)
 			assert.Equal(t, test.expError, err)
 			assert.Equal(t, test.expConfig, config)
 		})
@@ -0,0 +1,105 @@
+package dockercompose
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// resolveRelativePaths converts the relative paths in a Compose file into
+// absolute paths, relative to `dir`. It's used for Compose files that live in
+// a different directory than the project, so that their build contexts and
+// bind volumes resolve against their own directory, rather than the project
+// directory. This lets services from multiple repositories be booted in the
+// same sandbox.
+// Paths that reference environment variables are left as is, since they
+// might evaluate to absolute paths.
+func resolveRelativePaths(config map[string]interface{}, dir string) {
+	services, _ := config["services"].(map[string]interface{})
+	for _, svcIntf := range services {
+		svc, ok := svcIntf.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch build := svc["build"].(type) {
+		case string:
+			svc["build"] = resolveBuildContext(build, dir)
+		case map[string]interface{}:
+			if context, ok := build["context"].(string); ok {
+				build["context"] = resolveBuildContext(context, dir)
+			}
+		}
+
+		switch envFile := svc["env_file"].(type) {
+		case string:
+			svc["env_file"] = resolvePath(envFile, dir)
+		case []interface{}:
+			for i, path := range envFile {
+				if path, ok := path.(string); ok {
+					envFile[i] = resolvePath(path, dir)
+				}
+			}
+		}
+
+		volumes, _ := svc["volumes"].([]interface{})
+		for i, volumeIntf := range volumes {
+			switch volume := volumeIntf.(type) {
+			case string:
+				volumes[i] = resolveVolumeSpec(volume, dir)
+			case map[string]interface{}:
+				source, _ := volume["source"].(string)
+				if volume["type"] == "bind" && source != "" {
+					volume["source"] = resolvePath(source, dir)
+				}
+			}
+		}
+	}
+
+	// Secrets and configs can also reference local files.
+	for _, key := range []string{"secrets", "configs"} {
+		definitions, _ := config[key].(map[string]interface{})
+		for _, defIntf := range definitions {
+			def, ok := defIntf.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if file, ok := def["file"].(string); ok {
+				def["file"] = resolvePath(file, dir)
+			}
+		}
+	}
+}
+
+// resolveVolumeSpec resolves the source of a volume in the short syntax, such
+// as `./src:/app:ro`. Named volumes are left as is.
+func resolveVolumeSpec(spec, dir string) string {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 || !isRelativePath(parts[0]) {
+		return spec
+	}
+	return resolvePath(parts[0], dir) + ":" + parts[1]
+}
+
+func resolveBuildContext(context, dir string) string {
+	// Remote build contexts, such as Git repositories, aren't local paths.
+	if strings.Contains(context, "://") || strings.HasPrefix(context, "git@") {
+		return context
+	}
+	return resolvePath(context, dir)
+}
+
+func resolvePath(path, dir string) string {
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "~") || strings.Contains(path, "$") {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// isRelativePath returns whether the source of a volume in the short syntax
+// is a relative path. Docker Compose treats sources that start with a `.` as
+// paths, and all other non-absolute sources as named volumes.
+func isRelativePath(source string) bool {
+	return source == "." || source == ".." ||
+		strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
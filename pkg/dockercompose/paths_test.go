@@ -0,0 +1,90 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveRelativePaths(t *testing.T) {
+	config := map[string]interface{}{
+		"services": map[string]interface{}{
+			"api": map[string]interface{}{
+				"build":    ".",
+				"env_file": []interface{}{"./api.env", "/etc/shared.env"},
+				"volumes": []interface{}{
+					"./src:/app/src:ro",
+					"node_modules:/app/node_modules",
+					"${DATA_DIR}:/data",
+					map[string]interface{}{
+						"type":   "bind",
+						"source": "../shared",
+						"target": "/shared",
+					},
+					map[string]interface{}{
+						"type":   "volume",
+						"source": "cache",
+						"target": "/cache",
+					},
+				},
+			},
+			"web": map[string]interface{}{
+				"build": map[string]interface{}{
+					"context":    "frontend",
+					"dockerfile": "Dockerfile.dev",
+				},
+				"env_file": "web.env",
+			},
+			"docs": map[string]interface{}{
+				"build": "https://github.com/kelda/docs.git",
+			},
+		},
+		"secrets": map[string]interface{}{
+			"token": map[string]interface{}{
+				"file": "./token.txt",
+			},
+		},
+	}
+
+	exp := map[string]interface{}{
+		"services": map[string]interface{}{
+			"api": map[string]interface{}{
+				"build":    "/repos/api",
+				"env_file": []interface{}{"/repos/api/api.env", "/etc/shared.env"},
+				"volumes": []interface{}{
+					"/repos/api/src:/app/src:ro",
+					"node_modules:/app/node_modules",
+					"${DATA_DIR}:/data",
+					map[string]interface{}{
+						"type":   "bind",
+						"source": "/repos/shared",
+						"target": "/shared",
+					},
+					map[string]interface{}{
+						"type":   "volume",
+						"source": "cache",
+						"target": "/cache",
+					},
+				},
+			},
+			"web": map[string]interface{}{
+				"build": map[string]interface{}{
+					"context":    "/repos/api/frontend",
+					"dockerfile": "Dockerfile.dev",
+				},
+				"env_file": "/repos/api/web.env",
+			},
+			"docs": map[string]interface{}{
+				"build": "https://github.com/kelda/docs.git",
+			},
+		},
+		"secrets": map[string]interface{}{
+			"token": map[string]interface{}{
+				"file": "/repos/api/token.txt",
+			},
+		},
+	}
+
+	resolveRelativePaths(config, "/repos/api")
+	assert.Equal(t, exp, config)
+}
@@ -0,0 +1,64 @@
+package dockercompose
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kelda/compose-go/schema"
+	"github.com/kelda/compose-go/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// validateSchema validates each Compose file against the Compose spec. If
+// there are any violations, it returns an error that lists the file, line,
+// and column of each violation.
+// It's only used to explain errors returned by the loader, since the loader
+// accepts files that aren't v3, and there's no schema for those versions.
+func validateSchema(configFiles []types.ConfigFile, rawConfigFiles [][]byte) error {
+	var violations []string
+	for i, configFile := range configFiles {
+		version := schema.Version(configFile.Config)
+		err := schema.Validate(configFile.Config, version)
+		if err == nil || strings.HasPrefix(err.Error(), "unsupported Compose file version") {
+			continue
+		}
+
+		for _, msg := range strings.Split(err.Error(), "\n") {
+			if violation, ok := formatViolation(configFile.Filename, rawConfigFiles[i], msg); ok {
+				violations = append(violations, violation)
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.NewFriendlyError("Compose file doesn't match the Compose specification:\n\n%s",
+		strings.Join(violations, "\n\n"))
+}
+
+// formatViolation formats a schema violation with its location. It returns
+// false if the violation should be ignored.
+func formatViolation(filename string, file []byte, msg string) (string, bool) {
+	path, desc := parseSchemaError(msg)
+	keyPath := strings.Join(path, ".")
+	line, col, value, ok := locateKeyPath(file, path)
+	if !ok {
+		return fmt.Sprintf("%s: %s: %s", filename, keyPath, desc), true
+	}
+
+	// Validation happens before environment variables are interpolated, so
+	// values such as `${PORT}` might not have the right type yet. The loader
+	// reports any values that are still invalid after interpolation.
+	if value.Kind == yaml.ScalarNode && strings.Contains(value.Value, "$") {
+		return "", false
+	}
+
+	violation := fmt.Sprintf("%s:%d:%d: %s: %s", filename, line, col, keyPath, desc)
+	if context, ok := formatErrorContext(file, line); ok {
+		violation += "\n" + context
+	}
+	return violation, true
+}
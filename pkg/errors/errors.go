@@ -125,5 +125,28 @@ func HandleFatalError(err error) {
 	fmt.Fprintln(os.Stderr,
 		goterm.Color("[Error] Get help at https://kelda.io/blimp/docs/help/", goterm.RED))
 	fmt.Fprintln(os.Stderr, body)
-	os.Exit(1)
+	Exit(1)
+}
+
+// exitHandlers are run by Exit before the process exits.
+var exitHandlers []func(code int)
+
+// exit is stubbed out by the unit tests.
+var exit = os.Exit
+
+// RegisterExitHandler registers a function that's called with the exit code
+// before the process exits through Exit, such as to record the result of the
+// command.
+func RegisterExitHandler(handler func(code int)) {
+	exitHandlers = append(exitHandlers, handler)
+}
+
+// Exit runs the handlers registered with RegisterExitHandler, and then exits
+// with the given code. Commands should exit through it rather than calling
+// os.Exit directly, so that the handlers aren't skipped.
+func Exit(code int) {
+	for _, handler := range exitHandlers {
+		handler(code)
+	}
+	exit(code)
 }
@@ -68,3 +68,26 @@ func TestNewFriendlyErrorFmt(t *testing.T) {
 		1, 2, "red", "blue")
 	assert.EqualError(t, err, "1 fish, 2 fish, red fish, blue fish")
 }
+
+func TestExit(t *testing.T) {
+	defer func(origHandlers []func(int), origExit func(int)) {
+		exitHandlers = origHandlers
+		exit = origExit
+	}(exitHandlers, exit)
+
+	var handledCode, exitCode int
+	RegisterExitHandler(func(code int) {
+		handledCode = code
+	})
+	exit = func(code int) {
+		exitCode = code
+	}
+
+	HandleFatalError(New("failed"))
+	assert.Equal(t, 1, handledCode)
+	assert.Equal(t, 1, exitCode)
+
+	Exit(2)
+	assert.Equal(t, 2, handledCode)
+	assert.Equal(t, 2, exitCode)
+}
@@ -0,0 +1,88 @@
+package mirror
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// dockerHubDomains are the registry domains that refer to Docker Hub.
+var dockerHubDomains = map[string]struct{}{
+	"docker.io":       {},
+	"index.docker.io": {},
+}
+
+// RewriteImage rewrites references to images on Docker Hub so that they're
+// pulled from the given mirror instead. References to other registries are
+// returned unchanged.
+// For example, with the mirror `mirror.example.com`, `nginx:1.19` is rewritten
+// to `mirror.example.com/library/nginx:1.19`.
+func RewriteImage(image, mirror string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if mirror == "" || image == "" {
+		return image
+	}
+
+	// Don't try to rewrite images that reference variables, since we don't
+	// know what they'll evaluate to.
+	if strings.Contains(image, "$") {
+		return image
+	}
+
+	path := image
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && isDomain(parts[0]) {
+		if _, ok := dockerHubDomains[parts[0]]; !ok {
+			return image
+		}
+		path = parts[1]
+	}
+
+	// Official images live in the library namespace.
+	if !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+	return mirror + "/" + path
+}
+
+// isDomain returns whether the first component of an image reference is a
+// registry domain, using the same heuristic as Docker.
+func isDomain(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
+
+var fromPattern = regexp.MustCompile(`(?i)^(\s*FROM\s+(?:--\S+\s+)*)(\S+)(.*)$`)
+var stageNamePattern = regexp.MustCompile(`(?i)\s+AS\s+(\S+)`)
+
+// RewriteDockerfile rewrites the base images referenced by FROM instructions
+// so that they're pulled from the given mirror. References to earlier build
+// stages are left as is.
+func RewriteDockerfile(dockerfile []byte, mirror string) []byte {
+	var out bytes.Buffer
+	stages := map[string]struct{}{}
+	scanner := bufio.NewScanner(bytes.NewReader(dockerfile))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := fromPattern.FindStringSubmatch(line); match != nil {
+			prefix, image, suffix := match[1], match[2], match[3]
+			_, isStage := stages[strings.ToLower(image)]
+			if !isStage && strings.ToLower(image) != "scratch" {
+				line = prefix + RewriteImage(image, mirror) + suffix
+			}
+
+			if stage := stageNamePattern.FindStringSubmatch(suffix); stage != nil {
+				stages[strings.ToLower(stage[1])] = struct{}{}
+			}
+		}
+		out.WriteString(line)
+		out.WriteString("\n")
+	}
+
+	// Fall back to the original Dockerfile if it couldn't be read, so that
+	// Docker reports the error.
+	if scanner.Err() != nil {
+		return dockerfile
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,87 @@
+package mirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteImage(t *testing.T) {
+	tests := []struct {
+		name   string
+		image  string
+		mirror string
+		exp    string
+	}{
+		{
+			name:   "Official image",
+			image:  "nginx:1.19",
+			mirror: "mirror.example.com",
+			exp:    "mirror.example.com/library/nginx:1.19",
+		},
+		{
+			name:   "User image",
+			image:  "kelda/blimp@sha256:abc",
+			mirror: "mirror.example.com/dockerhub/",
+			exp:    "mirror.example.com/dockerhub/kelda/blimp@sha256:abc",
+		},
+		{
+			name:   "Explicit Docker Hub domain",
+			image:  "docker.io/library/postgres",
+			mirror: "mirror.example.com",
+			exp:    "mirror.example.com/library/postgres",
+		},
+		{
+			name:   "Other registry",
+			image:  "gcr.io/project/image:latest",
+			mirror: "mirror.example.com",
+			exp:    "gcr.io/project/image:latest",
+		},
+		{
+			name:   "Registry with port",
+			image:  "localhost:5000/image",
+			mirror: "mirror.example.com",
+			exp:    "localhost:5000/image",
+		},
+		{
+			name:   "Variable",
+			image:  "${IMAGE}",
+			mirror: "mirror.example.com",
+			exp:    "${IMAGE}",
+		},
+		{
+			name:  "No mirror",
+			image: "nginx",
+			exp:   "nginx",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.exp, RewriteImage(test.image, test.mirror))
+		})
+	}
+}
+
+func TestRewriteDockerfile(t *testing.T) {
+	dockerfile := `FROM golang:1.14 AS builder
+RUN go build .
+
+FROM --platform=linux/amd64 alpine
+COPY --from=builder /app /app
+
+from builder as test
+FROM scratch
+`
+	exp := `FROM mirror.example.com/library/golang:1.14 AS builder
+RUN go build .
+
+FROM --platform=linux/amd64 mirror.example.com/library/alpine
+COPY --from=builder /app /app
+
+from builder as test
+FROM scratch
+`
+	assert.Equal(t, exp, string(RewriteDockerfile([]byte(dockerfile), "mirror.example.com")))
+}
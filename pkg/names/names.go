@@ -41,3 +41,50 @@ func PodName(serviceName string) string {
 
 	return fmt.Sprintf("%s-%s", sanitized, h)
 }
+
+const (
+	// LogCaptureContainer is the name of the sidecar container that persists
+	// a service's logs to sandbox storage. Kubelet rotates container logs, so
+	// the sidecar is the only way to retrieve the full history of chatty
+	// services.
+	LogCaptureContainer = "blimp-log-capture"
+
+	// LogCapturePath is the file within LogCaptureContainer that contains the
+	// captured logs. Each line is prefixed with an RFC3339 timestamp, in the
+	// same format as the logs returned by Kubernetes. The timestamp may be
+	// followed by the stream and tag from the CRI logging format, such as
+	// `stderr F`.
+	LogCapturePath = "/var/log/blimp/service.log"
+
+	// LogCaptureLabel is the Docker Compose label that opts a service into
+	// log capture.
+	LogCaptureLabel = "io.kelda.blimp.log-capture"
+)
+
+// JobLabel is the Docker Compose label that marks a service as a job that
+// runs to completion, such as a database migration or seeder. Jobs aren't
+// restarted once they succeed, and the services that depend on them wait for
+// them to complete.
+const JobLabel = "io.kelda.blimp.job"
+
+const (
+	// TLSDir is where the certificates for managed TLS are mounted in each
+	// service. It contains the sandbox's CA certificate (ca.crt), and the
+	// service's certificate and private key (tls.crt and tls.key).
+	TLSDir = "/etc/blimp/tls"
+
+	// TLSCAFile is the path to the sandbox's CA certificate within TLSDir.
+	TLSCAFile = TLSDir + "/ca.crt"
+
+	// TLSCertFile is the path to the service's certificate within TLSDir.
+	TLSCertFile = TLSDir + "/tls.crt"
+
+	// TLSKeyFile is the path to the service's private key within TLSDir.
+	TLSKeyFile = TLSDir + "/tls.key"
+)
+
+// HotEnvFile is a dotenv file that's mounted in each service, containing its
+// environment. It's updated in place by `blimp env set --hot`, so that
+// applications that reload their config on a signal can pick up new
+// variables without being restarted.
+const HotEnvFile = "/etc/blimp/env"
@@ -61,6 +61,9 @@ const (
 	ServicePhase_RUNNING              ServicePhase = 5
 	ServicePhase_EXITED               ServicePhase = 6
 	ServicePhase_UNHEALTHY            ServicePhase = 7
+	// The service is a job, and it exited successfully. Jobs that exit with a
+	// non-zero code are in the EXITED phase.
+	ServicePhase_COMPLETED ServicePhase = 8
 )
 
 var ServicePhase_name = map[int32]string{
@@ -72,6 +75,7 @@ var ServicePhase_name = map[int32]string{
 	5: "RUNNING",
 	6: "EXITED",
 	7: "UNHEALTHY",
+	8: "COMPLETED",
 }
 
 var ServicePhase_value = map[string]int32{
@@ -83,6 +87,7 @@ var ServicePhase_value = map[string]int32{
 	"RUNNING":              5,
 	"EXITED":               6,
 	"UNHEALTHY":            7,
+	"COMPLETED":            8,
 }
 
 func (x ServicePhase) String() string {
@@ -124,6 +129,183 @@ func (SandboxStatus_SandboxPhase) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_d156d5389f4d1cd6, []int{14, 0}
 }
 
+type ServiceEvent_Type int32
+
+const (
+	ServiceEvent_UNKNOWN    ServiceEvent_Type = 0
+	ServiceEvent_STARTED    ServiceEvent_Type = 1
+	ServiceEvent_HEALTHY    ServiceEvent_Type = 2
+	ServiceEvent_UNHEALTHY  ServiceEvent_Type = 3
+	ServiceEvent_EXITED     ServiceEvent_Type = 4
+	ServiceEvent_OOM_KILLED ServiceEvent_Type = 5
+	ServiceEvent_RESTARTED  ServiceEvent_Type = 6
+	// The service's pod was evicted from its node, such as when the node
+	// was drained or a spot instance was preempted, and the node controller
+	// rescheduled it on another node. The service's volumes are preserved.
+	// The msg describes the old and new nodes.
+	ServiceEvent_RESCHEDULED ServiceEvent_Type = 7
+)
+
+var ServiceEvent_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "STARTED",
+	2: "HEALTHY",
+	3: "UNHEALTHY",
+	4: "EXITED",
+	5: "OOM_KILLED",
+	6: "RESTARTED",
+	7: "RESCHEDULED",
+}
+
+var ServiceEvent_Type_value = map[string]int32{
+	"UNKNOWN":     0,
+	"STARTED":     1,
+	"HEALTHY":     2,
+	"UNHEALTHY":   3,
+	"EXITED":      4,
+	"OOM_KILLED":  5,
+	"RESTARTED":   6,
+	"RESCHEDULED": 7,
+}
+
+func (x ServiceEvent_Type) String() string {
+	return proto.EnumName(ServiceEvent_Type_name, int32(x))
+}
+
+func (ServiceEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{20, 0}
+}
+
+type SessionEvent_Type int32
+
+const (
+	SessionEvent_OUTPUT SessionEvent_Type = 0
+	SessionEvent_INPUT  SessionEvent_Type = 1
+)
+
+var SessionEvent_Type_name = map[int32]string{
+	0: "OUTPUT",
+	1: "INPUT",
+}
+
+var SessionEvent_Type_value = map[string]int32{
+	"OUTPUT": 0,
+	"INPUT":  1,
+}
+
+func (x SessionEvent_Type) String() string {
+	return proto.EnumName(SessionEvent_Type_name, int32(x))
+}
+
+func (SessionEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{31, 0}
+}
+
+type PrunedResource_Type int32
+
+const (
+	PrunedResource_UNKNOWN PrunedResource_Type = 0
+	// A volume that isn't referenced by any service in the most recently
+	// deployed Compose file.
+	PrunedResource_VOLUME PrunedResource_Type = 1
+	// An image in the sandbox registry that isn't used by any service, and
+	// isn't the most recent build of a service.
+	PrunedResource_IMAGE PrunedResource_Type = 2
+	// A public URL for a service that has expired.
+	PrunedResource_EXPOSE_URL PrunedResource_Type = 3
+)
+
+var PrunedResource_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "VOLUME",
+	2: "IMAGE",
+	3: "EXPOSE_URL",
+}
+
+var PrunedResource_Type_value = map[string]int32{
+	"UNKNOWN":    0,
+	"VOLUME":     1,
+	"IMAGE":      2,
+	"EXPOSE_URL": 3,
+}
+
+func (x PrunedResource_Type) String() string {
+	return proto.EnumName(PrunedResource_Type_name, int32(x))
+}
+
+func (PrunedResource_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{34, 0}
+}
+
+type SandboxResource_Type int32
+
+const (
+	SandboxResource_UNKNOWN    SandboxResource_Type = 0
+	SandboxResource_POD        SandboxResource_Type = 1
+	SandboxResource_SERVICE    SandboxResource_Type = 2
+	SandboxResource_VOLUME     SandboxResource_Type = 3
+	SandboxResource_EXPOSE_URL SandboxResource_Type = 4
+	SandboxResource_SECRET     SandboxResource_Type = 5
+)
+
+var SandboxResource_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "POD",
+	2: "SERVICE",
+	3: "VOLUME",
+	4: "EXPOSE_URL",
+	5: "SECRET",
+}
+
+var SandboxResource_Type_value = map[string]int32{
+	"UNKNOWN":    0,
+	"POD":        1,
+	"SERVICE":    2,
+	"VOLUME":     3,
+	"EXPOSE_URL": 4,
+	"SECRET":     5,
+}
+
+func (x SandboxResource_Type) String() string {
+	return proto.EnumName(SandboxResource_Type_name, int32(x))
+}
+
+func (SandboxResource_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{52, 0}
+}
+
+type DataAtRisk_Reason int32
+
+const (
+	DataAtRisk_UNKNOWN DataAtRisk_Reason = 0
+	// The volume's configuration changed, such as its driver options, so
+	// it's recreated empty.
+	DataAtRisk_VOLUME_RESET DataAtRisk_Reason = 1
+	// The volume changed from persistent to ephemeral, so its contents are
+	// deleted when the services using it are recreated.
+	DataAtRisk_PERSISTENCE_CHANGED DataAtRisk_Reason = 2
+)
+
+var DataAtRisk_Reason_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "VOLUME_RESET",
+	2: "PERSISTENCE_CHANGED",
+}
+
+var DataAtRisk_Reason_value = map[string]int32{
+	"UNKNOWN":             0,
+	"VOLUME_RESET":        1,
+	"PERSISTENCE_CHANGED": 2,
+}
+
+func (x DataAtRisk_Reason) String() string {
+	return proto.EnumName(DataAtRisk_Reason_name, int32(x))
+}
+
+func (DataAtRisk_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{65, 0}
+}
+
 type ProxyAnalyticsRequest struct {
 	// The JSON payload to post to DataDog on behalf of the client.
 	Body                 string   `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
@@ -306,13 +488,37 @@ func (m *CheckVersionResponse) GetError() *errors.Error {
 }
 
 type CreateSandboxRequest struct {
-	Token                string                         `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	ComposeFile          string                         `protobuf:"bytes,2,opt,name=composeFile,proto3" json:"composeFile,omitempty"`
-	RegistryCredentials  map[string]*RegistryCredential `protobuf:"bytes,3,rep,name=registryCredentials,proto3" json:"registryCredentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	SyncedFolders        map[string]string              `protobuf:"bytes,4,rep,name=syncedFolders,proto3" json:"syncedFolders,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+	Token               string                         `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ComposeFile         string                         `protobuf:"bytes,2,opt,name=composeFile,proto3" json:"composeFile,omitempty"`
+	RegistryCredentials map[string]*RegistryCredential `protobuf:"bytes,3,rep,name=registryCredentials,proto3" json:"registryCredentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SyncedFolders       map[string]string              `protobuf:"bytes,4,rep,name=syncedFolders,proto3" json:"syncedFolders,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The name of the Docker Compose project, used to attribute the sandbox's
+	// resource usage.
+	Project string `protobuf:"bytes,5,opt,name=project,proto3" json:"project,omitempty"`
+	// If true, the node controller issues a certificate for each service,
+	// signed by a CA that's unique to the sandbox. The certificates are
+	// mounted into the services at names.TLSDir, so that services can use
+	// TLS when talking to each other.
+	ManagedTls bool `protobuf:"varint,6,opt,name=managed_tls,json=managedTls,proto3" json:"managed_tls,omitempty"`
+	// Tunes the DNS resolver config of the sandbox's pods. It's nil if the
+	// cluster's defaults should be used. See dockercompose.DNSConfig.
+	DnsConfig *DNSConfig `protobuf:"bytes,7,opt,name=dns_config,json=dnsConfig,proto3" json:"dns_config,omitempty"`
+	// The seccomp and AppArmor profiles of the services that set them, keyed
+	// by service name. The node controller applies them to the services'
+	// containers. See dockercompose.ServiceExtension.
+	SecurityProfiles map[string]*SecurityProfiles `protobuf:"bytes,8,rep,name=security_profiles,json=securityProfiles,proto3" json:"security_profiles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If true, the sandbox's pods get a preferred node affinity for the node
+	// that the user's sandbox last ran on, so that the image layers and
+	// volume caches on the node are reused. The sandbox is scheduled
+	// elsewhere if the node is gone or full.
+	StickyNode bool `protobuf:"varint,9,opt,name=sticky_node,json=stickyNode,proto3" json:"sticky_node,omitempty"`
+	// The cron schedules of services that run periodically, keyed by service
+	// name. The node controller runs these services as CronJobs rather than
+	// keeping them running. See dockercompose.ServiceExtension.
+	CronSchedules        map[string]string `protobuf:"bytes,10,rep,name=cron_schedules,json=cronSchedules,proto3" json:"cron_schedules,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *CreateSandboxRequest) Reset()         { *m = CreateSandboxRequest{} }
@@ -368,6 +574,48 @@ func (m *CreateSandboxRequest) GetSyncedFolders() map[string]string {
 	return nil
 }
 
+func (m *CreateSandboxRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *CreateSandboxRequest) GetManagedTls() bool {
+	if m != nil {
+		return m.ManagedTls
+	}
+	return false
+}
+
+func (m *CreateSandboxRequest) GetDnsConfig() *DNSConfig {
+	if m != nil {
+		return m.DnsConfig
+	}
+	return nil
+}
+
+func (m *CreateSandboxRequest) GetSecurityProfiles() map[string]*SecurityProfiles {
+	if m != nil {
+		return m.SecurityProfiles
+	}
+	return nil
+}
+
+func (m *CreateSandboxRequest) GetStickyNode() bool {
+	if m != nil {
+		return m.StickyNode
+	}
+	return false
+}
+
+func (m *CreateSandboxRequest) GetCronSchedules() map[string]string {
+	if m != nil {
+		return m.CronSchedules
+	}
+	return nil
+}
+
 type RegistryCredential struct {
 	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
@@ -416,16 +664,28 @@ func (m *RegistryCredential) GetPassword() string {
 }
 
 type CreateSandboxResponse struct {
-	Error                *errors.Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	NodeAddress          string           `protobuf:"bytes,2,opt,name=NodeAddress,proto3" json:"NodeAddress,omitempty"`
-	NodeCert             string           `protobuf:"bytes,3,opt,name=NodeCert,proto3" json:"NodeCert,omitempty"`
-	KubeCredentials      *KubeCredentials `protobuf:"bytes,4,opt,name=kubeCredentials,proto3" json:"kubeCredentials,omitempty"`
-	ImageNamespace       string           `protobuf:"bytes,5,opt,name=ImageNamespace,proto3" json:"ImageNamespace,omitempty"`
-	Message              string           `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
-	Action               CLIAction        `protobuf:"varint,7,opt,name=action,proto3,enum=blimp.cluster.v0.CLIAction" json:"action,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Error           *errors.Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	NodeAddress     string           `protobuf:"bytes,2,opt,name=NodeAddress,proto3" json:"NodeAddress,omitempty"`
+	NodeCert        string           `protobuf:"bytes,3,opt,name=NodeCert,proto3" json:"NodeCert,omitempty"`
+	KubeCredentials *KubeCredentials `protobuf:"bytes,4,opt,name=kubeCredentials,proto3" json:"kubeCredentials,omitempty"`
+	ImageNamespace  string           `protobuf:"bytes,5,opt,name=ImageNamespace,proto3" json:"ImageNamespace,omitempty"`
+	Message         string           `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Action          CLIAction        `protobuf:"varint,7,opt,name=action,proto3,enum=blimp.cluster.v0.CLIAction" json:"action,omitempty"`
+	// The registry mirror configured for the user's organization, if any.
+	// The manager rewrites references to Docker Hub images in the Compose file
+	// to use the mirror. The CLI uses it to rewrite the base images of the
+	// images that it builds, unless the project configures its own mirror.
+	RegistryMirror string `protobuf:"bytes,8,opt,name=registryMirror,proto3" json:"registryMirror,omitempty"`
+	// The PEM encoded certificate of the sandbox's CA, if managed TLS is
+	// enabled.
+	TlsCa string `protobuf:"bytes,9,opt,name=tls_ca,json=tlsCa,proto3" json:"tls_ca,omitempty"`
+	// The violations of the cluster's policies by the Compose file, such as
+	// images from registries that aren't allowed. If there are any, the
+	// sandbox isn't deployed.
+	PreflightViolations  []*PolicyViolation `protobuf:"bytes,10,rep,name=preflight_violations,json=preflightViolations,proto3" json:"preflight_violations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *CreateSandboxResponse) Reset()         { *m = CreateSandboxResponse{} }
@@ -502,6 +762,27 @@ func (m *CreateSandboxResponse) GetAction() CLIAction {
 	return CLIAction_OK
 }
 
+func (m *CreateSandboxResponse) GetRegistryMirror() string {
+	if m != nil {
+		return m.RegistryMirror
+	}
+	return ""
+}
+
+func (m *CreateSandboxResponse) GetTlsCa() string {
+	if m != nil {
+		return m.TlsCa
+	}
+	return ""
+}
+
+func (m *CreateSandboxResponse) GetPreflightViolations() []*PolicyViolation {
+	if m != nil {
+		return m.PreflightViolations
+	}
+	return nil
+}
+
 type DeployRequest struct {
 	Token                string            `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	ComposeFile          string            `protobuf:"bytes,2,opt,name=composeFile,proto3" json:"composeFile,omitempty"`
@@ -824,11 +1105,15 @@ func (m *GetStatusResponse) GetStatus() *SandboxStatus {
 }
 
 type SandboxStatus struct {
-	Services             map[string]*ServiceStatus  `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Phase                SandboxStatus_SandboxPhase `protobuf:"varint,2,opt,name=phase,proto3,enum=blimp.cluster.v0.SandboxStatus_SandboxPhase" json:"phase,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Services map[string]*ServiceStatus  `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Phase    SandboxStatus_SandboxPhase `protobuf:"varint,2,opt,name=phase,proto3,enum=blimp.cluster.v0.SandboxStatus_SandboxPhase" json:"phase,omitempty"`
+	// The cleanup policies that the sandbox violates. The manager enforces
+	// the policies once the grace period expires, so the CLI warns users
+	// beforehand.
+	PolicyViolations     []*PolicyViolation `protobuf:"bytes,3,rep,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *SandboxStatus) Reset()         { *m = SandboxStatus{} }
@@ -870,13 +1155,42 @@ func (m *SandboxStatus) GetPhase() SandboxStatus_SandboxPhase {
 	return SandboxStatus_UNKNOWN
 }
 
+func (m *SandboxStatus) GetPolicyViolations() []*PolicyViolation {
+	if m != nil {
+		return m.PolicyViolations
+	}
+	return nil
+}
+
 type ServiceStatus struct {
-	Phase                ServicePhase `protobuf:"varint,1,opt,name=phase,proto3,enum=blimp.cluster.v0.ServicePhase" json:"phase,omitempty"`
-	Msg                  string       `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
-	HasStarted           bool         `protobuf:"varint,3,opt,name=has_started,json=hasStarted,proto3" json:"has_started,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Phase      ServicePhase `protobuf:"varint,1,opt,name=phase,proto3,enum=blimp.cluster.v0.ServicePhase" json:"phase,omitempty"`
+	Msg        string       `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	HasStarted bool         `protobuf:"varint,3,opt,name=has_started,json=hasStarted,proto3" json:"has_started,omitempty"`
+	// The most recent resource usage sample for the service's pod. It's unset
+	// if metrics aren't available yet, such as when the pod was just created.
+	Usage *ResourceUsage `protobuf:"bytes,4,opt,name=usage,proto3" json:"usage,omitempty"`
+	// The most recent time that the service was rescheduled onto a new node,
+	// if ever. Connected CLIs use it to tell the user why the service
+	// restarted.
+	LastRescheduled *ServiceEvent `protobuf:"bytes,5,opt,name=last_rescheduled,json=lastRescheduled,proto3" json:"last_rescheduled,omitempty"`
+	// Whether the service is a job, such as a database migration, that's
+	// intended to run to completion. Jobs are declared with the
+	// io.kelda.blimp.job label, and are deployed as Kubernetes Jobs so that
+	// they aren't restarted once they succeed. Services that depend on a job
+	// don't start until the job completes successfully.
+	IsJob bool `protobuf:"varint,6,opt,name=is_job,json=isJob,proto3" json:"is_job,omitempty"`
+	// The exit code of the service's most recent container, if it has exited.
+	ExitCode int32 `protobuf:"varint,7,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// The temporary environment variables set by RestartService, which
+	// override the service's environment in the Compose file.
+	EnvOverrides map[string]string `protobuf:"bytes,8,rep,name=env_overrides,json=envOverrides,proto3" json:"env_overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Why the service is or isn't ready, for tools that wait on the sandbox.
+	// It's unset by managers that don't support it, in which case the CLI
+	// derives the readiness from the phase.
+	Readiness            *Readiness `protobuf:"bytes,9,opt,name=readiness,proto3" json:"readiness,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *ServiceStatus) Reset()         { *m = ServiceStatus{} }
@@ -925,208 +1239,3465 @@ func (m *ServiceStatus) GetHasStarted() bool {
 	return false
 }
 
-func init() {
-	proto.RegisterEnum("blimp.cluster.v0.CLIAction", CLIAction_name, CLIAction_value)
-	proto.RegisterEnum("blimp.cluster.v0.ServicePhase", ServicePhase_name, ServicePhase_value)
-	proto.RegisterEnum("blimp.cluster.v0.SandboxStatus_SandboxPhase", SandboxStatus_SandboxPhase_name, SandboxStatus_SandboxPhase_value)
-	proto.RegisterType((*ProxyAnalyticsRequest)(nil), "blimp.cluster.v0.ProxyAnalyticsRequest")
-	proto.RegisterType((*ProxyAnalyticsResponse)(nil), "blimp.cluster.v0.ProxyAnalyticsResponse")
-	proto.RegisterType((*CheckVersionRequest)(nil), "blimp.cluster.v0.CheckVersionRequest")
-	proto.RegisterType((*CheckVersionResponse)(nil), "blimp.cluster.v0.CheckVersionResponse")
-	proto.RegisterType((*CreateSandboxRequest)(nil), "blimp.cluster.v0.CreateSandboxRequest")
-	proto.RegisterMapType((map[string]*RegistryCredential)(nil), "blimp.cluster.v0.CreateSandboxRequest.RegistryCredentialsEntry")
-	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.CreateSandboxRequest.SyncedFoldersEntry")
-	proto.RegisterType((*RegistryCredential)(nil), "blimp.cluster.v0.RegistryCredential")
-	proto.RegisterType((*CreateSandboxResponse)(nil), "blimp.cluster.v0.CreateSandboxResponse")
-	proto.RegisterType((*DeployRequest)(nil), "blimp.cluster.v0.DeployRequest")
-	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.DeployRequest.BuiltImagesEntry")
-	proto.RegisterType((*DeployResponse)(nil), "blimp.cluster.v0.DeployResponse")
-	proto.RegisterType((*KubeCredentials)(nil), "blimp.cluster.v0.KubeCredentials")
-	proto.RegisterType((*DeleteSandboxRequest)(nil), "blimp.cluster.v0.DeleteSandboxRequest")
-	proto.RegisterType((*DeleteSandboxResponse)(nil), "blimp.cluster.v0.DeleteSandboxResponse")
-	proto.RegisterType((*GetStatusRequest)(nil), "blimp.cluster.v0.GetStatusRequest")
-	proto.RegisterType((*GetStatusResponse)(nil), "blimp.cluster.v0.GetStatusResponse")
-	proto.RegisterType((*SandboxStatus)(nil), "blimp.cluster.v0.SandboxStatus")
-	proto.RegisterMapType((map[string]*ServiceStatus)(nil), "blimp.cluster.v0.SandboxStatus.ServicesEntry")
-	proto.RegisterType((*ServiceStatus)(nil), "blimp.cluster.v0.ServiceStatus")
+func (m *ServiceStatus) GetUsage() *ResourceUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
 }
 
-func init() {
-	proto.RegisterFile("blimp/cluster/v0/manager.proto", fileDescriptor_d156d5389f4d1cd6)
+func (m *ServiceStatus) GetLastRescheduled() *ServiceEvent {
+	if m != nil {
+		return m.LastRescheduled
+	}
+	return nil
 }
 
-var fileDescriptor_d156d5389f4d1cd6 = []byte{
-	// 1152 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x5f, 0x73, 0xda, 0x46,
-	0x10, 0x8f, 0xf8, 0x67, 0xb3, 0x04, 0x50, 0x2f, 0x38, 0xc3, 0xd0, 0x34, 0x76, 0xd5, 0xd6, 0x61,
-	0x5c, 0x17, 0x3c, 0xa4, 0x9d, 0xb6, 0x79, 0x48, 0x8b, 0x41, 0x76, 0x34, 0xb6, 0x85, 0x47, 0x60,
-	0x3b, 0xf1, 0x74, 0x86, 0x39, 0xd0, 0x0d, 0x30, 0x08, 0x44, 0x74, 0x82, 0x9a, 0x7e, 0x8d, 0x7e,
-	0x86, 0x7e, 0x8b, 0xbe, 0xf7, 0xa1, 0x6f, 0x7d, 0xed, 0x97, 0xe9, 0x9c, 0x4e, 0xc2, 0x02, 0xc9,
-	0xc1, 0x75, 0xfb, 0x76, 0xbb, 0xf7, 0xdb, 0xfd, 0xed, 0x2e, 0xbb, 0xcb, 0x09, 0x9e, 0x77, 0x8c,
-	0xc1, 0x68, 0x52, 0xee, 0x1a, 0x53, 0x6a, 0x13, 0xab, 0x3c, 0x3b, 0x28, 0x8f, 0xf0, 0x18, 0xf7,
-	0x88, 0x55, 0x9a, 0x58, 0xa6, 0x6d, 0x22, 0xd1, 0xb9, 0x2f, 0xb9, 0xf7, 0xa5, 0xd9, 0x41, 0xe1,
-	0x19, 0xb7, 0x20, 0x96, 0x65, 0x5a, 0x94, 0x19, 0xf0, 0x13, 0xc7, 0x4b, 0x5f, 0xc2, 0xd6, 0xb9,
-	0x65, 0xde, 0xcc, 0xab, 0x63, 0x6c, 0xcc, 0xed, 0x41, 0x97, 0x6a, 0xe4, 0xfd, 0x94, 0x50, 0x1b,
-	0x21, 0x88, 0x75, 0x4c, 0x7d, 0x9e, 0x17, 0x76, 0x84, 0x62, 0x52, 0x73, 0xce, 0xd2, 0x11, 0x3c,
-	0x5d, 0x05, 0xd3, 0x89, 0x39, 0xa6, 0x04, 0xed, 0x43, 0xdc, 0x71, 0xeb, 0xc0, 0x53, 0x95, 0xa7,
-	0x25, 0x1e, 0x86, 0x4b, 0x35, 0x3b, 0x28, 0xc9, 0xec, 0xa4, 0x71, 0x90, 0x54, 0x86, 0x27, 0xb5,
-	0x3e, 0xe9, 0x0e, 0x2f, 0x89, 0x45, 0x07, 0xe6, 0xd8, 0xa3, 0xcc, 0xc3, 0xc6, 0x8c, 0x6b, 0x5c,
-	0x56, 0x4f, 0x94, 0x7e, 0x17, 0x20, 0xb7, 0x6c, 0xe1, 0xf2, 0xde, 0x69, 0x82, 0x5e, 0x40, 0x56,
-	0x1f, 0xd0, 0x89, 0x81, 0xe7, 0xed, 0x11, 0xa1, 0x14, 0xf7, 0x48, 0x3e, 0xe2, 0x20, 0x32, 0xae,
-	0xfa, 0x8c, 0x6b, 0xd1, 0x4b, 0x48, 0xe0, 0xae, 0xcd, 0x3c, 0x44, 0x77, 0x84, 0x62, 0xa6, 0xf2,
-	0x71, 0x69, 0xb5, 0x84, 0xa5, 0xda, 0xa9, 0x52, 0x75, 0x20, 0x9a, 0x0b, 0xbd, 0xcd, 0x37, 0x76,
-	0x9f, 0x7c, 0xff, 0x8a, 0x42, 0xae, 0x66, 0x11, 0x6c, 0x93, 0x26, 0x1e, 0xeb, 0x1d, 0xf3, 0xc6,
-	0xcb, 0x38, 0x07, 0x71, 0xdb, 0x1c, 0x12, 0x2f, 0x78, 0x2e, 0xa0, 0x1d, 0x48, 0x75, 0xcd, 0xd1,
-	0xc4, 0xa4, 0xe4, 0x68, 0x60, 0x78, 0x61, 0xfb, 0x55, 0xe8, 0x3d, 0x3c, 0xb1, 0x48, 0x6f, 0x40,
-	0x6d, 0x6b, 0x5e, 0xb3, 0x88, 0x4e, 0xc6, 0xf6, 0x00, 0x1b, 0x34, 0x1f, 0xdd, 0x89, 0x16, 0x53,
-	0x95, 0x1f, 0x42, 0x12, 0x08, 0x21, 0x2f, 0x69, 0x41, 0x0f, 0xf2, 0xd8, 0xb6, 0xe6, 0x5a, 0x98,
-	0x6f, 0xd4, 0x86, 0x34, 0x9d, 0x8f, 0xbb, 0x44, 0x3f, 0x32, 0x0d, 0x9d, 0x58, 0x34, 0x1f, 0x73,
-	0xc8, 0xbe, 0xbf, 0x27, 0x59, 0xd3, 0x6f, 0xcb, 0x69, 0x96, 0xfd, 0x15, 0x0c, 0xc8, 0xdf, 0x15,
-	0x11, 0x12, 0x21, 0x3a, 0x24, 0x5e, 0x2f, 0xb2, 0x23, 0x7a, 0x05, 0xf1, 0x19, 0x36, 0xa6, 0xbc,
-	0x3a, 0xa9, 0xca, 0xe7, 0xc1, 0x30, 0x82, 0xce, 0x34, 0x6e, 0xf2, 0x2a, 0xf2, 0x9d, 0x50, 0xf8,
-	0x11, 0x50, 0x30, 0xa4, 0x10, 0x9e, 0x9c, 0x9f, 0x27, 0xe9, 0xf3, 0x20, 0x9d, 0x02, 0x0a, 0x52,
-	0xa0, 0x02, 0x6c, 0x4e, 0x29, 0xb1, 0xc6, 0x78, 0x44, 0x5c, 0x37, 0x0b, 0x99, 0xdd, 0x4d, 0x30,
-	0xa5, 0x3f, 0x9b, 0x96, 0xee, 0xba, 0x5b, 0xc8, 0xd2, 0x1f, 0x11, 0xd8, 0x5a, 0x29, 0xdc, 0x43,
-	0x46, 0x8b, 0xf5, 0x8e, 0x6a, 0xea, 0xa4, 0xaa, 0xeb, 0x16, 0xa1, 0xd4, 0xeb, 0x1d, 0x9f, 0x8a,
-	0x45, 0xc1, 0xc4, 0x1a, 0xb1, 0x6c, 0xa7, 0xe3, 0x93, 0xda, 0x42, 0x46, 0x27, 0x90, 0x1d, 0x4e,
-	0x3b, 0xc4, 0xdf, 0x53, 0xbc, 0xc1, 0x3f, 0x0d, 0xd6, 0xf7, 0x64, 0x19, 0xa8, 0xad, 0x5a, 0xa2,
-	0x5d, 0xc8, 0x28, 0x23, 0xdc, 0x23, 0x2a, 0x1e, 0x11, 0x3a, 0xc1, 0x5d, 0x92, 0x8f, 0xf3, 0x01,
-	0x5c, 0xd6, 0xb2, 0x19, 0xf6, 0x26, 0x34, 0xc1, 0x67, 0x78, 0x14, 0x18, 0xcd, 0x8d, 0x7b, 0x8f,
-	0xa6, 0xf4, 0xb7, 0x00, 0xe9, 0x3a, 0x99, 0x18, 0xe6, 0xfc, 0xbf, 0x4e, 0x99, 0x06, 0xa9, 0xce,
-	0x74, 0x60, 0xd8, 0x4e, 0xbc, 0xde, 0x74, 0x1d, 0x04, 0x63, 0x58, 0x62, 0x2b, 0x1d, 0xde, 0x9a,
-	0xf0, 0x3e, 0xf7, 0x3b, 0x29, 0xbc, 0x06, 0x71, 0x15, 0xf0, 0xaf, 0xba, 0xee, 0x35, 0x64, 0x3c,
-	0xba, 0x07, 0xad, 0x5e, 0x13, 0xb2, 0x2b, 0x3f, 0x1c, 0xdb, 0xf4, 0x7d, 0x93, 0xda, 0xde, 0xa6,
-	0x67, 0x67, 0x16, 0x40, 0x17, 0xd7, 0x2c, 0xdb, 0x0b, 0xc0, 0x11, 0x6e, 0x0b, 0x19, 0xf5, 0x17,
-	0xf2, 0x19, 0x24, 0xc7, 0x8b, 0x9f, 0x38, 0xe6, 0xdc, 0xdc, 0x2a, 0xa4, 0x7d, 0xc8, 0xd5, 0x89,
-	0x41, 0xee, 0xb7, 0xfa, 0x24, 0x19, 0xb6, 0x56, 0xd0, 0x0f, 0xca, 0xb2, 0x08, 0xe2, 0x31, 0xb1,
-	0x9b, 0x36, 0xb6, 0xa7, 0xf4, 0xc3, 0x84, 0xbf, 0xc0, 0x47, 0x3e, 0xe4, 0x83, 0x46, 0xee, 0x5b,
-	0x48, 0x50, 0xc7, 0xde, 0xdd, 0x45, 0xdb, 0xc1, 0x0e, 0x71, 0xb3, 0x71, 0x69, 0x5c, 0xb8, 0xf4,
-	0x67, 0x04, 0xd2, 0x4b, 0x37, 0x48, 0x81, 0x4d, 0x4a, 0xac, 0xd9, 0xa0, 0x4b, 0x68, 0x5e, 0x70,
-	0xda, 0xed, 0xab, 0x35, 0xce, 0x4a, 0x4d, 0x17, 0xcf, 0x7b, 0x6d, 0x61, 0x8e, 0x0e, 0x21, 0x3e,
-	0xe9, 0x63, 0xca, 0x5b, 0x28, 0x53, 0xd9, 0x5f, 0xeb, 0x87, 0x4b, 0xe7, 0xcc, 0x46, 0xe3, 0xa6,
-	0x85, 0x9f, 0x20, 0xbd, 0xe4, 0x3e, 0xa4, 0x53, 0xbf, 0x59, 0xde, 0xc3, 0x61, 0xb9, 0x73, 0x0f,
-	0x6e, 0xee, 0xbe, 0x56, 0x3e, 0x83, 0xc7, 0x7e, 0x52, 0x94, 0x82, 0x8d, 0x0b, 0xf5, 0x44, 0x6d,
-	0x5c, 0xa9, 0xe2, 0x23, 0x26, 0x68, 0x17, 0xaa, 0xaa, 0xa8, 0xc7, 0xa2, 0x80, 0xb2, 0x90, 0x6a,
-	0xc9, 0xda, 0x99, 0xa2, 0x56, 0x5b, 0x4c, 0x11, 0x41, 0x08, 0x32, 0xf5, 0x86, 0xdc, 0x6c, 0xab,
-	0x8d, 0x56, 0x5b, 0x7e, 0xab, 0x34, 0x5b, 0x62, 0x54, 0xba, 0x59, 0x04, 0xeb, 0x16, 0xf3, 0x6b,
-	0xaf, 0x02, 0x82, 0x53, 0x81, 0xe7, 0x77, 0x86, 0xe6, 0xcf, 0x99, 0xa5, 0x38, 0xa2, 0x3d, 0xb7,
-	0xef, 0xd9, 0x11, 0x6d, 0x43, 0xaa, 0x8f, 0x69, 0x9b, 0xda, 0xd8, 0xb2, 0x89, 0xee, 0xf4, 0xfe,
-	0xa6, 0x06, 0x7d, 0x4c, 0x9b, 0x5c, 0xb3, 0xf7, 0x09, 0x24, 0x17, 0x6b, 0x08, 0x25, 0x20, 0xd2,
-	0x38, 0x11, 0x1f, 0xa1, 0x4d, 0x88, 0xc9, 0x6f, 0x95, 0x96, 0x28, 0xec, 0xfd, 0x2a, 0xc0, 0x63,
-	0x3f, 0xd3, 0x72, 0xa2, 0x79, 0xc8, 0x29, 0xaa, 0xd2, 0x52, 0xaa, 0xa7, 0xca, 0xb5, 0xa2, 0x1e,
-	0xb7, 0x2f, 0x1b, 0xa7, 0x17, 0x67, 0x72, 0x53, 0x14, 0xd0, 0x13, 0xc8, 0x5e, 0x55, 0x95, 0x56,
-	0xbb, 0x2e, 0x9f, 0xcb, 0x6a, 0xbd, 0xd9, 0x6e, 0xa8, 0x3c, 0x73, 0x47, 0xd9, 0x7c, 0xa7, 0xd6,
-	0xda, 0x87, 0x8a, 0x5a, 0x17, 0xa3, 0xcc, 0x1f, 0x43, 0xb0, 0xd2, 0xc4, 0xfc, 0x85, 0x8b, 0x23,
-	0x80, 0x04, 0x0b, 0x42, 0xae, 0x8b, 0x09, 0x94, 0x86, 0xe4, 0x85, 0xfa, 0x46, 0xae, 0x9e, 0xb6,
-	0xde, 0xbc, 0x13, 0x37, 0x2a, 0xbf, 0xc5, 0x61, 0xe3, 0x8c, 0x3f, 0x1d, 0x51, 0x07, 0xd2, 0x4b,
-	0xff, 0x3d, 0x68, 0xf7, 0x7e, 0xff, 0xea, 0x85, 0x17, 0x6b, 0x71, 0x7c, 0xa2, 0xa4, 0x47, 0xe8,
-	0x12, 0xb2, 0x7c, 0x71, 0xb5, 0x4c, 0x8f, 0x65, 0x7b, 0xcd, 0x2a, 0x2d, 0xec, 0xdc, 0x0d, 0x58,
-	0xf8, 0xed, 0xb0, 0x6d, 0xef, 0xdb, 0x18, 0x61, 0xb1, 0x87, 0x2d, 0xa0, 0xb0, 0xd8, 0x43, 0x57,
-	0x8f, 0x13, 0x7b, 0x72, 0xb1, 0x24, 0x90, 0x14, 0xb4, 0x5b, 0xdd, 0x35, 0x85, 0xcf, 0x3e, 0x88,
-	0x59, 0xf8, 0x25, 0x90, 0x59, 0x7e, 0x4f, 0xa3, 0x90, 0xa0, 0x42, 0x9f, 0xe7, 0x85, 0xe2, 0x7a,
-	0xe0, 0x82, 0xe6, 0x1a, 0x52, 0x57, 0xd8, 0xee, 0xf6, 0xff, 0xf7, 0x04, 0x0e, 0x04, 0xd4, 0x86,
-	0xc7, 0xfe, 0x87, 0x39, 0xfa, 0x22, 0xa4, 0x23, 0x82, 0x4f, 0xfd, 0xc2, 0xee, 0x3a, 0x98, 0x47,
-	0x71, 0xb8, 0x77, 0x5d, 0xec, 0x0d, 0xec, 0xfe, 0xb4, 0x53, 0xea, 0x9a, 0xa3, 0xf2, 0x90, 0x18,
-	0x3a, 0x2e, 0xf3, 0x2f, 0x9a, 0xc9, 0xb0, 0x57, 0x76, 0x3e, 0x62, 0xbc, 0xaf, 0xa1, 0x4e, 0xc2,
-	0x11, 0x5f, 0xfe, 0x13, 0x00, 0x00, 0xff, 0xff, 0x62, 0xcc, 0x60, 0xfe, 0x25, 0x0d, 0x00, 0x00,
+func (m *ServiceStatus) GetIsJob() bool {
+	if m != nil {
+		return m.IsJob
+	}
+	return false
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *ServiceStatus) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
 
-// ManagerClient is the client API for Manager service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ManagerClient interface {
-	CreateSandbox(ctx context.Context, in *CreateSandboxRequest, opts ...grpc.CallOption) (*CreateSandboxResponse, error)
-	DeployToSandbox(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error)
-	DeleteSandbox(ctx context.Context, in *DeleteSandboxRequest, opts ...grpc.CallOption) (*DeleteSandboxResponse, error)
-	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
-	ProxyAnalytics(ctx context.Context, in *ProxyAnalyticsRequest, opts ...grpc.CallOption) (*ProxyAnalyticsResponse, error)
-	WatchStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (Manager_WatchStatusClient, error)
-	CheckVersion(ctx context.Context, in *CheckVersionRequest, opts ...grpc.CallOption) (*CheckVersionResponse, error)
+func (m *ServiceStatus) GetEnvOverrides() map[string]string {
+	if m != nil {
+		return m.EnvOverrides
+	}
+	return nil
 }
 
-type managerClient struct {
-	cc grpc.ClientConnInterface
+func (m *ServiceStatus) GetReadiness() *Readiness {
+	if m != nil {
+		return m.Readiness
+	}
+	return nil
 }
 
-func NewManagerClient(cc grpc.ClientConnInterface) ManagerClient {
-	return &managerClient{cc}
+// Readiness is a machine-readable explanation of a service's state. Unlike
+// the human readable msg, the reasons are stable across releases.
+type Readiness struct {
+	// Whether the service is running and passing its healthcheck, or is a job
+	// that completed successfully.
+	Ready bool `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	// A CamelCase code for the state, such as "WaitingForDependencies" or
+	// "ImagePullBackOff".
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Human readable details about the reason, such as the image that failed
+	// to pull.
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *managerClient) CreateSandbox(ctx context.Context, in *CreateSandboxRequest, opts ...grpc.CallOption) (*CreateSandboxResponse, error) {
-	out := new(CreateSandboxResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CreateSandbox", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *Readiness) Reset()         { *m = Readiness{} }
+func (m *Readiness) String() string { return proto.CompactTextString(m) }
+func (*Readiness) ProtoMessage()    {}
+func (*Readiness) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{16}
 }
 
-func (c *managerClient) DeployToSandbox(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error) {
-	out := new(DeployResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeployToSandbox", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *Readiness) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Readiness.Unmarshal(m, b)
+}
+func (m *Readiness) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Readiness.Marshal(b, m, deterministic)
+}
+func (m *Readiness) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Readiness.Merge(m, src)
+}
+func (m *Readiness) XXX_Size() int {
+	return xxx_messageInfo_Readiness.Size(m)
+}
+func (m *Readiness) XXX_DiscardUnknown() {
+	xxx_messageInfo_Readiness.DiscardUnknown(m)
 }
 
-func (c *managerClient) DeleteSandbox(ctx context.Context, in *DeleteSandboxRequest, opts ...grpc.CallOption) (*DeleteSandboxResponse, error) {
-	out := new(DeleteSandboxResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeleteSandbox", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Readiness proto.InternalMessageInfo
+
+func (m *Readiness) GetReady() bool {
+	if m != nil {
+		return m.Ready
 	}
-	return out, nil
+	return false
 }
 
-func (c *managerClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
-	out := new(GetStatusResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetStatus", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Readiness) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	return out, nil
+	return ""
 }
 
-func (c *managerClient) ProxyAnalytics(ctx context.Context, in *ProxyAnalyticsRequest, opts ...grpc.CallOption) (*ProxyAnalyticsResponse, error) {
-	out := new(ProxyAnalyticsResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/ProxyAnalytics", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Readiness) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return out, nil
+	return ""
 }
 
-func (c *managerClient) WatchStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (Manager_WatchStatusClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[0], "/blimp.cluster.v0.Manager/WatchStatus", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &managerWatchStatusClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+// ResourceUsage is a point-in-time sample of the resources consumed by a
+// service. The cluster manager also uses these samples when deciding whether a
+// sandbox is idle, so that busy background workers aren't put to sleep.
+type ResourceUsage struct {
+	// CPU usage in millicores.
+	CpuMillicores int64 `protobuf:"varint,1,opt,name=cpu_millicores,json=cpuMillicores,proto3" json:"cpu_millicores,omitempty"`
+	// Working set memory in bytes.
+	MemoryBytes          int64    `protobuf:"varint,2,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type Manager_WatchStatusClient interface {
-	Recv() (*GetStatusResponse, error)
-	grpc.ClientStream
+func (m *ResourceUsage) Reset()         { *m = ResourceUsage{} }
+func (m *ResourceUsage) String() string { return proto.CompactTextString(m) }
+func (*ResourceUsage) ProtoMessage()    {}
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{17}
 }
 
-type managerWatchStatusClient struct {
-	grpc.ClientStream
+func (m *ResourceUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceUsage.Unmarshal(m, b)
+}
+func (m *ResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceUsage.Marshal(b, m, deterministic)
+}
+func (m *ResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceUsage.Merge(m, src)
+}
+func (m *ResourceUsage) XXX_Size() int {
+	return xxx_messageInfo_ResourceUsage.Size(m)
+}
+func (m *ResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceUsage proto.InternalMessageInfo
+
+func (m *ResourceUsage) GetCpuMillicores() int64 {
+	if m != nil {
+		return m.CpuMillicores
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetMemoryBytes() int64 {
+	if m != nil {
+		return m.MemoryBytes
+	}
+	return 0
+}
+
+type GetServiceHistoryRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Service              string   `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServiceHistoryRequest) Reset()         { *m = GetServiceHistoryRequest{} }
+func (m *GetServiceHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServiceHistoryRequest) ProtoMessage()    {}
+func (*GetServiceHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{18}
+}
+
+func (m *GetServiceHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServiceHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetServiceHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServiceHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetServiceHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServiceHistoryRequest.Merge(m, src)
+}
+func (m *GetServiceHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetServiceHistoryRequest.Size(m)
+}
+func (m *GetServiceHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServiceHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServiceHistoryRequest proto.InternalMessageInfo
+
+func (m *GetServiceHistoryRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GetServiceHistoryRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+type GetServiceHistoryResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The events are sorted from oldest to newest.
+	Events               []*ServiceEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetServiceHistoryResponse) Reset()         { *m = GetServiceHistoryResponse{} }
+func (m *GetServiceHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetServiceHistoryResponse) ProtoMessage()    {}
+func (*GetServiceHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{19}
+}
+
+func (m *GetServiceHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServiceHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetServiceHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServiceHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetServiceHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServiceHistoryResponse.Merge(m, src)
+}
+func (m *GetServiceHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetServiceHistoryResponse.Size(m)
+}
+func (m *GetServiceHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServiceHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServiceHistoryResponse proto.InternalMessageInfo
+
+func (m *GetServiceHistoryResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *GetServiceHistoryResponse) GetEvents() []*ServiceEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// ServiceEvent is a health or restart transition for a service. The events are
+// recorded by the node controller over the lifetime of the sandbox.
+type ServiceEvent struct {
+	Type ServiceEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=blimp.cluster.v0.ServiceEvent_Type" json:"type,omitempty"`
+	// The time that the transition was observed, in seconds since the Unix
+	// epoch.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Additional human readable details about the transition, such as the exit
+	// code of a crashed container.
+	Msg                  string   `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceEvent) Reset()         { *m = ServiceEvent{} }
+func (m *ServiceEvent) String() string { return proto.CompactTextString(m) }
+func (*ServiceEvent) ProtoMessage()    {}
+func (*ServiceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{20}
+}
+
+func (m *ServiceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceEvent.Unmarshal(m, b)
+}
+func (m *ServiceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceEvent.Marshal(b, m, deterministic)
+}
+func (m *ServiceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceEvent.Merge(m, src)
+}
+func (m *ServiceEvent) XXX_Size() int {
+	return xxx_messageInfo_ServiceEvent.Size(m)
+}
+func (m *ServiceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceEvent proto.InternalMessageInfo
+
+func (m *ServiceEvent) GetType() ServiceEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ServiceEvent_UNKNOWN
+}
+
+func (m *ServiceEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ServiceEvent) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+type GetUsageRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// If true, the usage of all users in the caller's organization is returned.
+	// Otherwise, only the caller's usage is returned.
+	Org bool `protobuf:"varint,2,opt,name=org,proto3" json:"org,omitempty"`
+	// The start of the reporting period, in seconds since the Unix epoch. The
+	// period always ends at the time of the request.
+	StartTime            int64    `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUsageRequest) Reset()         { *m = GetUsageRequest{} }
+func (m *GetUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUsageRequest) ProtoMessage()    {}
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{21}
+}
+
+func (m *GetUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsageRequest.Unmarshal(m, b)
+}
+func (m *GetUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsageRequest.Merge(m, src)
+}
+func (m *GetUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetUsageRequest.Size(m)
+}
+func (m *GetUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsageRequest proto.InternalMessageInfo
+
+func (m *GetUsageRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GetUsageRequest) GetOrg() bool {
+	if m != nil {
+		return m.Org
+	}
+	return false
+}
+
+func (m *GetUsageRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// There's one record per user and project.
+	Records              []*UsageRecord `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetUsageResponse) Reset()         { *m = GetUsageResponse{} }
+func (m *GetUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUsageResponse) ProtoMessage()    {}
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{22}
+}
+
+func (m *GetUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsageResponse.Unmarshal(m, b)
+}
+func (m *GetUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsageResponse.Marshal(b, m, deterministic)
+}
+func (m *GetUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsageResponse.Merge(m, src)
+}
+func (m *GetUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetUsageResponse.Size(m)
+}
+func (m *GetUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsageResponse proto.InternalMessageInfo
+
+func (m *GetUsageResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *GetUsageResponse) GetRecords() []*UsageRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// UsageRecord is the resources consumed by a user's sandboxes for a project
+// over the reporting period. The cluster manager accumulates usage while
+// sandboxes are running, so deleted sandboxes are still included.
+type UsageRecord struct {
+	User    string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Project string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	// The share of node capacity reserved by the sandbox, multiplied by the
+	// hours that it was running.
+	NodeHours float64 `protobuf:"fixed64,3,opt,name=node_hours,json=nodeHours,proto3" json:"node_hours,omitempty"`
+	// The average size of the sandbox's volumes, in bytes.
+	StorageBytes int64 `protobuf:"varint,4,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes,omitempty"`
+	// The bytes sent from the sandbox to the internet, or to the user's
+	// machine through tunnels.
+	EgressBytes          int64    `protobuf:"varint,5,opt,name=egress_bytes,json=egressBytes,proto3" json:"egress_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UsageRecord) Reset()         { *m = UsageRecord{} }
+func (m *UsageRecord) String() string { return proto.CompactTextString(m) }
+func (*UsageRecord) ProtoMessage()    {}
+func (*UsageRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{23}
+}
+
+func (m *UsageRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageRecord.Unmarshal(m, b)
+}
+func (m *UsageRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageRecord.Marshal(b, m, deterministic)
+}
+func (m *UsageRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageRecord.Merge(m, src)
+}
+func (m *UsageRecord) XXX_Size() int {
+	return xxx_messageInfo_UsageRecord.Size(m)
+}
+func (m *UsageRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsageRecord proto.InternalMessageInfo
+
+func (m *UsageRecord) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *UsageRecord) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *UsageRecord) GetNodeHours() float64 {
+	if m != nil {
+		return m.NodeHours
+	}
+	return 0
+}
+
+func (m *UsageRecord) GetStorageBytes() int64 {
+	if m != nil {
+		return m.StorageBytes
+	}
+	return 0
+}
+
+func (m *UsageRecord) GetEgressBytes() int64 {
+	if m != nil {
+		return m.EgressBytes
+	}
+	return 0
+}
+
+// RecordSessionRequest uploads part of an interactive `blimp ssh` or `blimp
+// exec` session. The first message in the stream must contain the header, and
+// subsequent messages contain the session's events.
+// Sessions are only recorded if the sandbox owner enabled recording when
+// sharing the sandbox. Otherwise, the manager closes the stream immediately,
+// and the CLI stops recording.
+type RecordSessionRequest struct {
+	Header               *SessionInfo    `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Events               []*SessionEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RecordSessionRequest) Reset()         { *m = RecordSessionRequest{} }
+func (m *RecordSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*RecordSessionRequest) ProtoMessage()    {}
+func (*RecordSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{24}
+}
+
+func (m *RecordSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordSessionRequest.Unmarshal(m, b)
+}
+func (m *RecordSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordSessionRequest.Marshal(b, m, deterministic)
+}
+func (m *RecordSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordSessionRequest.Merge(m, src)
+}
+func (m *RecordSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_RecordSessionRequest.Size(m)
+}
+func (m *RecordSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordSessionRequest proto.InternalMessageInfo
+
+func (m *RecordSessionRequest) GetHeader() *SessionInfo {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *RecordSessionRequest) GetEvents() []*SessionEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type RecordSessionResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RecordSessionResponse) Reset()         { *m = RecordSessionResponse{} }
+func (m *RecordSessionResponse) String() string { return proto.CompactTextString(m) }
+func (*RecordSessionResponse) ProtoMessage()    {}
+func (*RecordSessionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{25}
+}
+
+func (m *RecordSessionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordSessionResponse.Unmarshal(m, b)
+}
+func (m *RecordSessionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordSessionResponse.Marshal(b, m, deterministic)
+}
+func (m *RecordSessionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordSessionResponse.Merge(m, src)
+}
+func (m *RecordSessionResponse) XXX_Size() int {
+	return xxx_messageInfo_RecordSessionResponse.Size(m)
+}
+func (m *RecordSessionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordSessionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordSessionResponse proto.InternalMessageInfo
+
+func (m *RecordSessionResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type ListSessionsRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSessionsRequest) Reset()         { *m = ListSessionsRequest{} }
+func (m *ListSessionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSessionsRequest) ProtoMessage()    {}
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{26}
+}
+
+func (m *ListSessionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSessionsRequest.Unmarshal(m, b)
+}
+func (m *ListSessionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSessionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSessionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSessionsRequest.Merge(m, src)
+}
+func (m *ListSessionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSessionsRequest.Size(m)
+}
+func (m *ListSessionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSessionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSessionsRequest proto.InternalMessageInfo
+
+func (m *ListSessionsRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The sessions are sorted from oldest to newest.
+	Sessions             []*SessionInfo `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListSessionsResponse) Reset()         { *m = ListSessionsResponse{} }
+func (m *ListSessionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSessionsResponse) ProtoMessage()    {}
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{27}
+}
+
+func (m *ListSessionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSessionsResponse.Unmarshal(m, b)
+}
+func (m *ListSessionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSessionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSessionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSessionsResponse.Merge(m, src)
+}
+func (m *ListSessionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSessionsResponse.Size(m)
+}
+func (m *ListSessionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSessionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSessionsResponse proto.InternalMessageInfo
+
+func (m *ListSessionsResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ListSessionsResponse) GetSessions() []*SessionInfo {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
+type GetSessionRecordingRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSessionRecordingRequest) Reset()         { *m = GetSessionRecordingRequest{} }
+func (m *GetSessionRecordingRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSessionRecordingRequest) ProtoMessage()    {}
+func (*GetSessionRecordingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{28}
+}
+
+func (m *GetSessionRecordingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSessionRecordingRequest.Unmarshal(m, b)
+}
+func (m *GetSessionRecordingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSessionRecordingRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSessionRecordingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSessionRecordingRequest.Merge(m, src)
+}
+func (m *GetSessionRecordingRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSessionRecordingRequest.Size(m)
+}
+func (m *GetSessionRecordingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSessionRecordingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSessionRecordingRequest proto.InternalMessageInfo
+
+func (m *GetSessionRecordingRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GetSessionRecordingRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetSessionRecordingResponse struct {
+	Error                *errors.Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Session              *SessionInfo    `protobuf:"bytes,2,opt,name=session,proto3" json:"session,omitempty"`
+	Events               []*SessionEvent `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetSessionRecordingResponse) Reset()         { *m = GetSessionRecordingResponse{} }
+func (m *GetSessionRecordingResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSessionRecordingResponse) ProtoMessage()    {}
+func (*GetSessionRecordingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{29}
+}
+
+func (m *GetSessionRecordingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSessionRecordingResponse.Unmarshal(m, b)
+}
+func (m *GetSessionRecordingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSessionRecordingResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSessionRecordingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSessionRecordingResponse.Merge(m, src)
+}
+func (m *GetSessionRecordingResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSessionRecordingResponse.Size(m)
+}
+func (m *GetSessionRecordingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSessionRecordingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSessionRecordingResponse proto.InternalMessageInfo
+
+func (m *GetSessionRecordingResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *GetSessionRecordingResponse) GetSession() *SessionInfo {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
+func (m *GetSessionRecordingResponse) GetEvents() []*SessionEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// SessionInfo describes a recorded session.
+type SessionInfo struct {
+	// The ID is assigned by the manager, and is ignored when recording.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The token is only set when recording. The manager uses it to look up the
+	// user that ran the session.
+	Token   string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	User    string   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Service string   `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	Command []string `protobuf:"bytes,5,rep,name=command,proto3" json:"command,omitempty"`
+	// The size of the terminal when the session started.
+	Width  int32 `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	// The time that the session started, in seconds since the Unix epoch.
+	StartTime int64 `protobuf:"varint,8,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// The length of the session, in seconds. It's set by the manager once the
+	// session ends.
+	Duration             float64  `protobuf:"fixed64,9,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionInfo) Reset()         { *m = SessionInfo{} }
+func (m *SessionInfo) String() string { return proto.CompactTextString(m) }
+func (*SessionInfo) ProtoMessage()    {}
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{30}
+}
+
+func (m *SessionInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionInfo.Unmarshal(m, b)
+}
+func (m *SessionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionInfo.Marshal(b, m, deterministic)
+}
+func (m *SessionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionInfo.Merge(m, src)
+}
+func (m *SessionInfo) XXX_Size() int {
+	return xxx_messageInfo_SessionInfo.Size(m)
+}
+func (m *SessionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionInfo proto.InternalMessageInfo
+
+func (m *SessionInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SessionInfo) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *SessionInfo) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *SessionInfo) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *SessionInfo) GetCommand() []string {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *SessionInfo) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *SessionInfo) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SessionInfo) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *SessionInfo) GetDuration() float64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+// SessionEvent is a chunk of terminal input or output, in the same format as
+// asciinema recordings.
+type SessionEvent struct {
+	// The time that the event happened, in seconds since the start of the
+	// session.
+	Time                 float64           `protobuf:"fixed64,1,opt,name=time,proto3" json:"time,omitempty"`
+	Type                 SessionEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=blimp.cluster.v0.SessionEvent_Type" json:"type,omitempty"`
+	Data                 []byte            `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *SessionEvent) Reset()         { *m = SessionEvent{} }
+func (m *SessionEvent) String() string { return proto.CompactTextString(m) }
+func (*SessionEvent) ProtoMessage()    {}
+func (*SessionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{31}
+}
+
+func (m *SessionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionEvent.Unmarshal(m, b)
+}
+func (m *SessionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionEvent.Marshal(b, m, deterministic)
+}
+func (m *SessionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionEvent.Merge(m, src)
+}
+func (m *SessionEvent) XXX_Size() int {
+	return xxx_messageInfo_SessionEvent.Size(m)
+}
+func (m *SessionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionEvent proto.InternalMessageInfo
+
+func (m *SessionEvent) GetTime() float64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *SessionEvent) GetType() SessionEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return SessionEvent_OUTPUT
+}
+
+func (m *SessionEvent) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// PruneRequest removes the sandbox resources that are no longer used.
+type PruneRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// If true, the resources that would be removed are returned, but nothing
+	// is removed.
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneRequest) Reset()         { *m = PruneRequest{} }
+func (m *PruneRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneRequest) ProtoMessage()    {}
+func (*PruneRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{32}
+}
+
+func (m *PruneRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRequest.Unmarshal(m, b)
+}
+func (m *PruneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRequest.Merge(m, src)
+}
+func (m *PruneRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneRequest.Size(m)
+}
+func (m *PruneRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneRequest proto.InternalMessageInfo
+
+func (m *PruneRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *PruneRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PruneResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The resources that were removed, or would have been removed if dry_run
+	// was set.
+	Resources            []*PrunedResource `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PruneResponse) Reset()         { *m = PruneResponse{} }
+func (m *PruneResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneResponse) ProtoMessage()    {}
+func (*PruneResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{33}
+}
+
+func (m *PruneResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneResponse.Unmarshal(m, b)
+}
+func (m *PruneResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneResponse.Merge(m, src)
+}
+func (m *PruneResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneResponse.Size(m)
+}
+func (m *PruneResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneResponse proto.InternalMessageInfo
+
+func (m *PruneResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *PruneResponse) GetResources() []*PrunedResource {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type PrunedResource struct {
+	Type PrunedResource_Type `protobuf:"varint,1,opt,name=type,proto3,enum=blimp.cluster.v0.PrunedResource_Type" json:"type,omitempty"`
+	Name string              `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The storage freed by removing the resource, if known.
+	SizeBytes            int64    `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrunedResource) Reset()         { *m = PrunedResource{} }
+func (m *PrunedResource) String() string { return proto.CompactTextString(m) }
+func (*PrunedResource) ProtoMessage()    {}
+func (*PrunedResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{34}
+}
+
+func (m *PrunedResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrunedResource.Unmarshal(m, b)
+}
+func (m *PrunedResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrunedResource.Marshal(b, m, deterministic)
+}
+func (m *PrunedResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrunedResource.Merge(m, src)
+}
+func (m *PrunedResource) XXX_Size() int {
+	return xxx_messageInfo_PrunedResource.Size(m)
+}
+func (m *PrunedResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrunedResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrunedResource proto.InternalMessageInfo
+
+func (m *PrunedResource) GetType() PrunedResource_Type {
+	if m != nil {
+		return m.Type
+	}
+	return PrunedResource_UNKNOWN
+}
+
+func (m *PrunedResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PrunedResource) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+// PolicyViolation is a violation of a cleanup policy defined by the cluster's
+// administrators.
+type PolicyViolation struct {
+	// The rule that was violated, such as "max_idle_time".
+	Rule string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// A human readable description of the violation.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The action the manager takes if the violation isn't resolved, such as
+	// "delete sandbox".
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// When the action will be taken, in seconds since the Unix epoch.
+	EnforceAt int64 `protobuf:"varint,4,opt,name=enforce_at,json=enforceAt,proto3" json:"enforce_at,omitempty"`
+	// The service that violates the rule, for rules that apply to individual
+	// services.
+	Service              string   `protobuf:"bytes,5,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PolicyViolation) Reset()         { *m = PolicyViolation{} }
+func (m *PolicyViolation) String() string { return proto.CompactTextString(m) }
+func (*PolicyViolation) ProtoMessage()    {}
+func (*PolicyViolation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{35}
+}
+
+func (m *PolicyViolation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyViolation.Unmarshal(m, b)
+}
+func (m *PolicyViolation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyViolation.Marshal(b, m, deterministic)
+}
+func (m *PolicyViolation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyViolation.Merge(m, src)
+}
+func (m *PolicyViolation) XXX_Size() int {
+	return xxx_messageInfo_PolicyViolation.Size(m)
+}
+func (m *PolicyViolation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyViolation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyViolation proto.InternalMessageInfo
+
+func (m *PolicyViolation) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *PolicyViolation) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *PolicyViolation) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *PolicyViolation) GetEnforceAt() int64 {
+	if m != nil {
+		return m.EnforceAt
+	}
+	return 0
+}
+
+func (m *PolicyViolation) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+type WaitForCapacityRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// If true, the user isn't added to the queue if the cluster is at
+	// capacity. Instead, the manager returns a single status with the user's
+	// would-be position, and closes the stream.
+	NoQueue              bool     `protobuf:"varint,2,opt,name=no_queue,json=noQueue,proto3" json:"no_queue,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WaitForCapacityRequest) Reset()         { *m = WaitForCapacityRequest{} }
+func (m *WaitForCapacityRequest) String() string { return proto.CompactTextString(m) }
+func (*WaitForCapacityRequest) ProtoMessage()    {}
+func (*WaitForCapacityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{36}
+}
+
+func (m *WaitForCapacityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitForCapacityRequest.Unmarshal(m, b)
+}
+func (m *WaitForCapacityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitForCapacityRequest.Marshal(b, m, deterministic)
+}
+func (m *WaitForCapacityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitForCapacityRequest.Merge(m, src)
+}
+func (m *WaitForCapacityRequest) XXX_Size() int {
+	return xxx_messageInfo_WaitForCapacityRequest.Size(m)
+}
+func (m *WaitForCapacityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitForCapacityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitForCapacityRequest proto.InternalMessageInfo
+
+func (m *WaitForCapacityRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *WaitForCapacityRequest) GetNoQueue() bool {
+	if m != nil {
+		return m.NoQueue
+	}
+	return false
+}
+
+type QueueStatus struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// Admitted is true once the user can create their sandbox. The stream is
+	// closed after the user is admitted.
+	Admitted bool `protobuf:"varint,2,opt,name=admitted,proto3" json:"admitted,omitempty"`
+	// The user's position in the queue, starting from 1.
+	Position int32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	// The estimated time until the user is admitted, in seconds. It's zero if
+	// the manager can't estimate it.
+	EtaSeconds           int64    `protobuf:"varint,4,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueueStatus) Reset()         { *m = QueueStatus{} }
+func (m *QueueStatus) String() string { return proto.CompactTextString(m) }
+func (*QueueStatus) ProtoMessage()    {}
+func (*QueueStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{37}
+}
+
+func (m *QueueStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueueStatus.Unmarshal(m, b)
+}
+func (m *QueueStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueueStatus.Marshal(b, m, deterministic)
+}
+func (m *QueueStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueStatus.Merge(m, src)
+}
+func (m *QueueStatus) XXX_Size() int {
+	return xxx_messageInfo_QueueStatus.Size(m)
+}
+func (m *QueueStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueStatus proto.InternalMessageInfo
+
+func (m *QueueStatus) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *QueueStatus) GetAdmitted() bool {
+	if m != nil {
+		return m.Admitted
+	}
+	return false
+}
+
+func (m *QueueStatus) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
+func (m *QueueStatus) GetEtaSeconds() int64 {
+	if m != nil {
+		return m.EtaSeconds
+	}
+	return 0
+}
+
+type RenderSandboxRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The normalized Compose file, in the same format as
+	// DeployRequest.composeFile.
+	ComposeFile string `protobuf:"bytes,2,opt,name=compose_file,json=composeFile,proto3" json:"compose_file,omitempty"`
+	// The images built for services with a build section. Services that
+	// haven't been built are rendered with a placeholder image.
+	BuiltImages          map[string]string `protobuf:"bytes,3,rep,name=built_images,json=builtImages,proto3" json:"built_images,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RenderSandboxRequest) Reset()         { *m = RenderSandboxRequest{} }
+func (m *RenderSandboxRequest) String() string { return proto.CompactTextString(m) }
+func (*RenderSandboxRequest) ProtoMessage()    {}
+func (*RenderSandboxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{38}
+}
+
+func (m *RenderSandboxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderSandboxRequest.Unmarshal(m, b)
+}
+func (m *RenderSandboxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderSandboxRequest.Marshal(b, m, deterministic)
+}
+func (m *RenderSandboxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderSandboxRequest.Merge(m, src)
+}
+func (m *RenderSandboxRequest) XXX_Size() int {
+	return xxx_messageInfo_RenderSandboxRequest.Size(m)
+}
+func (m *RenderSandboxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderSandboxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderSandboxRequest proto.InternalMessageInfo
+
+func (m *RenderSandboxRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *RenderSandboxRequest) GetComposeFile() string {
+	if m != nil {
+		return m.ComposeFile
+	}
+	return ""
+}
+
+func (m *RenderSandboxRequest) GetBuiltImages() map[string]string {
+	if m != nil {
+		return m.BuiltImages
+	}
+	return nil
+}
+
+type RenderSandboxResponse struct {
+	Error   *errors.Error     `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Objects []*RenderedObject `protobuf:"bytes,2,rep,name=objects,proto3" json:"objects,omitempty"`
+	// Warnings about parts of the Compose file that aren't supported, and are
+	// ignored when deploying.
+	Warnings             []string `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenderSandboxResponse) Reset()         { *m = RenderSandboxResponse{} }
+func (m *RenderSandboxResponse) String() string { return proto.CompactTextString(m) }
+func (*RenderSandboxResponse) ProtoMessage()    {}
+func (*RenderSandboxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{39}
+}
+
+func (m *RenderSandboxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderSandboxResponse.Unmarshal(m, b)
+}
+func (m *RenderSandboxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderSandboxResponse.Marshal(b, m, deterministic)
+}
+func (m *RenderSandboxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderSandboxResponse.Merge(m, src)
+}
+func (m *RenderSandboxResponse) XXX_Size() int {
+	return xxx_messageInfo_RenderSandboxResponse.Size(m)
+}
+func (m *RenderSandboxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderSandboxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderSandboxResponse proto.InternalMessageInfo
+
+func (m *RenderSandboxResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *RenderSandboxResponse) GetObjects() []*RenderedObject {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
+}
+
+func (m *RenderSandboxResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+// RenderedObject is a Kubernetes object rendered from the Compose file.
+type RenderedObject struct {
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The service that the object was rendered for. It's empty for objects
+	// that are shared by the sandbox, such as network policies.
+	Service string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// The object as YAML.
+	Yaml                 string   `protobuf:"bytes,4,opt,name=yaml,proto3" json:"yaml,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenderedObject) Reset()         { *m = RenderedObject{} }
+func (m *RenderedObject) String() string { return proto.CompactTextString(m) }
+func (*RenderedObject) ProtoMessage()    {}
+func (*RenderedObject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{40}
+}
+
+func (m *RenderedObject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderedObject.Unmarshal(m, b)
+}
+func (m *RenderedObject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderedObject.Marshal(b, m, deterministic)
+}
+func (m *RenderedObject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderedObject.Merge(m, src)
+}
+func (m *RenderedObject) XXX_Size() int {
+	return xxx_messageInfo_RenderedObject.Size(m)
+}
+func (m *RenderedObject) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderedObject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderedObject proto.InternalMessageInfo
+
+func (m *RenderedObject) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *RenderedObject) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RenderedObject) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *RenderedObject) GetYaml() string {
+	if m != nil {
+		return m.Yaml
+	}
+	return ""
+}
+
+type WatchExposeLogsRequest struct {
+	Token   string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// Only requests made at or after this time are returned, in Unix seconds.
+	// If it's zero, all retained requests are returned.
+	Since int64 `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	// If true, the stream stays open and new requests are sent as they're
+	// made. Otherwise, the stream is closed after the retained requests are
+	// sent.
+	Follow               bool     `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchExposeLogsRequest) Reset()         { *m = WatchExposeLogsRequest{} }
+func (m *WatchExposeLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchExposeLogsRequest) ProtoMessage()    {}
+func (*WatchExposeLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{41}
+}
+
+func (m *WatchExposeLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchExposeLogsRequest.Unmarshal(m, b)
+}
+func (m *WatchExposeLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchExposeLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchExposeLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchExposeLogsRequest.Merge(m, src)
+}
+func (m *WatchExposeLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchExposeLogsRequest.Size(m)
+}
+func (m *WatchExposeLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchExposeLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchExposeLogsRequest proto.InternalMessageInfo
+
+func (m *WatchExposeLogsRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *WatchExposeLogsRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *WatchExposeLogsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *WatchExposeLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type ExposeLogsResponse struct {
+	Error                *errors.Error     `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Requests             []*HTTPRequestLog `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ExposeLogsResponse) Reset()         { *m = ExposeLogsResponse{} }
+func (m *ExposeLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExposeLogsResponse) ProtoMessage()    {}
+func (*ExposeLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{42}
+}
+
+func (m *ExposeLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExposeLogsResponse.Unmarshal(m, b)
+}
+func (m *ExposeLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExposeLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ExposeLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExposeLogsResponse.Merge(m, src)
+}
+func (m *ExposeLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExposeLogsResponse.Size(m)
+}
+func (m *ExposeLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExposeLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExposeLogsResponse proto.InternalMessageInfo
+
+func (m *ExposeLogsResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ExposeLogsResponse) GetRequests() []*HTTPRequestLog {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+// HTTPRequestLog is an access log entry for a request to a public URL.
+type HTTPRequestLog struct {
+	// The time the request was received, in Unix nanoseconds.
+	Timestamp int64  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Method    string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Host      string `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Path      string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// The response status code. It's zero if the connection was closed before
+	// a response was sent.
+	Status               int32    `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	LatencyMs            int64    `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	ClientIp             string   `protobuf:"bytes,7,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	UserAgent            string   `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HTTPRequestLog) Reset()         { *m = HTTPRequestLog{} }
+func (m *HTTPRequestLog) String() string { return proto.CompactTextString(m) }
+func (*HTTPRequestLog) ProtoMessage()    {}
+func (*HTTPRequestLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{43}
+}
+
+func (m *HTTPRequestLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HTTPRequestLog.Unmarshal(m, b)
+}
+func (m *HTTPRequestLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HTTPRequestLog.Marshal(b, m, deterministic)
+}
+func (m *HTTPRequestLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HTTPRequestLog.Merge(m, src)
+}
+func (m *HTTPRequestLog) XXX_Size() int {
+	return xxx_messageInfo_HTTPRequestLog.Size(m)
+}
+func (m *HTTPRequestLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_HTTPRequestLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HTTPRequestLog proto.InternalMessageInfo
+
+func (m *HTTPRequestLog) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *HTTPRequestLog) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *HTTPRequestLog) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *HTTPRequestLog) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *HTTPRequestLog) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *HTTPRequestLog) GetLatencyMs() int64 {
+	if m != nil {
+		return m.LatencyMs
+	}
+	return 0
+}
+
+func (m *HTTPRequestLog) GetClientIp() string {
+	if m != nil {
+		return m.ClientIp
+	}
+	return ""
+}
+
+func (m *HTTPRequestLog) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+type SetBootScheduleRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// A five field cron expression, such as "0 8 * * 1-5". See pkg/cron.
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// The IANA time zone that the cron expression is evaluated in, such as
+	// "America/Los_Angeles".
+	Timezone             string   `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetBootScheduleRequest) Reset()         { *m = SetBootScheduleRequest{} }
+func (m *SetBootScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBootScheduleRequest) ProtoMessage()    {}
+func (*SetBootScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{44}
+}
+
+func (m *SetBootScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBootScheduleRequest.Unmarshal(m, b)
+}
+func (m *SetBootScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBootScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *SetBootScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBootScheduleRequest.Merge(m, src)
+}
+func (m *SetBootScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_SetBootScheduleRequest.Size(m)
+}
+func (m *SetBootScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBootScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBootScheduleRequest proto.InternalMessageInfo
+
+func (m *SetBootScheduleRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *SetBootScheduleRequest) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *SetBootScheduleRequest) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+type SetBootScheduleResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The schedule after the update. It's nil if the schedule was cleared.
+	Schedule             *BootSchedule `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SetBootScheduleResponse) Reset()         { *m = SetBootScheduleResponse{} }
+func (m *SetBootScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBootScheduleResponse) ProtoMessage()    {}
+func (*SetBootScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{45}
+}
+
+func (m *SetBootScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBootScheduleResponse.Unmarshal(m, b)
+}
+func (m *SetBootScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBootScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *SetBootScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBootScheduleResponse.Merge(m, src)
+}
+func (m *SetBootScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_SetBootScheduleResponse.Size(m)
+}
+func (m *SetBootScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBootScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBootScheduleResponse proto.InternalMessageInfo
+
+func (m *SetBootScheduleResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *SetBootScheduleResponse) GetSchedule() *BootSchedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type GetBootScheduleRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBootScheduleRequest) Reset()         { *m = GetBootScheduleRequest{} }
+func (m *GetBootScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBootScheduleRequest) ProtoMessage()    {}
+func (*GetBootScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{46}
+}
+
+func (m *GetBootScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBootScheduleRequest.Unmarshal(m, b)
+}
+func (m *GetBootScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBootScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBootScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBootScheduleRequest.Merge(m, src)
+}
+func (m *GetBootScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBootScheduleRequest.Size(m)
+}
+func (m *GetBootScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBootScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBootScheduleRequest proto.InternalMessageInfo
+
+func (m *GetBootScheduleRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type GetBootScheduleResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The sandbox's schedule, or nil if it doesn't have one.
+	Schedule             *BootSchedule `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetBootScheduleResponse) Reset()         { *m = GetBootScheduleResponse{} }
+func (m *GetBootScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBootScheduleResponse) ProtoMessage()    {}
+func (*GetBootScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{47}
+}
+
+func (m *GetBootScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBootScheduleResponse.Unmarshal(m, b)
+}
+func (m *GetBootScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBootScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBootScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBootScheduleResponse.Merge(m, src)
+}
+func (m *GetBootScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBootScheduleResponse.Size(m)
+}
+func (m *GetBootScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBootScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBootScheduleResponse proto.InternalMessageInfo
+
+func (m *GetBootScheduleResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *GetBootScheduleResponse) GetSchedule() *BootSchedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type BootSchedule struct {
+	Cron     string `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	Timezone string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// The next time the sandbox will be booted, in seconds since the Unix
+	// epoch.
+	NextBoot int64 `protobuf:"varint,3,opt,name=next_boot,json=nextBoot,proto3" json:"next_boot,omitempty"`
+	// The last time the sandbox was booted by the schedule, in seconds since
+	// the Unix epoch. It's zero if it hasn't been booted by the schedule yet.
+	LastBoot int64 `protobuf:"varint,4,opt,name=last_boot,json=lastBoot,proto3" json:"last_boot,omitempty"`
+	// The error from the last scheduled boot, if it failed.
+	LastError            string   `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BootSchedule) Reset()         { *m = BootSchedule{} }
+func (m *BootSchedule) String() string { return proto.CompactTextString(m) }
+func (*BootSchedule) ProtoMessage()    {}
+func (*BootSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{48}
+}
+
+func (m *BootSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BootSchedule.Unmarshal(m, b)
+}
+func (m *BootSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BootSchedule.Marshal(b, m, deterministic)
+}
+func (m *BootSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BootSchedule.Merge(m, src)
+}
+func (m *BootSchedule) XXX_Size() int {
+	return xxx_messageInfo_BootSchedule.Size(m)
+}
+func (m *BootSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_BootSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BootSchedule proto.InternalMessageInfo
+
+func (m *BootSchedule) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *BootSchedule) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *BootSchedule) GetNextBoot() int64 {
+	if m != nil {
+		return m.NextBoot
+	}
+	return 0
+}
+
+func (m *BootSchedule) GetLastBoot() int64 {
+	if m != nil {
+		return m.LastBoot
+	}
+	return 0
+}
+
+func (m *BootSchedule) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+// DNSConfig is merged into the dnsConfig of each pod in the sandbox.
+type DNSConfig struct {
+	// If has_ndots is false, the cluster's default ndots is used.
+	HasNdots bool     `protobuf:"varint,1,opt,name=has_ndots,json=hasNdots,proto3" json:"has_ndots,omitempty"`
+	Ndots    int32    `protobuf:"varint,2,opt,name=ndots,proto3" json:"ndots,omitempty"`
+	Searches []string `protobuf:"bytes,3,rep,name=searches,proto3" json:"searches,omitempty"`
+	// Resolver options, in the format "name" or "name:value".
+	Options              []string `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DNSConfig) Reset()         { *m = DNSConfig{} }
+func (m *DNSConfig) String() string { return proto.CompactTextString(m) }
+func (*DNSConfig) ProtoMessage()    {}
+func (*DNSConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{49}
+}
+
+func (m *DNSConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DNSConfig.Unmarshal(m, b)
+}
+func (m *DNSConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DNSConfig.Marshal(b, m, deterministic)
+}
+func (m *DNSConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DNSConfig.Merge(m, src)
+}
+func (m *DNSConfig) XXX_Size() int {
+	return xxx_messageInfo_DNSConfig.Size(m)
+}
+func (m *DNSConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_DNSConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DNSConfig proto.InternalMessageInfo
+
+func (m *DNSConfig) GetHasNdots() bool {
+	if m != nil {
+		return m.HasNdots
+	}
+	return false
+}
+
+func (m *DNSConfig) GetNdots() int32 {
+	if m != nil {
+		return m.Ndots
+	}
+	return 0
+}
+
+func (m *DNSConfig) GetSearches() []string {
+	if m != nil {
+		return m.Searches
+	}
+	return nil
+}
+
+func (m *DNSConfig) GetOptions() []string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type ListSandboxResourcesRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSandboxResourcesRequest) Reset()         { *m = ListSandboxResourcesRequest{} }
+func (m *ListSandboxResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSandboxResourcesRequest) ProtoMessage()    {}
+func (*ListSandboxResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{50}
+}
+
+func (m *ListSandboxResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSandboxResourcesRequest.Unmarshal(m, b)
+}
+func (m *ListSandboxResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSandboxResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSandboxResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSandboxResourcesRequest.Merge(m, src)
+}
+func (m *ListSandboxResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSandboxResourcesRequest.Size(m)
+}
+func (m *ListSandboxResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSandboxResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSandboxResourcesRequest proto.InternalMessageInfo
+
+func (m *ListSandboxResourcesRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type ListSandboxResourcesResponse struct {
+	Error                *errors.Error      `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Resources            []*SandboxResource `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListSandboxResourcesResponse) Reset()         { *m = ListSandboxResourcesResponse{} }
+func (m *ListSandboxResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSandboxResourcesResponse) ProtoMessage()    {}
+func (*ListSandboxResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{51}
+}
+
+func (m *ListSandboxResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSandboxResourcesResponse.Unmarshal(m, b)
+}
+func (m *ListSandboxResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSandboxResourcesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSandboxResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSandboxResourcesResponse.Merge(m, src)
+}
+func (m *ListSandboxResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSandboxResourcesResponse.Size(m)
+}
+func (m *ListSandboxResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSandboxResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSandboxResourcesResponse proto.InternalMessageInfo
+
+func (m *ListSandboxResourcesResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ListSandboxResourcesResponse) GetResources() []*SandboxResource {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type SandboxResource struct {
+	Type SandboxResource_Type `protobuf:"varint,1,opt,name=type,proto3,enum=blimp.cluster.v0.SandboxResource_Type" json:"type,omitempty"`
+	Name string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The service that the resource belongs to. It's empty for resources that
+	// are shared by the sandbox, such as volumes used by multiple services.
+	Service string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// If true, the resource isn't deleted with the sandbox, and is reused by
+	// the next `blimp up`.
+	Retained bool `protobuf:"varint,4,opt,name=retained,proto3" json:"retained,omitempty"`
+	// The storage used by the resource, if known.
+	SizeBytes            int64    `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SandboxResource) Reset()         { *m = SandboxResource{} }
+func (m *SandboxResource) String() string { return proto.CompactTextString(m) }
+func (*SandboxResource) ProtoMessage()    {}
+func (*SandboxResource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{52}
+}
+
+func (m *SandboxResource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SandboxResource.Unmarshal(m, b)
+}
+func (m *SandboxResource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SandboxResource.Marshal(b, m, deterministic)
+}
+func (m *SandboxResource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SandboxResource.Merge(m, src)
+}
+func (m *SandboxResource) XXX_Size() int {
+	return xxx_messageInfo_SandboxResource.Size(m)
+}
+func (m *SandboxResource) XXX_DiscardUnknown() {
+	xxx_messageInfo_SandboxResource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SandboxResource proto.InternalMessageInfo
+
+func (m *SandboxResource) GetType() SandboxResource_Type {
+	if m != nil {
+		return m.Type
+	}
+	return SandboxResource_UNKNOWN
+}
+
+func (m *SandboxResource) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SandboxResource) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *SandboxResource) GetRetained() bool {
+	if m != nil {
+		return m.Retained
+	}
+	return false
+}
+
+func (m *SandboxResource) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+type RestartServiceRequest struct {
+	Token   string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// Environment variables to set in the restarted service, in addition to
+	// the ones from the Compose file. They replace any overrides from previous
+	// restarts, so restarting without overrides clears them.
+	EnvOverrides         map[string]string `protobuf:"bytes,3,rep,name=env_overrides,json=envOverrides,proto3" json:"env_overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RestartServiceRequest) Reset()         { *m = RestartServiceRequest{} }
+func (m *RestartServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*RestartServiceRequest) ProtoMessage()    {}
+func (*RestartServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{53}
+}
+
+func (m *RestartServiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestartServiceRequest.Unmarshal(m, b)
+}
+func (m *RestartServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestartServiceRequest.Marshal(b, m, deterministic)
+}
+func (m *RestartServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartServiceRequest.Merge(m, src)
+}
+func (m *RestartServiceRequest) XXX_Size() int {
+	return xxx_messageInfo_RestartServiceRequest.Size(m)
+}
+func (m *RestartServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestartServiceRequest proto.InternalMessageInfo
+
+func (m *RestartServiceRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *RestartServiceRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *RestartServiceRequest) GetEnvOverrides() map[string]string {
+	if m != nil {
+		return m.EnvOverrides
+	}
+	return nil
+}
+
+type RestartServiceResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RestartServiceResponse) Reset()         { *m = RestartServiceResponse{} }
+func (m *RestartServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*RestartServiceResponse) ProtoMessage()    {}
+func (*RestartServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{54}
+}
+
+func (m *RestartServiceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestartServiceResponse.Unmarshal(m, b)
+}
+func (m *RestartServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestartServiceResponse.Marshal(b, m, deterministic)
+}
+func (m *RestartServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestartServiceResponse.Merge(m, src)
+}
+func (m *RestartServiceResponse) XXX_Size() int {
+	return xxx_messageInfo_RestartServiceResponse.Size(m)
+}
+func (m *RestartServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestartServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestartServiceResponse proto.InternalMessageInfo
+
+func (m *RestartServiceResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type StreamLogsRequest struct {
+	Token    string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Services []string `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	// If true, the stream stays open and new lines are sent as they're logged.
+	Follow bool `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	// Only lines logged within this many seconds are returned. If it's zero,
+	// lines of any age are returned.
+	SinceSeconds int64 `protobuf:"varint,4,opt,name=since_seconds,json=sinceSeconds,proto3" json:"since_seconds,omitempty"`
+	// The number of lines to return from the end of each service's logs. If
+	// it's zero, all the lines are returned.
+	TailLines int64 `protobuf:"varint,5,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`
+	// If true, the logs of the services' previous containers are returned,
+	// such as after a crash.
+	Previous             bool     `protobuf:"varint,6,opt,name=previous,proto3" json:"previous,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamLogsRequest) Reset()         { *m = StreamLogsRequest{} }
+func (m *StreamLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamLogsRequest) ProtoMessage()    {}
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{55}
+}
+
+func (m *StreamLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamLogsRequest.Unmarshal(m, b)
+}
+func (m *StreamLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamLogsRequest.Merge(m, src)
+}
+func (m *StreamLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamLogsRequest.Size(m)
+}
+func (m *StreamLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamLogsRequest proto.InternalMessageInfo
+
+func (m *StreamLogsRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *StreamLogsRequest) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *StreamLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+func (m *StreamLogsRequest) GetSinceSeconds() int64 {
+	if m != nil {
+		return m.SinceSeconds
+	}
+	return 0
+}
+
+func (m *StreamLogsRequest) GetTailLines() int64 {
+	if m != nil {
+		return m.TailLines
+	}
+	return 0
+}
+
+func (m *StreamLogsRequest) GetPrevious() bool {
+	if m != nil {
+		return m.Previous
+	}
+	return false
+}
+
+type StreamLogsResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The lines logged since the previous response. Each service's lines are
+	// in order, but lines from different services may be interleaved.
+	Lines []*LogLine `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	// The services whose logs ended since the previous response, after all
+	// their lines were sent.
+	EndedServices        []string `protobuf:"bytes,3,rep,name=ended_services,json=endedServices,proto3" json:"ended_services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamLogsResponse) Reset()         { *m = StreamLogsResponse{} }
+func (m *StreamLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamLogsResponse) ProtoMessage()    {}
+func (*StreamLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{56}
+}
+
+func (m *StreamLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamLogsResponse.Unmarshal(m, b)
+}
+func (m *StreamLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamLogsResponse.Merge(m, src)
+}
+func (m *StreamLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamLogsResponse.Size(m)
+}
+func (m *StreamLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamLogsResponse proto.InternalMessageInfo
+
+func (m *StreamLogsResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *StreamLogsResponse) GetLines() []*LogLine {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *StreamLogsResponse) GetEndedServices() []string {
+	if m != nil {
+		return m.EndedServices
+	}
+	return nil
+}
+
+type LogLine struct {
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// The time the line was logged, in Unix nanoseconds.
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// The line, without a trailing newline.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// The stream that the line was logged to, either "stdout" or "stderr".
+	Stream               string   `protobuf:"bytes,4,opt,name=stream,proto3" json:"stream,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogLine) Reset()         { *m = LogLine{} }
+func (m *LogLine) String() string { return proto.CompactTextString(m) }
+func (*LogLine) ProtoMessage()    {}
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{57}
+}
+
+func (m *LogLine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogLine.Unmarshal(m, b)
+}
+func (m *LogLine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogLine.Marshal(b, m, deterministic)
+}
+func (m *LogLine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogLine.Merge(m, src)
+}
+func (m *LogLine) XXX_Size() int {
+	return xxx_messageInfo_LogLine.Size(m)
+}
+func (m *LogLine) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogLine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogLine proto.InternalMessageInfo
+
+func (m *LogLine) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *LogLine) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *LogLine) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *LogLine) GetStream() string {
+	if m != nil {
+		return m.Stream
+	}
+	return ""
+}
+
+type CloneSandboxRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The sandbox to clone, in the form OWNER/SANDBOX.
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// If true, the clone's volumes start empty rather than being restored
+	// from snapshots of the source's volumes.
+	SkipVolumes          bool     `protobuf:"varint,3,opt,name=skip_volumes,json=skipVolumes,proto3" json:"skip_volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloneSandboxRequest) Reset()         { *m = CloneSandboxRequest{} }
+func (m *CloneSandboxRequest) String() string { return proto.CompactTextString(m) }
+func (*CloneSandboxRequest) ProtoMessage()    {}
+func (*CloneSandboxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{58}
+}
+
+func (m *CloneSandboxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloneSandboxRequest.Unmarshal(m, b)
+}
+func (m *CloneSandboxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloneSandboxRequest.Marshal(b, m, deterministic)
+}
+func (m *CloneSandboxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloneSandboxRequest.Merge(m, src)
+}
+func (m *CloneSandboxRequest) XXX_Size() int {
+	return xxx_messageInfo_CloneSandboxRequest.Size(m)
+}
+func (m *CloneSandboxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloneSandboxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloneSandboxRequest proto.InternalMessageInfo
+
+func (m *CloneSandboxRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *CloneSandboxRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CloneSandboxRequest) GetSkipVolumes() bool {
+	if m != nil {
+		return m.SkipVolumes
+	}
+	return false
+}
+
+type CloneSandboxResponse struct {
+	Error           *errors.Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	KubeCredentials *KubeCredentials `protobuf:"bytes,2,opt,name=kubeCredentials,proto3" json:"kubeCredentials,omitempty"`
+	// The services in the clone, and the images that they run.
+	Images map[string]string `protobuf:"bytes,3,rep,name=images,proto3" json:"images,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The volumes that were restored from snapshots.
+	RestoredVolumes      []string `protobuf:"bytes,4,rep,name=restored_volumes,json=restoredVolumes,proto3" json:"restored_volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloneSandboxResponse) Reset()         { *m = CloneSandboxResponse{} }
+func (m *CloneSandboxResponse) String() string { return proto.CompactTextString(m) }
+func (*CloneSandboxResponse) ProtoMessage()    {}
+func (*CloneSandboxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{59}
+}
+
+func (m *CloneSandboxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloneSandboxResponse.Unmarshal(m, b)
+}
+func (m *CloneSandboxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloneSandboxResponse.Marshal(b, m, deterministic)
+}
+func (m *CloneSandboxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloneSandboxResponse.Merge(m, src)
+}
+func (m *CloneSandboxResponse) XXX_Size() int {
+	return xxx_messageInfo_CloneSandboxResponse.Size(m)
+}
+func (m *CloneSandboxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloneSandboxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloneSandboxResponse proto.InternalMessageInfo
+
+func (m *CloneSandboxResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *CloneSandboxResponse) GetKubeCredentials() *KubeCredentials {
+	if m != nil {
+		return m.KubeCredentials
+	}
+	return nil
+}
+
+func (m *CloneSandboxResponse) GetImages() map[string]string {
+	if m != nil {
+		return m.Images
+	}
+	return nil
+}
+
+func (m *CloneSandboxResponse) GetRestoredVolumes() []string {
+	if m != nil {
+		return m.RestoredVolumes
+	}
+	return nil
+}
+
+// SecurityProfiles are the security profiles that a service's containers run
+// with. Empty fields use the cluster's defaults.
+type SecurityProfiles struct {
+	// Either "runtime/default", "unconfined", or "localhost" if the profile is
+	// given by seccomp_profile.
+	Seccomp string `protobuf:"bytes,1,opt,name=seccomp,proto3" json:"seccomp,omitempty"`
+	// The contents of the JSON seccomp profile, if seccomp is "localhost".
+	SeccompProfile string `protobuf:"bytes,2,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`
+	// Either "runtime/default", "unconfined", or "localhost/NAME".
+	Apparmor             string   `protobuf:"bytes,3,opt,name=apparmor,proto3" json:"apparmor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SecurityProfiles) Reset()         { *m = SecurityProfiles{} }
+func (m *SecurityProfiles) String() string { return proto.CompactTextString(m) }
+func (*SecurityProfiles) ProtoMessage()    {}
+func (*SecurityProfiles) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{60}
+}
+
+func (m *SecurityProfiles) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecurityProfiles.Unmarshal(m, b)
+}
+func (m *SecurityProfiles) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecurityProfiles.Marshal(b, m, deterministic)
+}
+func (m *SecurityProfiles) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecurityProfiles.Merge(m, src)
+}
+func (m *SecurityProfiles) XXX_Size() int {
+	return xxx_messageInfo_SecurityProfiles.Size(m)
+}
+func (m *SecurityProfiles) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecurityProfiles.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecurityProfiles proto.InternalMessageInfo
+
+func (m *SecurityProfiles) GetSeccomp() string {
+	if m != nil {
+		return m.Seccomp
+	}
+	return ""
+}
+
+func (m *SecurityProfiles) GetSeccompProfile() string {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return ""
+}
+
+func (m *SecurityProfiles) GetApparmor() string {
+	if m != nil {
+		return m.Apparmor
+	}
+	return ""
+}
+
+type SetServiceEnvRequest struct {
+	Token   string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// The variables to set. They're merged into the service's existing
+	// temporary environment variables.
+	Env map[string]string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If true, the service isn't restarted. Instead, the dotenv file mounted
+	// at names.HotEnvFile is updated, and reload_signal is sent to the
+	// service's main process, for applications that reload their config.
+	Hot bool `protobuf:"varint,4,opt,name=hot,proto3" json:"hot,omitempty"`
+	// The signal to send when hot is true, such as "SIGHUP".
+	ReloadSignal         string   `protobuf:"bytes,5,opt,name=reload_signal,json=reloadSignal,proto3" json:"reload_signal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetServiceEnvRequest) Reset()         { *m = SetServiceEnvRequest{} }
+func (m *SetServiceEnvRequest) String() string { return proto.CompactTextString(m) }
+func (*SetServiceEnvRequest) ProtoMessage()    {}
+func (*SetServiceEnvRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{61}
+}
+
+func (m *SetServiceEnvRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetServiceEnvRequest.Unmarshal(m, b)
+}
+func (m *SetServiceEnvRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetServiceEnvRequest.Marshal(b, m, deterministic)
+}
+func (m *SetServiceEnvRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetServiceEnvRequest.Merge(m, src)
+}
+func (m *SetServiceEnvRequest) XXX_Size() int {
+	return xxx_messageInfo_SetServiceEnvRequest.Size(m)
+}
+func (m *SetServiceEnvRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetServiceEnvRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetServiceEnvRequest proto.InternalMessageInfo
+
+func (m *SetServiceEnvRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *SetServiceEnvRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *SetServiceEnvRequest) GetEnv() map[string]string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *SetServiceEnvRequest) GetHot() bool {
+	if m != nil {
+		return m.Hot
+	}
+	return false
+}
+
+func (m *SetServiceEnvRequest) GetReloadSignal() string {
+	if m != nil {
+		return m.ReloadSignal
+	}
+	return ""
+}
+
+type SetServiceEnvResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SetServiceEnvResponse) Reset()         { *m = SetServiceEnvResponse{} }
+func (m *SetServiceEnvResponse) String() string { return proto.CompactTextString(m) }
+func (*SetServiceEnvResponse) ProtoMessage()    {}
+func (*SetServiceEnvResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{62}
+}
+
+func (m *SetServiceEnvResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetServiceEnvResponse.Unmarshal(m, b)
+}
+func (m *SetServiceEnvResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetServiceEnvResponse.Marshal(b, m, deterministic)
+}
+func (m *SetServiceEnvResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetServiceEnvResponse.Merge(m, src)
+}
+func (m *SetServiceEnvResponse) XXX_Size() int {
+	return xxx_messageInfo_SetServiceEnvResponse.Size(m)
+}
+func (m *SetServiceEnvResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetServiceEnvResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetServiceEnvResponse proto.InternalMessageInfo
+
+func (m *SetServiceEnvResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type CheckDataLossRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The normalized Compose file, in the same format as
+	// DeployRequest.composeFile.
+	ComposeFile          string   `protobuf:"bytes,2,opt,name=compose_file,json=composeFile,proto3" json:"compose_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckDataLossRequest) Reset()         { *m = CheckDataLossRequest{} }
+func (m *CheckDataLossRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckDataLossRequest) ProtoMessage()    {}
+func (*CheckDataLossRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{63}
+}
+
+func (m *CheckDataLossRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckDataLossRequest.Unmarshal(m, b)
+}
+func (m *CheckDataLossRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckDataLossRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckDataLossRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckDataLossRequest.Merge(m, src)
+}
+func (m *CheckDataLossRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckDataLossRequest.Size(m)
+}
+func (m *CheckDataLossRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckDataLossRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckDataLossRequest proto.InternalMessageInfo
+
+func (m *CheckDataLossRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *CheckDataLossRequest) GetComposeFile() string {
+	if m != nil {
+		return m.ComposeFile
+	}
+	return ""
+}
+
+type CheckDataLossResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// The data that would be destroyed. It's empty if the deploy is safe, or
+	// if the sandbox doesn't exist yet.
+	AtRisk               []*DataAtRisk `protobuf:"bytes,2,rep,name=at_risk,json=atRisk,proto3" json:"at_risk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CheckDataLossResponse) Reset()         { *m = CheckDataLossResponse{} }
+func (m *CheckDataLossResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckDataLossResponse) ProtoMessage()    {}
+func (*CheckDataLossResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{64}
+}
+
+func (m *CheckDataLossResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckDataLossResponse.Unmarshal(m, b)
+}
+func (m *CheckDataLossResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckDataLossResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckDataLossResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckDataLossResponse.Merge(m, src)
+}
+func (m *CheckDataLossResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckDataLossResponse.Size(m)
+}
+func (m *CheckDataLossResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckDataLossResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckDataLossResponse proto.InternalMessageInfo
+
+func (m *CheckDataLossResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *CheckDataLossResponse) GetAtRisk() []*DataAtRisk {
+	if m != nil {
+		return m.AtRisk
+	}
+	return nil
+}
+
+// DataAtRisk is a volume whose contents would be lost by a deploy.
+type DataAtRisk struct {
+	Volume string `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	// The services that mount the volume.
+	Services []string          `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	Reason   DataAtRisk_Reason `protobuf:"varint,3,opt,name=reason,proto3,enum=blimp.cluster.v0.DataAtRisk_Reason" json:"reason,omitempty"`
+	// The storage used by the volume, if known.
+	SizeBytes            int64    `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataAtRisk) Reset()         { *m = DataAtRisk{} }
+func (m *DataAtRisk) String() string { return proto.CompactTextString(m) }
+func (*DataAtRisk) ProtoMessage()    {}
+func (*DataAtRisk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{65}
+}
+
+func (m *DataAtRisk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataAtRisk.Unmarshal(m, b)
+}
+func (m *DataAtRisk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataAtRisk.Marshal(b, m, deterministic)
+}
+func (m *DataAtRisk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataAtRisk.Merge(m, src)
+}
+func (m *DataAtRisk) XXX_Size() int {
+	return xxx_messageInfo_DataAtRisk.Size(m)
+}
+func (m *DataAtRisk) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataAtRisk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataAtRisk proto.InternalMessageInfo
+
+func (m *DataAtRisk) GetVolume() string {
+	if m != nil {
+		return m.Volume
+	}
+	return ""
+}
+
+func (m *DataAtRisk) GetServices() []string {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+func (m *DataAtRisk) GetReason() DataAtRisk_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return DataAtRisk_UNKNOWN
+}
+
+func (m *DataAtRisk) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("blimp.cluster.v0.CLIAction", CLIAction_name, CLIAction_value)
+	proto.RegisterEnum("blimp.cluster.v0.ServicePhase", ServicePhase_name, ServicePhase_value)
+	proto.RegisterEnum("blimp.cluster.v0.SandboxStatus_SandboxPhase", SandboxStatus_SandboxPhase_name, SandboxStatus_SandboxPhase_value)
+	proto.RegisterEnum("blimp.cluster.v0.ServiceEvent_Type", ServiceEvent_Type_name, ServiceEvent_Type_value)
+	proto.RegisterEnum("blimp.cluster.v0.SessionEvent_Type", SessionEvent_Type_name, SessionEvent_Type_value)
+	proto.RegisterEnum("blimp.cluster.v0.PrunedResource_Type", PrunedResource_Type_name, PrunedResource_Type_value)
+	proto.RegisterEnum("blimp.cluster.v0.SandboxResource_Type", SandboxResource_Type_name, SandboxResource_Type_value)
+	proto.RegisterEnum("blimp.cluster.v0.DataAtRisk_Reason", DataAtRisk_Reason_name, DataAtRisk_Reason_value)
+	proto.RegisterType((*ProxyAnalyticsRequest)(nil), "blimp.cluster.v0.ProxyAnalyticsRequest")
+	proto.RegisterType((*ProxyAnalyticsResponse)(nil), "blimp.cluster.v0.ProxyAnalyticsResponse")
+	proto.RegisterType((*CheckVersionRequest)(nil), "blimp.cluster.v0.CheckVersionRequest")
+	proto.RegisterType((*CheckVersionResponse)(nil), "blimp.cluster.v0.CheckVersionResponse")
+	proto.RegisterType((*CreateSandboxRequest)(nil), "blimp.cluster.v0.CreateSandboxRequest")
+	proto.RegisterMapType((map[string]*RegistryCredential)(nil), "blimp.cluster.v0.CreateSandboxRequest.RegistryCredentialsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.CreateSandboxRequest.SyncedFoldersEntry")
+	proto.RegisterMapType((map[string]*SecurityProfiles)(nil), "blimp.cluster.v0.CreateSandboxRequest.SecurityProfilesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.CreateSandboxRequest.CronSchedulesEntry")
+	proto.RegisterType((*RegistryCredential)(nil), "blimp.cluster.v0.RegistryCredential")
+	proto.RegisterType((*CreateSandboxResponse)(nil), "blimp.cluster.v0.CreateSandboxResponse")
+	proto.RegisterType((*DeployRequest)(nil), "blimp.cluster.v0.DeployRequest")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.DeployRequest.BuiltImagesEntry")
+	proto.RegisterType((*DeployResponse)(nil), "blimp.cluster.v0.DeployResponse")
+	proto.RegisterType((*KubeCredentials)(nil), "blimp.cluster.v0.KubeCredentials")
+	proto.RegisterType((*DeleteSandboxRequest)(nil), "blimp.cluster.v0.DeleteSandboxRequest")
+	proto.RegisterType((*DeleteSandboxResponse)(nil), "blimp.cluster.v0.DeleteSandboxResponse")
+	proto.RegisterType((*GetStatusRequest)(nil), "blimp.cluster.v0.GetStatusRequest")
+	proto.RegisterType((*GetStatusResponse)(nil), "blimp.cluster.v0.GetStatusResponse")
+	proto.RegisterType((*SandboxStatus)(nil), "blimp.cluster.v0.SandboxStatus")
+	proto.RegisterMapType((map[string]*ServiceStatus)(nil), "blimp.cluster.v0.SandboxStatus.ServicesEntry")
+	proto.RegisterType((*ServiceStatus)(nil), "blimp.cluster.v0.ServiceStatus")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.ServiceStatus.EnvOverridesEntry")
+	proto.RegisterType((*Readiness)(nil), "blimp.cluster.v0.Readiness")
+	proto.RegisterType((*ResourceUsage)(nil), "blimp.cluster.v0.ResourceUsage")
+	proto.RegisterType((*GetServiceHistoryRequest)(nil), "blimp.cluster.v0.GetServiceHistoryRequest")
+	proto.RegisterType((*GetServiceHistoryResponse)(nil), "blimp.cluster.v0.GetServiceHistoryResponse")
+	proto.RegisterType((*ServiceEvent)(nil), "blimp.cluster.v0.ServiceEvent")
+	proto.RegisterType((*GetUsageRequest)(nil), "blimp.cluster.v0.GetUsageRequest")
+	proto.RegisterType((*GetUsageResponse)(nil), "blimp.cluster.v0.GetUsageResponse")
+	proto.RegisterType((*UsageRecord)(nil), "blimp.cluster.v0.UsageRecord")
+	proto.RegisterType((*RecordSessionRequest)(nil), "blimp.cluster.v0.RecordSessionRequest")
+	proto.RegisterType((*RecordSessionResponse)(nil), "blimp.cluster.v0.RecordSessionResponse")
+	proto.RegisterType((*ListSessionsRequest)(nil), "blimp.cluster.v0.ListSessionsRequest")
+	proto.RegisterType((*ListSessionsResponse)(nil), "blimp.cluster.v0.ListSessionsResponse")
+	proto.RegisterType((*GetSessionRecordingRequest)(nil), "blimp.cluster.v0.GetSessionRecordingRequest")
+	proto.RegisterType((*GetSessionRecordingResponse)(nil), "blimp.cluster.v0.GetSessionRecordingResponse")
+	proto.RegisterType((*SessionInfo)(nil), "blimp.cluster.v0.SessionInfo")
+	proto.RegisterType((*SessionEvent)(nil), "blimp.cluster.v0.SessionEvent")
+	proto.RegisterType((*PruneRequest)(nil), "blimp.cluster.v0.PruneRequest")
+	proto.RegisterType((*PruneResponse)(nil), "blimp.cluster.v0.PruneResponse")
+	proto.RegisterType((*PrunedResource)(nil), "blimp.cluster.v0.PrunedResource")
+	proto.RegisterType((*PolicyViolation)(nil), "blimp.cluster.v0.PolicyViolation")
+	proto.RegisterType((*WaitForCapacityRequest)(nil), "blimp.cluster.v0.WaitForCapacityRequest")
+	proto.RegisterType((*QueueStatus)(nil), "blimp.cluster.v0.QueueStatus")
+	proto.RegisterType((*RenderSandboxRequest)(nil), "blimp.cluster.v0.RenderSandboxRequest")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.RenderSandboxRequest.BuiltImagesEntry")
+	proto.RegisterType((*RenderSandboxResponse)(nil), "blimp.cluster.v0.RenderSandboxResponse")
+	proto.RegisterType((*RenderedObject)(nil), "blimp.cluster.v0.RenderedObject")
+	proto.RegisterType((*WatchExposeLogsRequest)(nil), "blimp.cluster.v0.WatchExposeLogsRequest")
+	proto.RegisterType((*ExposeLogsResponse)(nil), "blimp.cluster.v0.ExposeLogsResponse")
+	proto.RegisterType((*HTTPRequestLog)(nil), "blimp.cluster.v0.HTTPRequestLog")
+	proto.RegisterType((*SetBootScheduleRequest)(nil), "blimp.cluster.v0.SetBootScheduleRequest")
+	proto.RegisterType((*SetBootScheduleResponse)(nil), "blimp.cluster.v0.SetBootScheduleResponse")
+	proto.RegisterType((*GetBootScheduleRequest)(nil), "blimp.cluster.v0.GetBootScheduleRequest")
+	proto.RegisterType((*GetBootScheduleResponse)(nil), "blimp.cluster.v0.GetBootScheduleResponse")
+	proto.RegisterType((*BootSchedule)(nil), "blimp.cluster.v0.BootSchedule")
+	proto.RegisterType((*DNSConfig)(nil), "blimp.cluster.v0.DNSConfig")
+	proto.RegisterType((*ListSandboxResourcesRequest)(nil), "blimp.cluster.v0.ListSandboxResourcesRequest")
+	proto.RegisterType((*ListSandboxResourcesResponse)(nil), "blimp.cluster.v0.ListSandboxResourcesResponse")
+	proto.RegisterType((*SandboxResource)(nil), "blimp.cluster.v0.SandboxResource")
+	proto.RegisterType((*RestartServiceRequest)(nil), "blimp.cluster.v0.RestartServiceRequest")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.RestartServiceRequest.EnvOverridesEntry")
+	proto.RegisterType((*RestartServiceResponse)(nil), "blimp.cluster.v0.RestartServiceResponse")
+	proto.RegisterType((*StreamLogsRequest)(nil), "blimp.cluster.v0.StreamLogsRequest")
+	proto.RegisterType((*StreamLogsResponse)(nil), "blimp.cluster.v0.StreamLogsResponse")
+	proto.RegisterType((*LogLine)(nil), "blimp.cluster.v0.LogLine")
+	proto.RegisterType((*CloneSandboxRequest)(nil), "blimp.cluster.v0.CloneSandboxRequest")
+	proto.RegisterType((*CloneSandboxResponse)(nil), "blimp.cluster.v0.CloneSandboxResponse")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.CloneSandboxResponse.ImagesEntry")
+	proto.RegisterType((*SecurityProfiles)(nil), "blimp.cluster.v0.SecurityProfiles")
+	proto.RegisterType((*SetServiceEnvRequest)(nil), "blimp.cluster.v0.SetServiceEnvRequest")
+	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.SetServiceEnvRequest.EnvEntry")
+	proto.RegisterType((*SetServiceEnvResponse)(nil), "blimp.cluster.v0.SetServiceEnvResponse")
+	proto.RegisterType((*CheckDataLossRequest)(nil), "blimp.cluster.v0.CheckDataLossRequest")
+	proto.RegisterType((*CheckDataLossResponse)(nil), "blimp.cluster.v0.CheckDataLossResponse")
+	proto.RegisterType((*DataAtRisk)(nil), "blimp.cluster.v0.DataAtRisk")
+}
+
+func init() {
+	proto.RegisterFile("blimp/cluster/v0/manager.proto", fileDescriptor_d156d5389f4d1cd6)
+}
+
+var fileDescriptor_d156d5389f4d1cd6 = []byte{
+	// 3816 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3b, 0x4d, 0x6f, 0xe3, 0x48,
+	0x76, 0x43, 0x7d, 0x59, 0x7a, 0xfe, 0x52, 0x57, 0xbb, 0x3d, 0x1e, 0xce, 0xf4, 0x4c, 0x37, 0x67,
+	0xb7, 0xed, 0x9d, 0x0f, 0xbb, 0xd3, 0x93, 0xd9, 0xd9, 0xee, 0x6c, 0x66, 0xd6, 0x2d, 0xb3, 0xdd,
+	0x9a, 0xb6, 0x65, 0x2f, 0x25, 0xf7, 0x7c, 0x24, 0x18, 0x86, 0x26, 0xcb, 0x12, 0xd7, 0x14, 0xa9,
+	0x61, 0x95, 0xdc, 0xad, 0x41, 0x82, 0x7c, 0x21, 0x87, 0x1c, 0x12, 0xe4, 0x10, 0x20, 0x40, 0x82,
+	0x5c, 0xf6, 0x67, 0x04, 0xc9, 0x29, 0x7f, 0x22, 0x40, 0x82, 0x00, 0x09, 0x72, 0x08, 0x90, 0x53,
+	0x6e, 0x39, 0x06, 0xf5, 0x41, 0x8a, 0xa4, 0x28, 0x4b, 0xad, 0x49, 0xb0, 0x27, 0xf1, 0xbd, 0x7a,
+	0xf5, 0x5e, 0xd5, 0xab, 0x7a, 0x1f, 0xf5, 0xaa, 0x04, 0x6f, 0x9f, 0x7b, 0x6e, 0x7f, 0xb0, 0x67,
+	0x7b, 0x43, 0x42, 0x71, 0xb8, 0x77, 0x75, 0x7f, 0xaf, 0x6f, 0xf9, 0x56, 0x17, 0x87, 0xbb, 0x83,
+	0x30, 0xa0, 0x01, 0xaa, 0xf3, 0xf6, 0x5d, 0xd9, 0xbe, 0x7b, 0x75, 0x5f, 0x7d, 0x4b, 0xf4, 0xc0,
+	0x61, 0x18, 0x84, 0x84, 0x75, 0x10, 0x5f, 0x82, 0x5e, 0x7b, 0x1f, 0x6e, 0x9d, 0x86, 0xc1, 0xcb,
+	0xd1, 0xbe, 0x6f, 0x79, 0x23, 0xea, 0xda, 0xc4, 0xc0, 0xdf, 0x0e, 0x31, 0xa1, 0x08, 0x41, 0xe9,
+	0x3c, 0x70, 0x46, 0x5b, 0xca, 0x1d, 0x65, 0xa7, 0x66, 0xf0, 0x6f, 0xed, 0x09, 0x6c, 0x66, 0x89,
+	0xc9, 0x20, 0xf0, 0x09, 0x46, 0x1f, 0x40, 0x99, 0xb3, 0xe5, 0xe4, 0xcb, 0x0f, 0x36, 0x77, 0xc5,
+	0x30, 0xa4, 0xa8, 0xab, 0xfb, 0xbb, 0x3a, 0xfb, 0x32, 0x04, 0x91, 0xb6, 0x07, 0x37, 0x1b, 0x3d,
+	0x6c, 0x5f, 0x3e, 0xc7, 0x21, 0x71, 0x03, 0x3f, 0x12, 0xb9, 0x05, 0x4b, 0x57, 0x02, 0x23, 0xa5,
+	0x46, 0xa0, 0xf6, 0xf7, 0x0a, 0x6c, 0xa4, 0x7b, 0x48, 0xb9, 0x53, 0xbb, 0xa0, 0x6d, 0x58, 0x77,
+	0x5c, 0x32, 0xf0, 0xac, 0x91, 0xd9, 0xc7, 0x84, 0x58, 0x5d, 0xbc, 0x55, 0xe0, 0x14, 0x6b, 0x12,
+	0x7d, 0x2c, 0xb0, 0xe8, 0x23, 0xa8, 0x58, 0x36, 0x65, 0x1c, 0x8a, 0x77, 0x94, 0x9d, 0xb5, 0x07,
+	0x6f, 0xee, 0x66, 0x55, 0xb8, 0xdb, 0x38, 0x6a, 0xee, 0x73, 0x12, 0x43, 0x92, 0x8e, 0xe7, 0x5b,
+	0x9a, 0x67, 0xbe, 0xff, 0xbe, 0x04, 0x1b, 0x8d, 0x10, 0x5b, 0x14, 0xb7, 0x2d, 0xdf, 0x39, 0x0f,
+	0x5e, 0x46, 0x33, 0xde, 0x80, 0x32, 0x0d, 0x2e, 0x71, 0x34, 0x78, 0x01, 0xa0, 0x3b, 0xb0, 0x6c,
+	0x07, 0xfd, 0x41, 0x40, 0xf0, 0x13, 0xd7, 0x8b, 0x86, 0x9d, 0x44, 0xa1, 0x6f, 0xe1, 0x66, 0x88,
+	0xbb, 0x2e, 0xa1, 0xe1, 0xa8, 0x11, 0x62, 0x07, 0xfb, 0xd4, 0xb5, 0x3c, 0xb2, 0x55, 0xbc, 0x53,
+	0xdc, 0x59, 0x7e, 0xf0, 0x59, 0xce, 0x04, 0x72, 0x84, 0xef, 0x1a, 0x93, 0x1c, 0x74, 0x9f, 0x86,
+	0x23, 0x23, 0x8f, 0x37, 0x32, 0x61, 0x95, 0x8c, 0x7c, 0x1b, 0x3b, 0x4f, 0x02, 0xcf, 0xc1, 0x21,
+	0xd9, 0x2a, 0x71, 0x61, 0x0f, 0xe7, 0x14, 0xd6, 0x4e, 0xf6, 0x15, 0x62, 0xd2, 0xfc, 0xd8, 0x52,
+	0x0e, 0xc2, 0xe0, 0x17, 0xd8, 0xa6, 0x5b, 0x65, 0xb1, 0x94, 0x12, 0x44, 0xef, 0xc0, 0xb2, 0xd8,
+	0xe4, 0x8e, 0x49, 0x3d, 0xb2, 0x55, 0xb9, 0xa3, 0xec, 0x54, 0x0d, 0x90, 0xa8, 0x8e, 0x47, 0xd0,
+	0x23, 0x00, 0xc7, 0x27, 0xa6, 0x1d, 0xf8, 0x17, 0x6e, 0x77, 0x6b, 0x89, 0x2f, 0x49, 0xce, 0x32,
+	0x1e, 0xb4, 0xda, 0x0d, 0x4e, 0x62, 0xd4, 0x1c, 0x9f, 0x88, 0x4f, 0xe4, 0xc2, 0x0d, 0x82, 0xed,
+	0x61, 0xe8, 0xd2, 0x91, 0x39, 0x08, 0x83, 0x0b, 0xd7, 0xc3, 0x64, 0xab, 0xca, 0xe7, 0xf6, 0xd3,
+	0x79, 0xe7, 0x26, 0xfb, 0x9f, 0xca, 0xee, 0x62, 0x7a, 0x75, 0x92, 0x41, 0xb3, 0x79, 0x10, 0xea,
+	0xda, 0x97, 0x23, 0xd3, 0x0f, 0x1c, 0xbc, 0x55, 0x13, 0xf3, 0x10, 0xa8, 0x56, 0xe0, 0x60, 0xf4,
+	0x3b, 0xb0, 0x66, 0x87, 0x81, 0x6f, 0x12, 0xbb, 0x87, 0x9d, 0x21, 0x1b, 0x08, 0xbc, 0x92, 0x92,
+	0x1b, 0x61, 0xe0, 0xb7, 0xa3, 0xbe, 0x52, 0xc9, 0x76, 0x12, 0xa7, 0x7a, 0xb0, 0x35, 0x6d, 0xd9,
+	0x51, 0x1d, 0x8a, 0x97, 0x38, 0x32, 0x78, 0xf6, 0x89, 0x1e, 0x41, 0xf9, 0xca, 0xf2, 0x86, 0x62,
+	0x0b, 0x2e, 0x3f, 0xf8, 0xc1, 0xe4, 0x30, 0x26, 0x99, 0x19, 0xa2, 0xcb, 0xa3, 0xc2, 0x4f, 0x14,
+	0xf5, 0x67, 0x80, 0x26, 0xd7, 0x3d, 0x47, 0xce, 0x46, 0x52, 0x4e, 0x2d, 0xc9, 0xa1, 0x0b, 0xb7,
+	0x72, 0xb5, 0x9b, 0xc3, 0xe4, 0x27, 0xe9, 0xc1, 0x6a, 0x93, 0x83, 0xcd, 0x72, 0xca, 0x0c, 0x75,
+	0x52, 0x7b, 0xaf, 0x32, 0x54, 0xed, 0x08, 0xd0, 0xa4, 0x36, 0x90, 0x0a, 0xd5, 0x21, 0xc1, 0xa1,
+	0x6f, 0xf5, 0xb1, 0x64, 0x13, 0xc3, 0xac, 0x6d, 0x60, 0x11, 0xf2, 0x22, 0x08, 0x1d, 0xc9, 0x2e,
+	0x86, 0xb5, 0x7f, 0x29, 0xc2, 0xad, 0xcc, 0x1a, 0x2f, 0xe2, 0x6a, 0x99, 0x2f, 0x61, 0x5b, 0x6b,
+	0xdf, 0x71, 0x42, 0x4c, 0x48, 0xe4, 0x4b, 0x12, 0x28, 0x36, 0x0a, 0x06, 0x36, 0x70, 0x48, 0xb9,
+	0x07, 0xac, 0x19, 0x31, 0x8c, 0x9e, 0xc1, 0xfa, 0xe5, 0xf0, 0x1c, 0x27, 0x7d, 0x8c, 0x70, 0x78,
+	0x77, 0x27, 0xb5, 0xfb, 0x2c, 0x4d, 0x68, 0x64, 0x7b, 0xa2, 0x7b, 0xb0, 0xd6, 0xec, 0x5b, 0x5d,
+	0xdc, 0xb2, 0xfa, 0x98, 0x0c, 0x2c, 0x1b, 0x4b, 0x3b, 0xcf, 0x60, 0x99, 0x23, 0x88, 0x3c, 0x76,
+	0x45, 0x38, 0x82, 0xfe, 0x84, 0xab, 0x5e, 0x9a, 0xdf, 0x55, 0xdf, 0x83, 0xb5, 0xc8, 0x9f, 0x1d,
+	0xbb, 0x5c, 0x71, 0x55, 0x21, 0x36, 0x8d, 0x45, 0xb7, 0xa0, 0x42, 0x3d, 0x62, 0xda, 0xd6, 0x56,
+	0x4d, 0x3a, 0x63, 0x8f, 0x34, 0x2c, 0xd4, 0x81, 0x8d, 0x41, 0x88, 0x2f, 0x3c, 0xb7, 0xdb, 0xa3,
+	0xe6, 0x95, 0x1b, 0x78, 0x16, 0xe3, 0x1a, 0x59, 0x66, 0x8e, 0x1e, 0x4e, 0x03, 0xcf, 0xb5, 0x47,
+	0xcf, 0x23, 0x4a, 0xe3, 0x66, 0xdc, 0x3d, 0xc6, 0x11, 0xed, 0x9f, 0x15, 0x58, 0x3d, 0xc0, 0x03,
+	0x2f, 0x18, 0x7d, 0xdf, 0x50, 0x60, 0xc0, 0xf2, 0xf9, 0xd0, 0xf5, 0x28, 0x57, 0x62, 0x14, 0x02,
+	0xee, 0xe7, 0x38, 0xbf, 0xa4, 0xb4, 0xdd, 0xc7, 0xe3, 0x2e, 0xc2, 0x4f, 0x24, 0x99, 0xa8, 0x9f,
+	0x42, 0x3d, 0x4b, 0xf0, 0x4a, 0xa6, 0xf0, 0x29, 0xac, 0x45, 0xe2, 0x16, 0xca, 0x0f, 0x02, 0x58,
+	0xcf, 0xec, 0x26, 0x96, 0x8e, 0xf4, 0x02, 0x42, 0xa3, 0x74, 0x84, 0x7d, 0xb3, 0x01, 0xd8, 0x56,
+	0x23, 0xa4, 0xd1, 0x00, 0x38, 0x30, 0x56, 0x64, 0x31, 0xa9, 0xc8, 0xb7, 0xa0, 0xe6, 0xc7, 0xfb,
+	0xae, 0xc4, 0x5b, 0xc6, 0x08, 0xed, 0x03, 0xd8, 0x38, 0xc0, 0x1e, 0x9e, 0x2f, 0x3e, 0x6b, 0x3a,
+	0xdc, 0xca, 0x50, 0x2f, 0x34, 0xcb, 0x1d, 0xa8, 0x1f, 0x62, 0xda, 0xa6, 0x16, 0x1d, 0x92, 0xeb,
+	0x05, 0x7e, 0x07, 0x37, 0x12, 0x94, 0x0b, 0xf9, 0x81, 0x4f, 0xa0, 0x42, 0x78, 0x7f, 0xe9, 0x1e,
+	0xdf, 0xc9, 0x71, 0x8f, 0x62, 0x36, 0x52, 0x8c, 0x24, 0xd7, 0xfe, 0xa6, 0x08, 0xab, 0xa9, 0x16,
+	0xd4, 0x84, 0x2a, 0xc1, 0xe1, 0x95, 0x6b, 0x63, 0xb2, 0xa5, 0xf0, 0xed, 0xf6, 0xe1, 0x0c, 0x66,
+	0xbb, 0x6d, 0x49, 0x2f, 0xf6, 0x5a, 0xdc, 0x1d, 0x3d, 0x86, 0xf2, 0xa0, 0x67, 0x11, 0xb1, 0x85,
+	0xd6, 0x1e, 0x7c, 0x30, 0x93, 0x8f, 0x80, 0x4e, 0x59, 0x1f, 0x43, 0x74, 0x45, 0x2d, 0xb8, 0x31,
+	0xe0, 0x26, 0x97, 0xb4, 0xce, 0xe2, 0xbc, 0xd6, 0x59, 0x1f, 0xa4, 0x11, 0x44, 0xfd, 0x6d, 0x58,
+	0x4d, 0x0d, 0x37, 0x67, 0xe7, 0x7f, 0x9c, 0x0e, 0x35, 0x79, 0xba, 0x14, 0x1c, 0xa4, 0x2e, 0x13,
+	0xa6, 0x71, 0x0c, 0x2b, 0xc9, 0x49, 0xa0, 0x65, 0x58, 0x3a, 0x6b, 0x3d, 0x6b, 0x9d, 0x7c, 0xd1,
+	0xaa, 0xbf, 0xc6, 0x00, 0xe3, 0xac, 0xd5, 0x6a, 0xb6, 0x0e, 0xeb, 0x0a, 0x5a, 0x87, 0xe5, 0x8e,
+	0x6e, 0x1c, 0x37, 0x5b, 0xfb, 0x1d, 0x86, 0x28, 0x20, 0x04, 0x6b, 0x07, 0x27, 0x7a, 0xdb, 0x6c,
+	0x9d, 0x74, 0x4c, 0xfd, 0xcb, 0x66, 0xbb, 0x53, 0x2f, 0x6a, 0x7f, 0x54, 0x82, 0xd5, 0x94, 0x2c,
+	0xf4, 0xeb, 0x91, 0x4a, 0x15, 0xae, 0xd2, 0xb7, 0xa7, 0x8e, 0x2d, 0xa5, 0xc4, 0x3a, 0x14, 0xfb,
+	0xa4, 0x2b, 0x0d, 0x89, 0x7d, 0xb2, 0x64, 0xa5, 0x67, 0x11, 0x93, 0x50, 0x2b, 0xa4, 0xd8, 0xe1,
+	0xc6, 0x54, 0x35, 0xa0, 0x67, 0x91, 0xb6, 0xc0, 0x30, 0x25, 0x0c, 0xb9, 0x93, 0x2e, 0x4d, 0x53,
+	0x82, 0x81, 0x49, 0x30, 0x0c, 0x6d, 0x7c, 0xc6, 0xc8, 0x0c, 0x41, 0x8d, 0x9a, 0x50, 0xf7, 0x2c,
+	0x42, 0xcd, 0x10, 0x47, 0x59, 0x8e, 0xc3, 0xe3, 0xc0, 0xf2, 0x35, 0x43, 0xd5, 0xaf, 0xb0, 0x4f,
+	0x8d, 0x75, 0xd6, 0xcf, 0x18, 0x77, 0x63, 0x1e, 0xdb, 0x25, 0xe6, 0x2f, 0x82, 0x73, 0x99, 0x12,
+	0x96, 0x5d, 0xf2, 0x79, 0x70, 0x8e, 0xde, 0x84, 0x1a, 0x7e, 0xe9, 0x52, 0xd3, 0x66, 0x49, 0x16,
+	0x0b, 0x14, 0x65, 0xa3, 0xca, 0x10, 0x0d, 0x96, 0x62, 0x3d, 0x87, 0x55, 0xec, 0x5f, 0x99, 0xc1,
+	0x15, 0x0e, 0x43, 0xd7, 0x89, 0x53, 0xbd, 0x5f, 0x9b, 0xb1, 0x84, 0xbb, 0xba, 0x7f, 0x75, 0x12,
+	0xf5, 0x11, 0xbb, 0x78, 0x05, 0x27, 0x50, 0xe8, 0x21, 0xd4, 0x42, 0x6c, 0x39, 0xae, 0xcf, 0xa2,
+	0x6c, 0x6d, 0x5a, 0x06, 0x6a, 0x44, 0x24, 0xc6, 0x98, 0x5a, 0xfd, 0x0c, 0x6e, 0x4c, 0x70, 0x7f,
+	0x25, 0x77, 0xdb, 0x86, 0x5a, 0xcc, 0x98, 0x91, 0x31, 0xd6, 0xa2, 0x6b, 0xd5, 0x10, 0x00, 0xda,
+	0x84, 0x4a, 0x88, 0x2d, 0x12, 0xf8, 0xb2, 0xb7, 0x84, 0x92, 0xb1, 0xb6, 0x98, 0x8a, 0xb5, 0xda,
+	0x57, 0xb0, 0x9a, 0x5a, 0x3f, 0xf4, 0x43, 0x58, 0xb3, 0x07, 0x43, 0xb3, 0xef, 0x7a, 0x9e, 0x6b,
+	0x07, 0x21, 0x37, 0x7e, 0x65, 0xa7, 0x68, 0xac, 0xda, 0x83, 0xe1, 0x71, 0x8c, 0x44, 0x77, 0x61,
+	0xa5, 0x8f, 0xfb, 0x41, 0x38, 0x32, 0xcf, 0x47, 0x14, 0x0b, 0x77, 0x53, 0x34, 0x96, 0x05, 0xee,
+	0x31, 0x43, 0x69, 0x9f, 0xc3, 0x16, 0x73, 0x67, 0x42, 0xbf, 0x4f, 0x5d, 0x42, 0x83, 0x70, 0x46,
+	0x18, 0xdc, 0x82, 0x25, 0xe9, 0x33, 0xe4, 0xf8, 0x23, 0x50, 0xfb, 0x43, 0x05, 0xde, 0xc8, 0x61,
+	0xb6, 0x90, 0x8f, 0xfc, 0x31, 0x54, 0x30, 0xdb, 0x69, 0x6c, 0xd0, 0xc5, 0x39, 0x36, 0xa4, 0xa4,
+	0xd6, 0xfe, 0x5b, 0x81, 0x95, 0x64, 0x03, 0xfa, 0x04, 0x4a, 0x74, 0x34, 0x88, 0x4c, 0xf0, 0xdd,
+	0xeb, 0xd9, 0xec, 0x76, 0x46, 0x03, 0x6c, 0xf0, 0x0e, 0x2c, 0x4a, 0x51, 0xb7, 0x8f, 0x09, 0xb5,
+	0xfa, 0x03, 0xa9, 0xb9, 0x31, 0x22, 0x32, 0xd2, 0x62, 0x6c, 0xa4, 0xda, 0x4b, 0x28, 0xb1, 0xde,
+	0x13, 0x5e, 0xa4, 0xdd, 0xd9, 0x37, 0x3a, 0xfa, 0x41, 0x5d, 0x61, 0xc0, 0x53, 0x7d, 0xff, 0xa8,
+	0xf3, 0xf4, 0xab, 0x7a, 0x01, 0xad, 0x42, 0xed, 0xac, 0x15, 0x81, 0x45, 0x04, 0x50, 0xd1, 0xbf,
+	0x6c, 0x32, 0xba, 0x12, 0x5a, 0x03, 0x38, 0x39, 0x39, 0x36, 0x9f, 0x35, 0x8f, 0x8e, 0xf4, 0x83,
+	0x7a, 0x99, 0x91, 0x1a, 0x7a, 0xc4, 0xa6, 0xc2, 0x9c, 0x91, 0xa1, 0xb7, 0x1b, 0x4f, 0xf5, 0x83,
+	0x33, 0xd6, 0xbe, 0xa4, 0x7d, 0x09, 0xeb, 0x87, 0x98, 0x0a, 0xcb, 0xbe, 0x76, 0xe9, 0xea, 0x50,
+	0x0c, 0x42, 0xe1, 0x59, 0xaa, 0x06, 0xfb, 0x44, 0xb7, 0x01, 0xb8, 0x57, 0x31, 0xd9, 0xcc, 0xf8,
+	0x6c, 0x8a, 0x46, 0x8d, 0x63, 0x3a, 0x6e, 0x1f, 0x6b, 0x23, 0xa8, 0x8f, 0x39, 0x2f, 0x18, 0xeb,
+	0x96, 0x42, 0x6c, 0x07, 0xa1, 0x13, 0x2d, 0xe4, 0xed, 0xc9, 0x15, 0x90, 0xfc, 0x19, 0x95, 0x11,
+	0x51, 0x6b, 0xbf, 0x54, 0x60, 0x39, 0xd1, 0xc0, 0x92, 0x8e, 0x21, 0xc1, 0x61, 0x94, 0x74, 0xb0,
+	0xef, 0xe4, 0x31, 0xb5, 0x90, 0x3e, 0xa6, 0xde, 0x06, 0x60, 0xe7, 0x3a, 0xb3, 0x17, 0x0c, 0x43,
+	0xc2, 0xe7, 0xa5, 0x18, 0x35, 0x86, 0x79, 0xca, 0x10, 0xe8, 0x5d, 0x58, 0x65, 0x9b, 0xd3, 0xea,
+	0x62, 0x69, 0x19, 0x25, 0x3e, 0xf3, 0x15, 0x89, 0xe4, 0xa6, 0xc1, 0xac, 0x07, 0x77, 0x43, 0x4c,
+	0x88, 0xa4, 0x29, 0x0b, 0xeb, 0x11, 0x38, 0x61, 0x3d, 0x7f, 0xa2, 0xc0, 0x86, 0x18, 0x5f, 0x1b,
+	0x93, 0x64, 0xf9, 0xe4, 0x63, 0xa8, 0xf4, 0xb0, 0xe5, 0xe0, 0x48, 0x4b, 0xb7, 0xf3, 0xf6, 0x1d,
+	0xef, 0xd1, 0xf4, 0x2f, 0x02, 0x43, 0x12, 0xcf, 0xb7, 0xeb, 0x79, 0xb7, 0xf4, 0xae, 0xd7, 0xe1,
+	0x56, 0x66, 0x18, 0x0b, 0x65, 0x41, 0xef, 0xc3, 0xcd, 0x23, 0x97, 0x50, 0xc9, 0x64, 0x46, 0x22,
+	0xf4, 0xfb, 0xb0, 0x91, 0x26, 0x5e, 0x68, 0x7f, 0x3c, 0x64, 0x09, 0x8c, 0xe0, 0x30, 0x7d, 0x83,
+	0x24, 0x55, 0x15, 0x93, 0x6b, 0x8f, 0x41, 0xe5, 0xde, 0x46, 0xce, 0x98, 0x4d, 0xdf, 0xf5, 0xbb,
+	0xd7, 0x5b, 0xc0, 0x1a, 0x14, 0xdc, 0xe8, 0x80, 0x57, 0x70, 0x1d, 0x56, 0xcc, 0x7a, 0x33, 0x97,
+	0xc9, 0xa2, 0x9b, 0x5d, 0x8e, 0x4e, 0x66, 0x23, 0x33, 0xe6, 0x12, 0x51, 0x27, 0xd6, 0xbd, 0xf8,
+	0x4a, 0xeb, 0xfe, 0x1f, 0x0a, 0x2c, 0x27, 0x18, 0xca, 0xe9, 0x29, 0xd1, 0xf4, 0xc6, 0x4a, 0x28,
+	0x24, 0x95, 0x10, 0x99, 0x52, 0x31, 0x6d, 0x4a, 0x91, 0x57, 0x2f, 0xa5, 0xbc, 0x3a, 0x6b, 0xb1,
+	0x83, 0x7e, 0xdf, 0xf2, 0x59, 0x6e, 0x50, 0x64, 0x2d, 0x12, 0x64, 0xdc, 0x5f, 0xb8, 0x0e, 0xed,
+	0xf1, 0x90, 0x5f, 0x36, 0x04, 0xc0, 0xc2, 0x5b, 0x0f, 0xb3, 0x23, 0x96, 0x8c, 0xf7, 0x12, 0xca,
+	0xb8, 0x9a, 0x6a, 0xc6, 0xd5, 0xb0, 0xa3, 0xaf, 0x33, 0x0c, 0x79, 0xde, 0xc7, 0x63, 0xb6, 0x62,
+	0xc4, 0xb0, 0xf6, 0xe7, 0xdc, 0xa9, 0x8f, 0xe7, 0xcf, 0x66, 0xc0, 0xb9, 0x28, 0x9c, 0x90, 0x7f,
+	0xc7, 0x8e, 0xbe, 0x30, 0xdd, 0xd1, 0x8f, 0x39, 0x24, 0x1d, 0x3d, 0x82, 0x92, 0x63, 0x51, 0x8b,
+	0xab, 0x63, 0xc5, 0xe0, 0xdf, 0xda, 0x6d, 0xe9, 0xcc, 0x01, 0x2a, 0x27, 0x67, 0x9d, 0xd3, 0xb3,
+	0x4e, 0xfd, 0x35, 0x54, 0x83, 0x72, 0xb3, 0xc5, 0x3e, 0x15, 0xed, 0x37, 0x61, 0xe5, 0x34, 0x1c,
+	0xfa, 0x33, 0xdc, 0xed, 0xeb, 0xb0, 0xe4, 0x84, 0x23, 0x33, 0x1c, 0xfa, 0xd2, 0xe5, 0x56, 0x9c,
+	0x70, 0x64, 0x0c, 0x7d, 0xed, 0xf7, 0x60, 0x55, 0x76, 0x5f, 0x68, 0x9b, 0x7d, 0xca, 0xf2, 0x1b,
+	0x91, 0x0e, 0x44, 0x46, 0x73, 0x27, 0x27, 0xbb, 0x66, 0x12, 0x9c, 0x28, 0x6f, 0x30, 0xc6, 0x5d,
+	0xb4, 0xbf, 0x53, 0x60, 0x2d, 0xdd, 0x8a, 0x1e, 0xa6, 0xa2, 0xe4, 0x0f, 0x67, 0x71, 0xcb, 0xa8,
+	0x8f, 0x57, 0x54, 0xc4, 0x16, 0xe3, 0xdf, 0x7c, 0xad, 0xdd, 0xef, 0x22, 0xe7, 0x1a, 0x85, 0x15,
+	0xf7, 0x3b, 0xe1, 0x59, 0xb5, 0x47, 0x79, 0xa1, 0x12, 0xa0, 0xf2, 0xfc, 0xe4, 0xe8, 0xec, 0x58,
+	0xaf, 0x2b, 0x5c, 0xd5, 0xc7, 0xfb, 0x87, 0x7a, 0xbd, 0xc0, 0x82, 0xa1, 0xfe, 0xe5, 0xe9, 0x49,
+	0x5b, 0x37, 0xcf, 0x8c, 0xa3, 0x7a, 0x51, 0xfb, 0x0b, 0x05, 0xd6, 0x33, 0x07, 0x07, 0x36, 0x84,
+	0x70, 0xe8, 0x45, 0x45, 0x1d, 0xfe, 0x9d, 0xcc, 0xa6, 0x0a, 0xe9, 0xca, 0xc5, 0x66, 0xaa, 0xc8,
+	0x5c, 0x8b, 0x8b, 0x13, 0xb7, 0x01, 0xb0, 0x7f, 0x11, 0x84, 0x36, 0x36, 0x2d, 0x2a, 0x23, 0x42,
+	0x4d, 0x62, 0xf6, 0x69, 0xd2, 0x42, 0xca, 0xe9, 0xbc, 0xa7, 0x09, 0x9b, 0x5f, 0x58, 0x2e, 0x7d,
+	0x12, 0x84, 0x0d, 0x6b, 0x60, 0xd9, 0x2e, 0x9d, 0x91, 0x41, 0xbd, 0x01, 0x55, 0x3f, 0x30, 0xbf,
+	0x1d, 0x62, 0x99, 0x40, 0x56, 0x8d, 0x25, 0x3f, 0xf8, 0x39, 0x03, 0xb5, 0xbf, 0x54, 0x60, 0x99,
+	0x7f, 0xc9, 0x13, 0xc4, 0xab, 0x6d, 0x0c, 0x15, 0xaa, 0x96, 0xd3, 0x77, 0x29, 0x3b, 0x24, 0x08,
+	0xc6, 0x31, 0xcc, 0xda, 0x06, 0x01, 0x71, 0xe3, 0x79, 0x97, 0x8d, 0x18, 0x66, 0xe7, 0x0b, 0x4c,
+	0x2d, 0x93, 0x60, 0x3b, 0xf0, 0x9d, 0x28, 0x18, 0x02, 0xa6, 0x56, 0x5b, 0x60, 0xb4, 0xff, 0xe2,
+	0x71, 0xce, 0x77, 0x70, 0x38, 0x57, 0xd1, 0xfc, 0x2e, 0xac, 0xc8, 0xb2, 0x88, 0x79, 0x31, 0xa5,
+	0x54, 0xf2, 0x35, 0xac, 0xf0, 0x2a, 0x87, 0xe9, 0x26, 0x6b, 0x25, 0x9f, 0xe4, 0xa5, 0xe9, 0x93,
+	0x62, 0xff, 0x9f, 0x4b, 0x26, 0x7f, 0xab, 0xc0, 0xad, 0x8c, 0xd8, 0x85, 0xec, 0xf4, 0x11, 0x2c,
+	0x05, 0xe7, 0x2c, 0x1d, 0xb9, 0xc6, 0x4a, 0x85, 0x1c, 0xec, 0x9c, 0x70, 0x42, 0x23, 0xea, 0xc0,
+	0x96, 0xeb, 0x85, 0x15, 0xfa, 0xae, 0xdf, 0x15, 0xba, 0xa9, 0x19, 0x31, 0xac, 0x5d, 0xc0, 0x5a,
+	0xba, 0x1b, 0x33, 0x80, 0x4b, 0xd7, 0x8f, 0x3c, 0x3f, 0xff, 0xce, 0xb5, 0xcb, 0xc4, 0x1e, 0x2e,
+	0xa6, 0xbd, 0x3c, 0x82, 0xd2, 0xc8, 0xea, 0x7b, 0xd2, 0xf9, 0xf3, 0x6f, 0xed, 0x8a, 0xed, 0x6b,
+	0x6a, 0xf7, 0xf4, 0x97, 0x6c, 0xd9, 0x8e, 0x82, 0x2e, 0x59, 0xf0, 0x64, 0xc0, 0xe8, 0x89, 0xeb,
+	0xdb, 0x51, 0x86, 0x29, 0x00, 0x66, 0x88, 0x17, 0x81, 0xe7, 0x05, 0x2f, 0xb8, 0xd4, 0xaa, 0x21,
+	0x21, 0xed, 0x0f, 0x14, 0x40, 0x49, 0x99, 0x0b, 0x29, 0xff, 0xa7, 0x50, 0x0d, 0xc5, 0x68, 0xaf,
+	0xd1, 0xfe, 0xd3, 0x4e, 0xe7, 0x54, 0xce, 0xe9, 0x28, 0xe8, 0x1a, 0x71, 0x0f, 0xed, 0xdf, 0x14,
+	0x58, 0x4b, 0x37, 0xa6, 0xcf, 0x03, 0x4a, 0xf6, 0x3c, 0xb0, 0x09, 0x95, 0x3e, 0xa6, 0xbd, 0x20,
+	0x4a, 0x2e, 0x24, 0x14, 0xd7, 0xca, 0x8a, 0x89, 0x5a, 0x19, 0x82, 0xd2, 0xc0, 0xa2, 0xbd, 0x48,
+	0xd7, 0xec, 0x9b, 0xf5, 0x97, 0x35, 0xa1, 0xb2, 0x88, 0x9a, 0x02, 0x62, 0x4e, 0xc9, 0xb3, 0x28,
+	0xf6, 0xed, 0x91, 0xd9, 0x17, 0xd7, 0x2d, 0x45, 0xa3, 0x26, 0x31, 0xc7, 0x84, 0x9d, 0xaf, 0x6d,
+	0xcf, 0xc5, 0x3e, 0x35, 0xdd, 0x01, 0x8f, 0xb7, 0x35, 0xa3, 0x2a, 0x10, 0xcd, 0x01, 0xeb, 0xcb,
+	0x62, 0xbb, 0x69, 0x75, 0xb1, 0x4f, 0x65, 0xa5, 0xb5, 0xc6, 0x30, 0xfb, 0x0c, 0xa1, 0x7d, 0x03,
+	0x9b, 0x6d, 0x4c, 0x1f, 0x07, 0x01, 0x8d, 0x2a, 0xed, 0xd7, 0x2f, 0x2f, 0x82, 0x12, 0xbb, 0xc0,
+	0x88, 0x36, 0x14, 0xfb, 0x66, 0xdb, 0x94, 0xe9, 0xe0, 0xbb, 0xc0, 0x8f, 0x76, 0x54, 0x0c, 0x6b,
+	0x7f, 0xac, 0xc0, 0xeb, 0x13, 0x02, 0x16, 0x34, 0xa4, 0x6a, 0x54, 0x69, 0x90, 0x89, 0x55, 0x4e,
+	0x82, 0x94, 0x92, 0x13, 0xd3, 0x6b, 0xbb, 0xb0, 0x79, 0xf8, 0x0a, 0xb3, 0xe4, 0xa3, 0x3e, 0xfc,
+	0x95, 0x8f, 0xfa, 0xaf, 0x14, 0x58, 0x49, 0x36, 0xc5, 0xca, 0x57, 0xa6, 0x28, 0xbf, 0x90, 0x56,
+	0x3e, 0xdb, 0x18, 0x3e, 0x7e, 0x49, 0xcd, 0xf3, 0x20, 0xa0, 0xd2, 0xea, 0xaa, 0x0c, 0xc1, 0x98,
+	0xb2, 0x46, 0x5e, 0xf7, 0xe1, 0x8d, 0xc2, 0xdb, 0x57, 0x19, 0x82, 0x37, 0xf2, 0x1d, 0x47, 0xa8,
+	0x29, 0x66, 0x2a, 0x42, 0x1d, 0x27, 0xe7, 0x93, 0xd3, 0x28, 0xd4, 0xe2, 0xbb, 0x3b, 0xc6, 0x88,
+	0x15, 0xa6, 0x7c, 0x27, 0xa0, 0x44, 0x16, 0x39, 0xaa, 0x3d, 0x8b, 0xb4, 0x18, 0xcc, 0xf4, 0x2b,
+	0x1a, 0x0a, 0x22, 0x3d, 0xe4, 0x00, 0x1b, 0x34, 0xc1, 0x56, 0x68, 0xf7, 0x70, 0xec, 0xd8, 0x22,
+	0x98, 0x39, 0x90, 0x60, 0x20, 0x8a, 0x86, 0x25, 0x91, 0x6a, 0x4a, 0x50, 0xfb, 0x08, 0xde, 0xe4,
+	0x87, 0x8d, 0xd8, 0x1f, 0x8b, 0x54, 0xe6, 0xfa, 0xa5, 0xfc, 0x33, 0x05, 0xde, 0xca, 0xef, 0xb5,
+	0xd0, 0x7a, 0x7e, 0x36, 0x99, 0x76, 0xdd, 0x9d, 0x5a, 0x24, 0xcd, 0xcb, 0xbb, 0xfe, 0xb4, 0x00,
+	0xeb, 0x99, 0x66, 0xf4, 0x28, 0x95, 0x78, 0xdd, 0x9b, 0xc9, 0x6f, 0x56, 0xe6, 0x35, 0xdd, 0xc3,
+	0xab, 0xcc, 0x21, 0x52, 0xcb, 0xf5, 0xb1, 0x23, 0xfd, 0x6d, 0x0c, 0x67, 0xf2, 0xb5, 0x72, 0x36,
+	0x5f, 0xfb, 0x79, 0x5e, 0xbe, 0xb6, 0x04, 0xc5, 0xd3, 0x13, 0x59, 0xd6, 0x68, 0xeb, 0xc6, 0xf3,
+	0x66, 0x83, 0xa5, 0x6b, 0xe3, 0x2c, 0xae, 0x98, 0x49, 0xdd, 0x4a, 0xac, 0xad, 0xad, 0x37, 0x0c,
+	0xbd, 0x53, 0x2f, 0x6b, 0xff, 0xc9, 0x63, 0x2c, 0x4f, 0xff, 0x65, 0x01, 0x66, 0xd1, 0xd8, 0xf2,
+	0x4d, 0xb6, 0x8a, 0x58, 0x9c, 0x76, 0x4f, 0x9b, 0x2b, 0x6f, 0x56, 0x35, 0xf1, 0xfb, 0x97, 0x04,
+	0x9f, 0xc0, 0x66, 0x56, 0xf2, 0x42, 0xa7, 0xf3, 0x7f, 0x50, 0xe0, 0x46, 0x9b, 0x86, 0xd8, 0xea,
+	0xcf, 0x0e, 0xc5, 0x6a, 0xe2, 0x5e, 0xa0, 0x10, 0x59, 0x99, 0x80, 0x13, 0x61, 0xb7, 0x98, 0x0c,
+	0xbb, 0xbc, 0x28, 0xc2, 0xe2, 0x72, 0x26, 0x0f, 0x5c, 0xe1, 0x48, 0x99, 0x09, 0xb2, 0x9d, 0x42,
+	0x2d, 0xd7, 0x33, 0x3d, 0xd7, 0x1f, 0xef, 0x14, 0x86, 0x39, 0x62, 0x08, 0x9e, 0x65, 0x86, 0xf8,
+	0xca, 0x0d, 0x86, 0xd1, 0xdb, 0x80, 0x18, 0xd6, 0xfe, 0x5a, 0x01, 0x94, 0x1c, 0xff, 0x42, 0x46,
+	0xb8, 0x07, 0x65, 0x21, 0x5a, 0x18, 0xe0, 0x1b, 0x93, 0xab, 0x7c, 0x14, 0x74, 0xd9, 0x58, 0x0c,
+	0x41, 0xc7, 0x4a, 0xa5, 0xd8, 0x77, 0xb0, 0x63, 0xc6, 0xfa, 0x10, 0x5e, 0x67, 0x95, 0x63, 0xe5,
+	0x8a, 0x10, 0x8d, 0xc0, 0x92, 0xec, 0x98, 0xdc, 0x6a, 0x4a, 0x7a, 0xab, 0x5d, 0x5f, 0x12, 0x9c,
+	0x5a, 0xbf, 0x15, 0xc1, 0x9d, 0x4d, 0x5c, 0x86, 0x7c, 0x09, 0x69, 0x17, 0x70, 0xb3, 0xe1, 0x05,
+	0xfe, 0x7c, 0x2f, 0x51, 0x18, 0x13, 0xee, 0x02, 0xa2, 0x0c, 0x43, 0x40, 0x2c, 0xd9, 0x26, 0x97,
+	0xee, 0xc0, 0xbc, 0x0a, 0xbc, 0x61, 0x5f, 0x9e, 0xb6, 0xaa, 0xc6, 0x32, 0xc3, 0x3d, 0x17, 0x28,
+	0xed, 0x1f, 0x0b, 0xb0, 0x91, 0x16, 0xb4, 0x90, 0xee, 0x73, 0x6e, 0xa0, 0x0b, 0x0b, 0xdf, 0x40,
+	0x7f, 0x0e, 0x95, 0x54, 0xea, 0xff, 0x20, 0xe7, 0xfe, 0x38, 0x67, 0xc8, 0xbb, 0xc9, 0xac, 0x5f,
+	0x72, 0x40, 0x3f, 0x82, 0x7a, 0x88, 0x09, 0x0d, 0x42, 0xec, 0xc4, 0x6a, 0x10, 0x01, 0x64, 0x3d,
+	0xc2, 0x4b, 0x55, 0xa8, 0x0f, 0x61, 0x79, 0xd1, 0x63, 0xc1, 0xb7, 0x50, 0xcf, 0xbe, 0x5a, 0x10,
+	0x7b, 0xc5, 0x66, 0x07, 0x9b, 0xf1, 0x5e, 0xe1, 0x20, 0x7b, 0xf3, 0x24, 0x3f, 0xa3, 0xa7, 0x2c,
+	0xd1, 0x9b, 0x27, 0x89, 0x96, 0x3c, 0xf8, 0xa1, 0x6d, 0x30, 0xb0, 0xc2, 0x7e, 0x10, 0x55, 0x64,
+	0x62, 0x58, 0xfb, 0x1f, 0x05, 0x36, 0xda, 0x71, 0x45, 0x5d, 0xf7, 0xaf, 0x16, 0x75, 0x92, 0xfb,
+	0x50, 0xc4, 0xfe, 0x95, 0x54, 0xf5, 0x5e, 0x5e, 0x6d, 0x64, 0x52, 0x08, 0xf3, 0x8c, 0x42, 0xcf,
+	0xac, 0x2f, 0x53, 0x55, 0x4f, 0xa6, 0x0b, 0x55, 0x83, 0x7d, 0x32, 0x87, 0x11, 0x62, 0x2f, 0xb0,
+	0x1c, 0x93, 0xb8, 0x5d, 0xdf, 0xf2, 0x64, 0xb2, 0xb0, 0x22, 0x90, 0x6d, 0x8e, 0x53, 0x7f, 0x0c,
+	0xd5, 0x88, 0xcf, 0x2b, 0x69, 0x5b, 0x87, 0x5b, 0x99, 0x41, 0x2d, 0xe4, 0x34, 0x4f, 0xe4, 0x63,
+	0xb5, 0x03, 0x8b, 0x5a, 0x47, 0x01, 0x21, 0xdf, 0xf7, 0xe0, 0xaa, 0xfd, 0x2e, 0xdc, 0xca, 0x30,
+	0x5c, 0xc8, 0x96, 0x3e, 0x86, 0x25, 0x8b, 0x9a, 0xa1, 0x4b, 0x2e, 0xa5, 0x27, 0x7b, 0x2b, 0xe7,
+	0x99, 0x80, 0x45, 0xad, 0x7d, 0x6a, 0xb8, 0xe4, 0xd2, 0xa8, 0x58, 0xfc, 0x57, 0xfb, 0x57, 0x05,
+	0x60, 0x8c, 0x66, 0x3e, 0x41, 0xec, 0x77, 0x39, 0x0d, 0x09, 0x5d, 0xeb, 0xfe, 0x7f, 0x23, 0xbe,
+	0x7e, 0x2a, 0x4e, 0xab, 0x94, 0x8d, 0x25, 0xec, 0x1a, 0x9c, 0x34, 0xbe, 0xa3, 0x4a, 0x27, 0x0a,
+	0xa5, 0x6c, 0xa2, 0xf0, 0x33, 0xa8, 0x88, 0x0e, 0xe9, 0x54, 0xa1, 0x0e, 0x2b, 0x22, 0x29, 0x30,
+	0x0d, 0xbd, 0xad, 0x77, 0xea, 0x0a, 0x7a, 0x1d, 0x6e, 0x9e, 0xea, 0x46, 0xbb, 0xd9, 0xee, 0xe8,
+	0xad, 0x86, 0x6e, 0x36, 0x9e, 0xee, 0xb7, 0x0e, 0xf5, 0x83, 0x7a, 0xe1, 0xbd, 0xdb, 0x50, 0x8b,
+	0x9f, 0x8d, 0xa0, 0x0a, 0x14, 0x4e, 0x9e, 0xd5, 0x5f, 0x43, 0x55, 0x28, 0xb1, 0xcb, 0x91, 0xba,
+	0xf2, 0xde, 0x2f, 0xc7, 0xd7, 0x3b, 0x39, 0x77, 0xb6, 0x5b, 0xb0, 0xd1, 0x6c, 0x35, 0x3b, 0xcd,
+	0xfd, 0xa3, 0xe6, 0xd7, 0xcd, 0xd6, 0xa1, 0x29, 0x84, 0xb6, 0xeb, 0x0a, 0xba, 0x09, 0xeb, 0x5f,
+	0xec, 0x37, 0x3b, 0xe6, 0x81, 0x7e, 0xaa, 0xb7, 0x0e, 0xda, 0xe6, 0x49, 0x4b, 0x5c, 0xe2, 0x72,
+	0x64, 0xfb, 0xab, 0x56, 0xc3, 0x7c, 0xdc, 0x6c, 0x1d, 0xd4, 0x8b, 0x8c, 0x1f, 0xa3, 0x60, 0xb7,
+	0xbc, 0xa5, 0xe4, 0x1d, 0x70, 0x39, 0x71, 0x43, 0x53, 0x49, 0x5f, 0xde, 0x2c, 0x31, 0xb0, 0x71,
+	0x72, 0x7c, 0x7a, 0xa4, 0xb3, 0xd6, 0xea, 0x83, 0x7f, 0x42, 0xb0, 0x74, 0x2c, 0x5e, 0x82, 0xa2,
+	0x73, 0x58, 0x4d, 0x3d, 0x1d, 0x42, 0xf7, 0xe6, 0x7b, 0x3f, 0xa6, 0x6e, 0xcf, 0xa4, 0x13, 0xfb,
+	0x4e, 0x7b, 0x0d, 0x3d, 0x87, 0x75, 0xf1, 0xc4, 0xa3, 0x13, 0x44, 0x52, 0xde, 0x99, 0xf1, 0xe8,
+	0x44, 0xbd, 0x33, 0x9d, 0x20, 0xe6, 0x7b, 0x0e, 0xab, 0xa9, 0xb7, 0x15, 0x79, 0x63, 0xcf, 0x7b,
+	0xaa, 0xa1, 0x6e, 0xcf, 0xa4, 0x4b, 0x8c, 0xbd, 0x16, 0x3f, 0xa7, 0x40, 0x39, 0xef, 0xc4, 0xb2,
+	0xaf, 0x32, 0xd4, 0x77, 0xaf, 0xa5, 0x89, 0xf9, 0x62, 0x58, 0x4b, 0x3f, 0x8f, 0x45, 0xdb, 0x79,
+	0x45, 0xcd, 0x9c, 0xd7, 0xb6, 0xea, 0xce, 0x6c, 0xc2, 0x58, 0xcc, 0xd7, 0xb0, 0xcc, 0x4b, 0x24,
+	0xff, 0xe7, 0x13, 0xb8, 0xaf, 0x20, 0x13, 0x56, 0x92, 0xef, 0x6c, 0x51, 0x4e, 0x55, 0x36, 0xe7,
+	0xe5, 0xae, 0x7a, 0x6f, 0x16, 0x59, 0x3c, 0x78, 0x5f, 0x3c, 0x65, 0x49, 0x5d, 0xd7, 0xa2, 0xf7,
+	0xf2, 0x87, 0x97, 0x77, 0x41, 0xac, 0xbe, 0x3f, 0x17, 0x6d, 0x2c, 0xaf, 0x0d, 0xd5, 0xe8, 0x36,
+	0x11, 0xdd, 0xcd, 0xed, 0x9a, 0xbc, 0xc3, 0x54, 0xb5, 0xeb, 0x48, 0x62, 0xa6, 0x0e, 0xac, 0x8a,
+	0x6b, 0x1b, 0x59, 0xde, 0xcf, 0xdb, 0xa4, 0x79, 0x57, 0x74, 0xea, 0xf6, 0x4c, 0xba, 0x48, 0xc6,
+	0x0e, 0x5f, 0x8b, 0xe4, 0x65, 0x57, 0xde, 0x5a, 0xe4, 0xdc, 0x9c, 0xa9, 0xf7, 0x66, 0x91, 0xc5,
+	0xd3, 0xa0, 0x70, 0x33, 0xe7, 0x1e, 0x0a, 0x7d, 0x30, 0x45, 0xc3, 0xb9, 0x77, 0x5e, 0xea, 0x87,
+	0x73, 0x52, 0xc7, 0x52, 0x3f, 0x87, 0x32, 0x2f, 0xec, 0xa3, 0xb7, 0xa7, 0x54, 0xfc, 0x23, 0xce,
+	0xef, 0x4c, 0x6d, 0x8f, 0x79, 0x7d, 0x03, 0xeb, 0x99, 0x2a, 0x38, 0xca, 0xb1, 0xa4, 0xfc, 0x42,
+	0xb9, 0x9a, 0x73, 0x51, 0x96, 0x28, 0x83, 0x73, 0x73, 0x38, 0x87, 0x55, 0x51, 0xf5, 0xbc, 0xc6,
+	0x1b, 0xe5, 0x15, 0x8b, 0xd5, 0xed, 0x99, 0x74, 0x09, 0xaf, 0xb1, 0x9e, 0xa9, 0x78, 0xe6, 0xcf,
+	0x21, 0xaf, 0x28, 0xaa, 0xe6, 0x3c, 0xc9, 0x9d, 0xac, 0x62, 0xf2, 0xa9, 0xf4, 0x60, 0x3d, 0x53,
+	0x18, 0xcb, 0x13, 0x93, 0x5f, 0x9c, 0x53, 0x7f, 0x34, 0x07, 0x65, 0x3c, 0xa1, 0x1e, 0x7f, 0x1a,
+	0x30, 0x4b, 0xd2, 0xe1, 0xdc, 0x92, 0x0e, 0xa7, 0x4a, 0x7a, 0x21, 0xaf, 0x83, 0x33, 0xb5, 0x16,
+	0xf4, 0xe1, 0x14, 0x13, 0xc8, 0xaf, 0xe4, 0xa8, 0xbb, 0xf3, 0x92, 0x27, 0x3d, 0x7d, 0xfa, 0x78,
+	0x8d, 0xb6, 0xe7, 0x3c, 0xfa, 0xab, 0x3b, 0xb3, 0x09, 0x63, 0x31, 0xbf, 0x05, 0x30, 0x3e, 0xbc,
+	0xa2, 0xbc, 0xeb, 0xc5, 0xec, 0xd1, 0x5c, 0xfd, 0xc1, 0xf5, 0x44, 0x19, 0x57, 0x9f, 0x38, 0xec,
+	0xe4, 0xba, 0xfa, 0xc9, 0x83, 0xa2, 0x7a, 0x6f, 0x16, 0x59, 0x32, 0x94, 0xa7, 0xb2, 0xe9, 0x3c,
+	0xe3, 0xc9, 0x3b, 0x03, 0xa8, 0xdb, 0x33, 0xe9, 0x92, 0x32, 0x52, 0x99, 0x31, 0x9a, 0x16, 0x89,
+	0x32, 0xb9, 0xb8, 0xba, 0x3d, 0x93, 0x2e, 0x92, 0xf1, 0xf8, 0xbd, 0xaf, 0x77, 0xba, 0x2e, 0xed,
+	0x0d, 0xcf, 0x77, 0xed, 0xa0, 0xbf, 0x77, 0x89, 0x3d, 0xc7, 0xda, 0x13, 0xff, 0xa9, 0x19, 0x5c,
+	0x76, 0xf7, 0xf8, 0xdf, 0x68, 0xa2, 0xff, 0xe3, 0x9c, 0x57, 0x38, 0xf8, 0xd1, 0xff, 0x0e, 0x00,
+	0x8e, 0xae, 0xf3, 0x96, 0xa7, 0x33, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ManagerClient is the client API for Manager service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ManagerClient interface {
+	CreateSandbox(ctx context.Context, in *CreateSandboxRequest, opts ...grpc.CallOption) (*CreateSandboxResponse, error)
+	DeployToSandbox(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error)
+	DeleteSandbox(ctx context.Context, in *DeleteSandboxRequest, opts ...grpc.CallOption) (*DeleteSandboxResponse, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	ProxyAnalytics(ctx context.Context, in *ProxyAnalyticsRequest, opts ...grpc.CallOption) (*ProxyAnalyticsResponse, error)
+	WatchStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (Manager_WatchStatusClient, error)
+	CheckVersion(ctx context.Context, in *CheckVersionRequest, opts ...grpc.CallOption) (*CheckVersionResponse, error)
+	GetServiceHistory(ctx context.Context, in *GetServiceHistoryRequest, opts ...grpc.CallOption) (*GetServiceHistoryResponse, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	RecordSession(ctx context.Context, opts ...grpc.CallOption) (Manager_RecordSessionClient, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	GetSessionRecording(ctx context.Context, in *GetSessionRecordingRequest, opts ...grpc.CallOption) (*GetSessionRecordingResponse, error)
+	Prune(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (*PruneResponse, error)
+	// WaitForCapacity queues the user for a sandbox when the cluster is at
+	// capacity. The manager streams the user's position in the queue until
+	// they're admitted, and then reserves capacity for the user's next
+	// CreateSandbox call.
+	WaitForCapacity(ctx context.Context, in *WaitForCapacityRequest, opts ...grpc.CallOption) (Manager_WaitForCapacityClient, error)
+	// RenderSandbox converts a Compose file into the Kubernetes objects that
+	// the manager would deploy for it. DeployToSandbox uses the same renderer,
+	// so support for new Compose features ships with the manager rather than
+	// requiring users to upgrade their CLI.
+	RenderSandbox(ctx context.Context, in *RenderSandboxRequest, opts ...grpc.CallOption) (*RenderSandboxResponse, error)
+	// WatchExposeLogs streams the HTTP requests made to a service's public URL.
+	// The requests are recorded by the ingress proxy, so requests that never
+	// reach the service, such as ones that time out while it boots, are still
+	// logged.
+	WatchExposeLogs(ctx context.Context, in *WatchExposeLogsRequest, opts ...grpc.CallOption) (Manager_WatchExposeLogsClient, error)
+	// SetBootSchedule configures the manager to boot the user's sandbox on a
+	// cron schedule, by redeploying the most recently deployed Compose file
+	// and pulling its images. Sandboxes booted by the schedule are still shut
+	// down by the usual idle timeout. Setting an empty cron expression clears
+	// the schedule.
+	SetBootSchedule(ctx context.Context, in *SetBootScheduleRequest, opts ...grpc.CallOption) (*SetBootScheduleResponse, error)
+	GetBootSchedule(ctx context.Context, in *GetBootScheduleRequest, opts ...grpc.CallOption) (*GetBootScheduleResponse, error)
+	// ListSandboxResources returns the resources that DeleteSandbox would
+	// delete, and the resources that would be retained. It's a separate RPC
+	// rather than a dry run flag on DeleteSandbox so that managers that don't
+	// support it fail rather than deleting the sandbox.
+	ListSandboxResources(ctx context.Context, in *ListSandboxResourcesRequest, opts ...grpc.CallOption) (*ListSandboxResourcesResponse, error)
+	// RestartService restarts a single service without redeploying the rest
+	// of the sandbox. The service can be restarted with temporary environment
+	// variables, which last until the sandbox is next deployed or deleted, so
+	// that they don't have to be added to the shared Compose file.
+	RestartService(ctx context.Context, in *RestartServiceRequest, opts ...grpc.CallOption) (*RestartServiceResponse, error)
+	// StreamLogs streams the logs of multiple services over a single
+	// connection. It's an alternative to reading the logs directly from
+	// Kubernetes, for networks that block the Kubernetes API, and lets the
+	// manager enforce rate limits on log streaming.
+	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (Manager_StreamLogsClient, error)
+	// CloneSandbox replaces the user's sandbox with a copy of a sandbox that
+	// was shared with them. The clone runs the exact images that the source
+	// sandbox is running, pinned by digest, and its volumes are restored from
+	// snapshots of the source's volumes. Bind mounts aren't synced, since the
+	// source's files are on its owner's machine.
+	CloneSandbox(ctx context.Context, in *CloneSandboxRequest, opts ...grpc.CallOption) (*CloneSandboxResponse, error)
+	// SetServiceEnv sets temporary environment variables in a service. Like
+	// the overrides passed to RestartService, they last until the sandbox is
+	// next deployed or deleted. Unless the request is hot, the service is
+	// restarted so that the variables take effect.
+	SetServiceEnv(ctx context.Context, in *SetServiceEnvRequest, opts ...grpc.CallOption) (*SetServiceEnvResponse, error)
+	// CheckDataLoss returns the data that deploying a Compose file would
+	// destroy, such as named volumes that would be reset because the services
+	// that use them are recreated. It doesn't modify the sandbox, so the CLI
+	// can ask the user to confirm before calling DeployToSandbox.
+	CheckDataLoss(ctx context.Context, in *CheckDataLossRequest, opts ...grpc.CallOption) (*CheckDataLossResponse, error)
+}
+
+type managerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewManagerClient(cc grpc.ClientConnInterface) ManagerClient {
+	return &managerClient{cc}
+}
+
+func (c *managerClient) CreateSandbox(ctx context.Context, in *CreateSandboxRequest, opts ...grpc.CallOption) (*CreateSandboxResponse, error) {
+	out := new(CreateSandboxResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CreateSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) DeployToSandbox(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error) {
+	out := new(DeployResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeployToSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) DeleteSandbox(ctx context.Context, in *DeleteSandboxRequest, opts ...grpc.CallOption) (*DeleteSandboxResponse, error) {
+	out := new(DeleteSandboxResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeleteSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) ProxyAnalytics(ctx context.Context, in *ProxyAnalyticsRequest, opts ...grpc.CallOption) (*ProxyAnalyticsResponse, error) {
+	out := new(ProxyAnalyticsResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/ProxyAnalytics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) WatchStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (Manager_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[0], "/blimp.cluster.v0.Manager/WatchStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Manager_WatchStatusClient interface {
+	Recv() (*GetStatusResponse, error)
+	grpc.ClientStream
+}
+
+type managerWatchStatusClient struct {
+	grpc.ClientStream
 }
 
 func (x *managerWatchStatusClient) Recv() (*GetStatusResponse, error) {
@@ -1134,184 +4705,864 @@ func (x *managerWatchStatusClient) Recv() (*GetStatusResponse, error) {
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return m, nil
+}
+
+func (c *managerClient) CheckVersion(ctx context.Context, in *CheckVersionRequest, opts ...grpc.CallOption) (*CheckVersionResponse, error) {
+	out := new(CheckVersionResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CheckVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetServiceHistory(ctx context.Context, in *GetServiceHistoryRequest, opts ...grpc.CallOption) (*GetServiceHistoryResponse, error) {
+	out := new(GetServiceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetServiceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) RecordSession(ctx context.Context, opts ...grpc.CallOption) (Manager_RecordSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[1], "/blimp.cluster.v0.Manager/RecordSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerRecordSessionClient{stream}
+	return x, nil
+}
+
+type Manager_RecordSessionClient interface {
+	Send(*RecordSessionRequest) error
+	CloseAndRecv() (*RecordSessionResponse, error)
+	grpc.ClientStream
+}
+
+type managerRecordSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerRecordSessionClient) Send(m *RecordSessionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *managerRecordSessionClient) CloseAndRecv() (*RecordSessionResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RecordSessionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managerClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetSessionRecording(ctx context.Context, in *GetSessionRecordingRequest, opts ...grpc.CallOption) (*GetSessionRecordingResponse, error) {
+	out := new(GetSessionRecordingResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetSessionRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) Prune(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (*PruneResponse, error) {
+	out := new(PruneResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Prune", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) WaitForCapacity(ctx context.Context, in *WaitForCapacityRequest, opts ...grpc.CallOption) (Manager_WaitForCapacityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[2], "/blimp.cluster.v0.Manager/WaitForCapacity", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerWaitForCapacityClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Manager_WaitForCapacityClient interface {
+	Recv() (*QueueStatus, error)
+	grpc.ClientStream
+}
+
+type managerWaitForCapacityClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerWaitForCapacityClient) Recv() (*QueueStatus, error) {
+	m := new(QueueStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managerClient) RenderSandbox(ctx context.Context, in *RenderSandboxRequest, opts ...grpc.CallOption) (*RenderSandboxResponse, error) {
+	out := new(RenderSandboxResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/RenderSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) WatchExposeLogs(ctx context.Context, in *WatchExposeLogsRequest, opts ...grpc.CallOption) (Manager_WatchExposeLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[3], "/blimp.cluster.v0.Manager/WatchExposeLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerWatchExposeLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Manager_WatchExposeLogsClient interface {
+	Recv() (*ExposeLogsResponse, error)
+	grpc.ClientStream
+}
+
+type managerWatchExposeLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerWatchExposeLogsClient) Recv() (*ExposeLogsResponse, error) {
+	m := new(ExposeLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managerClient) SetBootSchedule(ctx context.Context, in *SetBootScheduleRequest, opts ...grpc.CallOption) (*SetBootScheduleResponse, error) {
+	out := new(SetBootScheduleResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/SetBootSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetBootSchedule(ctx context.Context, in *GetBootScheduleRequest, opts ...grpc.CallOption) (*GetBootScheduleResponse, error) {
+	out := new(GetBootScheduleResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetBootSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) ListSandboxResources(ctx context.Context, in *ListSandboxResourcesRequest, opts ...grpc.CallOption) (*ListSandboxResourcesResponse, error) {
+	out := new(ListSandboxResourcesResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/ListSandboxResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) RestartService(ctx context.Context, in *RestartServiceRequest, opts ...grpc.CallOption) (*RestartServiceResponse, error) {
+	out := new(RestartServiceResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/RestartService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (Manager_StreamLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[4], "/blimp.cluster.v0.Manager/StreamLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerStreamLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Manager_StreamLogsClient interface {
+	Recv() (*StreamLogsResponse, error)
+	grpc.ClientStream
+}
+
+type managerStreamLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerStreamLogsClient) Recv() (*StreamLogsResponse, error) {
+	m := new(StreamLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managerClient) CloneSandbox(ctx context.Context, in *CloneSandboxRequest, opts ...grpc.CallOption) (*CloneSandboxResponse, error) {
+	out := new(CloneSandboxResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CloneSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) SetServiceEnv(ctx context.Context, in *SetServiceEnvRequest, opts ...grpc.CallOption) (*SetServiceEnvResponse, error) {
+	out := new(SetServiceEnvResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/SetServiceEnv", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) CheckDataLoss(ctx context.Context, in *CheckDataLossRequest, opts ...grpc.CallOption) (*CheckDataLossResponse, error) {
+	out := new(CheckDataLossResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CheckDataLoss", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ManagerServer is the server API for Manager service.
+type ManagerServer interface {
+	CreateSandbox(context.Context, *CreateSandboxRequest) (*CreateSandboxResponse, error)
+	DeployToSandbox(context.Context, *DeployRequest) (*DeployResponse, error)
+	DeleteSandbox(context.Context, *DeleteSandboxRequest) (*DeleteSandboxResponse, error)
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	ProxyAnalytics(context.Context, *ProxyAnalyticsRequest) (*ProxyAnalyticsResponse, error)
+	WatchStatus(*GetStatusRequest, Manager_WatchStatusServer) error
+	CheckVersion(context.Context, *CheckVersionRequest) (*CheckVersionResponse, error)
+	GetServiceHistory(context.Context, *GetServiceHistoryRequest) (*GetServiceHistoryResponse, error)
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	RecordSession(Manager_RecordSessionServer) error
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	GetSessionRecording(context.Context, *GetSessionRecordingRequest) (*GetSessionRecordingResponse, error)
+	Prune(context.Context, *PruneRequest) (*PruneResponse, error)
+	// WaitForCapacity queues the user for a sandbox when the cluster is at
+	// capacity. The manager streams the user's position in the queue until
+	// they're admitted, and then reserves capacity for the user's next
+	// CreateSandbox call.
+	WaitForCapacity(*WaitForCapacityRequest, Manager_WaitForCapacityServer) error
+	// RenderSandbox converts a Compose file into the Kubernetes objects that
+	// the manager would deploy for it. DeployToSandbox uses the same renderer,
+	// so support for new Compose features ships with the manager rather than
+	// requiring users to upgrade their CLI.
+	RenderSandbox(context.Context, *RenderSandboxRequest) (*RenderSandboxResponse, error)
+	// WatchExposeLogs streams the HTTP requests made to a service's public URL.
+	// The requests are recorded by the ingress proxy, so requests that never
+	// reach the service, such as ones that time out while it boots, are still
+	// logged.
+	WatchExposeLogs(*WatchExposeLogsRequest, Manager_WatchExposeLogsServer) error
+	// SetBootSchedule configures the manager to boot the user's sandbox on a
+	// cron schedule, by redeploying the most recently deployed Compose file
+	// and pulling its images. Sandboxes booted by the schedule are still shut
+	// down by the usual idle timeout. Setting an empty cron expression clears
+	// the schedule.
+	SetBootSchedule(context.Context, *SetBootScheduleRequest) (*SetBootScheduleResponse, error)
+	GetBootSchedule(context.Context, *GetBootScheduleRequest) (*GetBootScheduleResponse, error)
+	// ListSandboxResources returns the resources that DeleteSandbox would
+	// delete, and the resources that would be retained. It's a separate RPC
+	// rather than a dry run flag on DeleteSandbox so that managers that don't
+	// support it fail rather than deleting the sandbox.
+	ListSandboxResources(context.Context, *ListSandboxResourcesRequest) (*ListSandboxResourcesResponse, error)
+	// RestartService restarts a single service without redeploying the rest
+	// of the sandbox. The service can be restarted with temporary environment
+	// variables, which last until the sandbox is next deployed or deleted, so
+	// that they don't have to be added to the shared Compose file.
+	RestartService(context.Context, *RestartServiceRequest) (*RestartServiceResponse, error)
+	// StreamLogs streams the logs of multiple services over a single
+	// connection. It's an alternative to reading the logs directly from
+	// Kubernetes, for networks that block the Kubernetes API, and lets the
+	// manager enforce rate limits on log streaming.
+	StreamLogs(*StreamLogsRequest, Manager_StreamLogsServer) error
+	// CloneSandbox replaces the user's sandbox with a copy of a sandbox that
+	// was shared with them. The clone runs the exact images that the source
+	// sandbox is running, pinned by digest, and its volumes are restored from
+	// snapshots of the source's volumes. Bind mounts aren't synced, since the
+	// source's files are on its owner's machine.
+	CloneSandbox(context.Context, *CloneSandboxRequest) (*CloneSandboxResponse, error)
+	// SetServiceEnv sets temporary environment variables in a service. Like
+	// the overrides passed to RestartService, they last until the sandbox is
+	// next deployed or deleted. Unless the request is hot, the service is
+	// restarted so that the variables take effect.
+	SetServiceEnv(context.Context, *SetServiceEnvRequest) (*SetServiceEnvResponse, error)
+	// CheckDataLoss returns the data that deploying a Compose file would
+	// destroy, such as named volumes that would be reset because the services
+	// that use them are recreated. It doesn't modify the sandbox, so the CLI
+	// can ask the user to confirm before calling DeployToSandbox.
+	CheckDataLoss(context.Context, *CheckDataLossRequest) (*CheckDataLossResponse, error)
+}
+
+// UnimplementedManagerServer can be embedded to have forward compatible implementations.
+type UnimplementedManagerServer struct {
+}
+
+func (*UnimplementedManagerServer) CreateSandbox(ctx context.Context, req *CreateSandboxRequest) (*CreateSandboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSandbox not implemented")
+}
+func (*UnimplementedManagerServer) DeployToSandbox(ctx context.Context, req *DeployRequest) (*DeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployToSandbox not implemented")
+}
+func (*UnimplementedManagerServer) DeleteSandbox(ctx context.Context, req *DeleteSandboxRequest) (*DeleteSandboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSandbox not implemented")
+}
+func (*UnimplementedManagerServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (*UnimplementedManagerServer) ProxyAnalytics(ctx context.Context, req *ProxyAnalyticsRequest) (*ProxyAnalyticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProxyAnalytics not implemented")
+}
+func (*UnimplementedManagerServer) WatchStatus(req *GetStatusRequest, srv Manager_WatchStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
+func (*UnimplementedManagerServer) CheckVersion(ctx context.Context, req *CheckVersionRequest) (*CheckVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckVersion not implemented")
+}
+func (*UnimplementedManagerServer) GetServiceHistory(ctx context.Context, req *GetServiceHistoryRequest) (*GetServiceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceHistory not implemented")
+}
+func (*UnimplementedManagerServer) GetUsage(ctx context.Context, req *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (*UnimplementedManagerServer) RecordSession(srv Manager_RecordSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method RecordSession not implemented")
+}
+func (*UnimplementedManagerServer) ListSessions(ctx context.Context, req *ListSessionsRequest) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (*UnimplementedManagerServer) GetSessionRecording(ctx context.Context, req *GetSessionRecordingRequest) (*GetSessionRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionRecording not implemented")
+}
+func (*UnimplementedManagerServer) Prune(ctx context.Context, req *PruneRequest) (*PruneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Prune not implemented")
+}
+func (*UnimplementedManagerServer) WaitForCapacity(req *WaitForCapacityRequest, srv Manager_WaitForCapacityServer) error {
+	return status.Errorf(codes.Unimplemented, "method WaitForCapacity not implemented")
+}
+func (*UnimplementedManagerServer) RenderSandbox(ctx context.Context, req *RenderSandboxRequest) (*RenderSandboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderSandbox not implemented")
+}
+func (*UnimplementedManagerServer) WatchExposeLogs(req *WatchExposeLogsRequest, srv Manager_WatchExposeLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchExposeLogs not implemented")
+}
+func (*UnimplementedManagerServer) SetBootSchedule(ctx context.Context, req *SetBootScheduleRequest) (*SetBootScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBootSchedule not implemented")
+}
+func (*UnimplementedManagerServer) GetBootSchedule(ctx context.Context, req *GetBootScheduleRequest) (*GetBootScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBootSchedule not implemented")
+}
+func (*UnimplementedManagerServer) ListSandboxResources(ctx context.Context, req *ListSandboxResourcesRequest) (*ListSandboxResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSandboxResources not implemented")
+}
+func (*UnimplementedManagerServer) RestartService(ctx context.Context, req *RestartServiceRequest) (*RestartServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartService not implemented")
+}
+func (*UnimplementedManagerServer) StreamLogs(req *StreamLogsRequest, srv Manager_StreamLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLogs not implemented")
+}
+func (*UnimplementedManagerServer) CloneSandbox(ctx context.Context, req *CloneSandboxRequest) (*CloneSandboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneSandbox not implemented")
+}
+func (*UnimplementedManagerServer) SetServiceEnv(ctx context.Context, req *SetServiceEnvRequest) (*SetServiceEnvResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServiceEnv not implemented")
+}
+func (*UnimplementedManagerServer) CheckDataLoss(ctx context.Context, req *CheckDataLossRequest) (*CheckDataLossResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDataLoss not implemented")
+}
+
+func RegisterManagerServer(s *grpc.Server, srv ManagerServer) {
+	s.RegisterService(&_Manager_serviceDesc, srv)
+}
+
+func _Manager_CreateSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSandboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).CreateSandbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/CreateSandbox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).CreateSandbox(ctx, req.(*CreateSandboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_DeployToSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).DeployToSandbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/DeployToSandbox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).DeployToSandbox(ctx, req.(*DeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_DeleteSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSandboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).DeleteSandbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/DeleteSandbox",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).DeleteSandbox(ctx, req.(*DeleteSandboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_ProxyAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProxyAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).ProxyAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/ProxyAnalytics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).ProxyAnalytics(ctx, req.(*ProxyAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagerServer).WatchStatus(m, &managerWatchStatusServer{stream})
+}
+
+type Manager_WatchStatusServer interface {
+	Send(*GetStatusResponse) error
+	grpc.ServerStream
+}
+
+type managerWatchStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *managerWatchStatusServer) Send(m *GetStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Manager_CheckVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).CheckVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/CheckVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).CheckVersion(ctx, req.(*CheckVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *managerClient) CheckVersion(ctx context.Context, in *CheckVersionRequest, opts ...grpc.CallOption) (*CheckVersionResponse, error) {
-	out := new(CheckVersionResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CheckVersion", in, out, opts...)
-	if err != nil {
+func _Manager_GetServiceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceHistoryRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ManagerServer).GetServiceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/GetServiceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).GetServiceHistory(ctx, req.(*GetServiceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// ManagerServer is the server API for Manager service.
-type ManagerServer interface {
-	CreateSandbox(context.Context, *CreateSandboxRequest) (*CreateSandboxResponse, error)
-	DeployToSandbox(context.Context, *DeployRequest) (*DeployResponse, error)
-	DeleteSandbox(context.Context, *DeleteSandboxRequest) (*DeleteSandboxResponse, error)
-	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
-	ProxyAnalytics(context.Context, *ProxyAnalyticsRequest) (*ProxyAnalyticsResponse, error)
-	WatchStatus(*GetStatusRequest, Manager_WatchStatusServer) error
-	CheckVersion(context.Context, *CheckVersionRequest) (*CheckVersionResponse, error)
+func _Manager_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/GetUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedManagerServer can be embedded to have forward compatible implementations.
-type UnimplementedManagerServer struct {
+func _Manager_RecordSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ManagerServer).RecordSession(&managerRecordSessionServer{stream})
 }
 
-func (*UnimplementedManagerServer) CreateSandbox(ctx context.Context, req *CreateSandboxRequest) (*CreateSandboxResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateSandbox not implemented")
+type Manager_RecordSessionServer interface {
+	SendAndClose(*RecordSessionResponse) error
+	Recv() (*RecordSessionRequest, error)
+	grpc.ServerStream
 }
-func (*UnimplementedManagerServer) DeployToSandbox(ctx context.Context, req *DeployRequest) (*DeployResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeployToSandbox not implemented")
+
+type managerRecordSessionServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedManagerServer) DeleteSandbox(ctx context.Context, req *DeleteSandboxRequest) (*DeleteSandboxResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteSandbox not implemented")
+
+func (x *managerRecordSessionServer) SendAndClose(m *RecordSessionResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedManagerServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+
+func (x *managerRecordSessionServer) Recv() (*RecordSessionRequest, error) {
+	m := new(RecordSessionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (*UnimplementedManagerServer) ProxyAnalytics(ctx context.Context, req *ProxyAnalyticsRequest) (*ProxyAnalyticsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ProxyAnalytics not implemented")
+
+func _Manager_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedManagerServer) WatchStatus(req *GetStatusRequest, srv Manager_WatchStatusServer) error {
-	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+
+func _Manager_GetSessionRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).GetSessionRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/GetSessionRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).GetSessionRecording(ctx, req.(*GetSessionRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedManagerServer) CheckVersion(ctx context.Context, req *CheckVersionRequest) (*CheckVersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CheckVersion not implemented")
+
+func _Manager_Prune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).Prune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/Prune",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).Prune(ctx, req.(*PruneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterManagerServer(s *grpc.Server, srv ManagerServer) {
-	s.RegisterService(&_Manager_serviceDesc, srv)
+func _Manager_WaitForCapacity_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WaitForCapacityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagerServer).WaitForCapacity(m, &managerWaitForCapacityServer{stream})
 }
 
-func _Manager_CreateSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateSandboxRequest)
+type Manager_WaitForCapacityServer interface {
+	Send(*QueueStatus) error
+	grpc.ServerStream
+}
+
+type managerWaitForCapacityServer struct {
+	grpc.ServerStream
+}
+
+func (x *managerWaitForCapacityServer) Send(m *QueueStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Manager_RenderSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderSandboxRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ManagerServer).CreateSandbox(ctx, in)
+		return srv.(ManagerServer).RenderSandbox(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blimp.cluster.v0.Manager/CreateSandbox",
+		FullMethod: "/blimp.cluster.v0.Manager/RenderSandbox",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ManagerServer).CreateSandbox(ctx, req.(*CreateSandboxRequest))
+		return srv.(ManagerServer).RenderSandbox(ctx, req.(*RenderSandboxRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Manager_DeployToSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeployRequest)
+func _Manager_WatchExposeLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchExposeLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagerServer).WatchExposeLogs(m, &managerWatchExposeLogsServer{stream})
+}
+
+type Manager_WatchExposeLogsServer interface {
+	Send(*ExposeLogsResponse) error
+	grpc.ServerStream
+}
+
+type managerWatchExposeLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *managerWatchExposeLogsServer) Send(m *ExposeLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Manager_SetBootSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBootScheduleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ManagerServer).DeployToSandbox(ctx, in)
+		return srv.(ManagerServer).SetBootSchedule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blimp.cluster.v0.Manager/DeployToSandbox",
+		FullMethod: "/blimp.cluster.v0.Manager/SetBootSchedule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ManagerServer).DeployToSandbox(ctx, req.(*DeployRequest))
+		return srv.(ManagerServer).SetBootSchedule(ctx, req.(*SetBootScheduleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Manager_DeleteSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteSandboxRequest)
+func _Manager_GetBootSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBootScheduleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ManagerServer).DeleteSandbox(ctx, in)
+		return srv.(ManagerServer).GetBootSchedule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blimp.cluster.v0.Manager/DeleteSandbox",
+		FullMethod: "/blimp.cluster.v0.Manager/GetBootSchedule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ManagerServer).DeleteSandbox(ctx, req.(*DeleteSandboxRequest))
+		return srv.(ManagerServer).GetBootSchedule(ctx, req.(*GetBootScheduleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Manager_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetStatusRequest)
+func _Manager_ListSandboxResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSandboxResourcesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ManagerServer).GetStatus(ctx, in)
+		return srv.(ManagerServer).ListSandboxResources(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blimp.cluster.v0.Manager/GetStatus",
+		FullMethod: "/blimp.cluster.v0.Manager/ListSandboxResources",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ManagerServer).GetStatus(ctx, req.(*GetStatusRequest))
+		return srv.(ManagerServer).ListSandboxResources(ctx, req.(*ListSandboxResourcesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Manager_ProxyAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProxyAnalyticsRequest)
+func _Manager_RestartService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartServiceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ManagerServer).ProxyAnalytics(ctx, in)
+		return srv.(ManagerServer).RestartService(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blimp.cluster.v0.Manager/ProxyAnalytics",
+		FullMethod: "/blimp.cluster.v0.Manager/RestartService",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ManagerServer).ProxyAnalytics(ctx, req.(*ProxyAnalyticsRequest))
+		return srv.(ManagerServer).RestartService(ctx, req.(*RestartServiceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Manager_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetStatusRequest)
+func _Manager_StreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLogsRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ManagerServer).WatchStatus(m, &managerWatchStatusServer{stream})
+	return srv.(ManagerServer).StreamLogs(m, &managerStreamLogsServer{stream})
 }
 
-type Manager_WatchStatusServer interface {
-	Send(*GetStatusResponse) error
+type Manager_StreamLogsServer interface {
+	Send(*StreamLogsResponse) error
 	grpc.ServerStream
 }
 
-type managerWatchStatusServer struct {
+type managerStreamLogsServer struct {
 	grpc.ServerStream
 }
 
-func (x *managerWatchStatusServer) Send(m *GetStatusResponse) error {
+func (x *managerStreamLogsServer) Send(m *StreamLogsResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Manager_CheckVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckVersionRequest)
+func _Manager_CloneSandbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneSandboxRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ManagerServer).CheckVersion(ctx, in)
+		return srv.(ManagerServer).CloneSandbox(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/blimp.cluster.v0.Manager/CheckVersion",
+		FullMethod: "/blimp.cluster.v0.Manager/CloneSandbox",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ManagerServer).CheckVersion(ctx, req.(*CheckVersionRequest))
+		return srv.(ManagerServer).CloneSandbox(ctx, req.(*CloneSandboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_SetServiceEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetServiceEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).SetServiceEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/SetServiceEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).SetServiceEnv(ctx, req.(*SetServiceEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_CheckDataLoss_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDataLossRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).CheckDataLoss(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/CheckDataLoss",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).CheckDataLoss(ctx, req.(*CheckDataLossRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1344,6 +5595,58 @@ var _Manager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckVersion",
 			Handler:    _Manager_CheckVersion_Handler,
 		},
+		{
+			MethodName: "GetServiceHistory",
+			Handler:    _Manager_GetServiceHistory_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _Manager_GetUsage_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Manager_ListSessions_Handler,
+		},
+		{
+			MethodName: "GetSessionRecording",
+			Handler:    _Manager_GetSessionRecording_Handler,
+		},
+		{
+			MethodName: "Prune",
+			Handler:    _Manager_Prune_Handler,
+		},
+		{
+			MethodName: "RenderSandbox",
+			Handler:    _Manager_RenderSandbox_Handler,
+		},
+		{
+			MethodName: "SetBootSchedule",
+			Handler:    _Manager_SetBootSchedule_Handler,
+		},
+		{
+			MethodName: "GetBootSchedule",
+			Handler:    _Manager_GetBootSchedule_Handler,
+		},
+		{
+			MethodName: "ListSandboxResources",
+			Handler:    _Manager_ListSandboxResources_Handler,
+		},
+		{
+			MethodName: "RestartService",
+			Handler:    _Manager_RestartService_Handler,
+		},
+		{
+			MethodName: "CloneSandbox",
+			Handler:    _Manager_CloneSandbox_Handler,
+		},
+		{
+			MethodName: "SetServiceEnv",
+			Handler:    _Manager_SetServiceEnv_Handler,
+		},
+		{
+			MethodName: "CheckDataLoss",
+			Handler:    _Manager_CheckDataLoss_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1351,6 +5654,26 @@ var _Manager_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Manager_WatchStatus_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "RecordSession",
+			Handler:       _Manager_RecordSession_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WaitForCapacity",
+			Handler:       _Manager_WaitForCapacity_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchExposeLogs",
+			Handler:       _Manager_WatchExposeLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamLogs",
+			Handler:       _Manager_StreamLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "blimp/cluster/v0/manager.proto",
 }
@@ -184,8 +184,12 @@ func (m *LoginInstructions) GetURL() string {
 }
 
 type LoginResult struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// A refresh token for getting new tokens once `token` expires, so that
+	// long-running commands keep working without logging in again. It's
+	// empty if the login proxy doesn't support refreshing tokens.
+	RefreshToken         string   `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -230,6 +234,13 @@ func (m *LoginResult) GetError() string {
 	return ""
 }
 
+func (m *LoginResult) GetRefreshToken() string {
+	if m != nil {
+		return m.RefreshToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*LoginRequest)(nil), "blimp.login.v0.LoginRequest")
 	proto.RegisterType((*LoginResponse)(nil), "blimp.login.v0.LoginResponse")
@@ -242,26 +253,27 @@ func init() {
 }
 
 var fileDescriptor_fddd5c19a272a170 = []byte{
-	// 298 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x4f, 0x4b, 0xf3, 0x40,
-	0x10, 0xc6, 0x9b, 0x86, 0x14, 0xde, 0x69, 0xdf, 0xa2, 0x8b, 0x48, 0x88, 0x15, 0x34, 0xa0, 0xf4,
-	0x20, 0x9b, 0x50, 0xf1, 0xe0, 0xb5, 0x20, 0x46, 0xe8, 0xc5, 0x05, 0x2f, 0xde, 0x4c, 0x5d, 0x62,
-	0xc8, 0x9f, 0x8d, 0xbb, 0x9b, 0x7c, 0x42, 0x3f, 0x98, 0x64, 0x26, 0x42, 0x8b, 0xf6, 0xf6, 0xcc,
-	0xe4, 0xf7, 0x3c, 0x3b, 0x99, 0x81, 0x20, 0x2d, 0xf3, 0xaa, 0x89, 0x4a, 0x95, 0xe5, 0x75, 0xd4,
-	0xc5, 0x24, 0x78, 0xa3, 0x95, 0x55, 0x6c, 0x8e, 0xdf, 0x38, 0xb5, 0xba, 0x38, 0x58, 0x10, 0x2b,
-	0xb5, 0x56, 0xda, 0xf4, 0x30, 0x29, 0xa2, 0xc3, 0x39, 0xcc, 0x36, 0x3d, 0x29, 0xe4, 0x67, 0x2b,
-	0x8d, 0x0d, 0xbf, 0x1c, 0xf8, 0x3f, 0x34, 0x4c, 0xa3, 0x6a, 0x23, 0xd9, 0x0d, 0x78, 0xe8, 0xf0,
-	0x9d, 0x0b, 0x67, 0x39, 0x5d, 0x9d, 0x72, 0xca, 0x1f, 0x52, 0xba, 0x98, 0x3f, 0xf4, 0x4a, 0x10,
-	0xc4, 0x1e, 0x61, 0x96, 0xd7, 0xc6, 0xea, 0x76, 0x6b, 0x73, 0x55, 0x1b, 0x7f, 0x8c, 0xa6, 0x4b,
-	0xbe, 0x3f, 0x14, 0xc7, 0x27, 0x9e, 0x76, 0xc0, 0x64, 0x24, 0xf6, 0x8c, 0xec, 0x0e, 0x26, 0x5a,
-	0x9a, 0xb6, 0xb4, 0xbe, 0x8b, 0x11, 0x67, 0x7f, 0x46, 0x08, 0x44, 0x92, 0x91, 0x18, 0xe0, 0xb5,
-	0x07, 0x6e, 0x65, 0xb2, 0xf0, 0x0a, 0x8e, 0x7f, 0x3d, 0xc1, 0x8e, 0xc0, 0x7d, 0x11, 0x1b, 0xfc,
-	0x8f, 0x7f, 0xa2, 0x97, 0xe1, 0x3d, 0x4c, 0x77, 0x62, 0xd8, 0x09, 0x78, 0x56, 0x15, 0xb2, 0x1e,
-	0x10, 0x2a, 0xfa, 0x2e, 0x2d, 0x60, 0x4c, 0x5d, 0x2c, 0x56, 0xcf, 0xe0, 0xa1, 0x95, 0x25, 0x3f,
-	0x62, 0x71, 0x60, 0x42, 0x5c, 0x6c, 0x70, 0x7e, 0x68, 0x7e, 0xdc, 0x72, 0x38, 0x8a, 0x9d, 0xf5,
-	0xf2, 0xf5, 0x3a, 0xcb, 0xed, 0x47, 0x9b, 0xf2, 0xad, 0xaa, 0xa2, 0x42, 0x96, 0xef, 0x6f, 0x11,
-	0x1d, 0xaf, 0x29, 0xb2, 0x08, 0xef, 0x45, 0x97, 0x4e, 0x27, 0x58, 0xdc, 0x7e, 0x07, 0x00, 0x00,
-	0xff, 0xff, 0x41, 0x4a, 0x79, 0x62, 0x08, 0x02, 0x00, 0x00,
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x75, 0x52, 0xc1, 0x4a, 0xc3, 0x40,
+	0x10, 0x6d, 0x1a, 0x52, 0x70, 0x9a, 0x16, 0x5d, 0x44, 0x4a, 0xac, 0xa0, 0x11, 0xa5, 0x07, 0xd9,
+	0x84, 0x8a, 0x3f, 0x50, 0x10, 0x2b, 0xf4, 0x62, 0xd0, 0x8b, 0x07, 0xc5, 0xd4, 0x35, 0x0d, 0x4d,
+	0xb3, 0xe9, 0xee, 0xa6, 0x5f, 0xe8, 0x87, 0x99, 0xcc, 0x44, 0x68, 0xd1, 0xde, 0xde, 0xbe, 0x79,
+	0xef, 0xcd, 0xce, 0xce, 0x82, 0x17, 0x67, 0xe9, 0xaa, 0x08, 0x32, 0x99, 0xa4, 0x79, 0xb0, 0x09,
+	0x09, 0xf0, 0x42, 0x49, 0x23, 0x59, 0x1f, 0x6b, 0x9c, 0xa8, 0x4d, 0xe8, 0x0d, 0x49, 0x2b, 0x94,
+	0x92, 0x4a, 0xd7, 0x62, 0x42, 0xa4, 0xf6, 0xfb, 0xe0, 0xce, 0x6a, 0x65, 0x24, 0xd6, 0xa5, 0xd0,
+	0xc6, 0xff, 0xb6, 0xa0, 0xd7, 0x10, 0xba, 0x90, 0xb9, 0x16, 0xec, 0x06, 0x1c, 0x74, 0x0c, 0xac,
+	0x73, 0x6b, 0xd4, 0x1d, 0x9f, 0x70, 0xca, 0x6f, 0x52, 0x36, 0x21, 0xbf, 0xaf, 0x51, 0x44, 0x22,
+	0xf6, 0x00, 0x6e, 0x9a, 0x6b, 0xa3, 0xca, 0xb9, 0x49, 0x2b, 0xfb, 0xa0, 0x8d, 0xa6, 0x0b, 0xbe,
+	0x7b, 0x29, 0x8e, 0x2d, 0x1e, 0xb7, 0x84, 0xd3, 0x56, 0xb4, 0x63, 0x64, 0x77, 0xd0, 0x51, 0x42,
+	0x97, 0x99, 0x19, 0xd8, 0x18, 0x71, 0xfa, 0x6f, 0x44, 0x84, 0x92, 0xca, 0xdc, 0x88, 0x27, 0x0e,
+	0xd8, 0x2b, 0x9d, 0xf8, 0x57, 0x70, 0xf4, 0xa7, 0x05, 0x3b, 0x04, 0xfb, 0x25, 0x9a, 0xe1, 0x1c,
+	0x07, 0x51, 0x0d, 0xfd, 0x37, 0xe8, 0x6e, 0xc5, 0xb0, 0x63, 0x70, 0x8c, 0x5c, 0x8a, 0xbc, 0x91,
+	0xd0, 0xa1, 0x66, 0xe9, 0x01, 0xda, 0xc4, 0xd2, 0xa0, 0x97, 0xd0, 0x53, 0xe2, 0xab, 0xea, 0xba,
+	0x78, 0x27, 0x8f, 0x8d, 0x55, 0xb7, 0x21, 0x9f, 0x6b, 0x6e, 0xfc, 0x04, 0x0e, 0xe6, 0xb3, 0xe9,
+	0x2f, 0x18, 0xee, 0x19, 0x03, 0x5f, 0xdf, 0x3b, 0xdb, 0x37, 0x24, 0xae, 0xc2, 0x6f, 0x85, 0xd6,
+	0x64, 0xf4, 0x7a, 0x9d, 0xa4, 0x66, 0x51, 0xc6, 0x7c, 0x2e, 0x57, 0xc1, 0x52, 0x64, 0x9f, 0x1f,
+	0x01, 0x6d, 0xb8, 0x58, 0x26, 0x01, 0x2e, 0x95, 0xbe, 0x43, 0xdc, 0xc1, 0xc3, 0xed, 0x0f, 0xef,
+	0xff, 0xba, 0x24, 0x2d, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -25,10 +25,46 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ImageCacheStatus int32
+
+const (
+	// The image wasn't pulled through the cache, such as because it's from a
+	// private registry, or the cluster doesn't run a cache.
+	ImageCacheStatus_UNCACHED ImageCacheStatus = 0
+	// The cache already had the image.
+	ImageCacheStatus_HIT ImageCacheStatus = 1
+	// The cache fetched the image from the upstream registry.
+	ImageCacheStatus_MISS ImageCacheStatus = 2
+)
+
+var ImageCacheStatus_name = map[int32]string{
+	0: "UNCACHED",
+	1: "HIT",
+	2: "MISS",
+}
+
+var ImageCacheStatus_value = map[string]int32{
+	"UNCACHED": 0,
+	"HIT":      1,
+	"MISS":     2,
+}
+
+func (x ImageCacheStatus) String() string {
+	return proto.EnumName(ImageCacheStatus_name, int32(x))
+}
+
+func (ImageCacheStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{0}
+}
+
 type TunnelHeader struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
-	Token                string   `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Port  uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// If set, the tunnel carries a connection from the sandbox to a local
+	// service, rather than a connection to a service in the sandbox. See
+	// ExposeLocal.
+	ConnectionId         string   `protobuf:"bytes,4,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -80,6 +116,13 @@ func (m *TunnelHeader) GetToken() string {
 	return ""
 }
 
+func (m *TunnelHeader) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
 type EOF struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -111,15 +154,60 @@ func (m *EOF) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_EOF proto.InternalMessageInfo
 
+// TunnelAuth replaces the credentials used to authenticate a tunnel. Clients
+// periodically send fresh credentials on long-lived tunnels. When a tunnel's
+// credentials expire, the server sends a TunnelAuth without a token to ask the
+// client to re-authenticate, and closes the tunnel if it doesn't.
+type TunnelAuth struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TunnelAuth) Reset()         { *m = TunnelAuth{} }
+func (m *TunnelAuth) String() string { return proto.CompactTextString(m) }
+func (*TunnelAuth) ProtoMessage()    {}
+func (*TunnelAuth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{2}
+}
+
+func (m *TunnelAuth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TunnelAuth.Unmarshal(m, b)
+}
+func (m *TunnelAuth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TunnelAuth.Marshal(b, m, deterministic)
+}
+func (m *TunnelAuth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TunnelAuth.Merge(m, src)
+}
+func (m *TunnelAuth) XXX_Size() int {
+	return xxx_messageInfo_TunnelAuth.Size(m)
+}
+func (m *TunnelAuth) XXX_DiscardUnknown() {
+	xxx_messageInfo_TunnelAuth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TunnelAuth proto.InternalMessageInfo
+
+func (m *TunnelAuth) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
 // The first message the Client sends to the server must be a header.  After
 // that all messages either direction must be bufs.  Optionally, either
-// direction may send an EOF to indicate they have no more to send.
+// direction may send an EOF to indicate they have no more to send.  The
+// client may also send an auth message at any time to rotate its credentials.
 type TunnelMsg struct {
 	// Types that are valid to be assigned to Msg:
 	//	*TunnelMsg_Error
 	//	*TunnelMsg_Header
 	//	*TunnelMsg_Buf
 	//	*TunnelMsg_Eof
+	//	*TunnelMsg_Auth
 	Msg                  isTunnelMsg_Msg `protobuf_oneof:"msg"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
@@ -130,7 +218,7 @@ func (m *TunnelMsg) Reset()         { *m = TunnelMsg{} }
 func (m *TunnelMsg) String() string { return proto.CompactTextString(m) }
 func (*TunnelMsg) ProtoMessage()    {}
 func (*TunnelMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ffe3c8ce6343e9a1, []int{2}
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{3}
 }
 
 func (m *TunnelMsg) XXX_Unmarshal(b []byte) error {
@@ -171,6 +259,10 @@ type TunnelMsg_Eof struct {
 	Eof *EOF `protobuf:"bytes,4,opt,name=eof,proto3,oneof"`
 }
 
+type TunnelMsg_Auth struct {
+	Auth *TunnelAuth `protobuf:"bytes,5,opt,name=auth,proto3,oneof"`
+}
+
 func (*TunnelMsg_Error) isTunnelMsg_Msg() {}
 
 func (*TunnelMsg_Header) isTunnelMsg_Msg() {}
@@ -179,6 +271,8 @@ func (*TunnelMsg_Buf) isTunnelMsg_Msg() {}
 
 func (*TunnelMsg_Eof) isTunnelMsg_Msg() {}
 
+func (*TunnelMsg_Auth) isTunnelMsg_Msg() {}
+
 func (m *TunnelMsg) GetMsg() isTunnelMsg_Msg {
 	if m != nil {
 		return m.Msg
@@ -214,6 +308,13 @@ func (m *TunnelMsg) GetEof() *EOF {
 	return nil
 }
 
+func (m *TunnelMsg) GetAuth() *TunnelAuth {
+	if x, ok := m.GetMsg().(*TunnelMsg_Auth); ok {
+		return x.Auth
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*TunnelMsg) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -221,6 +322,7 @@ func (*TunnelMsg) XXX_OneofWrappers() []interface{} {
 		(*TunnelMsg_Header)(nil),
 		(*TunnelMsg_Buf)(nil),
 		(*TunnelMsg_Eof)(nil),
+		(*TunnelMsg_Auth)(nil),
 	}
 }
 
@@ -238,7 +340,7 @@ func (m *SyncStatusResponse) Reset()         { *m = SyncStatusResponse{} }
 func (m *SyncStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*SyncStatusResponse) ProtoMessage()    {}
 func (*SyncStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ffe3c8ce6343e9a1, []int{3}
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{4}
 }
 
 func (m *SyncStatusResponse) XXX_Unmarshal(b []byte) error {
@@ -314,7 +416,7 @@ func (m *GetSyncStatusRequest) Reset()         { *m = GetSyncStatusRequest{} }
 func (m *GetSyncStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetSyncStatusRequest) ProtoMessage()    {}
 func (*GetSyncStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ffe3c8ce6343e9a1, []int{4}
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{5}
 }
 
 func (m *GetSyncStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -335,12 +437,266 @@ func (m *GetSyncStatusRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetSyncStatusRequest proto.InternalMessageInfo
 
+type WatchImagePullsRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchImagePullsRequest) Reset()         { *m = WatchImagePullsRequest{} }
+func (m *WatchImagePullsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchImagePullsRequest) ProtoMessage()    {}
+func (*WatchImagePullsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{6}
+}
+
+func (m *WatchImagePullsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchImagePullsRequest.Unmarshal(m, b)
+}
+func (m *WatchImagePullsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchImagePullsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchImagePullsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchImagePullsRequest.Merge(m, src)
+}
+func (m *WatchImagePullsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchImagePullsRequest.Size(m)
+}
+func (m *WatchImagePullsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchImagePullsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchImagePullsRequest proto.InternalMessageInfo
+
+func (m *WatchImagePullsRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// ImagePullProgress is the progress of pulling the image for a service, as
+// reported by the container runtime on the node.
+type ImagePullProgress struct {
+	Service     string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Image       string `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	LayersTotal int32  `protobuf:"varint,3,opt,name=layers_total,json=layersTotal,proto3" json:"layers_total,omitempty"`
+	LayersDone  int32  `protobuf:"varint,4,opt,name=layers_done,json=layersDone,proto3" json:"layers_done,omitempty"`
+	// The total size of the image's layers, and the number of bytes that have
+	// been downloaded so far. Layers that were already cached on the node count
+	// as downloaded.
+	BytesTotal int64 `protobuf:"varint,5,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	BytesDone  int64 `protobuf:"varint,6,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	// Done is set once the image has been completely pulled.
+	Done bool `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+	// Whether the image was served by the cluster's pull-through cache. The
+	// node controller pulls public images through the cache, so that each
+	// image is only fetched from the upstream registry once per cluster.
+	CacheStatus          ImageCacheStatus `protobuf:"varint,8,opt,name=cache_status,json=cacheStatus,proto3,enum=blimp.node.v0.ImageCacheStatus" json:"cache_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ImagePullProgress) Reset()         { *m = ImagePullProgress{} }
+func (m *ImagePullProgress) String() string { return proto.CompactTextString(m) }
+func (*ImagePullProgress) ProtoMessage()    {}
+func (*ImagePullProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{7}
+}
+
+func (m *ImagePullProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImagePullProgress.Unmarshal(m, b)
+}
+func (m *ImagePullProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImagePullProgress.Marshal(b, m, deterministic)
+}
+func (m *ImagePullProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImagePullProgress.Merge(m, src)
+}
+func (m *ImagePullProgress) XXX_Size() int {
+	return xxx_messageInfo_ImagePullProgress.Size(m)
+}
+func (m *ImagePullProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImagePullProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImagePullProgress proto.InternalMessageInfo
+
+func (m *ImagePullProgress) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ImagePullProgress) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *ImagePullProgress) GetLayersTotal() int32 {
+	if m != nil {
+		return m.LayersTotal
+	}
+	return 0
+}
+
+func (m *ImagePullProgress) GetLayersDone() int32 {
+	if m != nil {
+		return m.LayersDone
+	}
+	return 0
+}
+
+func (m *ImagePullProgress) GetBytesTotal() int64 {
+	if m != nil {
+		return m.BytesTotal
+	}
+	return 0
+}
+
+func (m *ImagePullProgress) GetBytesDone() int64 {
+	if m != nil {
+		return m.BytesDone
+	}
+	return 0
+}
+
+func (m *ImagePullProgress) GetDone() bool {
+	if m != nil {
+		return m.Done
+	}
+	return false
+}
+
+func (m *ImagePullProgress) GetCacheStatus() ImageCacheStatus {
+	if m != nil {
+		return m.CacheStatus
+	}
+	return ImageCacheStatus_UNCACHED
+}
+
+type ExposeLocalRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The name of the Compose service that's running locally.
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// The ports that the local service listens on.
+	Ports                []uint32 `protobuf:"varint,3,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExposeLocalRequest) Reset()         { *m = ExposeLocalRequest{} }
+func (m *ExposeLocalRequest) String() string { return proto.CompactTextString(m) }
+func (*ExposeLocalRequest) ProtoMessage()    {}
+func (*ExposeLocalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{8}
+}
+
+func (m *ExposeLocalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExposeLocalRequest.Unmarshal(m, b)
+}
+func (m *ExposeLocalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExposeLocalRequest.Marshal(b, m, deterministic)
+}
+func (m *ExposeLocalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExposeLocalRequest.Merge(m, src)
+}
+func (m *ExposeLocalRequest) XXX_Size() int {
+	return xxx_messageInfo_ExposeLocalRequest.Size(m)
+}
+func (m *ExposeLocalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExposeLocalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExposeLocalRequest proto.InternalMessageInfo
+
+func (m *ExposeLocalRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ExposeLocalRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ExposeLocalRequest) GetPorts() []uint32 {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+// LocalConnection is a connection from the sandbox to a local service.
+type LocalConnection struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LocalConnection) Reset()         { *m = LocalConnection{} }
+func (m *LocalConnection) String() string { return proto.CompactTextString(m) }
+func (*LocalConnection) ProtoMessage()    {}
+func (*LocalConnection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{9}
+}
+
+func (m *LocalConnection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalConnection.Unmarshal(m, b)
+}
+func (m *LocalConnection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalConnection.Marshal(b, m, deterministic)
+}
+func (m *LocalConnection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalConnection.Merge(m, src)
+}
+func (m *LocalConnection) XXX_Size() int {
+	return xxx_messageInfo_LocalConnection.Size(m)
+}
+func (m *LocalConnection) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalConnection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalConnection proto.InternalMessageInfo
+
+func (m *LocalConnection) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LocalConnection) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("blimp.node.v0.ImageCacheStatus", ImageCacheStatus_name, ImageCacheStatus_value)
 	proto.RegisterType((*TunnelHeader)(nil), "blimp.node.v0.TunnelHeader")
 	proto.RegisterType((*EOF)(nil), "blimp.node.v0.EOF")
+	proto.RegisterType((*TunnelAuth)(nil), "blimp.node.v0.TunnelAuth")
 	proto.RegisterType((*TunnelMsg)(nil), "blimp.node.v0.TunnelMsg")
 	proto.RegisterType((*SyncStatusResponse)(nil), "blimp.node.v0.SyncStatusResponse")
 	proto.RegisterType((*GetSyncStatusRequest)(nil), "blimp.node.v0.GetSyncStatusRequest")
+	proto.RegisterType((*WatchImagePullsRequest)(nil), "blimp.node.v0.WatchImagePullsRequest")
+	proto.RegisterType((*ImagePullProgress)(nil), "blimp.node.v0.ImagePullProgress")
+	proto.RegisterType((*ExposeLocalRequest)(nil), "blimp.node.v0.ExposeLocalRequest")
+	proto.RegisterType((*LocalConnection)(nil), "blimp.node.v0.LocalConnection")
 }
 
 func init() {
@@ -348,32 +704,53 @@ func init() {
 }
 
 var fileDescriptor_ffe3c8ce6343e9a1 = []byte{
-	// 395 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0x71, 0x13, 0xd1, 0x69, 0x7b, 0x60, 0x54, 0x45, 0x56, 0x40, 0xa8, 0x18, 0x01, 0x39,
-	0xad, 0xa3, 0x20, 0xfe, 0x40, 0x50, 0x4a, 0x0e, 0x84, 0x4a, 0x2e, 0x27, 0x6e, 0xfe, 0x18, 0xbb,
-	0x56, 0xec, 0x5d, 0xb3, 0xbb, 0x8e, 0xd4, 0xdf, 0x85, 0xf8, 0x7f, 0x68, 0x67, 0x5b, 0x54, 0x42,
-	0x7b, 0x7b, 0xe3, 0x79, 0xf3, 0xe6, 0x3d, 0xef, 0xc0, 0xeb, 0xbc, 0x6d, 0xba, 0x3e, 0x91, 0xaa,
-	0xa4, 0x64, 0xbf, 0x48, 0x0a, 0x25, 0xad, 0x56, 0x6d, 0x4b, 0x5a, 0xf4, 0x5a, 0x59, 0x85, 0x67,
-	0xdc, 0x17, 0xae, 0x2f, 0xf6, 0x8b, 0xd9, 0x2b, 0x4f, 0x27, 0xad, 0x95, 0x36, 0x6e, 0xc0, 0x23,
-	0x4f, 0x8e, 0xbf, 0xc2, 0xe9, 0xf7, 0x41, 0x4a, 0x6a, 0x37, 0x94, 0x95, 0xa4, 0x11, 0xe1, 0x48,
-	0x66, 0x1d, 0x45, 0xc1, 0x45, 0x30, 0x3f, 0x4e, 0x19, 0xbb, 0x6f, 0xbd, 0xd2, 0x36, 0x7a, 0x76,
-	0x11, 0xcc, 0xcf, 0x52, 0xc6, 0x78, 0x0e, 0x63, 0xab, 0x76, 0x24, 0xa3, 0x90, 0x89, 0xbe, 0x88,
-	0xc7, 0x10, 0xae, 0xaf, 0x2e, 0xe3, 0xdf, 0x01, 0x1c, 0x7b, 0xd5, 0xad, 0xa9, 0x51, 0xc0, 0x98,
-	0x57, 0xb2, 0xe6, 0xc9, 0x72, 0x2a, 0xbc, 0xbf, 0x3b, 0x1b, 0xfb, 0x85, 0x58, 0x3b, 0xb4, 0x19,
-	0xa5, 0x9e, 0x86, 0x9f, 0x60, 0x72, 0xc3, 0x66, 0x78, 0xe1, 0xc9, 0xf2, 0xa5, 0xf8, 0x27, 0x90,
-	0x78, 0xe8, 0x77, 0x33, 0x4a, 0xef, 0xc8, 0x88, 0x10, 0xe6, 0x43, 0xc5, 0x7e, 0x4e, 0x37, 0xa3,
-	0xd4, 0x15, 0xf8, 0x1e, 0x42, 0x52, 0x55, 0x74, 0xc4, 0x3a, 0x78, 0xa0, 0xb3, 0xbe, 0xba, 0x74,
-	0x3c, 0x52, 0xd5, 0x6a, 0x0c, 0x61, 0x67, 0xea, 0x78, 0x0b, 0x78, 0x7d, 0x2b, 0x8b, 0x6b, 0x9b,
-	0xd9, 0xc1, 0xa4, 0x64, 0x7a, 0x25, 0x0d, 0xe1, 0xf4, 0x3e, 0x2a, 0xff, 0x13, 0xe7, 0x93, 0x4b,
-	0x8c, 0x60, 0x62, 0x6e, 0x65, 0x41, 0x25, 0xfb, 0x7c, 0xee, 0xac, 0xf8, 0xfa, 0x5e, 0x6e, 0x0a,
-	0xe7, 0x5f, 0xc8, 0x3e, 0x54, 0xfc, 0x39, 0x90, 0xb1, 0xcb, 0x5f, 0x01, 0xc0, 0xe7, 0xbf, 0xaf,
-	0x86, 0x2b, 0x98, 0xf8, 0x48, 0x18, 0x3d, 0x9a, 0x74, 0x6b, 0xea, 0xd9, 0x93, 0x9d, 0x78, 0x34,
-	0x0f, 0x16, 0x01, 0x66, 0xf0, 0xc2, 0xed, 0xf9, 0xa6, 0x6c, 0x53, 0x35, 0x45, 0x66, 0x1b, 0x25,
-	0x0d, 0xbe, 0x39, 0x18, 0xfa, 0x3f, 0xdb, 0xec, 0xed, 0x01, 0xe5, 0x31, 0xbf, 0x7e, 0xc5, 0xea,
-	0xc3, 0x8f, 0x77, 0x75, 0x63, 0x6f, 0x86, 0x5c, 0x14, 0xaa, 0x4b, 0x76, 0xd4, 0x96, 0x59, 0xe2,
-	0x4f, 0xab, 0xdf, 0xd5, 0x09, 0x5f, 0x13, 0xdf, 0x64, 0x3e, 0x61, 0xfc, 0xf1, 0x4f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x16, 0xba, 0x0e, 0x2f, 0xa8, 0x02, 0x00, 0x00,
+	// 725 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xdd, 0x6e, 0xea, 0x46,
+	0x10, 0xc6, 0x18, 0x08, 0x0c, 0x70, 0x0e, 0x67, 0x14, 0x21, 0x97, 0xb6, 0x09, 0x71, 0x94, 0x16,
+	0xf5, 0xc2, 0x20, 0xa2, 0x3c, 0x40, 0x20, 0xa4, 0x20, 0x95, 0x24, 0x32, 0x54, 0x95, 0x72, 0x51,
+	0x64, 0xec, 0x05, 0xac, 0x18, 0x2f, 0xf1, 0xae, 0x51, 0x79, 0x9a, 0xbe, 0x5d, 0xa5, 0xbe, 0x45,
+	0xb5, 0xbb, 0x90, 0x10, 0x43, 0x7a, 0x37, 0x3f, 0xdf, 0x7e, 0x3b, 0xf3, 0xcd, 0xec, 0xc2, 0xd9,
+	0x34, 0xf0, 0x97, 0xab, 0x66, 0x48, 0x3d, 0xd2, 0x5c, 0xb7, 0x9a, 0x2e, 0x0d, 0x79, 0x44, 0x83,
+	0x80, 0x44, 0xd6, 0x2a, 0xa2, 0x9c, 0x62, 0x59, 0xe6, 0x2d, 0x91, 0xb7, 0xd6, 0xad, 0xda, 0x0f,
+	0x0a, 0x4e, 0xa2, 0x88, 0x46, 0x4c, 0x1c, 0x50, 0x96, 0x02, 0x9b, 0xaf, 0x50, 0x1a, 0xc7, 0x61,
+	0x48, 0x82, 0x3e, 0x71, 0x3c, 0x12, 0x21, 0x42, 0x26, 0x74, 0x96, 0xc4, 0xd0, 0xea, 0x5a, 0xa3,
+	0x60, 0x4b, 0x5b, 0xc4, 0x56, 0x34, 0xe2, 0x46, 0xba, 0xae, 0x35, 0xca, 0xb6, 0xb4, 0xf1, 0x14,
+	0xb2, 0x9c, 0xbe, 0x90, 0xd0, 0xd0, 0x25, 0x50, 0x39, 0x78, 0x09, 0x65, 0x97, 0x86, 0x21, 0x71,
+	0xb9, 0x4f, 0xc3, 0x89, 0xef, 0x19, 0x19, 0x99, 0x2d, 0xbd, 0x07, 0x07, 0x9e, 0x99, 0x05, 0xbd,
+	0xf7, 0x78, 0x6f, 0x9a, 0x00, 0xea, 0xe6, 0xdb, 0x98, 0x2f, 0xde, 0xf9, 0xb4, 0x3d, 0x3e, 0xf3,
+	0x1f, 0x0d, 0x0a, 0x0a, 0x34, 0x64, 0x73, 0xb4, 0x20, 0x2b, 0x6b, 0x97, 0x98, 0x62, 0xbb, 0x6a,
+	0xa9, 0x46, 0xb7, 0xfd, 0xac, 0x5b, 0x56, 0x4f, 0x58, 0xfd, 0x94, 0xad, 0x60, 0x78, 0x03, 0xb9,
+	0x85, 0xec, 0x4a, 0x56, 0x5e, 0x6c, 0x7f, 0x6f, 0x7d, 0x50, 0xc6, 0xda, 0x6f, 0xbc, 0x9f, 0xb2,
+	0xb7, 0x60, 0x44, 0xd0, 0xa7, 0xf1, 0x4c, 0x36, 0x56, 0xea, 0xa7, 0x6c, 0xe1, 0xe0, 0x4f, 0xa0,
+	0x13, 0x3a, 0x93, 0xed, 0x14, 0xdb, 0x98, 0xe0, 0xe9, 0x3d, 0xde, 0x0b, 0x1c, 0xa1, 0x33, 0x6c,
+	0x42, 0xc6, 0x89, 0xf9, 0xc2, 0xc8, 0x4a, 0xe0, 0x77, 0x47, 0x2f, 0x14, 0xfd, 0xf6, 0x53, 0xb6,
+	0x04, 0x76, 0xb2, 0xa0, 0x2f, 0xd9, 0xdc, 0x1c, 0x02, 0x8e, 0x36, 0xa1, 0x3b, 0xe2, 0x0e, 0x8f,
+	0x99, 0x4d, 0xd8, 0x8a, 0x86, 0x8c, 0x60, 0xf5, 0x83, 0x28, 0xa2, 0x31, 0xe9, 0xa2, 0x01, 0x39,
+	0xb6, 0x09, 0x5d, 0xe2, 0xc9, 0xc6, 0xf2, 0xa2, 0x76, 0xe5, 0xef, 0xe8, 0xaa, 0x70, 0xfa, 0x2b,
+	0xe1, 0xfb, 0x8c, 0xaf, 0x31, 0x61, 0xdc, 0xb4, 0xa0, 0xfa, 0x87, 0xc3, 0xdd, 0xc5, 0x60, 0xe9,
+	0xcc, 0xc9, 0x53, 0x1c, 0x04, 0xbb, 0xcc, 0x27, 0xfa, 0xff, 0x9d, 0x86, 0x6f, 0x6f, 0xd8, 0xa7,
+	0x88, 0xce, 0x23, 0xc2, 0x18, 0x1a, 0x70, 0xc2, 0x48, 0xb4, 0xf6, 0xdd, 0xdd, 0x9a, 0xec, 0x5c,
+	0xc1, 0xe2, 0x0b, 0xb8, 0xac, 0xab, 0x60, 0x2b, 0x07, 0x2f, 0xa0, 0x14, 0x38, 0x1b, 0x12, 0xb1,
+	0x09, 0xa7, 0xdc, 0x09, 0xa4, 0xb2, 0x59, 0xbb, 0xa8, 0x62, 0x63, 0x11, 0xc2, 0x73, 0xd8, 0xba,
+	0x13, 0x8f, 0x86, 0x44, 0xea, 0x9c, 0xb5, 0x41, 0x85, 0xee, 0x68, 0x48, 0x04, 0x60, 0xba, 0xe1,
+	0x64, 0x47, 0x21, 0xf4, 0xd5, 0x6d, 0x90, 0x21, 0xc5, 0xf0, 0x23, 0x28, 0x4f, 0x11, 0xe4, 0x64,
+	0xbe, 0x20, 0x23, 0xf2, 0x3c, 0x42, 0x46, 0x26, 0x4e, 0x84, 0x60, 0xb6, 0xb4, 0xb1, 0x03, 0x25,
+	0xd7, 0x71, 0x17, 0x64, 0xc2, 0xa4, 0x48, 0x46, 0xbe, 0xae, 0x35, 0xbe, 0xb4, 0xcf, 0x13, 0x43,
+	0x93, 0xfd, 0x77, 0x05, 0x6e, 0xab, 0x65, 0xd1, 0x7d, 0x77, 0xcc, 0x67, 0xc0, 0xde, 0x5f, 0x2b,
+	0xca, 0xc8, 0x6f, 0xd4, 0x75, 0x82, 0xff, 0x55, 0x73, 0x5f, 0xb7, 0xf4, 0x81, 0x6e, 0xe2, 0x55,
+	0x31, 0x43, 0xaf, 0xeb, 0x8d, 0xb2, 0xad, 0x1c, 0xf3, 0x06, 0xbe, 0x4a, 0xd6, 0xee, 0xdb, 0xeb,
+	0xc1, 0x2f, 0x90, 0xf6, 0xbd, 0x2d, 0x6b, 0xda, 0xf7, 0x8e, 0x3d, 0xcd, 0x5f, 0xae, 0xa1, 0x92,
+	0xac, 0x19, 0x4b, 0x90, 0xff, 0xfd, 0xa1, 0x7b, 0xdb, 0xed, 0xf7, 0xee, 0x2a, 0x29, 0x3c, 0x01,
+	0xbd, 0x3f, 0x18, 0x57, 0x34, 0xcc, 0x43, 0x66, 0x38, 0x18, 0x8d, 0x2a, 0xe9, 0xf6, 0xbf, 0x69,
+	0x80, 0xee, 0xdb, 0x4f, 0x82, 0x1d, 0xc8, 0xa9, 0x65, 0x45, 0xe3, 0xe8, 0x0e, 0x0f, 0xd9, 0xbc,
+	0xf6, 0x69, 0xc6, 0x4c, 0x35, 0xb4, 0x96, 0x86, 0x0e, 0x7c, 0x13, 0x1b, 0xf8, 0x40, 0xb9, 0x3f,
+	0xf3, 0x5d, 0x47, 0xd4, 0xcf, 0xf0, 0x22, 0x71, 0xe8, 0x70, 0xeb, 0x6b, 0x97, 0x09, 0xc8, 0xd1,
+	0x4d, 0x56, 0x57, 0xfc, 0x09, 0x5f, 0x13, 0xfb, 0x8c, 0x57, 0x89, 0xd3, 0xc7, 0xf7, 0xbd, 0x56,
+	0x3f, 0x36, 0xe5, 0xfd, 0x2d, 0x37, 0x53, 0x2d, 0x0d, 0xc7, 0x50, 0xdc, 0x9b, 0xee, 0x41, 0xf1,
+	0x87, 0x93, 0xaf, 0x9d, 0x25, 0x20, 0x89, 0x01, 0x0a, 0xd6, 0xce, 0xcf, 0xcf, 0x57, 0x73, 0x9f,
+	0x2f, 0xe2, 0xa9, 0xe5, 0xd2, 0x65, 0xf3, 0x85, 0x04, 0x9e, 0xd3, 0x54, 0x9f, 0xf4, 0xea, 0x65,
+	0xde, 0x94, 0xff, 0xb2, 0xfc, 0xdd, 0xa7, 0x39, 0x69, 0x5f, 0xff, 0x37, 0x00, 0xa2, 0x0c, 0x94,
+	0x9f, 0xf2, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -393,6 +770,15 @@ type ControllerClient interface {
 	// querying the CLI for status updates, but the CLI is initiating the
 	// connection.
 	SyncNotifications(ctx context.Context, opts ...grpc.CallOption) (Controller_SyncNotificationsClient, error)
+	// WatchImagePulls streams the progress of the image pulls for the user's
+	// services. An update is sent whenever the progress of a pull changes.
+	WatchImagePulls(ctx context.Context, in *WatchImagePullsRequest, opts ...grpc.CallOption) (Controller_WatchImagePullsClient, error)
+	// ExposeLocal makes a service running on the user's machine reachable from
+	// the sandbox. While the stream is open, the service's name resolves to
+	// the node controller within the sandbox. The node controller sends a
+	// LocalConnection for each connection to the service, and the CLI handles
+	// it by opening a Tunnel with the connection's ID in the header.
+	ExposeLocal(ctx context.Context, in *ExposeLocalRequest, opts ...grpc.CallOption) (Controller_ExposeLocalClient, error)
 }
 
 type controllerClient struct {
@@ -465,6 +851,70 @@ func (x *controllerSyncNotificationsClient) Recv() (*GetSyncStatusRequest, error
 	return m, nil
 }
 
+func (c *controllerClient) WatchImagePulls(ctx context.Context, in *WatchImagePullsRequest, opts ...grpc.CallOption) (Controller_WatchImagePullsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[2], "/blimp.node.v0.Controller/WatchImagePulls", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controllerWatchImagePullsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Controller_WatchImagePullsClient interface {
+	Recv() (*ImagePullProgress, error)
+	grpc.ClientStream
+}
+
+type controllerWatchImagePullsClient struct {
+	grpc.ClientStream
+}
+
+func (x *controllerWatchImagePullsClient) Recv() (*ImagePullProgress, error) {
+	m := new(ImagePullProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controllerClient) ExposeLocal(ctx context.Context, in *ExposeLocalRequest, opts ...grpc.CallOption) (Controller_ExposeLocalClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[3], "/blimp.node.v0.Controller/ExposeLocal", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controllerExposeLocalClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Controller_ExposeLocalClient interface {
+	Recv() (*LocalConnection, error)
+	grpc.ClientStream
+}
+
+type controllerExposeLocalClient struct {
+	grpc.ClientStream
+}
+
+func (x *controllerExposeLocalClient) Recv() (*LocalConnection, error) {
+	m := new(LocalConnection)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ControllerServer is the server API for Controller service.
 type ControllerServer interface {
 	Tunnel(Controller_TunnelServer) error
@@ -472,6 +922,15 @@ type ControllerServer interface {
 	// querying the CLI for status updates, but the CLI is initiating the
 	// connection.
 	SyncNotifications(Controller_SyncNotificationsServer) error
+	// WatchImagePulls streams the progress of the image pulls for the user's
+	// services. An update is sent whenever the progress of a pull changes.
+	WatchImagePulls(*WatchImagePullsRequest, Controller_WatchImagePullsServer) error
+	// ExposeLocal makes a service running on the user's machine reachable from
+	// the sandbox. While the stream is open, the service's name resolves to
+	// the node controller within the sandbox. The node controller sends a
+	// LocalConnection for each connection to the service, and the CLI handles
+	// it by opening a Tunnel with the connection's ID in the header.
+	ExposeLocal(*ExposeLocalRequest, Controller_ExposeLocalServer) error
 }
 
 // UnimplementedControllerServer can be embedded to have forward compatible implementations.
@@ -484,6 +943,12 @@ func (*UnimplementedControllerServer) Tunnel(srv Controller_TunnelServer) error
 func (*UnimplementedControllerServer) SyncNotifications(srv Controller_SyncNotificationsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SyncNotifications not implemented")
 }
+func (*UnimplementedControllerServer) WatchImagePulls(req *WatchImagePullsRequest, srv Controller_WatchImagePullsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchImagePulls not implemented")
+}
+func (*UnimplementedControllerServer) ExposeLocal(req *ExposeLocalRequest, srv Controller_ExposeLocalServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExposeLocal not implemented")
+}
 
 func RegisterControllerServer(s *grpc.Server, srv ControllerServer) {
 	s.RegisterService(&_Controller_serviceDesc, srv)
@@ -541,6 +1006,48 @@ func (x *controllerSyncNotificationsServer) Recv() (*SyncStatusResponse, error)
 	return m, nil
 }
 
+func _Controller_WatchImagePulls_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchImagePullsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControllerServer).WatchImagePulls(m, &controllerWatchImagePullsServer{stream})
+}
+
+type Controller_WatchImagePullsServer interface {
+	Send(*ImagePullProgress) error
+	grpc.ServerStream
+}
+
+type controllerWatchImagePullsServer struct {
+	grpc.ServerStream
+}
+
+func (x *controllerWatchImagePullsServer) Send(m *ImagePullProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Controller_ExposeLocal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExposeLocalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControllerServer).ExposeLocal(m, &controllerExposeLocalServer{stream})
+}
+
+type Controller_ExposeLocalServer interface {
+	Send(*LocalConnection) error
+	grpc.ServerStream
+}
+
+type controllerExposeLocalServer struct {
+	grpc.ServerStream
+}
+
+func (x *controllerExposeLocalServer) Send(m *LocalConnection) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Controller_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "blimp.node.v0.Controller",
 	HandlerType: (*ControllerServer)(nil),
@@ -558,6 +1065,16 @@ var _Controller_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchImagePulls",
+			Handler:       _Controller_WatchImagePulls_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExposeLocal",
+			Handler:       _Controller_ExposeLocal_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "blimp/node/v0/controller.proto",
 }
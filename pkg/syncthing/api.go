@@ -1,9 +1,11 @@
 package syncthing
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"path"
@@ -71,16 +73,34 @@ func (api APIClient) Ping() error {
 	return api.get("/rest/system/ping", nil, nil)
 }
 
+// GetConfig returns Syncthing's full configuration. The configuration is
+// returned as a generic map so that it can be modified and posted back
+// without dropping any fields.
+func (api APIClient) GetConfig() (config map[string]interface{}, err error) {
+	err = api.get("/rest/system/config", nil, &config)
+	return config, err
+}
+
+// SetConfig replaces Syncthing's configuration. Syncthing applies most
+// changes, such as pausing folders, without restarting.
+func (api APIClient) SetConfig(config map[string]interface{}) error {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return errors.WithContext("marshal config", err)
+	}
+	return api.do("POST", "/rest/system/config", nil, bytes.NewReader(body), nil)
+}
+
 func (api APIClient) get(path string, params map[string]string, respObj interface{}) error {
-	return api.do("GET", path, params, respObj)
+	return api.do("GET", path, params, nil, respObj)
 }
 
 func (api APIClient) post(path string, params map[string]string) error {
-	return api.do("POST", path, params, nil)
+	return api.do("POST", path, params, nil, nil)
 }
 
-func (api APIClient) do(method, p string, params map[string]string, respObj interface{}) error {
-	req, err := http.NewRequest(method, fmt.Sprintf("http://%s", path.Join(api.Address, p)), nil)
+func (api APIClient) do(method, p string, params map[string]string, reqBody io.Reader, respObj interface{}) error {
+	req, err := http.NewRequest(method, fmt.Sprintf("http://%s", path.Join(api.Address, p)), reqBody)
 	if err != nil {
 		return errors.WithContext("create request", err)
 	}
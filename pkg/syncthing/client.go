@@ -276,7 +276,7 @@ func (c Client) Run(ctx context.Context, ncc node.ControllerClient, remoteAPIAdd
 }
 
 func (c Client) performInitialSync(ctx context.Context, remoteAPIAddr string, idPathMap map[string]string) error {
-	localAPI := APIClient{fmt.Sprintf("localhost:%d", APIPort)}
+	localAPI := LocalAPI()
 	remoteAPI := APIClient{remoteAPIAddr}
 
 	var folders []string
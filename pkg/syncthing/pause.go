@@ -0,0 +1,126 @@
+package syncthing
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// Folder is a folder that's being synced by the local Syncthing daemon.
+type Folder struct {
+	ID     string
+	Path   string
+	Paused bool
+}
+
+// SyncSummary describes the changes that were synced when a folder was
+// resumed.
+type SyncSummary struct {
+	Items   int
+	Deletes int
+	Bytes   int
+}
+
+// LocalAPI returns a client for the Syncthing daemon run by `blimp up`.
+func LocalAPI() APIClient {
+	return APIClient{fmt.Sprintf("localhost:%d", APIPort)}
+}
+
+// GetFolders returns the folders synced by the Syncthing daemon.
+func (api APIClient) GetFolders() ([]Folder, error) {
+	config, err := api.GetConfig()
+	if err != nil {
+		return nil, errors.WithContext("get config", err)
+	}
+
+	var folders []Folder
+	for _, folderConfig := range getFolderConfigs(config) {
+		id, _ := folderConfig["id"].(string)
+		path, _ := folderConfig["path"].(string)
+		paused, _ := folderConfig["paused"].(bool)
+		folders = append(folders, Folder{ID: id, Path: path, Paused: paused})
+	}
+	return folders, nil
+}
+
+// SetPaused pauses or resumes syncing for the given folders. Changes made to
+// paused folders are synced once the folder is resumed.
+func (api APIClient) SetPaused(folderIDs []string, paused bool) error {
+	config, err := api.GetConfig()
+	if err != nil {
+		return errors.WithContext("get config", err)
+	}
+
+	toUpdate := map[string]struct{}{}
+	for _, id := range folderIDs {
+		toUpdate[id] = struct{}{}
+	}
+
+	for _, folderConfig := range getFolderConfigs(config) {
+		id, _ := folderConfig["id"].(string)
+		if _, ok := toUpdate[id]; ok {
+			folderConfig["paused"] = paused
+			delete(toUpdate, id)
+		}
+	}
+
+	if len(toUpdate) != 0 {
+		return errors.New("unknown folders: %v", toUpdate)
+	}
+	return api.SetConfig(config)
+}
+
+// WaitForResume blocks until the changes made while the given folders were
+// paused have been synced to the sandbox. It returns a summary of the synced
+// changes.
+func (api APIClient) WaitForResume(ctx context.Context, folderIDs []string) (SyncSummary, error) {
+	// Wait for Syncthing to notice the changes made while the folders were
+	// paused.
+	if err := waitUntilScanned(ctx, api, folderIDs); err != nil {
+		return SyncSummary{}, errors.WithContext("wait for scan", err)
+	}
+
+	var summary SyncSummary
+	for _, id := range folderIDs {
+		completion, err := api.GetCompletion(id, RemoteDeviceID)
+		if err != nil {
+			return SyncSummary{}, errors.WithContext("get remote folder completion", err)
+		}
+
+		summary.Items += completion.NeedItems
+		summary.Deletes += completion.NeedDeletes
+		summary.Bytes += completion.NeedBytes
+	}
+
+	err := waitUntil(ctx, 10, func() progressStatus {
+		log.Debug("Waiting for remote Syncthing to sync resumed folders")
+
+		for _, id := range folderIDs {
+			completion, err := api.GetCompletion(id, RemoteDeviceID)
+			if err != nil {
+				return progressStatus{phase: PROGRESS_ERROR, err: err}
+			}
+
+			if completion.NeedBytes != 0 || completion.NeedDeletes != 0 || completion.NeedItems != 0 {
+				return progressStatus{phase: PROGRESS_PENDING}
+			}
+		}
+		return progressStatus{phase: PROGRESS_DONE}
+	})
+	return summary, err
+}
+
+func getFolderConfigs(config map[string]interface{}) []map[string]interface{} {
+	folderIntfs, _ := config["folders"].([]interface{})
+
+	var folders []map[string]interface{}
+	for _, folderIntf := range folderIntfs {
+		if folder, ok := folderIntf.(map[string]interface{}); ok {
+			folders = append(folders, folder)
+		}
+	}
+	return folders
+}
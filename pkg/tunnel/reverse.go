@@ -0,0 +1,101 @@
+package tunnel
+
+import (
+	"context"
+	"net"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/node"
+)
+
+// ReverseClient makes the local service reachable from the sandbox under the
+// given name. Connections to the service's ports within the sandbox are
+// forwarded to the corresponding addresses in `localAddrs`. It blocks until
+// the node controller closes the stream.
+func ReverseClient(scc node.ControllerClient, tokenSource TokenSource,
+	name string, localAddrs map[uint32]string) error {
+
+	var ports []uint32
+	for port := range localAddrs {
+		ports = append(ports, port)
+	}
+
+	stream, err := scc.ExposeLocal(context.Background(), &node.ExposeLocalRequest{
+		Token:   tokenSource(),
+		Service: name,
+		Ports:   ports,
+	})
+	if err != nil {
+		return errors.WithContext("expose local service", err)
+	}
+
+	for {
+		conn, err := stream.Recv()
+		if err != nil {
+			return errors.WithContext("receive connection", err)
+		}
+
+		addr, ok := localAddrs[conn.Port]
+		if !ok {
+			log.WithField("name", name).WithField("port", conn.Port).
+				Warn("Received connection for unexposed port")
+			continue
+		}
+
+		go acceptReverse(scc, tokenSource, name, conn, addr)
+	}
+}
+
+// acceptReverse connects the local service to a connection from the sandbox.
+func acceptReverse(scc node.ControllerClient, tokenSource TokenSource,
+	name string, conn *node.LocalConnection, addr string) {
+
+	fields := log.Fields{
+		"name":       name,
+		"port":       conn.Port,
+		"connection": conn.Id,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	grpcTnl, err := scc.Tunnel(ctx)
+	if err != nil {
+		log.WithFields(fields).WithError(err).Error("failed to establish tunnel")
+		return
+	}
+
+	tnl := &lockedTunnel{tunnel: grpcTnl}
+	token := tokenSource()
+	err = tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Header{
+		Header: &node.TunnelHeader{
+			Token:        token,
+			Name:         name,
+			Port:         conn.Port,
+			ConnectionId: conn.Id,
+		}}})
+	if err != nil {
+		log.WithFields(fields).WithError(err).Error("failed to send tunnel connect")
+		grpcTnl.CloseSend()
+		return
+	}
+
+	// Dial the local service after establishing the tunnel, so that the
+	// connection in the sandbox is closed if the local service isn't
+	// running.
+	stream, err := net.Dial("tcp", addr)
+	if err != nil {
+		log.WithFields(fields).WithError(err).Warn("Failed to connect to local service")
+		grpcTnl.CloseSend()
+		return
+	}
+	defer stream.Close()
+
+	log.WithFields(fields).Trace("new reverse connection")
+	reauth, onAuth := reauthRequests()
+	go rotateCredentials(ctx, tnl, tokenSource, token, reauth)
+	streamBidirectional(stream, tnl, cancel, onAuth)
+	log.WithFields(fields).Trace("finish reverse connection")
+}
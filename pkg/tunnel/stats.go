@@ -0,0 +1,83 @@
+package tunnel
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats tracks the traffic through a tunneled port. It's safe to use from
+// multiple goroutines.
+type Stats struct {
+	bytesSent         int64
+	bytesReceived     int64
+	activeConnections int64
+	totalConnections  int64
+}
+
+// StatsSnapshot is a point-in-time copy of Stats. Sent bytes are from the
+// local machine to the sandbox, and received bytes are from the sandbox.
+type StatsSnapshot struct {
+	BytesSent         int64 `json:"bytes_sent"`
+	BytesReceived     int64 `json:"bytes_received"`
+	ActiveConnections int64 `json:"active_connections"`
+	TotalConnections  int64 `json:"total_connections"`
+}
+
+func (s *Stats) Snapshot() StatsSnapshot {
+	return StatsSnapshot{
+		BytesSent:         atomic.LoadInt64(&s.bytesSent),
+		BytesReceived:     atomic.LoadInt64(&s.bytesReceived),
+		ActiveConnections: atomic.LoadInt64(&s.activeConnections),
+		TotalConnections:  atomic.LoadInt64(&s.totalConnections),
+	}
+}
+
+// WrapListener returns a listener that records the traffic of the
+// connections that it accepts in the stats.
+func (s *Stats) WrapListener(ln net.Listener) net.Listener {
+	return statsListener{ln, s}
+}
+
+type statsListener struct {
+	net.Listener
+	stats *Stats
+}
+
+func (ln statsListener) Accept() (net.Conn, error) {
+	conn, err := ln.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	atomic.AddInt64(&ln.stats.activeConnections, 1)
+	atomic.AddInt64(&ln.stats.totalConnections, 1)
+	return &statsConn{Conn: conn, stats: ln.stats}, nil
+}
+
+type statsConn struct {
+	net.Conn
+	stats     *Stats
+	closeOnce sync.Once
+}
+
+func (c *statsConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	atomic.AddInt64(&c.stats.bytesSent, int64(n))
+	return n, err
+}
+
+func (c *statsConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	atomic.AddInt64(&c.stats.bytesReceived, int64(n))
+	return n, err
+}
+
+// Close is called multiple times by the tunnel, so the active connection
+// count is only decremented the first time.
+func (c *statsConn) Close() error {
+	c.closeOnce.Do(func() {
+		atomic.AddInt64(&c.stats.activeConnections, -1)
+	})
+	return c.Conn.Close()
+}
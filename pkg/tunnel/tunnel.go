@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -16,39 +17,106 @@ import (
 	"github.com/kelda/blimp/pkg/proto/node"
 )
 
+// RotationInterval is how often clients send fresh credentials on open
+// tunnels.
+const RotationInterval = 5 * time.Minute
+
+// reauthGracePeriod is how long the server waits for fresh credentials after
+// asking the client to re-authenticate before closing the tunnel.
+const reauthGracePeriod = time.Minute
+
 type tunnel interface {
 	Send(*node.TunnelMsg) error
 	Recv() (*node.TunnelMsg, error)
 }
 
-func ServerHeader(nsrv node.Controller_TunnelServer) (
-	name string, port uint32, namespace string, err error) {
+// TokenSource returns the current token for authenticating tunnels.
+type TokenSource func() string
+
+// Header is the parsed header of a tunnel.
+type Header struct {
+	Name      string
+	Port      uint32
+	Namespace string
+
+	// Expiry is when the credentials used to open the tunnel expire. The
+	// client is asked to re-authenticate once they do, and the tunnel is
+	// closed if it doesn't.
+	Expiry time.Time
+}
 
+func ServerHeader(nsrv node.Controller_TunnelServer) (Header, error) {
 	msg, err := nsrv.Recv()
 	if err != nil {
-		return "", 0, "", err
+		return Header{}, err
 	}
 
 	header := msg.GetHeader()
 	if header == nil {
 		msg := fmt.Sprintf("first message must be a header")
-		return "", 0, "", status.New(codes.Internal, msg).Err()
+		return Header{}, status.New(codes.Internal, msg).Err()
 	}
 
 	user, err := auth.ParseIDToken(header.GetToken())
 	if err != nil {
-		return "", 0, "", errors.WithContext("bad token", err)
+		return Header{}, errors.WithContext("bad token", err)
 	}
 
-	return header.Name, header.Port, user.Namespace, nil
+	return Header{
+		Name:      header.Name,
+		Port:      header.Port,
+		Namespace: user.Namespace,
+		Expiry:    user.Expiry,
+	}, nil
 }
 
-func ServerStream(nsrv node.Controller_TunnelServer, stream net.Conn) {
-	streamBidirectional(stream, nsrv, func() {})
+// ServerStream proxies traffic between the tunnel and the given connection.
+// Once the tunnel's credentials expire, the client is asked to re-authenticate
+// with its refreshed token. The tunnel is only closed if the client doesn't
+// send fresh credentials for the same user within reauthGracePeriod.
+func ServerStream(nsrv node.Controller_TunnelServer, stream net.Conn, header Header) {
+	fields := log.Fields{
+		"name":      header.Name,
+		"namespace": header.Namespace,
+	}
+
+	// The re-authentication request is sent from the timer's goroutine, so
+	// sends need to be synchronized.
+	tnl := &lockedTunnel{tunnel: nsrv}
+	requestAuth := func() {
+		// An auth message without a token asks the client for its current
+		// credentials.
+		err := tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Auth{
+			Auth: &node.TunnelAuth{}}})
+		if err != nil {
+			log.WithError(err).WithFields(fields).Debug("Failed to request fresh tunnel credentials")
+		}
+	}
+	closeTunnel := func() {
+		log.WithFields(fields).Info("Closing tunnel with expired credentials")
+		stream.Close()
+	}
+	expiry := newExpiryTimer(header.Expiry, requestAuth, closeTunnel)
+	defer expiry.Stop()
+
+	onAuth := func(msg *node.TunnelAuth) error {
+		user, err := auth.ParseIDToken(msg.GetToken())
+		if err != nil {
+			return errors.WithContext("bad token", err)
+		}
+
+		if user.Namespace != header.Namespace {
+			return errors.New("token is for a different user")
+		}
+
+		expiry.Reset(user.Expiry)
+		return nil
+	}
+	streamBidirectional(stream, tnl, func() {}, onAuth)
 }
 
 // TODO, How does this thing get cleaned up?  Do we leak a go routine here?
-func Client(scc node.ControllerClient, ln net.Listener, token,
+func Client(scc node.ControllerClient, ln net.Listener, tokenSource TokenSource,
 	name string, port uint32) error {
 
 	fields := log.Fields{
@@ -65,7 +133,7 @@ func Client(scc node.ControllerClient, ln net.Listener, token,
 
 		log.WithFields(fields).Trace("new connection")
 		go func() {
-			connect(scc, stream, token, name, port)
+			connect(scc, stream, tokenSource, name, port)
 			log.WithFields(fields).Trace("finish connection")
 		}()
 	}
@@ -74,16 +142,20 @@ func Client(scc node.ControllerClient, ln net.Listener, token,
 }
 
 func connect(scc node.ControllerClient, stream net.Conn,
-	token, name string, port uint32) {
+	tokenSource TokenSource, name string, port uint32) {
 	defer stream.Close()
 
 	ctx, cancel := context.WithCancel(context.Background())
-	tnl, err := scc.Tunnel(ctx)
+	grpcTnl, err := scc.Tunnel(ctx)
 	if err != nil {
 		log.WithError(err).Error("failed to establish tunnel")
 		return
 	}
 
+	// The credentials are rotated from a separate goroutine, so sends need to
+	// be synchronized.
+	tnl := &lockedTunnel{tunnel: grpcTnl}
+	token := tokenSource()
 	err = tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Header{
 		Header: &node.TunnelHeader{
 			Token: token,
@@ -92,21 +164,158 @@ func connect(scc node.ControllerClient, stream net.Conn,
 		}}})
 	if err != nil {
 		log.WithError(err).Error("failed to send tunnel connect")
-		tnl.CloseSend()
+		grpcTnl.CloseSend()
+		return
+	}
+
+	reauth, onAuth := reauthRequests()
+	go rotateCredentials(ctx, tnl, tokenSource, token, reauth)
+	streamBidirectional(stream, tnl, cancel, onAuth)
+}
+
+// reauthRequests returns a channel that receives whenever the server asks the
+// client to re-authenticate, which it does once the current credentials
+// expire. The returned auth handler feeds the channel.
+func reauthRequests() (<-chan struct{}, func(*node.TunnelAuth) error) {
+	reauth := make(chan struct{}, 1)
+	return reauth, func(*node.TunnelAuth) error {
+		select {
+		case reauth <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+}
+
+// rotateCredentials sends the latest token over the tunnel whenever it
+// changes, or the server asks to re-authenticate, until the context is
+// cancelled.
+func rotateCredentials(ctx context.Context, tnl tunnel, tokenSource TokenSource, lastToken string,
+	reauth <-chan struct{}) {
+	ticker := time.NewTicker(RotationInterval)
+	defer ticker.Stop()
+
+	for {
+		var requested bool
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-reauth:
+			requested = true
+		}
+
+		token := tokenSource()
+		if token == lastToken && !requested {
+			continue
+		}
+
+		err := tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Auth{
+			Auth: &node.TunnelAuth{Token: token}}})
+		if err != nil {
+			log.WithError(err).Debug("failed to rotate tunnel credentials")
+			return
+		}
+		lastToken = token
+	}
+}
+
+type lockedTunnel struct {
+	tunnel
+	sendLock sync.Mutex
+}
+
+func (tnl *lockedTunnel) Send(msg *node.TunnelMsg) error {
+	tnl.sendLock.Lock()
+	defer tnl.sendLock.Unlock()
+	return tnl.tunnel.Send(msg)
+}
+
+// expiryTimer runs `onExpire` once the current credentials expire, and
+// `onGraceExpired` if they aren't replaced within reauthGracePeriod after
+// that.
+type expiryTimer struct {
+	onExpire, onGraceExpired func()
+
+	timer   *time.Timer
+	expired bool
+	stopped bool
+
+	// gen is incremented whenever the timer is rescheduled, so that a timer
+	// that fired concurrently with a reschedule is ignored.
+	gen int
+
+	sync.Mutex
+}
+
+func newExpiryTimer(expiry time.Time, onExpire, onGraceExpired func()) *expiryTimer {
+	t := &expiryTimer{onExpire: onExpire, onGraceExpired: onGraceExpired}
+	t.Lock()
+	t.schedule(time.Until(expiry))
+	t.Unlock()
+	return t
+}
+
+// schedule must be called with the lock held.
+func (t *expiryTimer) schedule(d time.Duration) {
+	t.gen++
+	gen := t.gen
+	t.timer = time.AfterFunc(d, func() {
+		t.fire(gen)
+	})
+}
+
+func (t *expiryTimer) fire(gen int) {
+	t.Lock()
+	if t.stopped || gen != t.gen {
+		t.Unlock()
 		return
 	}
 
-	streamBidirectional(stream, tnl, cancel)
+	callback := t.onGraceExpired
+	if !t.expired {
+		t.expired = true
+		callback = t.onExpire
+		t.schedule(reauthGracePeriod)
+	}
+	t.Unlock()
+
+	callback()
 }
 
-func streamBidirectional(stream net.Conn, tnl tunnel, cancel func()) {
+// Reset pushes back the expiration to the given time.
+func (t *expiryTimer) Reset(expiry time.Time) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.stopped {
+		return
+	}
+	t.timer.Stop()
+	t.expired = false
+	t.schedule(time.Until(expiry))
+}
+
+func (t *expiryTimer) Stop() {
+	t.Lock()
+	defer t.Unlock()
+
+	t.stopped = true
+	t.timer.Stop()
+}
+
+// streamBidirectional copies data between the connection and the tunnel. If
+// onAuth is non-nil, the other end of the tunnel may send auth messages, and
+// the tunnel is closed if onAuth rejects them.
+func streamBidirectional(stream net.Conn, tnl tunnel, cancel func(),
+	onAuth func(*node.TunnelAuth) error) {
 	var wg sync.WaitGroup
 	wg.Add(2)
 
 	streamDone := make(chan struct{})
 
 	go func() {
-		tunnelToStream(stream, tnl)
+		tunnelToStream(stream, tnl, onAuth)
 		close(streamDone)
 		wg.Done()
 
@@ -193,7 +402,7 @@ loop:
 	}
 }
 
-func tunnelToStream(stream io.ReadWriter, tnl tunnel) {
+func tunnelToStream(stream io.ReadWriter, tnl tunnel, onAuth func(*node.TunnelAuth) error) {
 	for {
 		msg, err := tnl.Recv()
 		switch {
@@ -210,6 +419,14 @@ func tunnelToStream(stream io.ReadWriter, tnl tunnel) {
 			return
 		}
 
+		if authMsg := msg.GetAuth(); authMsg != nil && onAuth != nil {
+			if err := onAuth(authMsg); err != nil {
+				log.WithError(err).Warn("Rejected tunnel credential rotation")
+				return
+			}
+			continue
+		}
+
 		buf := msg.GetBuf()
 		if buf == nil {
 			// This shouldn't happen.  The other end of the